@@ -0,0 +1,20 @@
+package accelerator
+
+import "github.com/spf13/cobra"
+
+// AcceleratorCmd returns the parent command for inspecting accelerators.
+func AcceleratorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accelerator",
+		Short: "Inspect accelerator cards",
+		Long: `Inspect the Spyre cards attached to the catalog host, including their topology,
+driver binding, health and current owner.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newListCmd())
+
+	return cmd
+}
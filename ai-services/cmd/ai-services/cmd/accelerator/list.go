@@ -0,0 +1,156 @@
+package accelerator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	tablePadding = 3
+
+	outputWide = "wide"
+	outputJSON = "json"
+)
+
+func newListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List accelerator cards and their owners",
+		Long: `Lists every Spyre card on the catalog host with its PCI address, NUMA node,
+driver binding, health and the application and service or component currently
+holding it.
+
+Cards are reported as host "local". Cards attached to registered workers are not
+listed.`,
+		Example: `  # List all cards
+  ai-services accelerator list
+
+  # With health details
+  ai-services accelerator list -o wide
+
+  # Machine-readable output
+  ai-services accelerator list -o json
+
+Note:
+  - Requires prior authentication via 'ai-services catalog login'`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch output {
+			case "", outputWide, outputJSON:
+				return nil
+			default:
+				return fmt.Errorf("invalid output format %q (options: %s, %s)", output, outputWide, outputJSON)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.ListAccelerators()
+			if err != nil {
+				return err
+			}
+
+			if output == outputJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+
+				return enc.Encode(resp)
+			}
+
+			for _, msg := range resp.Errors {
+				logger.Warningf("Inventory incomplete: %s\n", msg)
+			}
+
+			return printAcceleratorTable(resp, output == outputWide)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format (options: wide, json)")
+
+	return cmd
+}
+
+// printAcceleratorTable writes a tab-aligned accelerator list to stdout.
+func printAcceleratorTable(resp *catalogtypes.AcceleratorListResponse, wide bool) error {
+	if len(resp.Accelerators) == 0 {
+		logger.Infoln("No accelerators found.")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, tablePadding, ' ', 0)
+	header := "HOST\tPCI ADDRESS\tNUMA\tDRIVER\tHEALTH\tAPPLICATION\tOWNER"
+	if wide {
+		header += "\tOWNER ID\tPROBLEMS"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+
+	for _, acc := range resp.Accelerators {
+		row := []string{acc.Host, acc.PCIAddress, numaNode(acc.NUMANode), orDash(acc.Driver), acc.Health}
+		app, owner, ownerID := "-", "-", "-"
+		if acc.Owner != nil {
+			app = orDash(acc.Owner.ApplicationName)
+			owner = ownerName(acc.Owner)
+			ownerID = orDash(acc.Owner.ID)
+		}
+		row = append(row, app, owner)
+		if wide {
+			row = append(row, ownerID, orDash(strings.Join(acc.Problems, "; ")))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	logger.Infof("\n%d card(s), %d in use\n", resp.Total, resp.InUse)
+
+	return nil
+}
+
+func ownerName(owner *catalogtypes.AcceleratorOwner) string {
+	if owner.Kind == "" {
+		return orDash(owner.Name)
+	}
+
+	return fmt.Sprintf("%s/%s", owner.Kind, orDash(owner.Name))
+}
+
+func numaNode(node int) string {
+	if node < 0 {
+		return "-"
+	}
+
+	return strconv.Itoa(node)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
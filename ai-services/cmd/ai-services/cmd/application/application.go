@@ -7,6 +7,7 @@ import (
 
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/image"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/model"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application/volume"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
@@ -50,6 +51,7 @@ func init() {
 	ApplicationCmd.AddCommand(infoCmd)
 	ApplicationCmd.AddCommand(logsCmd)
 	ApplicationCmd.AddCommand(model.ModelCmd)
+	ApplicationCmd.AddCommand(volume.VolumeCmd)
 	ApplicationCmd.AddCommand(restoreCmd)
 	ApplicationCmd.AddCommand(backupCmd)
 
@@ -57,7 +59,7 @@ func init() {
 	ApplicationCmd.PersistentFlags().StringVarP(&runtimeType, "runtime", "r", "", fmt.Sprintf("runtime to use (options: %s, %s) (required)", types.RuntimeTypePodman, types.RuntimeTypeOpenShift))
 	_ = ApplicationCmd.MarkPersistentFlagRequired("runtime")
 
-	ApplicationCmd.PersistentFlags().StringVar(&vars.ToolImage, "tool-image", vars.ToolImage, "Tool image to use for downloading the model(only for the development purpose)")
+	ApplicationCmd.PersistentFlags().StringVar(&vars.ToolImage, "tool-image", vars.ToolImage, "Tool image to use for helper containers(only for the development purpose)")
 	ApplicationCmd.PersistentFlags().BoolVar(&hiddenTemplates, "hidden", false, "Show hidden templates")
 	_ = ApplicationCmd.PersistentFlags().MarkHidden("tool-image")
 	_ = ApplicationCmd.PersistentFlags().MarkHidden("hidden")
@@ -5,19 +5,32 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/application"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
 	appTypes "github.com/project-ai-services/ai-services/internal/pkg/application/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
+// backupPassphraseEnv supplies the archive passphrase without prompting.
+const backupPassphraseEnv = "AI_SERVICES_BACKUP_PASSPHRASE"
+
 var (
 	backupTarget   string
 	backupFilename string
+	backupSignKey  string
+	backupEncrypt  bool
+	backupOSMode   string
+	backupS3Config string
+	backupPortable bool
 )
 
 var backupCmd = &cobra.Command{
@@ -28,9 +41,34 @@ var backupCmd = &cobra.Command{
 Arguments:
   [name] : Application name (required)
 
-Supported targets:
-  - opensearch: Backup OpenSearch indices and data (Podman and OpenShift)
-  - digitize:   Backup digitize metadata (jobs and documents) (Podman and OpenShift)`,
+Targets:
+  A target covers the data of one component or service of the application and
+  is offered when its catalog metadata declares backup support on the current
+  runtime. "all" backs up every target of the application into one archive.
+  An invalid --target lists the targets that are available.
+
+Every archive carries a manifest with the SHA-256 checksum of each file, the
+document count of each index and the CLI and schema versions it was written
+with. OpenSearch indices are backed up as an incremental snapshot into a
+repository on the OpenSearch data volume, falling back to exporting every
+document when the deployment has no snapshot repository; --opensearch-mode
+forces either method. Snapshot archives restore only on Podman, as OpenShift
+runs OpenSearch without a shared snapshot repository; --portable exports every
+document instead, so the archive also restores on the other runtime. With
+--sign-key the manifest is signed with an ed25519 key, and with --encrypt the
+archive is encrypted with a passphrase read from AI_SERVICES_BACKUP_PASSPHRASE
+or prompted for. Check an archive with 'ai-services application backup
+verify'.
+
+A --filename of the form s3://bucket/key streams the archive to S3-compatible
+object storage as a multipart upload, without writing the final archive to
+local disk. A bucket or a key ending in "/" gets a generated archive name. The
+endpoint and credentials are read from the JSON file given with --s3-config,
+which uses the fields of the object_storage connector (endpoint_url,
+access_key_id, secret_access_key, prefix), or from the environment variables
+AI_SERVICES_S3_ENDPOINT, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+AWS_SESSION_TOKEN (for temporary credentials), AI_SERVICES_S3_PREFIX and
+AWS_REGION.`,
 	Example: `  # Backup OpenSearch data with Podman (auto-generated filename)
   ai-services application backup myapp --target opensearch --runtime podman
 
@@ -38,23 +76,44 @@ Supported targets:
   ai-services application backup myapp --target digitize --runtime openshift
 
   # Backup digitize data with custom filename
-  ai-services application backup myapp --target digitize --filename mybackup.tar.gz --runtime podman`,
+  ai-services application backup myapp --target digitize --filename mybackup.tar.gz --runtime podman
+
+  # Backup all targets into one archive
+  ai-services application backup myapp --target all --runtime podman
+
+  # Export every OpenSearch document instead of taking a snapshot
+  ai-services application backup myapp --target opensearch --opensearch-mode scroll --runtime podman
+
+  # Backup a Podman application to restore it into OpenShift
+  ai-services application backup devapp --target all --portable --runtime podman
+
+  # Backup all targets into an S3-compatible bucket
+  export AI_SERVICES_S3_ENDPOINT=https://minio.example.com:9000 AWS_ACCESS_KEY_ID=... AWS_SECRET_ACCESS_KEY=...
+  ai-services application backup myapp --target all --filename s3://backups/myapp/ --runtime podman
+
+  # Backup into a signed and encrypted archive
+  openssl genpkey -algorithm ed25519 -out backup-key.pem
+  ai-services application backup myapp --target all --sign-key backup-key.pem --encrypt --runtime podman`,
 	Args: cobra.ExactArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		target := backupTarget
+		if err := validateBackupTarget(backupTarget, backuptarget.HookBackup); err != nil {
+			return err
+		}
 
-		// Validate target
-		validTargets := []string{"opensearch", "digitize"}
-		isValid := false
-		for _, t := range validTargets {
-			if target == t {
-				isValid = true
+		if !slices.Contains(backuptarget.OpenSearchModes, backupOSMode) {
+			return fmt.Errorf("invalid --opensearch-mode %q, must be one of: %s", backupOSMode, strings.Join(backuptarget.OpenSearchModes, ", "))
+		}
 
-				break
+		if backupPortable {
+			// Only the scroll export restores on both runtimes.
+			if cmd.Flags().Changed("opensearch-mode") && backupOSMode != backuptarget.OpenSearchModeScroll {
+				return fmt.Errorf("--portable requires --opensearch-mode %s", backuptarget.OpenSearchModeScroll)
 			}
+			backupOSMode = backuptarget.OpenSearchModeScroll
 		}
-		if !isValid {
-			return fmt.Errorf("invalid target '%s'. Valid targets are: %s", target, strings.Join(validTargets, ", "))
+
+		if objectstore.IsURL(backupFilename) {
+			return validateObjectURL(backupFilename)
 		}
 
 		// Validate filename extension if provided
@@ -86,8 +145,8 @@ Supported targets:
 		logger.Infof("Runtime: %s\n", rt)
 
 		// Get absolute path to backup file if provided
-		var absFilename string
-		if backupFilename != "" {
+		absFilename := backupFilename
+		if backupFilename != "" && !objectstore.IsURL(backupFilename) {
 			var err error
 			absFilename, err = filepath.Abs(backupFilename)
 			if err != nil {
@@ -102,11 +161,24 @@ Supported targets:
 			return fmt.Errorf("failed to create application instance: %w", err)
 		}
 
+		protection, err := backupProtection()
+		if err != nil {
+			return err
+		}
+
+		storeConfig, err := loadObjectStoreConfig(backupS3Config)
+		if err != nil {
+			return err
+		}
+
 		// Create backup options
 		opts := appTypes.BackupOptions{
-			Name:       applicationName,
-			Target:     backupTarget,
-			BackupFile: absFilename, // Can be empty for auto-generation
+			Name:           applicationName,
+			Target:         backupTarget,
+			BackupFile:     absFilename, // Can be empty for auto-generation
+			Protection:     protection,
+			OpenSearchMode: backupOSMode,
+			ObjectStore:    storeConfig,
 		}
 
 		// Execute backup using the application interface
@@ -114,9 +186,149 @@ Supported targets:
 	},
 }
 
+// validateBackupTarget checks target against the targets registered for the
+// current runtime whose catalog metadata declares hook.
+func validateBackupTarget(target, hook string) error {
+	registry, err := backuptarget.ForRuntime(vars.RuntimeFactory.GetRuntimeType())
+	if err != nil {
+		return err
+	}
+
+	validTargets := append(registry.Names(hook), backuptarget.All)
+	if !slices.Contains(validTargets, target) {
+		return fmt.Errorf("invalid target '%s'. Valid targets are: %s", target, strings.Join(validTargets, ", "))
+	}
+
+	return nil
+}
+
+// validateObjectURL checks an s3:// backup location, which must name a .tar.gz
+// archive, a bucket or a directory.
+func validateObjectURL(file string) error {
+	location, err := objectstore.ParseURL(file)
+	if err != nil {
+		return err
+	}
+
+	if !location.IsDir() && !strings.HasSuffix(location.Key, ".tar.gz") {
+		return fmt.Errorf("backup file must have .tar.gz extension, got: %s", file)
+	}
+
+	return nil
+}
+
+// loadObjectStoreConfig reads the object storage configuration file given with
+// --s3-config. Without one, nil selects the configuration from the environment.
+func loadObjectStoreConfig(path string) (*objectstore.Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	cfg, err := objectstore.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// backupSource resolves the backup archive file: local files to an absolute
+// path, s3:// URLs to the object storage configuration they are streamed with.
+func backupSource(file, s3Config string) (string, *objectstore.Config, error) {
+	if !objectstore.IsURL(file) {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get absolute path for backup file: %w", err)
+		}
+
+		return absFile, nil, nil
+	}
+
+	if err := validateObjectURL(file); err != nil {
+		return "", nil, err
+	}
+
+	cfg, err := loadObjectStoreConfig(s3Config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return file, cfg, nil
+}
+
+// backupProtection loads the signing key and passphrase requested by the flags.
+func backupProtection() (backuptarget.Protection, error) {
+	var protection backuptarget.Protection
+
+	if backupSignKey != "" {
+		key, err := backuptarget.LoadSigningKey(backupSignKey)
+		if err != nil {
+			return protection, err
+		}
+		protection.SigningKey = key
+	}
+
+	if backupEncrypt {
+		passphrase, err := readBackupPassphrase(true)
+		if err != nil {
+			return protection, err
+		}
+		protection.Passphrase = passphrase
+	}
+
+	return protection, nil
+}
+
+// readBackupPassphrase returns the archive passphrase from the environment or,
+// when unset, prompts for it. New passphrases are prompted for twice.
+func readBackupPassphrase(confirm bool) ([]byte, error) {
+	if passphrase := os.Getenv(backupPassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	passphrase, err := promptPassphrase("Enter backup passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	if confirm {
+		again, err := promptPassphrase("Confirm backup passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if string(again) != string(passphrase) {
+			return nil, fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	return passphrase, nil
+}
+
+// promptPassphrase reads a passphrase from the terminal without echoing.
+func promptPassphrase(prompt string) ([]byte, error) {
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
+
 func init() {
-	backupCmd.Flags().StringVar(&backupTarget, "target", "", "Target to backup (opensearch, digitize) (required)")
+	backupCmd.AddCommand(backupVerifyCmd)
+
+	backupCmd.Flags().StringVar(&backupTarget, "target", "", "Target to backup, as declared by the catalog metadata, or all (required)")
 	backupCmd.Flags().StringVar(&backupFilename, "filename", "", "Path to save the backup tar.gz file (optional, auto-generated if not specified)")
+	backupCmd.Flags().StringVar(&backupSignKey, "sign-key", "", "Path to an ed25519 private key (PEM) to sign the archive manifest with")
+	backupCmd.Flags().StringVar(&backupOSMode, "opensearch-mode", backuptarget.OpenSearchModeAuto, "How to back up OpenSearch: snapshot, scroll, or auto (snapshot with scroll fallback)")
+	backupCmd.Flags().BoolVar(&backupPortable, "portable", false, "Write an archive that restores on both Podman and OpenShift (exports OpenSearch with --opensearch-mode scroll)")
+	backupCmd.Flags().StringVar(&backupS3Config, "s3-config", "", "Path to a JSON file with the object storage endpoint and credentials for s3:// filenames (default: environment)")
+	backupCmd.Flags().BoolVar(&backupEncrypt, "encrypt", false, "Encrypt the archive with a passphrase (read from "+backupPassphraseEnv+" or prompted)")
 
 	_ = backupCmd.MarkFlagRequired("target")
 }
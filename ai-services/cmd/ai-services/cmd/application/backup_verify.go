@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var (
+	verifyPublicKey string
+	verifyS3Config  string
+)
+
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify [file]",
+	Short: "Verify a backup archive before restoring it",
+	Long: `Verify the integrity and compatibility of a backup archive without restoring it.
+
+Arguments:
+  [file] : Path or s3:// URL of the backup archive (required)
+
+The command checks:
+  - the manifest signature, when --public-key is given
+  - the SHA-256 checksum of every file listed in the manifest
+  - that every target in the archive is supported for the runtime in the
+    archive's schema version, and that its archive is well-formed
+
+Encrypted archives are decrypted with the passphrase from
+AI_SERVICES_BACKUP_PASSPHRASE or a prompt. Archives in S3-compatible object
+storage are streamed without a local copy, configured with --s3-config or the
+environment.`,
+	Example: `  # Verify a backup archive
+  ai-services application backup verify myapp_backup.tar.gz --runtime podman
+
+  # Verify that the archive was signed with a known key
+  ai-services application backup verify myapp_backup.tar.gz --public-key backup-key.pub --runtime podman
+
+  # Verify an archive in an S3-compatible bucket
+  ai-services application backup verify s3://backups/myapp/myapp_backup_20260101_020000.tar.gz --runtime podman`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		ctx := context.Background()
+
+		file, cfg, err := backupSource(args[0], verifyS3Config)
+		if err != nil {
+			return err
+		}
+
+		if cfg == nil {
+			if _, err := os.Stat(file); err != nil {
+				return fmt.Errorf("backup file not found: %s", file)
+			}
+		}
+
+		protection, err := restoreProtection(ctx, cfg, file, verifyPublicKey)
+		if err != nil {
+			return err
+		}
+
+		report, err := backuptarget.Verify(ctx, vars.RuntimeFactory.GetRuntimeType(), cfg, file, protection)
+		if err != nil {
+			return fmt.Errorf("backup archive is not valid: %w", err)
+		}
+
+		printVerifyReport(args[0], report)
+
+		return nil
+	},
+}
+
+// printVerifyReport prints what Verify found out about the archive.
+func printVerifyReport(file string, report *backuptarget.Report) {
+	logger.Infof("Archive:     %s\n", file)
+	logger.Infof("Encrypted:   %s\n", yesNo(report.Encrypted))
+
+	m := report.Manifest
+	if m == nil {
+		logger.Warningln("The archive has no manifest; it was written by an older CLI and its integrity cannot be checked")
+		logger.Infoln("✓ Backup archive is readable")
+
+		return
+	}
+
+	logger.Infof("Application: %s (%s)\n", m.Application, m.Runtime)
+	logger.Infof("Created:     %s by CLI %s\n", m.CreatedAt.Local().Format("2006-01-02 15:04:05 MST"), valueOrUnknown(m.CLIVersion))
+	logger.Infof("Manifest:    version %d\n", m.Version)
+
+	switch {
+	case report.SignatureVerified:
+		logger.Infoln("Signature:   verified")
+	case report.Signed:
+		logger.Infoln("Signature:   present, not checked (pass --public-key to check it)")
+	default:
+		logger.Infoln("Signature:   none")
+	}
+
+	entries := m.Targets
+	if m.Target != nil {
+		entries = []backuptarget.ManifestEntry{*m.Target}
+	}
+
+	logger.Infoln("Targets:")
+	for _, e := range entries {
+		logger.Infof("  - %s (schema version %d)%s\n", e.Name, e.SchemaVersion, documentSummary(e.Documents))
+	}
+
+	if len(m.Files) > 0 {
+		logger.Infof("Checksums:   %d file(s) verified\n", len(m.Files))
+	} else {
+		logger.Warningln("The manifest predates checksums; file integrity was not checked")
+	}
+
+	logger.Infoln("✓ Backup archive is valid")
+}
+
+// documentSummary formats the document counts of a target's indices.
+func documentSummary(documents map[string]int) string {
+	if len(documents) == 0 {
+		return ""
+	}
+
+	indices := make([]string, 0, len(documents))
+	for index := range documents {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+
+	counts := make([]string, 0, len(indices))
+	for _, index := range indices {
+		counts = append(counts, fmt.Sprintf("%s=%d", index, documents[index]))
+	}
+
+	return ": " + strings.Join(counts, ", ")
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func valueOrUnknown(v string) string {
+	if v == "" {
+		return "unknown"
+	}
+
+	return v
+}
+
+func init() {
+	backupVerifyCmd.Flags().StringVar(&verifyPublicKey, "public-key", "", "Path to an ed25519 public key (PEM) the archive must be signed with")
+	backupVerifyCmd.Flags().StringVar(&verifyS3Config, "s3-config", "", "Path to a JSON file with the object storage endpoint and credentials for s3:// archives (default: environment)")
+}
@@ -27,6 +27,7 @@ import (
 	cliutils "github.com/project-ai-services/ai-services/internal/pkg/cli/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/image"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
@@ -42,10 +43,11 @@ const (
 // Variables for flags placeholder.
 var (
 	// common flags.
-	templateName string
-	rawArgParams []string
-	argParams    map[string]string
-	legacyCreate bool
+	templateName   string
+	rawArgParams   []string
+	argParams      map[string]string
+	legacyCreate   bool
+	ignoreCapacity bool
 
 	// podman flags.
 	skipModelDownload     bool
@@ -53,6 +55,7 @@ var (
 	skipChecks            []string
 	valuesFiles           []string
 	rawArgImagePullPolicy string
+	autostart             bool
 
 	// openshift flags.
 	timeout time.Duration
@@ -196,6 +199,15 @@ func initCreateCommonFlags() {
 	)
 
 	createCmd.Flags().BoolVar(&legacyCreate, appFlags.Create.Legacy, false, "Use legacy application create implementation")
+
+	createCmd.Flags().BoolVar(
+		&ignoreCapacity,
+		appFlags.Create.IgnoreCapacity,
+		false,
+		"Deploy even if the application does not fit in the remaining host capacity\n\n"+
+			"By default the catalog rejects applications whose CPU, memory or Spyre card\n"+
+			"requirements exceed host capacity minus what existing applications reserve\n",
+	)
 }
 
 func initCreatePodmanFlags() {
@@ -223,6 +235,15 @@ func initCreatePodmanFlags() {
 			"- If left false in air-gapped environments → download attempt will fail\n"+
 			"Note: Supported for podman runtime only.\n",
 	)
+	createCmd.Flags().BoolVar(
+		&autostart,
+		appFlags.Create.Autostart,
+		true,
+		"Start the application pods when the host boots\n\n"+
+			"Generates a systemd unit per pod, ordered by the dependencies between the services\n"+
+			"Requires the host to be configured with 'ai-services bootstrap configure'\n"+
+			"Note: Supported for podman runtime only.\n",
+	)
 	initializeImagePullPolicyFlag()
 
 	// deprecated flags
@@ -273,13 +294,15 @@ func buildFlagValidator() *flagvalidator.FlagValidator {
 		AddCommonFlag(appFlags.Create.Template, validateTemplateFlag).
 		AddCommonFlag(appFlags.Create.Params, validateParamsFlag).
 		AddCommonFlag(appFlags.Create.Values, validateValuesFlag).
-		AddCommonFlag(appFlags.Create.Legacy, nil)
+		AddCommonFlag(appFlags.Create.Legacy, nil).
+		AddCommonFlag(appFlags.Create.IgnoreCapacity, nil)
 
 	// Register Podman-specific flags
 	builder.
 		AddPodmanFlag(appFlags.Create.SkipImageDownload, nil).
 		AddPodmanFlag(appFlags.Create.SkipModelDownload, nil).
-		AddPodmanFlag(appFlags.Create.ImagePullPolicy, validateImagePullPolicyFlag)
+		AddPodmanFlag(appFlags.Create.ImagePullPolicy, validateImagePullPolicyFlag).
+		AddPodmanFlag(appFlags.Create.Autostart, nil)
 
 	// Register OpenShift-specific flags
 	builder.
@@ -401,6 +424,10 @@ func createApp(appName string) error {
 	if err != nil {
 		return err
 	}
+	payload.IgnoreCapacity = ignoreCapacity
+	if vars.RuntimeFactory.GetRuntimeType() == types.RuntimeTypePodman {
+		payload.Autostart = &autostart
+	}
 
 	// 4. Create application via catalog API
 	logger.Infof("Creating application '%s' using template '%s'...\n", appName, templateName)
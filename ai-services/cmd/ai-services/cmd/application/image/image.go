@@ -42,7 +42,11 @@ func getCatalogImages(templateID string) ([]string, error) {
 func init() {
 	ImageCmd.AddCommand(listCmd)
 	ImageCmd.AddCommand(pullCmd)
-	ImageCmd.PersistentFlags().StringVarP(&templateName, "template", "t", "", "Application template name (Required)")
-	_ = ImageCmd.MarkPersistentFlagRequired("template")
+	ImageCmd.AddCommand(mirrorCmd)
+	ImageCmd.AddCommand(pruneCmd)
+	for _, cmd := range []*cobra.Command{listCmd, pullCmd, mirrorCmd} {
+		cmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template name (Required)")
+		_ = cmd.MarkFlagRequired("template")
+	}
 	ImageCmd.PersistentFlags().BoolVar(&legacyImage, "legacy", false, "Use legacy application image implementation")
 }
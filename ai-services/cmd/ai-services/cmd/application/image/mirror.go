@@ -0,0 +1,136 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/image"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+var (
+	mirrorTo           string
+	mirrorTLSVerify    bool
+	mirrorUpdateConfig bool
+)
+
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror",
+	Short: "Copies all container images for a given application template to a private registry",
+	Long: `Copies every container image a template needs to a private registry, for hosts
+without internet access. Images keep their repository path below the target and
+are copied with all their platforms and unchanged digests.
+
+Unless --update-config=false is given, the source registries are recorded in
+the registry mirror configuration (registry-mirrors.yaml in the base directory).
+Image pulls and deployed pod templates then use the mirror instead of the
+source registry, for example:
+
+  mirrors:
+    - source: icr.io
+      mirror: registry.local/ns
+
+Credentials for both registries are read from REGISTRY_AUTH_FILE or the default
+podman auth file; log in with 'podman login' first.`,
+	Example: `  # Mirror the images of Digital Assistant into a private registry
+  ai-services application image mirror --template rag --to registry.local/ai-services
+
+  # Mirror to a registry with a self-signed certificate
+  ai-services application image mirror --template chat --to registry.local:5000/ns --tls-verify=false`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Once precheck passes, silence usage for any *later* internal errors.
+		cmd.SilenceUsage = true
+
+		return mirrorImages(cmd.Context(), templateName)
+	},
+}
+
+func init() {
+	mirrorCmd.Flags().StringVar(&mirrorTo, "to", "", "Registry and namespace to mirror the images to, e.g. registry.local/ns (Required)")
+	_ = mirrorCmd.MarkFlagRequired("to")
+	mirrorCmd.Flags().BoolVar(&mirrorTLSVerify, "tls-verify", true, "Verify the certificate of the target registry")
+	mirrorCmd.Flags().BoolVar(&mirrorUpdateConfig, "update-config", true, "Record the mirror in the registry mirror configuration")
+}
+
+func mirrorImages(ctx context.Context, templateID string) error {
+	images, err := templateImages(templateID)
+	if err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		logger.Infoln("No images to mirror")
+
+		return nil
+	}
+
+	opts := mirror.CopyOptions{AuthFile: os.Getenv("REGISTRY_AUTH_FILE"), TLSVerify: mirrorTLSVerify}
+	sources := map[string]bool{}
+
+	logger.Infof("Mirroring %d images for template '%s' to %s...\n", len(images), templateID, mirrorTo)
+	for _, img := range images {
+		source, err := mirror.Source(img)
+		if err != nil {
+			return err
+		}
+		sources[source] = true
+
+		var result *mirror.Result
+		if err := utils.Retry(ctx, vars.RetryCount, vars.RetryInterval, nil, func() error {
+			var err error
+			result, err = mirror.Copy(ctx, img, mirrorTo, opts)
+
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to mirror image: %w", err)
+		}
+
+		logger.Infof("- %s -> %s@%s\n", result.Source, result.Destination, result.Digest)
+	}
+
+	logger.Infof("Successfully mirrored all images for template '%s'\n", templateID)
+
+	if !mirrorUpdateConfig {
+		return nil
+	}
+
+	config, err := mirror.Load()
+	if err != nil {
+		return err
+	}
+	for _, source := range utils.ExtractMapKeys(sources) {
+		config.Set(source, mirrorTo)
+	}
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	logger.Infof("Registry mirror configuration updated: %s\n", utils.GetRegistryMirrorsPath())
+
+	return nil
+}
+
+// templateImages returns the images of a catalog template or, with --legacy, of
+// a legacy application template.
+func templateImages(templateID string) ([]string, error) {
+	if !legacyImage {
+		return getCatalogImages(templateID)
+	}
+
+	img := &image.Images{
+		AppTemplate: templateID,
+	}
+	images, err := img.ListImages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %w", err)
+	}
+
+	return images, nil
+}
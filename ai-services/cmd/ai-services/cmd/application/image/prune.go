@@ -0,0 +1,89 @@
+package image
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var pruneDryRun bool
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove images no application refers to",
+	Long: `Removes the images of the catalog templates and of the configured registry
+mirrors on the catalog host that no container uses and that are not referenced by
+the templates of the deployed applications, the digests their images are pinned
+to, the tool image or an imported offline bundle. Images pulled through a registry
+mirror count as their source image. Other images on the host are never removed.
+
+Supported for the podman runtime only; log in first with 'ai-services catalog login'.
+The catalog refuses to prune while an application is downloading or deploying.`,
+	Example: `  # Show what would be removed and the space it would free
+  ai-services application image prune --runtime podman --dry-run
+
+  # Remove unreferenced images
+  ai-services application image prune --runtime podman`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.PruneImages(pruneDryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range resp.Errors {
+			logger.Warningln(e)
+		}
+
+		if len(resp.Removed) == 0 {
+			logger.Infoln("No unreferenced images found.")
+
+			return nil
+		}
+
+		verb := "Removed"
+		if resp.DryRun {
+			verb = "Would remove"
+		}
+		for _, img := range resp.Removed {
+			name := "<none>"
+			if len(img.Names) > 0 {
+				name = strings.Join(img.Names, ", ")
+			}
+			logger.Infof("%s %s %s (%s)\n", verb, shortID(img.ID), name, utils.FormatBytes(img.Size))
+		}
+
+		if resp.DryRun {
+			logger.Infof("\n%d image(s), %s would be reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+		} else {
+			logger.Infof("\n%d image(s) removed, %s reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+		}
+
+		return nil
+	},
+}
+
+// shortID returns the first 12 characters of an image ID, as podman prints it.
+func shortID(id string) string {
+	const shortIDLength = 12
+	if len(id) > shortIDLength {
+		return id[:shortIDLength]
+	}
+
+	return id
+}
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only show what would be removed")
+}
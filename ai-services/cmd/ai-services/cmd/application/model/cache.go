@@ -0,0 +1,323 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	tablePadding = 3
+	revisionLen  = 12
+
+	outputWide = "wide"
+	outputJSON = "json"
+)
+
+var errUnhealthyModels = errors.New("model cache verification failed")
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the models cached on the catalog host",
+		Long: `Inspect, verify and prune the models downloaded to the models directory of the
+catalog host.
+
+Note:
+  - Supports only podman runtime
+  - Requires prior authentication via 'ai-services catalog login'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCacheListCmd())
+	cmd.AddCommand(newCacheVerifyCmd())
+	cmd.AddCommand(newCachePruneCmd())
+
+	return cmd
+}
+
+func newCacheListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List cached models and the applications using them",
+		Example: `  # List cached models
+  ai-services application model cache list --runtime podman
+
+  # Include revision, source and components
+  ai-services application model cache list --runtime podman -o wide`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput(output)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.ListCachedModels()
+			if err != nil {
+				return err
+			}
+
+			if output == outputJSON {
+				return printJSON(resp)
+			}
+
+			return printCacheTable(resp, output == outputWide)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format (options: wide, json)")
+
+	return cmd
+}
+
+func newCacheVerifyCmd() *cobra.Command {
+	var (
+		model  string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify cached model files against their checksums",
+		Long: `Checks every file of the cached models against the SHA-256 checksums recorded when
+they were downloaded or imported. Models downloaded by older releases are checked
+against the checksums Hugging Face reported for them.
+
+Exits with an error when a file is missing or does not match.`,
+		Example: `  # Verify all cached models
+  ai-services application model cache verify --runtime podman
+
+  # Verify one model
+  ai-services application model cache verify --runtime podman --model ibm-granite/granite-3.3-8b-instruct`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput(output)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.VerifyCachedModels(model)
+			if err != nil {
+				return err
+			}
+
+			if output == outputJSON {
+				if err := printJSON(resp); err != nil {
+					return err
+				}
+			} else {
+				printVerification(resp)
+			}
+
+			if !resp.Healthy {
+				return errUnhealthyModels
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&model, "model", "", "Only verify this model")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format (options: json)")
+
+	return cmd
+}
+
+func newCachePruneCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached models no application uses",
+		Long: `Removes the cached models that no application component is configured to serve.
+Custom models are kept; remove them with 'ai-services application model custom unregister'.
+
+The catalog refuses to prune while an application is downloading or deploying.`,
+		Example: `  # Show what would be removed
+  ai-services application model cache prune --runtime podman --dry-run
+
+  # Remove unreferenced models
+  ai-services application model cache prune --runtime podman`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.PruneCachedModels(dryRun)
+			if err != nil {
+				return err
+			}
+
+			if len(resp.Removed) == 0 {
+				logger.Infoln("No unreferenced models found.")
+
+				return nil
+			}
+
+			verb := "Removed"
+			if resp.DryRun {
+				verb = "Would remove"
+			}
+			for _, m := range resp.Removed {
+				logger.Infof("%s %s (%s)\n", verb, m.Model, utils.FormatBytes(m.Size))
+			}
+
+			if resp.DryRun {
+				logger.Infof("\n%d model(s), %s would be reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+			} else {
+				logger.Infof("\n%d model(s) removed, %s reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show what would be removed")
+
+	return cmd
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", outputWide, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (options: %s, %s)", output, outputWide, outputJSON)
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
+
+// printCacheTable writes a tab-aligned list of cached models to stdout.
+func printCacheTable(resp *catalogtypes.ModelListResponse, wide bool) error {
+	if len(resp.Models) == 0 {
+		logger.Infoln("No cached models found.")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, tablePadding, ' ', 0)
+	header := "MODEL\tSIZE\tDOWNLOADED\tUSED BY"
+	if wide {
+		header += "\tREVISION\tSOURCE\tCOMPONENTS"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+
+	for _, m := range resp.Models {
+		apps, components := usedBy(m.UsedBy)
+		row := []string{m.Model, utils.FormatBytes(m.Size), m.StoredAt.Local().Format(time.DateTime), orDash(apps)}
+		if wide {
+			row = append(row, orDash(shortRevision(m.Revision)), orDash(m.Source), orDash(components))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	logger.Infof("\n%d model(s), %s total, %s unreferenced\n",
+		resp.Total, utils.FormatBytes(resp.TotalSize), utils.FormatBytes(resp.UnreferencedSize))
+
+	return nil
+}
+
+// printVerification reports the verification result of every model.
+func printVerification(resp *catalogtypes.ModelVerifyResponse) {
+	if len(resp.Models) == 0 {
+		logger.Infoln("No cached models found.")
+
+		return
+	}
+
+	for _, m := range resp.Models {
+		switch {
+		case m.Error != "":
+			logger.Errorf("%s: %s\n", m.Model, m.Error)
+		case len(m.Failed) > 0:
+			logger.Errorf("%s: %d of %d file(s) missing or corrupted: %s\n",
+				m.Model, len(m.Failed), m.Checked, strings.Join(m.Failed, ", "))
+		default:
+			logger.Infof("%s: %d file(s) verified against %s\n", m.Model, m.Checked, m.VerifiedAgainst)
+		}
+		if len(m.Unverifiable) > 0 {
+			logger.Warningf("%s: %d file(s) have no recorded checksum\n", m.Model, len(m.Unverifiable))
+		}
+	}
+}
+
+// usedBy returns the distinct application names and components referencing a model.
+func usedBy(refs []catalogtypes.ModelReference) (string, string) {
+	var apps, components []string
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		if ref.ApplicationName != "" && !seen[ref.ApplicationName] {
+			seen[ref.ApplicationName] = true
+			apps = append(apps, ref.ApplicationName)
+		}
+		if ref.Component == "" {
+			components = append(components, "pod/"+ref.Pod)
+
+			continue
+		}
+		components = append(components, ref.Component)
+	}
+
+	return strings.Join(apps, ","), strings.Join(components, ",")
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > revisionLen {
+		return revision[:revisionLen]
+	}
+
+	return revision
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,260 @@
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	registrationPollInterval = 2 * time.Second
+
+	statusRegistering = "registering"
+	statusFailed      = "failed"
+)
+
+func newCustomCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "custom",
+		Short: "Manage custom models registered in the catalog",
+		Long: `Register fine-tuned or private models with the catalog so that applications can serve
+them. A model is registered from a directory on the catalog host or from an OCI
+artifact or modelcar image, and can then be selected as the model of the providers
+of its component type that accept custom models, e.g. vllm-spyre and vllm-cpu.
+
+Note:
+  - Supports only podman runtime
+  - Requires prior authentication via 'ai-services catalog login'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCustomRegisterCmd())
+	cmd.AddCommand(newCustomListCmd())
+	cmd.AddCommand(newCustomUnregisterCmd())
+
+	return cmd
+}
+
+func newCustomRegisterCmd() *cobra.Command {
+	var (
+		req           catalogtypes.RegisterModelRequest
+		passwordStdin bool
+		tlsVerify     bool
+		wait          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "register <name>",
+		Short: "Register a custom model",
+		Long: `Registers a custom model under <name>, a model id of the form <name> or
+<organisation>/<name> that must not clash with a model of the catalog.
+
+--path is a directory below the base directory of the catalog host holding the
+Hugging Face model files, including config.json. --image is an ORAS artifact whose
+layers are the model files, or a modelcar image holding them below /models.`,
+		Example: `  # Register a fine-tuned model copied to the catalog host
+  ai-services application model custom register acme/granite-support --type llm \
+    --path /var/lib/ai-services/imports/granite-support --runtime podman --wait
+
+  # Register a model published as an OCI artifact
+  echo "$TOKEN" | ai-services application model custom register acme/granite-support --type llm \
+    --image quay.io/acme/granite-support:1.0 --registry-username acme --registry-password-stdin --runtime podman`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if (req.Path == "") == (req.Image == "") {
+				return errors.New("exactly one of --path and --image is required")
+			}
+			if passwordStdin && req.Username == "" {
+				return errors.New("--registry-password-stdin requires --registry-username")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			req.Name = args[0]
+			req.SkipTLSVerify = !tlsVerify
+			if passwordStdin {
+				password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil && password == "" {
+					return fmt.Errorf("failed to read registry password from stdin: %w", err)
+				}
+				req.Password = strings.TrimSpace(password)
+			}
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			model, err := c.RegisterModel(req)
+			if err != nil {
+				return err
+			}
+			logger.Infof("Registering model %s from %s\n", model.Name, model.Source)
+
+			if !wait {
+				logger.Infoln("Follow the registration with 'ai-services application model custom list'")
+
+				return nil
+			}
+
+			return waitForRegistration(c, model.Name)
+		},
+	}
+
+	cmd.Flags().StringVar(&req.ComponentType, "type", "", "Component type serving the model (options: llm, embedding, reranker)")
+	cmd.Flags().StringVar(&req.Description, "description", "", "Description shown next to the model")
+	cmd.Flags().StringVar(&req.Path, "path", "", "Directory on the catalog host holding the model files")
+	cmd.Flags().StringVar(&req.Image, "image", "", "OCI artifact or modelcar image holding the model files")
+	cmd.Flags().StringVar(&req.Username, "registry-username", "", "Username for the registry of --image")
+	cmd.Flags().BoolVar(&passwordStdin, "registry-password-stdin", false, "Read the registry password from stdin")
+	cmd.Flags().BoolVar(&tlsVerify, "tls-verify", true, "Verify the certificate of the registry of --image")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the model files are in place")
+	_ = cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+// waitForRegistration polls the model until its files are placed.
+func waitForRegistration(c *client.Client, name string) error {
+	for {
+		model, err := c.GetRegisteredModel(name)
+		if err != nil {
+			return err
+		}
+
+		switch model.Status {
+		case statusRegistering:
+			time.Sleep(registrationPollInterval)
+		case statusFailed:
+			return fmt.Errorf("registration of model %s failed: %s", name, model.Error)
+		default:
+			logger.Infof("Model %s is ready (%s)\n", name, formatSize(model.SizeBytes))
+
+			return nil
+		}
+	}
+}
+
+func newCustomListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List custom models",
+		Example: `  # List custom models
+  ai-services application model custom list --runtime podman
+
+  # Include source, digest and errors
+  ai-services application model custom list --runtime podman -o wide`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput(output)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			resp, err := c.ListRegisteredModels()
+			if err != nil {
+				return err
+			}
+
+			if output == outputJSON {
+				return printJSON(resp)
+			}
+
+			return printCustomTable(resp, output == outputWide)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output format (options: wide, json)")
+
+	return cmd
+}
+
+func newCustomUnregisterCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unregister <name>",
+		Short: "Unregister a custom model and remove its files",
+		Long: `Unregisters a custom model and removes its files from the models directory.
+
+The catalog refuses while an application component is configured to serve the model.`,
+		Example: `  ai-services application model custom unregister acme/granite-support --runtime podman`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.New()
+			if err != nil {
+				return err
+			}
+
+			if err := c.UnregisterModel(args[0]); err != nil {
+				return err
+			}
+			logger.Infof("Model %s unregistered\n", args[0])
+
+			return nil
+		},
+	}
+}
+
+// printCustomTable writes a tab-aligned list of custom models to stdout.
+func printCustomTable(resp *catalogtypes.RegisteredModelListResponse, wide bool) error {
+	if len(resp.Models) == 0 {
+		logger.Infoln("No custom models registered.")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, tablePadding, ' ', 0)
+	header := "NAME\tTYPE\tSTATUS\tSIZE\tREGISTERED"
+	if wide {
+		header += "\tSOURCE\tDIGEST\tERROR"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+
+	for _, m := range resp.Models {
+		row := []string{m.Name, m.ComponentType, m.Status, formatSize(m.SizeBytes), m.CreatedAt.Local().Format(time.DateTime)}
+		if wide {
+			row = append(row, m.Source, orDash(shortRevision(strings.TrimPrefix(m.Digest, "sha256:"))), orDash(m.Error))
+		}
+
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+func formatSize(size *int64) string {
+	if size == nil {
+		return "-"
+	}
+
+	return utils.FormatBytes(*size)
+}
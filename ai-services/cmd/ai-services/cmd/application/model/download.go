@@ -6,7 +6,9 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 	"github.com/spf13/cobra"
@@ -22,6 +24,9 @@ var downloadCmd = &cobra.Command{
 Note:
   - Supports only podman runtime
   - Models are downloaded to the default models directory unless --dir is specified
+  - Interrupted downloads resume where they stopped; every file is verified against
+    the checksum reported by the hub
+  - Set HF_TOKEN for gated or private models
   - Use 'ai-services application model list' to see available models for a template`,
 	Example: `  # Download models for Digital Assistant
 	 ai-services application model download --template rag --runtime podman
@@ -32,6 +37,9 @@ Note:
 	 # Download models to a custom directory
 	 ai-services application model download --template chat --dir /path/to/models --runtime podman
 
+	 # Download models from a Hugging Face mirror, 8 files at a time
+	 ai-services application model download --template rag --hf-endpoint https://hf-mirror.example.com --parallel 8 --runtime podman
+
 	 # Download models using legacy implementation
 	 ai-services application model download --template rag --legacy --runtime podman`,
 	Args: cobra.MaximumNArgs(0),
@@ -47,9 +55,11 @@ Note:
 func init() {
 	downloadCmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template name(Required)")
 	_ = downloadCmd.MarkFlagRequired("template")
-	downloadCmd.Flags().StringVar(&vars.ToolImage, "tool-image", vars.ToolImage, "Tool container image used for downloading the model (for development purposes only)")
-	_ = downloadCmd.Flags().MarkHidden("tool-image")
 	downloadCmd.Flags().StringVar(&modelDirectory, "dir", utils.GetModelsPath(), "Directory to download the model files")
+	downloadCmd.Flags().IntVar(&vars.ModelDownloadConcurrency, "parallel", 0,
+		fmt.Sprintf("Number of files to download at the same time (default from model-download.yaml, else %d)", modelstore.DefaultConcurrency))
+	downloadCmd.Flags().StringVar(&vars.HFEndpoint, "hf-endpoint", "",
+		fmt.Sprintf("Hugging Face-compatible endpoint to download from (default $%s, model-download.yaml, else %s)", modelstore.EndpointEnv, modelstore.DefaultEndpoint))
 }
 
 func download(cmd *cobra.Command) error {
@@ -92,16 +102,21 @@ func downloadCatalogModels(templateID string) error {
 		return nil
 	}
 
-	// Download all models
 	logger.Infof("Downloading %d models for template '%s'...\n", len(models), templateID)
 
-	for _, model := range models {
-		logger.Infof("Downloading model: %s\n", model)
+	ctx := context.Background()
+	s := spinner.New("Downloading models...")
+	s.Start(ctx)
 
-		if err := helpers.DownloadModelContainer(context.Background(), model, modelDirectory); err != nil {
-			return fmt.Errorf("failed to download model %s: %w", model, err)
-		}
+	err = helpers.DownloadModels(ctx, models, modelDirectory, func(p modelstore.Progress) {
+		s.UpdateMessage("Downloading models: " + p.String())
+	})
+	if err != nil {
+		s.Fail("failed to download models")
+
+		return err
 	}
+	s.Stop("Model download completed.")
 
 	logger.Infof("Successfully downloaded all models for template '%s'\n", templateID)
 
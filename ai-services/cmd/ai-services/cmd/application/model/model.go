@@ -17,7 +17,8 @@ var (
 		Use:   "model",
 		Short: "Manage application models",
 		Long: `Manage AI models for application templates.
-This command provides subcommands to list and download models required by application templates.`,
+This command provides subcommands to list and download models required by application templates,
+and to manage the models cached on the catalog host.`,
 		Args: cobra.MaximumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
@@ -31,6 +32,8 @@ This command provides subcommands to list and download models required by applic
 func init() {
 	ModelCmd.AddCommand(listCmd)
 	ModelCmd.AddCommand(downloadCmd)
+	ModelCmd.AddCommand(newCacheCmd())
+	ModelCmd.AddCommand(newCustomCmd())
 	ModelCmd.PersistentFlags().BoolVar(&legacyModel, "legacy", false, "Use legacy application model implementation")
 }
 
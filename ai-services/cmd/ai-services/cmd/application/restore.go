@@ -4,22 +4,29 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/application"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
 	appTypes "github.com/project-ai-services/ai-services/internal/pkg/application/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 var (
-	restoreTarget   string
-	restoreFilename string
-	restoreAutoYes  bool
+	restoreTarget    string
+	restoreFilename  string
+	restoreAutoYes   bool
+	restorePublicKey string
+	restoreS3Config  string
+	restoreRenames   []string
+	restorePrefix    string
+	restoreSkip      bool
 )
 
 var restoreCmd = &cobra.Command{
@@ -30,9 +37,30 @@ var restoreCmd = &cobra.Command{
 Arguments:
   [name] : Application name (required)
 
-Supported targets:
-  - opensearch: Restore OpenSearch indices and data (Podman and OpenShift)
-  - digitize:   Restore digitize metadata (jobs and documents) (Podman and OpenShift)
+Targets:
+  A target covers the data of one component or service of the application and
+  is offered when its catalog metadata declares restore support on the current
+  runtime. "all" restores every target listed in the manifest of a combined
+  archive, and a single target can also be restored from one. An invalid
+  --target lists the targets that are available.
+
+The archive manifest and checksums are verified before any data is restored.
+Encrypted archives are decrypted with the passphrase from
+AI_SERVICES_BACKUP_PASSPHRASE or a prompt. With --public-key the archive must
+be signed by the matching ed25519 key.
+
+A --filename of the form s3://bucket/key streams the archive from
+S3-compatible object storage without a local copy, configured with --s3-config
+or the environment as for 'ai-services application backup'.
+
+OpenSearch backups in the scroll format restore into applications on either
+runtime and under any name, e.g. to promote a RAG corpus from a development
+application into production. Podman backups are snapshots by default, which
+restore only on Podman; take them with 'ai-services application backup
+--portable' to restore them into OpenShift. --rename-index and --index-prefix
+restore indices under new names next to existing data; --skip-existing leaves
+indices that already exist untouched. Indices that do not start with "rag" are restored but
+not picked up by later backups.
 
 Note:
   - WARNING: Restore will overwrite existing data`,
@@ -43,12 +71,27 @@ Note:
   # Restore with automatic confirmation
   ai-services application restore myapp --target digitize --filename backup.tar.gz --runtime podman --yes
 
+  # Restore every target from a combined archive
+  ai-services application restore myapp --target all --filename myapp_backup.tar.gz --runtime podman
+
+  # Restore every target from an archive in an S3-compatible bucket
+  ai-services application restore myapp --target all --filename s3://backups/myapp/myapp_backup_20260101_020000.tar.gz --runtime podman
+
+  # Promote the corpus of a development application next to the existing indices
+  ai-services application restore prodapp --target opensearch --filename devapp_backup.tar.gz --runtime podman --index-prefix promoted-
+
+  # Restore one index under a new name and keep every index that already exists
+  ai-services application restore prodapp --target opensearch --filename devapp_backup.tar.gz --runtime podman --rename-index rag_docs=rag_docs_v2 --skip-existing
+
   For OpenShift:
   # Restore OpenSearch data with OpenShift
   ai-services application restore myapp --target opensearch --filename backup.tar.gz --runtime openshift
 
   # Restore digitize data with OpenShift
-  ai-services application restore myapp --target digitize --filename digitize_backup.tar.gz --runtime openshift `,
+  ai-services application restore myapp --target digitize --filename digitize_backup.tar.gz --runtime openshift
+
+  # Restore a backup taken with Podman into an OpenShift application
+  ai-services application restore prodapp --target opensearch --filename devapp_backup.tar.gz --runtime openshift --skip-existing `,
 	Args: cobra.ExactArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		target := restoreTarget
@@ -57,18 +100,8 @@ Note:
 		// Once precheck passes, silence usage for any later internal errors
 		cmd.SilenceUsage = true
 
-		// Validate target
-		validTargets := []string{"opensearch", "digitize"}
-		isValid := false
-		for _, t := range validTargets {
-			if target == t {
-				isValid = true
-
-				break
-			}
-		}
-		if !isValid {
-			return fmt.Errorf("invalid target '%s'. Valid targets are: %s", target, strings.Join(validTargets, ", "))
+		if err := validateBackupTarget(target, backuptarget.HookRestore); err != nil {
+			return err
 		}
 
 		// Validate filename extension
@@ -76,6 +109,15 @@ Note:
 			return fmt.Errorf("backup file must have .tar.gz extension, got: %s", filename)
 		}
 
+		if _, err := restoreIndexMapping(); err != nil {
+			return err
+		}
+
+		// Archives in object storage are checked when they are read
+		if objectstore.IsURL(filename) {
+			return nil
+		}
+
 		// Check if file exists
 		if _, err := os.Stat(filename); os.IsNotExist(err) {
 			return fmt.Errorf("backup file not found: %s", filename)
@@ -90,12 +132,6 @@ Note:
 		rt := vars.RuntimeFactory.GetRuntimeType()
 		logger.Infof("Runtime: %s\n", rt)
 
-		// Get absolute path to backup file
-		absFilename, err := filepath.Abs(restoreFilename)
-		if err != nil {
-			return fmt.Errorf("failed to get absolute path for backup file: %w", err)
-		}
-
 		// Display warning and get confirmation unless --yes flag is used
 		if !restoreAutoYes {
 			logger.Warningln("This operation will overwrite existing data!")
@@ -118,12 +154,31 @@ Note:
 			return fmt.Errorf("failed to create application instance: %w", err)
 		}
 
+		// Resolve the backup file, or the object storage it is streamed from
+		absFilename, cfg, err := backupSource(restoreFilename, restoreS3Config)
+		if err != nil {
+			return err
+		}
+
+		protection, err := restoreProtection(ctx, cfg, absFilename, restorePublicKey)
+		if err != nil {
+			return err
+		}
+
+		indices, err := restoreIndexMapping()
+		if err != nil {
+			return err
+		}
+
 		// Create restore options
 		opts := appTypes.RestoreOptions{
-			Name:       applicationName,
-			Target:     restoreTarget,
-			BackupFile: absFilename,
-			AutoYes:    restoreAutoYes,
+			Name:        applicationName,
+			Target:      restoreTarget,
+			BackupFile:  absFilename,
+			ObjectStore: cfg,
+			AutoYes:     restoreAutoYes,
+			Protection:  protection,
+			Indices:     indices,
 		}
 
 		// Execute restore using the application interface
@@ -137,10 +192,68 @@ Note:
 	},
 }
 
+// restoreIndexMapping builds the index mapping from the --rename-index,
+// --index-prefix and --skip-existing flags.
+func restoreIndexMapping() (opensearch.IndexMapping, error) {
+	renames, err := opensearch.ParseRenames(restoreRenames)
+	if err != nil {
+		return opensearch.IndexMapping{}, err
+	}
+
+	for _, to := range renames {
+		if err := opensearch.ValidateIndexName(to); err != nil {
+			return opensearch.IndexMapping{}, fmt.Errorf("invalid --rename-index target: %w", err)
+		}
+	}
+	if restorePrefix != "" {
+		// The prefix must itself start a valid index name.
+		if err := opensearch.ValidateIndexName(restorePrefix + "index"); err != nil {
+			return opensearch.IndexMapping{}, fmt.Errorf("invalid --index-prefix: %w", err)
+		}
+	}
+
+	return opensearch.IndexMapping{Rename: renames, Prefix: restorePrefix, SkipExisting: restoreSkip}, nil
+}
+
+// restoreProtection loads the public key to check file with and, for encrypted
+// archives, the passphrase to decrypt it with. cfg is used for s3:// files.
+func restoreProtection(ctx context.Context, cfg *objectstore.Config, file, publicKeyFile string) (backuptarget.Protection, error) {
+	var protection backuptarget.Protection
+
+	if publicKeyFile != "" {
+		key, err := backuptarget.LoadPublicKey(publicKeyFile)
+		if err != nil {
+			return protection, err
+		}
+		protection.PublicKey = key
+	}
+
+	encrypted, err := backuptarget.IsEncryptedArchive(ctx, cfg, file)
+	if err != nil {
+		return protection, err
+	}
+
+	if encrypted {
+		passphrase, err := readBackupPassphrase(false)
+		if err != nil {
+			return protection, err
+		}
+		protection.Passphrase = passphrase
+	}
+
+	return protection, nil
+}
+
 func init() {
-	restoreCmd.Flags().StringVar(&restoreTarget, "target", "", "Target to restore (opensearch, digitize) (required)")
-	restoreCmd.Flags().StringVar(&restoreFilename, "filename", "", "Path to the backup tar.gz file (required)")
+	restoreCmd.Flags().StringVar(&restoreTarget, "target", "", "Target to restore, as declared by the catalog metadata, or all (required)")
+	restoreCmd.Flags().StringVar(&restoreFilename, "filename", "", "Path or s3:// URL of the backup tar.gz file (required)")
+	restoreCmd.Flags().StringVar(&restoreS3Config, "s3-config", "", "Path to a JSON file with the object storage endpoint and credentials for s3:// filenames (default: environment)")
 	restoreCmd.Flags().BoolVarP(&restoreAutoYes, "yes", "y", false, "Automatically accept all confirmation prompts (default=false)")
+	restoreCmd.Flags().StringVar(&restorePublicKey, "public-key", "", "Path to an ed25519 public key (PEM) the archive must be signed with")
+
+	restoreCmd.Flags().StringArrayVar(&restoreRenames, "rename-index", nil, "Restore an OpenSearch index under a new name, as old=new (repeatable)")
+	restoreCmd.Flags().StringVar(&restorePrefix, "index-prefix", "", "Prefix for the names OpenSearch indices are restored as, unless renamed with --rename-index")
+	restoreCmd.Flags().BoolVar(&restoreSkip, "skip-existing", false, "Leave OpenSearch indices that already exist untouched instead of replacing them")
 
 	_ = restoreCmd.MarkFlagRequired("target")
 	_ = restoreCmd.MarkFlagRequired("filename")
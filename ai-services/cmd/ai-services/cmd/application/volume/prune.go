@@ -0,0 +1,71 @@
+package volume
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+var pruneDryRun bool
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove volumes of deleted applications and components",
+	Long: `Removes the unused volumes named after an application or component that no longer
+exists, such as those kept when an application was deleted. Volumes not created for
+a catalog application or component are never removed.
+
+Supported for the podman runtime only; log in first with 'ai-services catalog login'.
+The catalog refuses to prune while an application is downloading or deploying.`,
+	Example: `  # Show what would be removed and the space it would free
+  ai-services application volume prune --runtime podman --dry-run
+
+  # Remove orphaned volumes
+  ai-services application volume prune --runtime podman`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		c, err := client.New()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.PruneVolumes(pruneDryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range resp.Errors {
+			logger.Warningln(e)
+		}
+
+		if len(resp.Removed) == 0 {
+			logger.Infoln("No orphaned volumes found.")
+
+			return nil
+		}
+
+		verb := "Removed"
+		if resp.DryRun {
+			verb = "Would remove"
+		}
+		for _, v := range resp.Removed {
+			logger.Infof("%s %s (%s)\n", verb, v.Name, utils.FormatBytes(v.Size))
+		}
+
+		if resp.DryRun {
+			logger.Infof("\n%d volume(s), %s would be reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+		} else {
+			logger.Infof("\n%d volume(s) removed, %s reclaimed\n", len(resp.Removed), utils.FormatBytes(resp.Reclaimed))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only show what would be removed")
+}
@@ -0,0 +1,21 @@
+package volume
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// VolumeCmd groups the commands managing the volumes of applications.
+var VolumeCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "Manage application volumes",
+	Long: `Manage the volumes the applications and components deployed from the catalog
+store their data in.`,
+	Args: cobra.MaximumNArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+func init() {
+	VolumeCmd.AddCommand(pruneCmd)
+}
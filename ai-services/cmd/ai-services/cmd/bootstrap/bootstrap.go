@@ -43,6 +43,7 @@ func BootstrapCmd() *cobra.Command {
 	// subcommands
 	bootstrapCmd.AddCommand(validateCmd())
 	bootstrapCmd.AddCommand(configureCmd())
+	bootstrapCmd.AddCommand(syncUnitsCmd())
 
 	return bootstrapCmd
 }
@@ -132,8 +133,11 @@ Available subcommands:
 Configure - Configure performs below actions
 - For Podman:
  - Installs podman on host if not installed
+ - For rootless podman (run with sudo by a non-root user), configures subordinate IDs,
+   lingering, cgroup delegation and user-owned data directories
  - Runs servicereport tool to configure required spyre cards
  - Initializes the AI Services infrastructure
+ - Installs the systemd path unit that starts application pods on boot
 
 - For OpenShift:
  - Applies required machine configs for Spyre operator
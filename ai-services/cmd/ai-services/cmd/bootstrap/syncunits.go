@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// syncUnitsCmd represents the sync-units subcommand of bootstrap, run by the
+// path unit installed by configure whenever the catalog writes pod units.
+func syncUnitsCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:    "sync-units",
+		Short:  "Installs the systemd units of application pods",
+		Long:   `Installs, enables and removes the systemd units starting application pods on boot, as written by the catalog.`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			result, err := systemd.Sync(dir, systemd.SystemUnitDir)
+			logSyncResult(result)
+			if err != nil {
+				return fmt.Errorf("failed to sync systemd units: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", utils.GetSystemdUnitsPath(), "directory the catalog writes the pod units to")
+
+	return cmd
+}
+
+func logSyncResult(result *systemd.SyncResult) {
+	if result == nil {
+		return
+	}
+	for action, units := range map[string][]string{"Installed": result.Installed, "Updated": result.Updated, "Removed": result.Removed} {
+		if len(units) > 0 {
+			logger.Infof("%s units: %s\n", action, strings.Join(units, ", "))
+		}
+	}
+}
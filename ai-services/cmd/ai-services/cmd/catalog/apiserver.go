@@ -14,8 +14,14 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver"
 	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	acceleratorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/accelerator"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	backupsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/backup"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	eventsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	modelcachesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/modelcache"
+	prunesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/prune"
+	registeredmodelsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/registeredmodel"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/sync"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db"
@@ -83,6 +89,23 @@ func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, secretKey, a
 	svcRepo := repository.NewServiceRepository(pool)
 	compRepo := repository.NewComponentRepository(pool)
 	svcDepRepo := repository.NewServiceDependencyRepository(pool)
+	appImageRepo := repository.NewApplicationImageRepository(pool)
+	backupJobRepo := repository.NewBackupJobRepository(pool)
+	backupScheduleRepo := repository.NewBackupScheduleRepository(pool)
+	eventRepo := repository.NewApplicationEventRepository(pool)
+	registeredModelRepo := repository.NewRegisteredModelRepository(pool)
+
+	// Jobs still pending or running were interrupted by the previous shutdown.
+	if n, err := backupJobRepo.FailUnfinished(ctx, "interrupted by API server restart"); err != nil {
+		logger.Warningf("Failed to mark interrupted backup jobs as failed: %v\n", err)
+	} else if n > 0 {
+		logger.Infof("Marked %d interrupted backup job(s) as failed\n", n)
+	}
+	if n, err := registeredModelRepo.FailUnfinished(ctx, "interrupted by API server restart"); err != nil {
+		logger.Warningf("Failed to mark interrupted model registrations as failed: %v\n", err)
+	} else if n > 0 {
+		logger.Infof("Marked %d interrupted model registration(s) as failed\n", n)
+	}
 
 	// Initialize sync service for background DB-Pod synchronization
 	// TODO: implement sync service on remote machines
@@ -113,18 +136,55 @@ func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, secretKey, a
 		authSvc = auth.NewAuthService(userRepo, tokenMgr, blacklist)
 	}
 
+	// Registered models are offered next to the catalog models of their component type.
+	registeredModelService := registeredmodelsvc.NewService(registeredModelRepo, compRepo, catalogProvider, vars.RuntimeFactory.GetRuntimeType(), utils.GetModelsPath())
+	modelCatalog := catalogProvider.WithModelRegistry(registeredModelService)
+
+	appService := apirepository.NewApplicationService(appRepo, svcRepo, compRepo, svcDepRepo, appImageRepo, modelCatalog, vars.RuntimeFactory.GetRuntimeType())
+	eventService := eventsvc.NewService(eventRepo, appService)
+	backupService := backupsvc.NewService(backupJobRepo, backupScheduleRepo, appService, eventService, vars.RuntimeFactory.GetRuntimeType())
+
+	// Run backup schedules in the background
+	backupScheduler := backupsvc.NewScheduler(backupService, backupsvc.DefaultScheduleInterval)
+	backupScheduler.Start(ctx)
+
+	// Prune unreferenced images and orphaned volumes when PRUNE_SCHEDULE is set.
+	pruneService := prunesvc.NewService(appRepo, compRepo, appImageRepo, catalogProvider, vars.RuntimeFactory.GetRuntimeType())
+	var pruneScheduler *prunesvc.Scheduler
+	if expr := os.Getenv("PRUNE_SCHEDULE"); expr != "" {
+		pruneScheduler, err = prunesvc.NewScheduler(pruneService, expr)
+		if err != nil {
+			backupScheduler.Stop(ctx)
+			syncService.Stop(ctx)
+
+			return apiserver.APIServerOptions{}, nil, fmt.Errorf("invalid PRUNE_SCHEDULE: %w", err)
+		}
+		pruneScheduler.Start(ctx)
+	}
+
 	opts := apiserver.APIServerOptions{
-		Port:               0, // set by caller
-		AuthService:        authSvc,
-		TokenManager:       tokenMgr,
-		Blacklist:          blacklist,
-		ApplicationService: apirepository.NewApplicationService(appRepo, svcRepo, compRepo, svcDepRepo, catalogProvider, vars.RuntimeFactory.GetRuntimeType()),
-		BundleService:      bundlesvc.NewBundleService(bundleRepo, svcRepo, compRepo),
-		WorkerGatewayPort:  workerGatewayPort,
-		WorkerRegistry:     workerReg,
+		Port:                   0, // set by caller
+		AuthService:            authSvc,
+		TokenManager:           tokenMgr,
+		Blacklist:              blacklist,
+		CatalogProvider:        modelCatalog,
+		ApplicationService:     appService,
+		BundleService:          bundlesvc.NewBundleService(bundleRepo, svcRepo, compRepo),
+		AcceleratorService:     acceleratorsvc.NewService(appRepo, compRepo),
+		BackupService:          backupService,
+		EventService:           eventService,
+		ModelService:           modelcachesvc.NewService(appRepo, compRepo, svcDepRepo, vars.RuntimeFactory.GetRuntimeType(), utils.GetModelsPath()),
+		RegisteredModelService: registeredModelService,
+		PruneService:           pruneService,
+		WorkerGatewayPort:      workerGatewayPort,
+		WorkerRegistry:         workerReg,
 	}
 	cleanup := func() {
 		blacklist.Stop()
+		if pruneScheduler != nil {
+			pruneScheduler.Stop(ctx)
+		}
+		backupScheduler.Stop(ctx)
 		syncService.Stop(ctx)
 	}
 
@@ -201,7 +261,8 @@ func NewAPIServerCmd() *cobra.Command {
 
 Note:
   - Requires database connection via environment variables (DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME)
-  - AUTH_JWT_SECRET environment variable is recommended for production use`,
+  - AUTH_JWT_SECRET environment variable is recommended for production use
+  - PRUNE_SCHEDULE (a cron expression, e.g. "0 3 * * 0") prunes unreferenced images and orphaned volumes on podman`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return common.InitAndValidateRuntimeFlag(runtimeType)
 		},
@@ -0,0 +1,239 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup"
+	backupUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// catalogPassphraseEnv supplies the archive passphrase without prompting. It is
+// shared with application backups.
+const catalogPassphraseEnv = "AI_SERVICES_BACKUP_PASSPHRASE"
+
+// NewBackupCmd creates a new backup command for the catalog service.
+func NewBackupCmd() *cobra.Command {
+	var (
+		filename string
+		signKey  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the catalog database, bundles and secrets",
+		Long: `Writes a backup of the catalog control plane into an encrypted tar.gz archive:
+  - a dump of the catalog database (applications, components, bundles, workers,
+    connectors and backup history), taken with pg_dump in the catalog-db container
+  - the bundle files of the catalog-bundles volume
+  - the connector encryption secret and the admin password secret
+  - the Caddyfile and Caddy's saved routes
+
+The catalog pod is stopped while the backup is taken, so the database dump and
+the bundle files are consistent. The archive holds secrets and is always
+encrypted with a passphrase read from AI_SERVICES_BACKUP_PASSPHRASE or prompted
+for. Application data is not included; back it up with 'ai-services application
+backup'.`,
+		Example: `  # Back up the catalog (auto-generated filename)
+  ai-services catalog backup --runtime podman
+
+  # Back up the catalog into a given file and sign the archive
+  ai-services catalog backup --runtime podman --filename catalog.tar.gz --sign-key backup-key.pem`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if filename != "" && !strings.HasSuffix(filename, ".tar.gz") {
+				return fmt.Errorf("backup file must have .tar.gz extension, got: %s", filename)
+			}
+
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := catalogBackupFile(filename)
+			if err != nil {
+				return err
+			}
+
+			protection := backuptarget.Protection{}
+			if signKey != "" {
+				key, err := backuptarget.LoadSigningKey(signKey)
+				if err != nil {
+					return err
+				}
+				protection.SigningKey = key
+			}
+
+			passphrase, err := readCatalogPassphrase(true)
+			if err != nil {
+				return err
+			}
+			protection.Passphrase = passphrase
+
+			return backup.Backup(cmd.Context(), backupUtils.BackupOptions{
+				Runtime:    vars.RuntimeFactory.GetRuntimeType(),
+				File:       file,
+				Protection: protection,
+			})
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+	cmd.Flags().StringVar(&filename, "filename", "", "Path to save the backup tar.gz file (optional, auto-generated if not specified)")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Path to an ed25519 private key (PEM) to sign the archive manifest with")
+
+	return cmd
+}
+
+// NewRestoreCmd creates a new restore command for the catalog service.
+func NewRestoreCmd() *cobra.Command {
+	var (
+		filename      string
+		publicKey     string
+		restoreRoutes bool
+		autoYes       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the catalog from a catalog backup",
+		Long: `Restores a backup written by 'ai-services catalog backup' onto a configured
+catalog, typically a freshly configured one on a replacement host.
+
+The catalog database, the bundle files, the connector encryption secret and the
+admin password secret are replaced, and the Caddyfile is restored. Caddy's saved
+routes point at the host the backup was taken on and are only restored with
+--restore-routes. Applications that were being deployed or deleted when the
+backup was taken, and backup jobs that were running, are marked as failed.
+
+Once the catalog pod is started again it runs pending database migrations and
+reconciles every application against the pods on this host: applications
+whose pods are missing are reported in the Error state.
+
+Note:
+  - WARNING: Restore replaces all catalog data`,
+		Example: `  # Restore the catalog onto a freshly configured catalog
+  ai-services catalog configure --runtime podman
+  ai-services catalog restore --runtime podman --filename catalog.tar.gz
+
+  # Restore on the original host, including the Caddy routes of its applications
+  ai-services catalog restore --runtime podman --filename catalog.tar.gz --restore-routes --yes`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if _, err := os.Stat(filename); err != nil {
+				return fmt.Errorf("backup file not found: %s", filename)
+			}
+
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !autoYes {
+				logger.Warningln("This operation will replace all catalog data!")
+
+				confirmed, err := utils.ConfirmAction("Are you sure you want to proceed with the restore? ")
+				if err != nil {
+					return fmt.Errorf("failed to get user confirmation: %w", err)
+				}
+				if !confirmed {
+					logger.Infoln("Restore cancelled")
+
+					return nil
+				}
+			}
+
+			protection := backuptarget.Protection{}
+			if publicKey != "" {
+				key, err := backuptarget.LoadPublicKey(publicKey)
+				if err != nil {
+					return err
+				}
+				protection.PublicKey = key
+			}
+
+			encrypted, err := backuptarget.IsEncrypted(filename)
+			if err != nil {
+				return err
+			}
+			if encrypted {
+				passphrase, err := readCatalogPassphrase(false)
+				if err != nil {
+					return err
+				}
+				protection.Passphrase = passphrase
+			}
+
+			return backup.Restore(cmd.Context(), backupUtils.RestoreOptions{
+				Runtime:       vars.RuntimeFactory.GetRuntimeType(),
+				File:          filename,
+				Protection:    protection,
+				RestoreRoutes: restoreRoutes,
+			})
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+	cmd.Flags().StringVar(&filename, "filename", "", "Path of the catalog backup tar.gz file (required)")
+	cmd.Flags().StringVar(&publicKey, "public-key", "", "Path to an ed25519 public key (PEM) the archive must be signed with")
+	cmd.Flags().BoolVar(&restoreRoutes, "restore-routes", false, "Also restore Caddy's saved routes (only when restoring on the original host)")
+	cmd.Flags().BoolVarP(&autoYes, "yes", "y", false, "Automatically accept all confirmation prompts (default=false)")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// catalogBackupFile returns the absolute archive path, generating a name in the
+// working directory when file is empty.
+func catalogBackupFile(file string) (string, error) {
+	if file == "" {
+		file = fmt.Sprintf("catalog_backup_%s.tar.gz", time.Now().Format("20060102_150405"))
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve backup file path: %w", err)
+	}
+
+	if _, err := os.Stat(abs); err == nil {
+		return "", fmt.Errorf("backup file already exists: %s", abs)
+	}
+
+	return abs, nil
+}
+
+// readCatalogPassphrase returns the archive passphrase from the environment or,
+// when unset, prompts for it. New passphrases are prompted for twice.
+func readCatalogPassphrase(confirm bool) ([]byte, error) {
+	if passphrase := os.Getenv(catalogPassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	passphrase, err := readPasswordFromTerminal("Enter backup passphrase: ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	if confirm {
+		again, err := readPasswordFromTerminal("Confirm backup passphrase: ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if again != passphrase {
+			return nil, fmt.Errorf("passphrases do not match")
+		}
+	}
+
+	return []byte(passphrase), nil
+}
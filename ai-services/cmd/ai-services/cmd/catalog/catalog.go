@@ -24,6 +24,8 @@ deploy them, and handle service metadata`,
 	catalogCMD.AddCommand(NewMigrateCmd())
 	catalogCMD.AddCommand(NewInfoCmd())
 	catalogCMD.AddCommand(NewWorkerCmd())
+	catalogCMD.AddCommand(NewBackupCmd())
+	catalogCMD.AddCommand(NewRestoreCmd())
 
 	return catalogCMD
 }
@@ -0,0 +1,71 @@
+package offline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/offline"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		templateName string
+		filename     string
+		modelsDir    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the images, models and catalog assets of a template into a bundle",
+		Long: `Writes an offline bundle for a template: a tar archive holding a manifest, every
+container image of the template, every model of its components and the catalog
+assets of this CLI.
+
+Images that are not in local storage are pulled and models that are not in the
+models directory are downloaded first, so the export must run on a host with
+registry and Hugging Face access and the same architecture as the target hosts.`,
+		Example: `  # Export the Digital Assistant
+  ai-services offline export --template rag
+
+  # Export a service into a given file
+  ai-services offline export --template chat --filename /mnt/usb/chat.tar`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			return utils.CheckPodmanPlatformSupport(types.RuntimeTypePodman)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if filename == "" {
+				filename = fmt.Sprintf("ai-services-offline-%s-%s.tar", templateName, runtime.GOARCH)
+			}
+
+			file, err := filepath.Abs(filename)
+			if err != nil {
+				return fmt.Errorf("failed to resolve bundle path: %w", err)
+			}
+			if _, err := os.Stat(file); err == nil {
+				return fmt.Errorf("bundle file already exists: %s", file)
+			}
+
+			return offline.Export(cmd.Context(), offline.ExportOptions{
+				Template:  templateName,
+				File:      file,
+				ModelsDir: modelsDir,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&templateName, "template", "t", "", "Application template name (Required)")
+	_ = cmd.MarkFlagRequired("template")
+	cmd.Flags().StringVar(&filename, "filename", "", "Path to write the bundle to (optional, auto-generated if not specified)")
+	cmd.Flags().StringVar(&modelsDir, "models-dir", utils.GetModelsPath(), "Directory to take the models from")
+
+	return cmd
+}
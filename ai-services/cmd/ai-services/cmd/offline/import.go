@@ -0,0 +1,60 @@
+package offline
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/offline"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+func newImportCmd() *cobra.Command {
+	var (
+		filename  string
+		modelsDir string
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Load the images and models of a bundle on this host",
+		Long: `Loads the container images of an offline bundle into local podman storage and
+places its models in the models directory, verifying every model file against
+the checksums of the bundle manifest.
+
+Afterwards applications of the template deploy without registry or Hugging Face
+access, e.g. with 'ai-services application create --image-pull-policy Never'.
+The bundle must have been exported with a CLI of the same version, since its
+images and models belong to the templates of that CLI.`,
+		Example: `  # Import a bundle
+  ai-services offline import --filename ai-services-offline-rag-ppc64le.tar`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if _, err := os.Stat(filename); err != nil {
+				return fmt.Errorf("bundle file not found: %s", filename)
+			}
+
+			return utils.CheckPodmanPlatformSupport(types.RuntimeTypePodman)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return offline.Import(cmd.Context(), offline.ImportOptions{
+				File:         filename,
+				ModelsDir:    modelsDir,
+				ManifestsDir: utils.GetOfflineManifestsPath(),
+				Force:        force,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&filename, "filename", "", "Path of the bundle to import (required)")
+	_ = cmd.MarkFlagRequired("filename")
+	cmd.Flags().StringVar(&modelsDir, "models-dir", utils.GetModelsPath(), "Directory to place the models in")
+	cmd.Flags().BoolVar(&force, "force", false, "Import a bundle exported with a CLI whose templates differ")
+
+	return cmd
+}
@@ -0,0 +1,25 @@
+package offline
+
+import "github.com/spf13/cobra"
+
+// OfflineCmd returns the parent command for offline installation bundles.
+func OfflineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "offline",
+		Short: "Export and import offline installation bundles",
+		Long: `Packs the container images, models and catalog assets of a template into one
+archive on a connected host, and loads it on a host without access to container
+registries or Hugging Face.
+
+Note:
+  - Supports only podman runtime`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newImportCmd())
+
+	return cmd
+}
@@ -6,12 +6,15 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/accelerator"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/application"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/bootstrap"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/mustgather"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/offline"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // RootCmd represents the base command when called without any subcommands.
@@ -41,6 +44,7 @@ func Execute() {
 
 func init() {
 	logger.Init()
+	vars.CLIVersion = version.GetVersion()
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 
 	RootCmd.AddCommand(version.VersionCmd)
@@ -48,4 +52,6 @@ func init() {
 	RootCmd.AddCommand(application.ApplicationCmd)
 	RootCmd.AddCommand(catalog.CatalogCmd())
 	RootCmd.AddCommand(mustgather.MustGatherCmd())
+	RootCmd.AddCommand(accelerator.AcceleratorCmd())
+	RootCmd.AddCommand(offline.OfflineCmd())
 }
@@ -24,6 +24,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/accelerators": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every Spyre card on the catalog host with its PCI address, NUMA node, driver binding,\nhealth and the application, service or component holding it. Cards on registered workers are not listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accelerators"
+                ],
+                "summary": "List accelerators",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/applications": {
             "get": {
                 "security": [
@@ -100,7 +137,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new application (architecture or service) with optional custom parameters",
+                "description": "Creates a new application (architecture or service) with optional custom parameters.\nThe application is rejected when it does not fit in the remaining host capacity unless ignore_capacity is set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -148,7 +185,7 @@ const docTemplate = `{
                         }
                     },
                     "422": {
-                        "description": "Parameter validation failed or invalid template",
+                        "description": "Parameter validation failed, invalid template, insufficient host capacity or images rejected by the image policy",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -362,21 +399,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/applications/{id}/ps": {
+        "/applications/{id}/backup-schedules": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the process status and runtime information for an application",
+                "description": "Lists the backup schedules of the application with their next run and last outcome.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Backups"
                 ],
-                "summary": "Get application process status",
+                "summary": "List backup schedules",
                 "parameters": [
                     {
                         "type": "string",
@@ -390,11 +427,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationPSResponse"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupScheduleListResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid application ID format",
+                        "description": "Invalid application ID",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -418,37 +455,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/applications/{id}/resources": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves used and total allocated CPU (in cores) and memory usage (in bytes), along with the hardware accelerator cards for an application and its services",
+                "description": "Adds a cron backup schedule to the application. The API server starts a backup job\nwhenever the schedule is due and then prunes the schedule's older backups per its\nretention policy. Failed runs are reported as application events.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Applications"
+                    "Backups"
                 ],
-                "summary": "Get application resources",
+                "summary": "Create backup schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Application ID",
+                        "description": "Application ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CreateBackupScheduleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationResourcesResponse"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid application ID, target, cron expression or retention",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
@@ -472,33 +525,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/architectures": {
-            "get": {
+        "/applications/{id}/backup-schedules/{schedule_id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all available architecture templates with summary information",
+                "description": "Changes the target, cron expression, retention or enabled state of a backup schedule.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Backups"
+                ],
+                "summary": "Update backup schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID (UUID)",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.UpdateBackupScheduleRequest"
+                        }
+                    }
                 ],
-                "summary": "List available architectures",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureSummary"
-                            }
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, target, cron expression or retention",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -510,47 +600,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/architectures/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific architecture template",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Removes a backup schedule. Backups it created are kept.",
                 "tags": [
-                    "Catalog"
+                    "Backups"
                 ],
-                "summary": "Get architecture details",
+                "summary": "Delete backup schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Architecture template ID (e.g., 'rag')",
+                        "description": "Application ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID (UUID)",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid application or schedule ID",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Architecture"
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Architecture not found",
+                        "description": "Schedule not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -564,25 +659,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/architectures/{id}/deploy-options": {
+        "/applications/{id}/backups": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves available providers and dependency rules for all services and their components within an architecture",
+                "description": "Lists the backup and restore jobs of the application, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Backups"
                 ],
-                "summary": "Get architecture deploy options",
+                "summary": "List backup jobs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Architecture ID (e.g., 'rag')",
+                        "description": "Application ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -592,17 +687,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsArchitecture"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJobListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid application ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Architecture not found",
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -614,11 +715,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/login": {
+            },
             "post": {
-                "description": "Authenticate user and return access and refresh tokens",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts an async backup of the application into the server-side backup volume and returns\nthe backup job immediately. Poll the job to follow its status and progress.",
                 "consumes": [
                     "application/json"
                 ],
@@ -626,131 +730,216 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Backups"
                 ],
-                "summary": "User login",
+                "summary": "Create backup",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Backup target (defaults to 'all')",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.loginReq"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CreateBackupRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Returns access_token, refresh_token, and token_type",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJob"
                         }
                     },
                     "400": {
-                        "description": "Invalid payload",
+                        "description": "Invalid application ID or unsupported target",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Application is not Running or a job is already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/applications/{id}/backups/{backup_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Invalidate the current access token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a single backup or restore job of the application with its status and progress.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Backups"
+                ],
+                "summary": "Get backup job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID (UUID)",
+                        "name": "backup_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "User logout",
                 "responses": {
                     "200": {
-                        "description": "Successfully logged out",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJob"
                         }
                     },
                     "400": {
-                        "description": "Missing token",
+                        "description": "Invalid application or job ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to logout",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
-                    }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "/auth/me": {
+        "/applications/{id}/backups/{backup_id}/download": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get information about the currently authenticated user",
+                "description": "Streams the archive of a completed backup job.",
                 "produces": [
-                    "application/json"
+                    "application/gzip"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Backups"
+                ],
+                "summary": "Download backup archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Backup job ID (UUID)",
+                        "name": "backup_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user info",
                 "responses": {
                     "200": {
-                        "description": "Returns user id, username, and name",
+                        "description": "Backup archive (.tar.gz)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid application or backup ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Backup not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job is not a completed backup",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Archive no longer available",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/refresh": {
+        "/applications/{id}/backups/{backup_id}/restore": {
             "post": {
-                "description": "Get new access and refresh tokens using a valid refresh token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts an async restore of a completed backup into the application and returns the\nrestore job immediately. With source_application_id the backup of another application\nis restored; OpenSearch indices can be restored under new names or next to existing ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,127 +947,100 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Backups"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Restore backup",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "refresh",
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Backup job ID (UUID)",
+                        "name": "backup_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target, source application and index mapping of the restore",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.refreshReq"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RestoreBackupRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Returns new access_token, refresh_token, and token_type",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJob"
                         }
                     },
                     "400": {
-                        "description": "Invalid payload",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid refresh token",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/token": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "**Experimental** — This endpoint is under active development and may change without notice.\nIBM Power Mission Control use this\nendpoint to exchange a pre-existing ManageIQ token for an internal JWT\nwithout supplying username/password credentials.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Exchange a ManageIQ token for a Catalog API JWT",
-                "responses": {
-                    "200": {
-                        "description": "Returns access_token, refresh_token, and token_type",
+                        "description": "Invalid ID, unsupported target or invalid index name",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid or expired ManageIQ token",
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "ManageIQ token does not have required permissions",
+                    "404": {
+                        "description": "Application or backup not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "ManageIQ resource not found",
+                    "409": {
+                        "description": "Backup not completed, application not Running or a job is already in progress",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "ManageIQ unavailable or returned an unexpected server error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/catalog/bundles": {
+        "/applications/{id}/events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of all registered bundles ordered by created_at DESC.",
+                "description": "Lists the most recent events of an application, newest first. Events report background\nactivity such as backup and restore jobs and scheduled backup failures.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bundles"
+                    "Applications"
                 ],
-                "summary": "List all bundles",
+                "summary": "List application events",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number (1-indexed)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "Number of items per page (max: 100)",
-                        "name": "page_size",
+                        "description": "Maximum number of events (default: 100)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -886,11 +1048,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleListResponse"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationEventListResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid pagination parameters",
+                        "description": "Invalid application ID or limit",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -901,6 +1063,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -908,42 +1076,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/applications/{id}/ps": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Uploads a .tar.gz archive and creates a new bundle. id, type, and version are read from metadata.yaml inside the archive.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieves the process status and runtime information for an application",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bundles"
+                    "Applications"
                 ],
-                "summary": "Create a new catalog bundle",
+                "summary": "Get application process status",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": ".tar.gz archive containing the catalog item assets",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Application ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationPSResponse"
                         }
                     },
                     "400": {
-                        "description": "Missing file, wrong content-type, exceeds size limit, or metadata.yaml malformed",
+                        "description": "Invalid application ID format",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -954,20 +1121,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden — admin role required",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict — bundle with same catalog_id already exists",
+                    "404": {
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity — validation failed",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -975,30 +1136,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/catalog/bundles/validate": {
-            "post": {
+        "/applications/{id}/resources": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Validates a .tar.gz archive without writing a DB row or reloading CatalogProvider.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieves used and total allocated CPU (in cores) and memory usage (in bytes), along with the hardware accelerator cards for an application and its services",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bundles"
+                    "Applications"
                 ],
-                "summary": "Validate a bundle without storing it",
+                "summary": "Get application resources",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": ".tar.gz archive to validate",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1006,29 +1164,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.ServiceValidationResult"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationResourcesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Application not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Validation failed",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -1036,161 +1188,143 @@ const docTemplate = `{
                 }
             }
         },
-        "/catalog/bundles/{id}": {
+        "/architectures": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the status and metadata for a specific bundle.",
+                "description": "Retrieves a list of all available architecture templates with summary information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bundles"
-                ],
-                "summary": "Get a bundle by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Internal bundle UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Catalog"
                 ],
+                "summary": "List available architectures",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureSummary"
+                            }
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - Invalid or missing access token",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Bundle not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/architectures/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Replaces the bundle identified by id with a new archive. catalog_id and catalog_type are resolved from the DB record.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieves detailed information about a specific architecture template",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bundles"
+                    "Catalog"
                 ],
-                "summary": "Replace an existing bundle",
+                "summary": "Get architecture details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Internal bundle UUID",
+                        "description": "Architecture template ID (e.g., 'rag')",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Replacement .tar.gz archive",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Architecture"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "Unauthorized - Invalid or missing access token",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Bundle not found",
+                        "description": "Architecture not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "catalog_id or catalog_type mismatch, or validation failed",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/architectures/{id}/deploy-options": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Marks the bundle deleting, removes the on-disk directory, reloads CatalogProvider, and removes the DB row.",
+                "description": "Retrieves available providers and dependency rules for all services and their components within an architecture",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Bundles"
+                    "Catalog"
                 ],
-                "summary": "Delete a bundle",
+                "summary": "Get architecture deploy options",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Internal bundle UUID",
+                        "description": "Architecture ID (e.g., 'rag')",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsArchitecture"
+                        }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - Invalid or missing access token",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Architecture not found",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Bundle not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -1198,154 +1332,116 @@ const docTemplate = `{
                 }
             }
         },
-        "/components/{component_type}/providers/{provider_id}/params": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return access and refresh tokens",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves the configuration schema (JSON Schema) for a specific provider within a component type. Returns a JSON Schema object with properties that may include x-data-id for fields that should be populated from metadata specifications.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Authentication"
                 ],
-                "summary": "Get component provider parameters",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Component type (e.g., 'vector_db', 'llm', 'embedding', 'reranker')",
-                        "name": "component_type",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Provider identifier (e.g., 'opensearch', 'vllm', 'watsonx')",
-                        "name": "provider_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.loginReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JSON Schema object with $schema, type, and properties. Properties may include x-data-id field indicating data should be populated from metadata specifications (e.g., supported_models)",
+                        "description": "Returns access_token, refresh_token, and token_type",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid component_type or provider_id",
+                        "description": "Invalid payload",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Component type or provider not found",
-                        "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Invalid credentials",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/connectors": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns registered providers. When connector_type is supplied only that type is returned; omitting it returns all providers across all connector types.",
+                "description": "Invalidate the current access token",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
-                ],
-                "summary": "List connector providers",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by connector type (e.g. 'datasource'). Omit to return all types.",
-                        "name": "connector_type",
-                        "in": "query"
-                    }
+                    "Authentication"
                 ],
+                "summary": "User logout",
                 "responses": {
                     "200": {
-                        "description": "List of providers",
+                        "description": "Successfully logged out",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Connector"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Missing token",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Connector type not found",
+                    "500": {
+                        "description": "Failed to logout",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/connectors/{connector_type}/providers/{provider_id}/params": {
+        "/auth/me": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the JSON Schema for the configuration parameters of a specific connector provider.",
+                "description": "Get information about the currently authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
-                ],
-                "summary": "Get connector provider parameters",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Connector type (e.g. 'datasource')",
-                        "name": "connector_type",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Provider identifier (e.g. 'object_storage', 'file_system')",
-                        "name": "provider_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Authentication"
                 ],
+                "summary": "Get current user info",
                 "responses": {
                     "200": {
-                        "description": "JSON Schema for the provider's configuration",
+                        "description": "Returns user id, username, and name",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1354,134 +1450,169 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Connector type or provider not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/resources": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/auth/refresh": {
+            "post": {
+                "description": "Get new access and refresh tokens using a valid refresh token",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves system resource information including CPU, memory, and accelerator availability",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.refreshReq"
+                        }
+                    }
                 ],
-                "summary": "Get system resources",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Returns new access_token, refresh_token, and token_type",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ResourcesResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                    "400": {
+                        "description": "Invalid payload",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Invalid refresh token",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/services": {
-            "get": {
+        "/auth/token": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all deployable service templates. Dependency-only services are excluded from this list. Returns service summaries including standalone flag without endpoints and pod templates.",
+                "description": "**Experimental** — This endpoint is under active development and may change without notice.\nIBM Power Mission Control use this\nendpoint to exchange a pre-existing ManageIQ token for an internal JWT\nwithout supplying username/password credentials.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Authentication"
                 ],
-                "summary": "List available services",
+                "summary": "Exchange a ManageIQ token for a Catalog API JWT",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Returns access_token, refresh_token, and token_type",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceSummary"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Invalid or expired ManageIQ token",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "ManageIQ token does not have required permissions",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "ManageIQ resource not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "ManageIQ unavailable or returned an unexpected server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/services/{id}": {
+        "/catalog/bundles": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific service template",
+                "description": "Returns a paginated list of all registered bundles ordered by created_at DESC.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Bundles"
                 ],
-                "summary": "Get service details",
+                "summary": "List all bundles",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service template ID (e.g., 'summarize')",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (1-indexed)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items per page (max: 100)",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Service"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                    "400": {
+                        "description": "Invalid pagination parameters",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -1493,53 +1624,66 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/services/{id}/deploy-options": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves available providers and dependency rules for a specific service",
+                "description": "Uploads a .tar.gz archive and creates a new bundle. id, type, and version are read from metadata.yaml inside the archive.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Bundles"
                 ],
-                "summary": "Get service deploy options",
+                "summary": "Create a new catalog bundle",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service ID (e.g., 'digitize', 'chat')",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": ".tar.gz archive containing the catalog item assets",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file, wrong content-type, exceeds size limit, or metadata.yaml malformed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "403": {
+                        "description": "Forbidden — admin role required",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict — bundle with same catalog_id already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity — validation failed",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -1547,58 +1691,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{id}/params": {
-            "get": {
+        "/catalog/bundles/validate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the configuration schema (JSON Schema) for a specific service. Returns a JSON Schema object with properties that define the service's configurable parameters.",
+                "description": "Validates a .tar.gz archive without writing a DB row or reloading CatalogProvider.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalog"
+                    "Bundles"
                 ],
-                "summary": "Get service parameters",
+                "summary": "Validate a bundle without storing it",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service ID (e.g., 'chat', 'digitize', 'similarity')",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": ".tar.gz archive to validate",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JSON Schema object with $schema, type, and properties defining service parameters",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.ServiceValidationResult"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid service ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing access token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Validation failed",
                         "schema": {
                             "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
@@ -1606,212 +1752,1675 @@ const docTemplate = `{
                 }
             }
         },
-        "/workers": {
+        "/catalog/bundles/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns all registered workers and their current status from the database.",
+                "description": "Returns the status and metadata for a specific bundle.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Workers"
+                    "Bundles"
+                ],
+                "summary": "Get a bundle by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Internal bundle UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all workers",
                 "responses": {
                     "200": {
-                        "description": "List of workers",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Worker"
-                            }
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Pre-registers a worker by name, creates a pending DB row, and returns a single-use bootstrap token.\nThe operator passes this token when starting the worker daemon (` + "`" + `worker join --token \u003ctoken\u003e` + "`" + `).",
+                "description": "Replaces the bundle identified by id with a new archive. catalog_id and catalog_type are resolved from the DB record.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Workers"
+                    "Bundles"
                 ],
-                "summary": "Register a new worker",
+                "summary": "Replace an existing bundle",
                 "parameters": [
                     {
-                        "description": "Worker registration request",
-                        "name": "worker",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Internal bundle UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Replacement .tar.gz archive",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.createWorkerReq"
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "catalog_id or catalog_type mismatch, or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the bundle deleting, removes the on-disk directory, reloads CatalogProvider, and removes the DB row.",
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Delete a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Internal bundle UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Worker registered; token valid for 24 hours",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.createWorkerResp"
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid payload",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal error",
+                    "404": {
+                        "description": "Bundle not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/workers/{id}": {
-            "delete": {
+        "/components/{component_type}/providers/{provider_id}/params": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permanently removes a worker from the registry and the database.\nIf the worker is currently connected its gRPC stream is also cleaned up.",
+                "description": "Retrieves the configuration schema (JSON Schema) for a specific provider within a component type. Returns a JSON Schema object with properties that may include x-data-id for fields that should be populated from metadata specifications.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Workers"
+                    "Catalog"
                 ],
-                "summary": "Deregister a worker",
+                "summary": "Get component provider parameters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Worker ID (UUID)",
-                        "name": "id",
+                        "description": "Component type (e.g., 'vector_db', 'llm', 'embedding', 'reranker')",
+                        "name": "component_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider identifier (e.g., 'opensearch', 'vllm', 'watsonx')",
+                        "name": "provider_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Worker deleted"
-                    },
-                    "400": {
-                        "description": "Invalid worker ID",
+                    "200": {
+                        "description": "JSON Schema object with $schema, type, and properties. Properties may include x-data-id field indicating data should be populated from metadata specifications (e.g., supported_models)",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request - Invalid component_type or provider_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Worker not found",
+                        "description": "Component type or provider not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns registered providers. When connector_type is supplied only that type is returned; omitting it returns all providers across all connector types.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "List connector providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by connector type (e.g. 'datasource'). Omit to return all types.",
+                        "name": "connector_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of providers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Connector"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector type not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/connectors/{connector_type}/providers/{provider_id}/params": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the JSON Schema for the configuration parameters of a specific connector provider.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get connector provider parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Connector type (e.g. 'datasource')",
+                        "name": "connector_type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider identifier (e.g. 'object_storage', 'file_system')",
+                        "name": "provider_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JSON Schema for the provider's configuration",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Connector type or provider not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/prune": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
+                ],
+                "description": "Removes the local images of the catalog templates and of the configured registry mirrors that\nno container uses and that are neither in the templates of the deployed applications, nor the\ndigests their images are pinned to, nor the tool image, nor an imported offline bundle.\nImages are matched under their own name and that of their registry mirror. Other images are\nnever removed.\nRefused while an application is downloading or deploying.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Prune unreferenced images",
+                "parameters": [
+                    {
+                        "description": "Set dry_run to only report what would be removed",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PruneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ImagePruneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An application is downloading or deploying",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime other than podman",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the models in the models directory of the catalog host with their size, revision,\ndownload date and the application components configured to serve them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "List cached models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime without a model cache",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/prune": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the cached models that no application component is configured to serve. Registered\ncustom models are kept; unregister them instead.\nRefused while an application is downloading or deploying.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Prune unreferenced models",
+                "parameters": [
+                    {
+                        "description": "Set dry_run to only report what would be removed",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelPruneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelPruneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An application is downloading or deploying",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime without a model cache",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/registered": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the registered custom models with their source and status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "List custom models",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModelListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a fine-tuned or private model, either from a directory below the base directory\nof the catalog host or from an OCI artifact or modelcar image. The model files are placed in\nthe models directory in the background; poll the model until it is ready. Ready models can\nbe selected as the model of the providers of their component type that accept custom models.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Register custom model",
+                "parameters": [
+                    {
+                        "description": "Model name, component type and source",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisterModelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Model name already in use",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime without a model cache",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/registered/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a registered custom model. The name may contain a slash, e.g. acme/granite-support.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Get custom model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModel"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not registered",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unregisters a custom model and removes its files from the models directory. Refused while\nthe model is being registered or an application component is configured to serve it.",
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Unregister custom model",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not registered",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Model is registering or in use",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime without a model cache",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/models/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks every file of the cached models against the checksums recorded when they were\ndownloaded or imported. Models downloaded by older releases are checked against the\nchecksums Hugging Face reported for them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Models"
+                ],
+                "summary": "Verify cached models",
+                "parameters": [
+                    {
+                        "description": "Only verify this model",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerifyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Model not cached",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime without a model cache",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves system resource information including CPU, memory, and accelerator availability",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get system resources",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ResourcesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all deployable service templates. Dependency-only services are excluded from this list. Returns service summaries including standalone flag without endpoints and pod templates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "List available services",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceSummary"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a specific service template",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get service details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service template ID (e.g., 'summarize')",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Service"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/deploy-options": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves available providers and dependency rules for a specific service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get service deploy options",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID (e.g., 'digitize', 'chat')",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{id}/params": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the configuration schema (JSON Schema) for a specific service. Returns a JSON Schema object with properties that define the service's configurable parameters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "summary": "Get service parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID (e.g., 'chat', 'digitize', 'similarity')",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JSON Schema object with $schema, type, and properties defining service parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid service ID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volumes/prune": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the unused volumes named after an application or component instance that no longer\nexists, such as those kept when an application was deleted. Other volumes are never removed.\nRefused while an application is downloading or deploying.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Volumes"
+                ],
+                "summary": "Prune orphaned volumes",
+                "parameters": [
+                    {
+                        "description": "Set dry_run to only report what would be removed",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PruneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.VolumePruneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing access token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An application is downloading or deploying",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Runtime other than podman",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all registered workers and their current status from the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workers"
+                ],
+                "summary": "List all workers",
+                "responses": {
+                    "200": {
+                        "description": "List of workers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Worker"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pre-registers a worker by name, creates a pending DB row, and returns a single-use bootstrap token.\nThe operator passes this token when starting the worker daemon (` + "`" + `worker join --token \u003ctoken\u003e` + "`" + `).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workers"
+                ],
+                "summary": "Register a new worker",
+                "parameters": [
+                    {
+                        "description": "Worker registration request",
+                        "name": "worker",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.createWorkerReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Worker registered; token valid for 24 hours",
+                        "schema": {
+                            "$ref": "#/definitions/internal_pkg_catalog_apiserver_handlers.createWorkerResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/workers/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently removes a worker from the registry and the database.\nIf the worker is currently connected its gRPC stream is also cleaned up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workers"
+                ],
+                "summary": "Deregister a worker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Worker ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Worker deleted"
+                    },
+                    "400": {
+                        "description": "Invalid worker ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Worker not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Component": {
+            "type": "object",
+            "required": [
+                "component_type",
+                "provider_id",
+                "version"
+            ],
+            "properties": {
+                "component_type": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.CreateApplicationRequest": {
+            "type": "object",
+            "required": [
+                "catalog_id",
+                "name",
+                "services",
+                "version"
+            ],
+            "properties": {
+                "autostart": {
+                    "description": "Autostart generates systemd units that start the pods of the application\nwhen the host boots. Defaults to true; ignored on OpenShift.",
+                    "type": "boolean"
+                },
+                "catalog_id": {
+                    "type": "string"
+                },
+                "ignore_capacity": {
+                    "description": "IgnoreCapacity deploys the application even when admission finds that it\ndoes not fit in the remaining host capacity.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Service"
+                    }
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.CreateApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Service": {
+            "type": "object",
+            "required": [
+                "catalog_id",
+                "components",
+                "version"
+            ],
+            "properties": {
+                "catalog_id": {
+                    "type": "string"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Component"
+                    }
+                },
+                "params": {
+                    "description": "Service-level parameters",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_repository.DeleteApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleListResponse": {
+            "type": "object",
+            "properties": {
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PaginationMetadata"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse": {
+            "type": "object",
+            "properties": {
+                "catalog_id": {
+                    "type": "string"
+                },
+                "catalog_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.ServiceValidationResult": {
+            "type": "object",
+            "properties": {
+                "catalog_id": {
+                    "type": "string"
+                },
+                "catalog_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Accelerator": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string"
+                },
+                "health": {
+                    "type": "string"
+                },
+                "host": {
+                    "description": "Host is LocalHostName.",
+                    "type": "string"
+                },
+                "numa_node": {
+                    "description": "NUMANode is -1 when the host does not report NUMA affinity.",
+                    "type": "integer"
+                },
+                "owner": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorOwner"
+                },
+                "pci_address": {
+                    "type": "string"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "vfio_bound": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorListResponse": {
+            "type": "object",
+            "properties": {
+                "accelerators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Accelerator"
+                    }
+                },
+                "errors": {
+                    "description": "Errors lists why the inventory could not be collected.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorOwner": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "application_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorOwnerKind"
+                },
+                "name": {
+                    "description": "Name is the service catalog ID or \"\u003ccomponent type\u003e/\u003cprovider\u003e\".",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.AcceleratorOwnerKind": {
+            "type": "string",
+            "enum": [
+                "service",
+                "component"
+            ],
+            "x-enum-varnames": [
+                "AcceleratorOwnerService",
+                "AcceleratorOwnerComponent"
+            ]
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Application": {
+            "type": "object",
+            "properties": {
+                "catalog_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deployment_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationService"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationCPUInfo": {
+            "type": "object",
+            "properties": {
+                "total_cpu": {
+                    "description": "Total allocated CPUs",
+                    "type": "number"
+                },
+                "used_cpu": {
+                    "description": "Actually used CPUs",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is a short machine-readable cause such as \"BackupFailed\".",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is \"Normal\" or \"Warning\".",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationEventListResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Application"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PaginationMetadata"
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Component": {
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationMemInfo": {
             "type": "object",
-            "required": [
-                "component_type",
-                "provider_id",
-                "version"
-            ],
             "properties": {
-                "component_type": {
-                    "type": "string"
-                },
-                "params": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "provider_id": {
-                    "type": "string"
+                "total_bytes": {
+                    "description": "Total allocated memory in bytes",
+                    "type": "integer"
                 },
-                "version": {
-                    "type": "string"
+                "used_bytes": {
+                    "description": "Actually used memory in bytes",
+                    "type": "integer"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.CreateApplicationRequest": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationPSResponse": {
             "type": "object",
-            "required": [
-                "catalog_id",
-                "name",
-                "services",
-                "version"
-            ],
             "properties": {
-                "catalog_id": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Pod"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "type": "string"
                 },
                 "services": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Service"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Pod"
                     }
-                },
-                "version": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.CreateApplicationResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationResourcesResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "accelerators": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "cpu": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationCPUInfo"
+                },
+                "memory": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationMemInfo"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Service": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationService": {
             "type": "object",
-            "required": [
-                "catalog_id",
-                "components",
-                "version"
-            ],
             "properties": {
                 "catalog_id": {
                     "type": "string"
@@ -1819,54 +3428,143 @@ const docTemplate = `{
                 "components": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_models.Component"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceComponentResp"
                     }
                 },
-                "params": {
-                    "description": "Service-level parameters",
-                    "type": "object",
-                    "additionalProperties": {}
+                "created_at": {
+                    "type": "string"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resources": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ResourceLimits"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 },
                 "version": {
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_repository.DeleteApplicationResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Architecture": {
             "type": "object",
             "properties": {
+                "certified_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "global_components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ComponentReference"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
-                "message": {
+                "links": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureLinks"
+                },
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "requirements": {
+                    "description": "Requirements is the minimum host capacity needed to deploy the architecture,\nenforced by admission control in addition to the sum of its parts.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources"
+                        }
+                    ]
+                },
+                "runtimes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceReference"
+                    }
+                },
+                "type": {
+                    "description": "\"architecture\"",
+                    "type": "string"
+                },
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleListResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureLinks": {
             "type": "object",
             "properties": {
-                "bundles": {
+                "code": {
+                    "type": "string"
+                },
+                "demo": {
+                    "type": "string"
+                },
+                "documentation": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureSummary": {
+            "type": "object",
+            "properties": {
+                "certified_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "services": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse"
+                        "type": "string"
                     }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PaginationMetadata"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.BundleResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJob": {
             "type": "object",
             "properties": {
-                "catalog_id": {
+                "application_id": {
                     "type": "string"
                 },
-                "catalog_type": {
+                "application_name": {
+                    "description": "ApplicationName is kept after the application is deleted.",
+                    "type": "string"
+                },
+                "completed_at": {
                     "type": "string"
                 },
                 "created_at": {
@@ -1875,134 +3573,251 @@ const docTemplate = `{
                 "created_by": {
                     "type": "string"
                 },
+                "file_name": {
+                    "description": "FileName is the archive path relative to the backup volume (backup jobs only).",
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 },
+                "progress": {
+                    "description": "Progress is the percentage of targets processed.",
+                    "type": "integer"
+                },
                 "size_bytes": {
                     "type": "integer"
                 },
+                "source_backup_id": {
+                    "description": "SourceBackupID is the backup restored by a restore job.",
+                    "type": "string"
+                },
                 "status": {
+                    "description": "Status is one of pending, running, completed or failed.",
                     "type": "string"
                 },
-                "updated_at": {
+                "target": {
                     "type": "string"
                 },
-                "version": {
+                "type": {
+                    "description": "Type is \"backup\" or \"restore\".",
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_apiserver_services_bundle.ServiceValidationResult": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJobListResponse": {
             "type": "object",
             "properties": {
-                "catalog_id": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupJob"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupRetention": {
+            "type": "object",
+            "properties": {
+                "keep_daily": {
+                    "description": "KeepDaily keeps the newest backup of each of the last N days with a backup.",
+                    "type": "integer",
+                    "example": 7
+                },
+                "keep_last": {
+                    "description": "KeepLast keeps the N most recent backups.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "keep_weekly": {
+                    "description": "KeepWeekly keeps the newest backup of each of the last N ISO weeks with a backup.",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupSchedule": {
+            "type": "object",
+            "properties": {
+                "application_id": {
                     "type": "string"
                 },
-                "catalog_type": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "created_by": {
                     "type": "string"
                 },
-                "valid": {
+                "cron": {
+                    "type": "string"
+                },
+                "enabled": {
                     "type": "boolean"
                 },
-                "version": {
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "description": "LastStatus is the status of the backup job started by the last run.",
+                    "type": "string"
+                },
+                "next_run_at": {
                     "type": "string"
+                },
+                "retention": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupRetention"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupScheduleListResponse": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupSchedule"
+                    }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Application": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CachedModel": {
             "type": "object",
             "properties": {
-                "catalog_id": {
-                    "type": "string"
-                },
-                "created_at": {
+                "model": {
+                    "description": "Model is the Hugging Face id of the model, e.g. \"ibm-granite/granite-3.3-8b-instruct\".",
                     "type": "string"
                 },
-                "deployment_type": {
+                "revision": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "size": {
+                    "type": "integer"
                 },
-                "message": {
+                "source": {
+                    "description": "Source is \"huggingface\" or \"offline\", or \"local\" or \"oci\" for registered\nmodels; empty for models downloaded before it was recorded.",
                     "type": "string"
                 },
-                "name": {
+                "stored_at": {
                     "type": "string"
                 },
-                "services": {
+                "used_by": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationService"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelReference"
                     }
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ComponentReference": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Connector": {
+            "type": "object",
+            "properties": {
+                "connector_name": {
+                    "description": "display label for connector_type",
+                    "type": "string"
                 },
-                "status": {
+                "connector_type": {
+                    "description": "e.g. \"datasource\"",
                     "type": "string"
                 },
-                "type": {
+                "description": {
+                    "description": "short description",
                     "type": "string"
                 },
-                "updated_at": {
+                "id": {
+                    "description": "e.g. \"object_storage\", \"file_system\"",
                     "type": "string"
                 },
-                "version": {
+                "name": {
+                    "description": "display name",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "always \"connector\"",
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationCPUInfo": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CreateBackupRequest": {
             "type": "object",
             "properties": {
-                "total_cpu": {
-                    "description": "Total allocated CPUs",
-                    "type": "number"
-                },
-                "used_cpu": {
-                    "description": "Actually used CPUs",
-                    "type": "number"
+                "target": {
+                    "description": "Target is a backup target such as \"opensearch\" or \"digitize\". Defaults to \"all\".",
+                    "type": "string",
+                    "example": "all"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationListResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CreateBackupScheduleRequest": {
             "type": "object",
+            "required": [
+                "cron"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Application"
-                    }
+                "cron": {
+                    "description": "Cron is a five-field cron expression or a macro such as @daily.",
+                    "type": "string",
+                    "example": "0 2 * * *"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PaginationMetadata"
+                "enabled": {
+                    "description": "Enabled defaults to true.",
+                    "type": "boolean"
+                },
+                "retention": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupRetention"
+                },
+                "target": {
+                    "description": "Target is a backup target such as \"opensearch\" or \"digitize\". Defaults to \"all\".",
+                    "type": "string",
+                    "example": "all"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationMemInfo": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DependencyReference": {
             "type": "object",
             "properties": {
-                "total_bytes": {
-                    "description": "Total allocated memory in bytes",
-                    "type": "integer"
-                },
-                "used_bytes": {
-                    "description": "Actually used memory in bytes",
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationPSResponse": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsArchitecture": {
             "type": "object",
             "properties": {
-                "components": {
+                "global_components": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Pod"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent"
                     }
                 },
                 "id": {
@@ -2014,66 +3829,50 @@ const docTemplate = `{
                 "services": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Pod"
-                    }
-                }
-            }
-        },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationResourcesResponse": {
-            "type": "object",
-            "properties": {
-                "accelerators": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        }
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService"
                     }
                 },
-                "cpu": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationCPUInfo"
-                },
-                "memory": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationMemInfo"
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ApplicationService": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent": {
             "type": "object",
             "properties": {
-                "catalog_id": {
+                "name": {
                     "type": "string"
                 },
-                "components": {
+                "providers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceComponentResp"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsProvider"
                     }
                 },
-                "created_at": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsProvider": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean"
                 },
-                "endpoints": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "additionalProperties": {}
-                    }
-                },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "message": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "name": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "resources": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources"
                 },
-                "updated_at": {
+                "schema": {
                     "type": "string"
                 },
                 "version": {
@@ -2081,44 +3880,28 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Architecture": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService": {
             "type": "object",
             "properties": {
-                "certified_by": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
+                "accepts_datasource": {
+                    "type": "boolean"
                 },
-                "global_components": {
+                "components": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ComponentReference"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent"
                     }
                 },
                 "id": {
                     "type": "string"
                 },
-                "links": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureLinks"
-                },
                 "name": {
                     "type": "string"
                 },
-                "runtimes": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ServiceReference"
-                    }
+                "resources": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources"
                 },
-                "type": {
-                    "description": "\"architecture\"",
+                "schema": {
                     "type": "string"
                 },
                 "version": {
@@ -2126,183 +3909,155 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureLinks": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ImagePruneResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "demo": {
-                    "type": "string"
+                "errors": {
+                    "description": "Errors lists the images that could not be removed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "documentation": {
-                    "type": "string"
+                "reclaimed": {
+                    "description": "Reclaimed is the disk space freed, or that would be freed on a dry run. Layers\nshared only by removed images are not counted, so more may be freed.",
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PrunedImage"
+                    }
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ArchitectureSummary": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelListResponse": {
             "type": "object",
             "properties": {
-                "certified_by": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "services": {
+                "models": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CachedModel"
                     }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_size": {
+                    "type": "integer"
+                },
+                "unreferenced_size": {
+                    "description": "UnreferencedSize is the disk space held by models no application uses.",
+                    "type": "integer"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ComponentReference": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelPruneRequest": {
             "type": "object",
             "properties": {
-                "type": {
-                    "type": "string"
+                "dry_run": {
+                    "description": "DryRun reports what would be removed without removing it.",
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Connector": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelPruneResponse": {
             "type": "object",
             "properties": {
-                "connector_name": {
-                    "description": "display label for connector_type",
-                    "type": "string"
-                },
-                "connector_type": {
-                    "description": "e.g. \"datasource\"",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "short description",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "e.g. \"object_storage\", \"file_system\"",
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "display name",
-                    "type": "string"
+                "reclaimed": {
+                    "description": "Reclaimed is the disk space freed, or that would be freed on a dry run.",
+                    "type": "integer"
                 },
-                "type": {
-                    "description": "always \"connector\"",
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DependencyReference": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.CachedModel"
+                    }
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsArchitecture": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelReference": {
             "type": "object",
             "properties": {
-                "global_components": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent"
-                    }
+                "application_id": {
+                    "type": "string"
                 },
-                "id": {
+                "application_name": {
                     "type": "string"
                 },
-                "name": {
+                "component": {
+                    "description": "Component is \"\u003ccomponent type\u003e/\u003cprovider\u003e\".",
                     "type": "string"
                 },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService"
-                    }
+                "component_id": {
+                    "type": "string"
                 },
-                "version": {
+                "pod": {
+                    "description": "Pod is the pod serving the model, for applications deployed outside the catalog.",
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerification": {
             "type": "object",
             "properties": {
-                "name": {
+                "checked": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error is set when the model could not be verified at all.",
                     "type": "string"
                 },
-                "providers": {
+                "failed": {
+                    "description": "Failed lists files that are missing or whose checksum does not match.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsProvider"
+                        "type": "string"
                     }
                 },
-                "type": {
+                "model": {
+                    "type": "string"
+                },
+                "unverifiable": {
+                    "description": "Unverifiable lists files without a recorded checksum.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "verified_against": {
+                    "description": "VerifiedAgainst is \"metadata\" for models placed by ai-services and\n\"huggingface\" for models verified against the checksums hf recorded.",
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsProvider": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerifyRequest": {
             "type": "object",
             "properties": {
-                "default": {
-                    "type": "boolean"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "resources": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources"
-                },
-                "schema": {
-                    "type": "string"
-                },
-                "version": {
+                "model": {
+                    "description": "Model restricts verification to one model.",
                     "type": "string"
                 }
             }
         },
-        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsService": {
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerifyResponse": {
             "type": "object",
             "properties": {
-                "accepts_datasource": {
+                "healthy": {
+                    "description": "Healthy is true when every file that could be checked matched.",
                     "type": "boolean"
                 },
-                "components": {
+                "models": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.DeployOptionsComponent"
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ModelVerification"
                     }
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "resources": {
-                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources"
-                },
-                "schema": {
-                    "type": "string"
-                },
-                "version": {
-                    "type": "string"
                 }
             }
         },
@@ -2380,6 +4135,159 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PruneRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "DryRun reports what would be removed without removing it.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PrunedImage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names are the tags and digests of the image; empty for dangling images.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "description": "Size is the disk space only this image holds.",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PrunedVolume": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisterModelRequest": {
+            "type": "object",
+            "required": [
+                "component_type",
+                "name"
+            ],
+            "properties": {
+                "component_type": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "description": "Image is an OCI artifact or modelcar image holding the model files.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path is a directory on the catalog host holding the model files.",
+                    "type": "string"
+                },
+                "skip_tls_verify": {
+                    "description": "SkipTLSVerify disables certificate verification of the registry of Image.",
+                    "type": "boolean"
+                },
+                "username": {
+                    "description": "Username and Password authenticate to the registry of Image; they are not stored.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModel": {
+            "type": "object",
+            "properties": {
+                "component_type": {
+                    "description": "ComponentType is the component type serving the model: \"llm\", \"embedding\" or \"reranker\".",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "digest": {
+                    "description": "Digest is the manifest digest of an OCI source.",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is the model id used in component params, e.g. \"acme/granite-support\".",
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source is the directory or image reference the model was registered from.",
+                    "type": "string"
+                },
+                "source_type": {
+                    "description": "SourceType is \"local\" or \"oci\".",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is \"registering\", \"ready\" or \"failed\". Only ready models can be selected.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModelListResponse": {
+            "type": "object",
+            "properties": {
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RegisteredModel"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ResourceLimits": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "CPU cores",
+                    "type": "integer"
+                },
+                "memory_bytes": {
+                    "description": "Memory in bytes",
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Resources": {
             "type": "object",
             "properties": {
@@ -2404,6 +4312,37 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.RestoreBackupRequest": {
+            "type": "object",
+            "properties": {
+                "index_prefix": {
+                    "description": "IndexPrefix is prepended to the names of OpenSearch indices that\nRenameIndices does not cover.",
+                    "type": "string",
+                    "example": "promoted-"
+                },
+                "rename_indices": {
+                    "description": "RenameIndices restores OpenSearch indices under new names, keyed by the\nindex name in the backup.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "skip_existing_indices": {
+                    "description": "SkipExistingIndices leaves OpenSearch indices that already exist untouched\ninstead of replacing them.",
+                    "type": "boolean"
+                },
+                "source_application_id": {
+                    "description": "SourceApplicationID restores a backup of another application, e.g. to\npromote a corpus from a development application. The backup_id in the path\nthen names a backup of that application. Defaults to the application itself.",
+                    "type": "string",
+                    "example": "7b1c3a52-8f0e-4d2b-9c55-2f1a6e0b9d41"
+                },
+                "target": {
+                    "description": "Target restores a single target from a combined backup. Defaults to the\ntarget the backup was created for.",
+                    "type": "string",
+                    "example": "opensearch"
+                }
+            }
+        },
         "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Service": {
             "type": "object",
             "properties": {
@@ -2416,6 +4355,13 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "backup": {
+                    "description": "Supported backup hooks: backup, restore, verify",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "certified_by": {
                     "type": "string"
                 },
@@ -2459,6 +4405,9 @@ const docTemplate = `{
                 "provider": {
                     "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ProviderInfo"
                 },
+                "resources": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.ResourceLimits"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -2532,6 +4481,48 @@ const docTemplate = `{
                 "Dead"
             ]
         },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.UpdateBackupScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "retention": {
+                    "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.BackupRetention"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.VolumePruneResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Errors lists the volumes that could not be removed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reclaimed": {
+                    "description": "Reclaimed is the disk space freed, or that would be freed on a dry run.",
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_project-ai-services_ai-services_internal_pkg_catalog_types.PrunedVolume"
+                    }
+                }
+            }
+        },
         "github_com_project-ai-services_ai-services_internal_pkg_catalog_types.Worker": {
             "type": "object",
             "properties": {
@@ -2704,6 +4695,26 @@ const docTemplate = `{
         {
             "description": "Catalog endpoints for architectures and services",
             "name": "Catalog"
+        },
+        {
+            "description": "Accelerator inventory endpoints",
+            "name": "Accelerators"
+        },
+        {
+            "description": "Application backup and restore jobs and backup schedules",
+            "name": "Backups"
+        },
+        {
+            "description": "Model cache inventory, verification and pruning, and custom model registration",
+            "name": "Models"
+        },
+        {
+            "description": "Pruning of the images no application refers to",
+            "name": "Images"
+        },
+        {
+            "description": "Pruning of the volumes of deleted applications and components",
+            "name": "Volumes"
         }
     ]
 }`
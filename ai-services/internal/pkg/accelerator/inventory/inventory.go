@@ -0,0 +1,139 @@
+// Package inventory builds a per-card view of the Spyre accelerators attached to
+// the local host: PCI address, NUMA affinity, driver binding and health as
+// reported by the bootstrap Spyre checks.
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jaypipes/ghw"
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/topology"
+	"github.com/project-ai-services/ai-services/internal/pkg/bootstrap/spyreconfig/check"
+	"github.com/project-ai-services/ai-services/internal/pkg/bootstrap/spyreconfig/spyre"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// VFIODriver is the kernel driver Spyre cards must be bound to before they
+	// can be passed through to containers.
+	VFIODriver = "vfio-pci"
+
+	// UnknownNUMANode is reported when the platform exposes no NUMA affinity for a card.
+	UnknownNUMANode = topology.UnknownNode
+)
+
+// Health describes whether a card is usable for deployments.
+type Health string
+
+const (
+	HealthHealthy   Health = "healthy"
+	HealthUnhealthy Health = "unhealthy"
+)
+
+// Card is a single Spyre card as seen on one host.
+type Card struct {
+	PCIAddress string `json:"pci_address"`
+	// NUMANode is the NUMA node the card is attached to, or UnknownNUMANode.
+	NUMANode  int    `json:"numa_node"`
+	Driver    string `json:"driver"`
+	VFIOBound bool   `json:"vfio_bound"`
+	Health    Health `json:"health"`
+	// Problems lists the reasons a card is unhealthy; empty when healthy.
+	Problems []string `json:"problems,omitempty"`
+
+	// Application and TemplateID identify the pod that currently holds the card,
+	// taken from the ai-services.io/application and ai-services.io/template labels.
+	// Both are empty for free cards.
+	Application string `json:"application,omitempty"`
+	TemplateID  string `json:"template_id,omitempty"`
+}
+
+// Discover returns every Spyre card on the local host. Host-level bootstrap checks
+// are run once and any failure marks all cards unhealthy, since a broken VFIO
+// setup affects every card equally.
+func Discover(ctx context.Context) ([]Card, error) {
+	devices, err := spyre.GetSpyreDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover spyre devices: %w", err)
+	}
+
+	if len(devices) == 0 {
+		return []Card{}, nil
+	}
+
+	hostProblems := failedChecks(spyre.RunChecks())
+	if len(hostProblems) > 0 {
+		logger.DebugfCtx(ctx, "Spyre host checks failing: %v\n", hostProblems)
+	}
+
+	cards := make([]Card, 0, len(devices))
+	for _, device := range devices {
+		cards = append(cards, cardFromDevice(device, hostProblems))
+	}
+
+	return cards, nil
+}
+
+// NUMANodes maps the PCI address of every Spyre card on the local host to its
+// NUMA node. Cards without NUMA affinity are left out. Unlike Discover it does not
+// run the host checks, so it is cheap enough to call while planning deployments.
+func NUMANodes() (map[string]int, error) {
+	devices, err := spyre.GetSpyreDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover spyre devices: %w", err)
+	}
+
+	nodes := make(map[string]int, len(devices))
+	for _, device := range devices {
+		if device.Node != nil {
+			nodes[device.Address] = device.Node.ID
+		}
+	}
+
+	return nodes, nil
+}
+
+// cardFromDevice converts a ghw PCI device into a Card, combining the host-level
+// problems with the card's own driver binding.
+func cardFromDevice(device *ghw.PCIDevice, hostProblems []string) Card {
+	card := Card{
+		PCIAddress: device.Address,
+		NUMANode:   UnknownNUMANode,
+		Driver:     device.Driver,
+		VFIOBound:  device.Driver == VFIODriver,
+	}
+
+	if device.Node != nil {
+		card.NUMANode = device.Node.ID
+	}
+
+	problems := append([]string{}, hostProblems...)
+	if !card.VFIOBound {
+		driver := card.Driver
+		if driver == "" {
+			driver = "none"
+		}
+		problems = append(problems, fmt.Sprintf("not bound to %s (driver: %s)", VFIODriver, driver))
+	}
+
+	card.Health = HealthHealthy
+	if len(problems) > 0 {
+		card.Health = HealthUnhealthy
+		card.Problems = problems
+	}
+
+	return card
+}
+
+// failedChecks returns the descriptions of all checks that did not pass.
+func failedChecks(results []check.CheckResult) []string {
+	var failed []string
+	for _, result := range results {
+		if !result.GetStatus() {
+			failed = append(failed, result.GetDescription())
+		}
+	}
+
+	return failed
+}
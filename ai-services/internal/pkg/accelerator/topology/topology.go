@@ -0,0 +1,170 @@
+// Package topology describes the NUMA layout of the local host and decides how
+// Spyre cards and CPUs are placed so that a pod's cards, CPUs and memory share a
+// NUMA node wherever the free cards allow it.
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jaypipes/ghw"
+)
+
+// UnknownNode is used for cards whose NUMA affinity is not reported by the platform.
+const UnknownNode = -1
+
+// Node is a single NUMA node and the logical CPUs attached to it.
+type Node struct {
+	ID   int
+	CPUs []int
+}
+
+// Topology is the NUMA layout of a host.
+type Topology struct {
+	Nodes []Node
+}
+
+// Placement holds the cpuset and memory-node constraints for one container, in
+// the list format accepted by cgroups (e.g. "0-15,32-47").
+type Placement struct {
+	CPUs        string
+	MemoryNodes string
+}
+
+// Discover reads the NUMA layout of the local host through ghw. Options are passed
+// to ghw unchanged, which allows tests to load the layout from a ghw snapshot.
+func Discover(opts ...*ghw.WithOption) (*Topology, error) {
+	info, err := ghw.Topology(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NUMA topology: %w", err)
+	}
+
+	t := &Topology{Nodes: make([]Node, 0, len(info.Nodes))}
+	for _, n := range info.Nodes {
+		node := Node{ID: n.ID}
+		for _, core := range n.Cores {
+			node.CPUs = append(node.CPUs, core.LogicalProcessors...)
+		}
+		sort.Ints(node.CPUs)
+		t.Nodes = append(t.Nodes, node)
+	}
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].ID < t.Nodes[j].ID })
+
+	return t, nil
+}
+
+// IsNUMA reports whether the host has more than one NUMA node. Pinning is only
+// useful on such hosts.
+func (t *Topology) IsNUMA() bool {
+	return t != nil && len(t.Nodes) > 1
+}
+
+// PlacementFor returns the cpuset and memory nodes covering the given NUMA nodes.
+// It returns false when none of the nodes is known, in which case no constraint
+// should be applied.
+func (t *Topology) PlacementFor(nodeIDs []int) (Placement, bool) {
+	if t == nil {
+		return Placement{}, false
+	}
+
+	var cpus, mems []int
+	for _, id := range nodeIDs {
+		for _, n := range t.Nodes {
+			if n.ID == id && len(n.CPUs) > 0 {
+				cpus = append(cpus, n.CPUs...)
+				mems = append(mems, n.ID)
+			}
+		}
+	}
+
+	if len(cpus) == 0 {
+		return Placement{}, false
+	}
+
+	return Placement{CPUs: FormatList(cpus), MemoryNodes: FormatList(mems)}, true
+}
+
+// SelectCards picks n cards from free, which is in allocation order, keeping them
+// on a single NUMA node when possible. Among the nodes that can hold all n cards
+// the one with the fewest free cards is chosen, so larger nodes stay available for
+// larger requests. Otherwise the cards are spread over as few nodes as possible,
+// and cards of unknown affinity are used last.
+//
+// It returns the selected addresses in allocation order and the sorted NUMA nodes
+// they live on. The caller must ensure len(free) >= n.
+func SelectCards(free []string, nodeOf map[string]int, n int) ([]string, []int) {
+	byNode := make(map[int][]string)
+	var order []int
+	for _, addr := range free {
+		node, ok := nodeOf[addr]
+		if !ok || node < 0 {
+			node = UnknownNode
+		}
+		if _, seen := byNode[node]; !seen {
+			order = append(order, node)
+		}
+		byNode[node] = append(byNode[node], addr)
+	}
+
+	best := UnknownNode
+	for _, node := range order {
+		if node == UnknownNode || len(byNode[node]) < n {
+			continue
+		}
+		if best == UnknownNode || len(byNode[node]) < len(byNode[best]) {
+			best = node
+		}
+	}
+	if best != UnknownNode {
+		return append([]string(nil), byNode[best][:n]...), []int{best}
+	}
+
+	// No single node fits: take the fullest nodes first.
+	sort.SliceStable(order, func(i, j int) bool {
+		if (order[i] == UnknownNode) != (order[j] == UnknownNode) {
+			return order[j] == UnknownNode
+		}
+
+		return len(byNode[order[i]]) > len(byNode[order[j]])
+	})
+
+	selected := make([]string, 0, n)
+	var nodes []int
+	for _, node := range order {
+		if len(selected) == n {
+			break
+		}
+		take := min(n-len(selected), len(byNode[node]))
+		selected = append(selected, byNode[node][:take]...)
+		if node != UnknownNode {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Ints(nodes)
+
+	return selected, nodes
+}
+
+// FormatList renders IDs as a cgroup list, collapsing consecutive runs into ranges.
+func FormatList(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+
+	var parts []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] <= sorted[j]+1 {
+			j++
+		}
+		if sorted[i] == sorted[j] {
+			parts = append(parts, strconv.Itoa(sorted[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(parts, ",")
+}
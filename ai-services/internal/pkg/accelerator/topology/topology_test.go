@@ -0,0 +1,91 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/jaypipes/ghw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// twoNodeSnapshot is a ghw snapshot of a two-socket host: NUMA node 0 has CPUs
+// 0-7 and node 1 has CPUs 8-15, each as two SMT4 cores.
+const twoNodeSnapshot = "testdata/linux-ppc64le-2numa.tar.gz"
+
+func discoverSnapshot(t *testing.T) *Topology {
+	t.Helper()
+
+	topo, err := Discover(
+		ghw.WithSnapshot(ghw.SnapshotOptions{Path: twoNodeSnapshot}),
+		ghw.WithDisableWarnings(),
+	)
+	require.NoError(t, err)
+
+	return topo
+}
+
+func TestDiscoverFromSnapshot(t *testing.T) {
+	topo := discoverSnapshot(t)
+
+	require.Len(t, topo.Nodes, 2)
+	assert.True(t, topo.IsNUMA())
+	assert.Equal(t, 0, topo.Nodes[0].ID)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, topo.Nodes[0].CPUs)
+	assert.Equal(t, 1, topo.Nodes[1].ID)
+	assert.Equal(t, []int{8, 9, 10, 11, 12, 13, 14, 15}, topo.Nodes[1].CPUs)
+}
+
+func TestPlacementFor(t *testing.T) {
+	topo := discoverSnapshot(t)
+
+	p, ok := topo.PlacementFor([]int{1})
+	require.True(t, ok)
+	assert.Equal(t, Placement{CPUs: "8-15", MemoryNodes: "1"}, p)
+
+	p, ok = topo.PlacementFor([]int{0, 1})
+	require.True(t, ok)
+	assert.Equal(t, Placement{CPUs: "0-15", MemoryNodes: "0-1"}, p)
+
+	_, ok = topo.PlacementFor([]int{7})
+	assert.False(t, ok, "unknown nodes must not produce a constraint")
+
+	_, ok = topo.PlacementFor(nil)
+	assert.False(t, ok)
+}
+
+func TestSelectCards(t *testing.T) {
+	free := []string{"a0", "a1", "b0", "b1", "b2", "b3", "c0"}
+	nodeOf := map[string]int{"a0": 0, "a1": 0, "b0": 1, "b1": 1, "b2": 1, "b3": 1}
+
+	tests := []struct {
+		name      string
+		n         int
+		wantCards []string
+		wantNodes []int
+	}{
+		{name: "smallest node that fits", n: 2, wantCards: []string{"a0", "a1"}, wantNodes: []int{0}},
+		{name: "only one node fits", n: 4, wantCards: []string{"b0", "b1", "b2", "b3"}, wantNodes: []int{1}},
+		{name: "span fullest nodes first", n: 5, wantCards: []string{"b0", "b1", "b2", "b3", "a0"}, wantNodes: []int{0, 1}},
+		{name: "unknown affinity last", n: 7, wantCards: []string{"b0", "b1", "b2", "b3", "a0", "a1", "c0"}, wantNodes: []int{0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, nodes := SelectCards(free, nodeOf, tt.n)
+			assert.Equal(t, tt.wantCards, cards)
+			assert.Equal(t, tt.wantNodes, nodes)
+		})
+	}
+
+	t.Run("no affinity keeps pool order", func(t *testing.T) {
+		cards, nodes := SelectCards([]string{"x", "y", "z"}, nil, 2)
+		assert.Equal(t, []string{"x", "y"}, cards)
+		assert.Empty(t, nodes)
+	})
+}
+
+func TestFormatList(t *testing.T) {
+	assert.Equal(t, "", FormatList(nil))
+	assert.Equal(t, "3", FormatList([]int{3}))
+	assert.Equal(t, "0-3,8,10-11", FormatList([]int{11, 0, 1, 2, 3, 8, 10, 2}))
+}
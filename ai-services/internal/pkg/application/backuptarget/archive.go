@@ -0,0 +1,172 @@
+package backuptarget
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ManifestFile is the name of the manifest at the root of an archive.
+	ManifestFile = "manifest.json"
+	// SignatureFile holds the ed25519 signature of the manifest of a signed archive.
+	SignatureFile = "manifest.sig"
+	// ManifestVersion is the schema version written into new manifests. Version 1
+	// manifests only described combined archives and carry no checksums.
+	ManifestVersion = 2
+	// checksumManifestVersion is the first manifest version listing file checksums.
+	checksumManifestVersion = 2
+
+	defaultFilePermission = 0o644
+	defaultDirPermission  = 0o755
+)
+
+// errNoManifest is returned by checkContent for archives written without a manifest.
+var errNoManifest = errors.New("archive has no manifest")
+
+// Manifest describes the contents of an archive. The archive of a single target
+// sets Target; a combined archive lists the archives of its targets in Targets.
+type Manifest struct {
+	Version     int             `json:"version"`
+	Application string          `json:"application"`
+	Runtime     string          `json:"runtime"`
+	CLIVersion  string          `json:"cli_version,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Target      *ManifestEntry  `json:"target,omitempty"`
+	Targets     []ManifestEntry `json:"targets,omitempty"`
+	Files       []FileDigest    `json:"files,omitempty"`
+}
+
+// ManifestEntry describes the data of one target.
+type ManifestEntry struct {
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	ComponentType string `json:"component_type,omitempty"`
+	CatalogID     string `json:"catalog_id"`
+	// SchemaVersion is the archive layout version of the target.
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// File is the archive of the target inside a combined archive.
+	File string `json:"file,omitempty"`
+	// Documents maps each index in the archive to its number of documents.
+	Documents map[string]int `json:"documents,omitempty"`
+}
+
+// FileDigest is the size and SHA-256 checksum of one file in an archive.
+type FileDigest struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Combined reports whether the manifest describes a combined archive.
+func (m *Manifest) Combined() bool {
+	return len(m.Targets) > 0
+}
+
+// Entry returns the manifest entry of the named target.
+func (m *Manifest) Entry(name string) (ManifestEntry, bool) {
+	for _, e := range m.Targets {
+		if e.Name == name {
+			return e, true
+		}
+	}
+
+	return ManifestEntry{}, false
+}
+
+// marshalManifest returns the manifest as written into archives. Signatures cover
+// exactly these bytes.
+func marshalManifest(m *Manifest) ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+func writeManifest(dir string, m *Manifest, signingKey ed25519.PrivateKey) error {
+	data, err := marshalManifest(m)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, defaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+
+	if signingKey == nil {
+		return nil
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, SignatureFile), signManifest(data, signingKey), defaultFilePermission); err != nil {
+		return fmt.Errorf("failed to write backup manifest signature: %w", err)
+	}
+
+	return nil
+}
+
+// IsNoManifest reports whether err was returned for an archive without a
+// manifest.
+func IsNoManifest(err error) bool {
+	return errors.Is(err, errNoManifest)
+}
+
+// RequireEntry checks that entries, the names in an archive, include one below
+// one of the given path prefixes. Targets use it to verify the layout of their
+// archives.
+func RequireEntry(entries []string, prefixes ...string) error {
+	for _, entry := range entries {
+		name := strings.TrimPrefix(entry, "./")
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("archive contains none of: %s", strings.Join(prefixes, ", "))
+}
+
+// walkTarGz calls fn for every entry of the tar.gz archive read from r until fn
+// reports done. name identifies the archive in errors.
+func walkTarGz(r io.Reader, name string, fn func(header *tar.Header, r io.Reader) (bool, error)) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read backup archive %s: %w", name, err)
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive %s: %w", name, err)
+		}
+
+		done, err := fn(header, tarReader)
+		if err != nil || done {
+			return err
+		}
+	}
+
+	// Read up to the end of the stream, so its checksum is verified.
+	if _, err := io.Copy(io.Discard, gzipReader); err != nil {
+		return fmt.Errorf("failed to read backup archive %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package backuptarget
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+)
+
+// openSearchDataSuffix ends the name of the file holding the documents of an index.
+const openSearchDataSuffix = "_data.json"
+
+// Modes of OpenSearch backups.
+const (
+	// OpenSearchModeAuto takes a snapshot and falls back to the scroll export when
+	// the snapshot API is not available.
+	OpenSearchModeAuto = "auto"
+	// OpenSearchModeSnapshot takes a snapshot into an fs repository.
+	OpenSearchModeSnapshot = "snapshot"
+	// OpenSearchModeScroll exports every document with the scroll API.
+	OpenSearchModeScroll = "scroll"
+)
+
+// OpenSearchModes lists the accepted OpenSearch backup modes.
+var OpenSearchModes = []string{OpenSearchModeAuto, OpenSearchModeSnapshot, OpenSearchModeScroll}
+
+// Descriptions of the built-in targets. Each runtime registers its own
+// implementation under these descriptions.
+var (
+	OpenSearch = Description{
+		Name:          "opensearch",
+		Kind:          KindComponent,
+		ComponentType: "vector_store",
+		CatalogID:     "opensearch",
+		Summary:       "OpenSearch indices and data",
+		SchemaVersion: 2, // 2 adds snapshot archives (opensearch_snapshot/)
+	}
+	Digitize = Description{
+		Name:          "digitize",
+		Kind:          KindService,
+		CatalogID:     "digitize",
+		Summary:       "Digitize metadata (jobs and documents)",
+		SchemaVersion: 1,
+	}
+)
+
+// VerifyOpenSearchArchive checks the layout of an OpenSearch archive, accepting
+// snapshot archives (opensearch_snapshot/) and scroll exports in the current
+// (opensearch_backup/) and the old (backup/opensearch/) format.
+func VerifyOpenSearchArchive(entries []string) error {
+	return RequireEntry(entries, opensearch.SnapshotArchiveDir+"/", "opensearch_backup/", "backup/opensearch/")
+}
+
+// IsOpenSearchSnapshot reports whether dir holds an extracted OpenSearch
+// snapshot archive.
+func IsOpenSearchSnapshot(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, opensearch.SnapshotArchiveDir))
+
+	return err == nil && info.IsDir()
+}
+
+// VerifyDigitizeArchive checks the layout of a digitize archive.
+func VerifyDigitizeArchive(entries []string) error {
+	return RequireEntry(entries, "backup/cache/")
+}
+
+// CountOpenSearchDocuments counts the documents per index in an OpenSearch
+// archive entry. Scroll exports hold the hits of an index as one JSON array;
+// snapshot archives record the counts of all indices in their info file.
+func CountOpenSearchDocuments(name string, r io.Reader) (map[string]int, error) {
+	name = strings.TrimPrefix(name, "./")
+	if name == path.Join(opensearch.SnapshotArchiveDir, opensearch.SnapshotInfoFile) {
+		var info opensearch.SnapshotInfo
+		if err := json.NewDecoder(r).Decode(&info); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		return info.Indices, nil
+	}
+
+	dir, file := path.Split(name)
+	if (dir != "opensearch_backup/" && dir != "backup/opensearch/") || !strings.HasSuffix(file, openSearchDataSuffix) {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%s does not hold a JSON array", name)
+	}
+
+	count := 0
+	for decoder.More() {
+		var hit json.RawMessage
+		if err := decoder.Decode(&hit); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		count++
+	}
+
+	return map[string]int{strings.TrimSuffix(file, openSearchDataSuffix): count}, nil
+}
@@ -0,0 +1,421 @@
+package backuptarget
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// Encrypted archives follow the layout of age's scrypt recipient: a header with
+// the scrypt work factor and salt, then the archive split into chunks that are
+// sealed with ChaCha20-Poly1305 under a nonce made of the chunk counter and a
+// last-chunk flag, so truncation and reordering are detected.
+const (
+	encryptedMagic = "ai-services-backup-encrypted/v1\n"
+
+	encryptionChunkSize = 64 * 1024
+	saltSize            = 16
+	scryptLogN          = 15
+	maxScryptLogN       = 22
+	scryptR             = 8
+	scryptP             = 1
+
+	lastChunkFlag = 1
+	counterSize   = chacha20poly1305.NonceSize - 1
+)
+
+// errWrongPassphrase is returned when an archive cannot be decrypted, either
+// because the passphrase is wrong or the archive was modified.
+var errWrongPassphrase = errors.New("wrong passphrase or corrupted archive")
+
+// IsEncrypted reports whether file is an archive encrypted with a passphrase.
+func IsEncrypted(file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	header := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false, nil
+	}
+
+	return string(header) == encryptedMagic, nil
+}
+
+// plainReader returns the plain archive read from r, decrypting it under
+// passphrase when it is encrypted, and whether it is. name identifies the archive
+// in errors.
+func plainReader(r io.Reader, name string, passphrase []byte) (io.Reader, bool, error) {
+	buffered := bufio.NewReader(r)
+	if header, _ := buffered.Peek(len(encryptedMagic)); string(header) != encryptedMagic {
+		return buffered, false, nil
+	}
+
+	if len(passphrase) == 0 {
+		return nil, true, fmt.Errorf("%s is encrypted, a passphrase is required", name)
+	}
+
+	plain, err := newDecryptingReader(buffered, passphrase)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decrypt %s: %w", name, err)
+	}
+
+	return plain, true, nil
+}
+
+// decryptFile writes the decryption of file under passphrase to dst.
+func decryptFile(file, dst string, passphrase []byte) error {
+	in, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	plain, err := newDecryptingReader(in, passphrase)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, defaultFilePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create decrypted archive: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	if _, err := io.Copy(out, plain); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
+
+// writeProtected writes what write produces to w, encrypted under the passphrase
+// of protection when it has one.
+func writeProtected(w io.Writer, protection Protection, write func(w io.Writer) error) error {
+	if len(protection.Passphrase) == 0 {
+		return write(w)
+	}
+
+	encrypted, err := newEncryptingWriter(w, protection.Passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := write(encrypted); err != nil {
+		return err
+	}
+
+	return encrypted.Close()
+}
+
+// encryptingWriter encrypts what is written to it into dst chunk by chunk. A full
+// chunk is only sealed once more data follows it, so Close seals the last chunk
+// with the last-chunk flag.
+type encryptingWriter struct {
+	dst     io.Writer
+	aead    cipher.AEAD
+	buf     []byte
+	nonce   []byte
+	counter uint64
+}
+
+// newEncryptingWriter writes the header of an archive encrypted under passphrase
+// to dst and returns a writer for its content. The caller must Close it.
+func newEncryptingWriter(dst io.Writer, passphrase []byte) (io.WriteCloser, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := scrypt.Key(passphrase, salt, 1<<scryptLogN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(encryptedMagic), scryptLogN)
+	if _, err := dst.Write(append(header, salt...)); err != nil {
+		return nil, fmt.Errorf("failed to write encrypted archive: %w", err)
+	}
+
+	return &encryptingWriter{
+		dst:   dst,
+		aead:  aead,
+		buf:   make([]byte, 0, encryptionChunkSize),
+		nonce: make([]byte, chacha20poly1305.NonceSize),
+	}, nil
+}
+
+func (w *encryptingWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if len(w.buf) == encryptionChunkSize {
+			if err := w.seal(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(w.buf[len(w.buf):encryptionChunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close seals the last chunk.
+func (w *encryptingWriter) Close() error {
+	return w.seal(true)
+}
+
+func (w *encryptingWriter) seal(last bool) error {
+	setNonce(w.nonce, w.counter, last)
+	if _, err := w.dst.Write(w.aead.Seal(nil, w.nonce, w.buf, nil)); err != nil {
+		return fmt.Errorf("failed to write encrypted archive: %w", err)
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+
+	return nil
+}
+
+// decryptingReader reads the decryption of an encrypted archive chunk by chunk,
+// failing on the first chunk that does not authenticate. The end of the content
+// is only reported once the chunk flagged as the last one was read, so truncated
+// archives fail too.
+type decryptingReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	buf     []byte
+	plain   []byte
+	nonce   []byte
+	counter uint64
+	last    bool
+	err     error
+}
+
+// newDecryptingReader reads the header of an archive encrypted under passphrase
+// from src and returns a reader for its decrypted content.
+func newDecryptingReader(src io.Reader, passphrase []byte) (io.Reader, error) {
+	header := make([]byte, len(encryptedMagic)+1+saltSize)
+	if _, err := io.ReadFull(src, header); err != nil || string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return nil, errors.New("not an encrypted backup archive")
+	}
+
+	logN := int(header[len(encryptedMagic)])
+	if logN > maxScryptLogN {
+		return nil, fmt.Errorf("unsupported scrypt work factor 2^%d", logN)
+	}
+
+	key, err := scrypt.Key(passphrase, header[len(encryptedMagic)+1:], 1<<logN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{
+		src:   bufio.NewReaderSize(src, encryptionChunkSize+aead.Overhead()),
+		aead:  aead,
+		buf:   make([]byte, encryptionChunkSize+aead.Overhead()),
+		nonce: make([]byte, chacha20poly1305.NonceSize),
+	}, nil
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.last {
+			return 0, io.EOF
+		}
+		r.err = r.open()
+	}
+
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+
+	return n, nil
+}
+
+// open decrypts the next chunk.
+func (r *decryptingReader) open() error {
+	n, err := io.ReadFull(r.src, r.buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read encrypted archive: %w", err)
+	}
+
+	last := n < len(r.buf)
+	if !last {
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		}
+	}
+
+	setNonce(r.nonce, r.counter, last)
+	plain, err := r.aead.Open(r.buf[:0], r.nonce, r.buf[:n], nil)
+	if err != nil {
+		return errWrongPassphrase
+	}
+
+	r.plain = plain
+	r.counter++
+	r.last = last
+
+	return nil
+}
+
+func setNonce(nonce []byte, counter uint64, last bool) {
+	clear(nonce)
+	binary.BigEndian.PutUint64(nonce[counterSize-8:counterSize], counter)
+	if last {
+		nonce[counterSize] = lastChunkFlag
+	}
+}
+
+// openArchive returns the path of the plain archive of file, decrypting it into a
+// temporary file when it is encrypted. The returned cleanup removes that file.
+func openArchive(file string, passphrase []byte) (string, func(), error) {
+	encrypted, err := IsEncrypted(file)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !encrypted {
+		return file, func() {}, nil
+	}
+
+	if len(passphrase) == 0 {
+		return "", nil, fmt.Errorf("%s is encrypted, a passphrase is required", filepath.Base(file))
+	}
+
+	tmp, err := os.CreateTemp("", "backup-decrypted-*"+archiveExtension)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	_ = tmp.Close()
+
+	cleanup := func() {
+		if err := os.Remove(tmp.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warningf("Failed to remove decrypted archive: %v\n", err)
+		}
+	}
+
+	if err := decryptFile(file, tmp.Name(), passphrase); err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("failed to decrypt %s: %w", filepath.Base(file), err)
+	}
+
+	return tmp.Name(), cleanup, nil
+}
+
+// LoadSigningKey reads an ed25519 private key in PKCS #8 PEM form, as written by
+// "openssl genpkey -algorithm ed25519".
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an ed25519 key", path)
+	}
+
+	return edKey, nil
+}
+
+// LoadPublicKey reads an ed25519 public key in PKIX PEM form, as written by
+// "openssl pkey -pubout".
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an ed25519 key", path)
+	}
+
+	return edKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded key", path)
+	}
+
+	return block, nil
+}
+
+// signManifest returns the base64 encoded ed25519 signature of a manifest.
+func signManifest(manifest []byte, key ed25519.PrivateKey) []byte {
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifest))
+
+	return []byte(signature + "\n")
+}
+
+// verifySignature checks a signature written by signManifest.
+func verifySignature(manifest, signature []byte, key ed25519.PublicKey) error {
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return fmt.Errorf("malformed manifest signature: %w", err)
+	}
+
+	if !ed25519.Verify(key, manifest, raw) {
+		return errors.New("manifest signature does not match the public key")
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package backuptarget
+
+import (
+	"archive/tar"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// newManifest returns a manifest for an archive of the application.
+func newManifest(req Request, rt runtimeTypes.RuntimeType) *Manifest {
+	return &Manifest{
+		Version:     ManifestVersion,
+		Application: req.AppName,
+		Runtime:     string(rt),
+		CLIVersion:  vars.CLIVersion,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// manifestEntry describes a target in a manifest.
+func manifestEntry(d Description) ManifestEntry {
+	return ManifestEntry{
+		Name:          d.Name,
+		Kind:          d.Kind,
+		ComponentType: d.ComponentType,
+		CatalogID:     d.CatalogID,
+		SchemaVersion: d.SchemaVersion,
+	}
+}
+
+// stagedEntries returns the sorted entries of dir to archive. A manifest left in
+// dir is replaced when the archive is sealed.
+func stagedEntries(dir string) ([]string, error) {
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staged backup: %w", err)
+	}
+
+	entries := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Name() != ManifestFile && item.Name() != SignatureFile {
+			entries = append(entries, item.Name())
+		}
+	}
+
+	return entries, nil
+}
+
+// digestDir returns the checksum of every regular file below the entries of dir,
+// sorted by path, the names the entries are archived under and the documents per
+// index counted by counter when it is set. Names and paths are those that
+// commonBackup.WriteTarGz writes.
+func digestDir(dir string, entries []string, counter DocumentCounter) ([]FileDigest, []string, map[string]int, error) {
+	files := []FileDigest{}
+	names := []string{}
+	documents := map[string]int{}
+
+	for _, entry := range entries {
+		err := filepath.WalkDir(filepath.Join(dir, entry), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+
+			if d.IsDir() {
+				names = append(names, name+"/")
+
+				return nil
+			}
+			names = append(names, name)
+
+			digest, counts, err := digestFile(path, name, counter)
+			if err != nil {
+				return err
+			}
+			for index, count := range counts {
+				documents[index] = count
+			}
+			files = append(files, digest)
+
+			return nil
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to digest staged backup: %w", err)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return files, names, documents, nil
+}
+
+// digestFile returns the checksum of the file at path, archived as name, and the
+// documents per index counted by counter when it is set.
+func digestFile(path, name string, counter DocumentCounter) (FileDigest, map[string]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileDigest{}, nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	hash := sha256.New()
+	tee := io.TeeReader(f, hash)
+
+	var counts map[string]int
+	if counter != nil {
+		counts, err = counter.CountDocuments(name, tee)
+		if err != nil {
+			return FileDigest{}, nil, fmt.Errorf("failed to count documents in %s: %w", name, err)
+		}
+	}
+
+	// Hash whatever the counter did not read.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return FileDigest{}, nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return FileDigest{}, nil, fmt.Errorf("failed to stat %s: %w", name, err)
+	}
+
+	return FileDigest{Path: name, Size: info.Size(), SHA256: hex.EncodeToString(hash.Sum(nil))}, counts, nil
+}
+
+// sealDir writes the manifest and, with a signing key, its signature into dir and
+// returns the entries of the archive, the manifest and signature first.
+func sealDir(dir string, m *Manifest, entries []string, signingKey ed25519.PrivateKey) ([]string, error) {
+	if err := writeManifest(dir, m, signingKey); err != nil {
+		return nil, err
+	}
+
+	sealed := []string{ManifestFile}
+	if signingKey != nil {
+		sealed = append(sealed, SignatureFile)
+	}
+
+	return append(sealed, entries...), nil
+}
+
+// fileDigest returns the checksum of a file on disk.
+func fileDigest(path, name string) (FileDigest, error) {
+	digest, _, err := digestFile(path, name, nil)
+
+	return digest, err
+}
+
+// archiveContent is what readArchive found in an archive.
+type archiveContent struct {
+	// manifest is nil for archives written without one.
+	manifest *Manifest
+	// raw holds the manifest as archived, which the signature covers.
+	raw       []byte
+	signature []byte
+	// files holds the checksum of every regular file, sorted by path.
+	files []FileDigest
+	// entries names every entry but the manifest and its signature.
+	entries []string
+	// targets holds the contents of the target archives of a combined archive
+	// by target name.
+	targets map[string]*archiveContent
+}
+
+// target returns the name of the target whose archive is the entry name of a
+// combined archive.
+func (c *archiveContent) target(name string) (string, bool) {
+	if c.manifest == nil {
+		return "", false
+	}
+
+	for _, e := range c.manifest.Targets {
+		if e.File == name {
+			return e.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// readArchive reads the tar.gz archive name from r in one pass, checksumming every
+// file and extracting the archive into dir unless dir is empty. The archives of
+// the targets of a combined archive are read in turn, and extracted into
+// dir/<target> only for the targets that extract reports; the archive files
+// themselves are never written.
+func readArchive(r io.Reader, name, dir string, extract func(target string) bool) (*archiveContent, error) {
+	content := &archiveContent{files: []FileDigest{}, targets: map[string]*archiveContent{}}
+
+	err := walkTarGz(r, name, func(header *tar.Header, r io.Reader) (bool, error) {
+		var err error
+		switch {
+		case header.Name == ManifestFile && content.raw == nil:
+			if content.raw, err = io.ReadAll(r); err != nil {
+				return true, fmt.Errorf("failed to read backup manifest: %w", err)
+			}
+			content.manifest = &Manifest{}
+			if err := json.Unmarshal(content.raw, content.manifest); err != nil {
+				return true, fmt.Errorf("failed to parse backup manifest: %w", err)
+			}
+
+			return false, nil
+		case header.Name == SignatureFile && content.signature == nil:
+			if content.signature, err = io.ReadAll(r); err != nil {
+				return true, fmt.Errorf("failed to read backup manifest signature: %w", err)
+			}
+
+			return false, nil
+		}
+
+		content.entries = append(content.entries, header.Name)
+		if header.Typeflag != tar.TypeReg {
+			if dir == "" {
+				return false, nil
+			}
+
+			return false, utils.ExtractTarEntry(r, header, dir)
+		}
+
+		hash := sha256.New()
+		tee := io.TeeReader(r, hash)
+
+		if target, ok := content.target(header.Name); ok {
+			targetDir := ""
+			if dir != "" && extract != nil && extract(target) {
+				targetDir = filepath.Join(dir, target)
+			}
+
+			inner, err := readArchive(tee, header.Name, targetDir, nil)
+			if err != nil {
+				return true, fmt.Errorf("target %s: %w", target, err)
+			}
+			content.targets[target] = inner
+		} else if dir != "" {
+			if err := utils.ExtractTarEntry(tee, header, dir); err != nil {
+				return true, fmt.Errorf("failed to extract %s: %w", header.Name, err)
+			}
+		}
+
+		// Hash whatever was not read.
+		if _, err := io.Copy(io.Discard, tee); err != nil {
+			return true, fmt.Errorf("failed to read %s from backup archive: %w", header.Name, err)
+		}
+
+		content.files = append(content.files, FileDigest{Path: header.Name, Size: header.Size, SHA256: hex.EncodeToString(hash.Sum(nil))})
+
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(content.files, func(i, j int) bool { return content.files[i].Path < content.files[j].Path })
+
+	return content, nil
+}
+
+// checkIntegrity verifies the manifest of an archive file: its version, its
+// signature and the checksum of every file it lists. With a public key the archive
+// must be signed by the matching private key. Archives written without a manifest
+// return an error matching IsNoManifest.
+func checkIntegrity(file string, publicKey ed25519.PublicKey) (*Manifest, bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	content, err := readArchive(f, filepath.Base(file), "", nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return checkContent(content, filepath.Base(file), publicKey)
+}
+
+// checkContent verifies the manifest of the archive name that readArchive read,
+// as checkIntegrity does.
+func checkContent(content *archiveContent, name string, publicKey ed25519.PublicKey) (*Manifest, bool, error) {
+	manifest := content.manifest
+	if manifest == nil {
+		return nil, false, errNoManifest
+	}
+
+	if manifest.Version > ManifestVersion {
+		return nil, false, fmt.Errorf("%s was written by a newer CLI (%s, manifest version %d); upgrade ai-services to use it",
+			name, manifest.CLIVersion, manifest.Version)
+	}
+
+	signed := content.signature != nil
+	if publicKey != nil {
+		if !signed {
+			return nil, false, fmt.Errorf("%s is not signed", name)
+		}
+		if err := verifySignature(content.raw, content.signature, publicKey); err != nil {
+			return nil, true, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	// Manifests before version 2 carry no checksums.
+	if manifest.Version < checksumManifestVersion {
+		return manifest, signed, nil
+	}
+
+	if len(manifest.Files) == 0 {
+		return nil, signed, fmt.Errorf("%s failed the integrity check: manifest version %d lists no files", name, manifest.Version)
+	}
+
+	if err := compareDigests(manifest.Files, content.files); err != nil {
+		return nil, signed, fmt.Errorf("%s failed the integrity check: %w", name, err)
+	}
+
+	return manifest, signed, nil
+}
+
+// compareDigests checks that the files of an archive match the manifest exactly.
+func compareDigests(expected, actual []FileDigest) error {
+	found := make(map[string]FileDigest, len(actual))
+	for _, d := range actual {
+		found[d.Path] = d
+	}
+
+	for _, want := range expected {
+		got, ok := found[want.Path]
+		if !ok {
+			return fmt.Errorf("%s is missing", want.Path)
+		}
+		if got.Size != want.Size || got.SHA256 != want.SHA256 {
+			return fmt.Errorf("checksum mismatch for %s", want.Path)
+		}
+		delete(found, want.Path)
+	}
+
+	for path := range found {
+		return fmt.Errorf("%s is not listed in the manifest", path)
+	}
+
+	return nil
+}
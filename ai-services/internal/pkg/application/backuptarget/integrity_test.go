@@ -0,0 +1,256 @@
+package backuptarget
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+func TestSealedArchiveManifest(t *testing.T) {
+	registerFakes(t)
+	file := filepath.Join(t.TempDir(), "opensearch.tar.gz")
+
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, Request{AppName: "app"}, "opensearch", file))
+
+	manifest, _, err := checkIntegrity(file, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "app", manifest.Application)
+	assert.Equal(t, "unknown", manifest.CLIVersion)
+	assert.Equal(t, OpenSearch.SchemaVersion, manifest.Target.SchemaVersion)
+	assert.Equal(t, map[string]int{"rag": 2}, manifest.Target.Documents)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, "opensearch_backup/rag_data.json", manifest.Files[0].Path)
+	assert.Len(t, manifest.Files[0].SHA256, 64)
+
+	report, err := Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, file, Protection{})
+	require.NoError(t, err)
+	assert.False(t, report.Encrypted)
+	assert.False(t, report.Signed)
+	assert.Equal(t, manifest, report.Manifest)
+}
+
+func TestVerifyDetectsModifiedArchives(t *testing.T) {
+	registerFakes(t)
+	dir := t.TempDir()
+	file := filepath.Join(dir, "opensearch.tar.gz")
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, Request{AppName: "app"}, "opensearch", file))
+
+	t.Run("modified file", func(t *testing.T) {
+		raw := readTestArchive(t, file).raw
+
+		// Rebuild the archive with the original manifest and different data.
+		src := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(src, ManifestFile), raw, defaultFilePermission))
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "opensearch_backup"), defaultDirPermission))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "opensearch_backup", "rag_data.json"), []byte(`[{"_id":"1"}]`), defaultFilePermission))
+		tampered := filepath.Join(dir, "tampered.tar.gz")
+		require.NoError(t, commonBackup.CreateTarGzArchive(src, tampered, []string{ManifestFile, "opensearch_backup"}))
+
+		_, err := Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, tampered, Protection{})
+		require.ErrorContains(t, err, "checksum mismatch for opensearch_backup/rag_data.json")
+
+		err = Restore(context.Background(), runtimeTypes.RuntimeTypePodman, Request{AppName: "app"}, "opensearch", tampered)
+		require.ErrorContains(t, err, "failed the integrity check")
+	})
+
+	t.Run("manifest without files", func(t *testing.T) {
+		manifest := readTestArchive(t, file).manifest
+		manifest.Files = nil
+		raw, err := json.Marshal(manifest)
+		require.NoError(t, err)
+
+		src := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(src, ManifestFile), raw, defaultFilePermission))
+		stripped := filepath.Join(dir, "stripped.tar.gz")
+		require.NoError(t, commonBackup.CreateTarGzArchive(src, stripped, []string{ManifestFile}))
+
+		_, err = Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, stripped, Protection{})
+		require.ErrorContains(t, err, "lists no files")
+	})
+
+	t.Run("truncated archive", func(t *testing.T) {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		truncated := filepath.Join(dir, "truncated.tar.gz")
+		require.NoError(t, os.WriteFile(truncated, data[:len(data)/2], defaultFilePermission))
+
+		_, err = Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, truncated, Protection{})
+		require.Error(t, err)
+	})
+
+	t.Run("newer target schema", func(t *testing.T) {
+		newer := &fakeTarget{desc: OpenSearch, entry: "opensearch_backup/"}
+		newer.desc.SchemaVersion = OpenSearch.SchemaVersion + 1
+		PodmanRegistry.Register(newer)
+		newerFile := filepath.Join(dir, "newer.tar.gz")
+		require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, Request{AppName: "app"}, "opensearch", newerFile))
+		registerFakes(t)
+
+		_, err := Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, newerFile, Protection{})
+		require.ErrorContains(t, err, "schema version 3, this CLI supports up to 2")
+	})
+}
+
+func TestSignedAndEncryptedArchive(t *testing.T) {
+	opensearch, digitize := registerFakes(t)
+	file := filepath.Join(t.TempDir(), "app_backup.tar.gz")
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	req := Request{AppName: "app", Protection: Protection{SigningKey: private, Passphrase: []byte("secret")}}
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file))
+
+	encrypted, err := IsEncrypted(file)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	_, err = Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, file, Protection{})
+	require.ErrorContains(t, err, "a passphrase is required")
+
+	_, err = Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, file, Protection{Passphrase: []byte("wrong")})
+	require.ErrorContains(t, err, "wrong passphrase")
+
+	report, err := Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, file, Protection{Passphrase: []byte("secret"), PublicKey: public})
+	require.NoError(t, err)
+	assert.True(t, report.Encrypted)
+	assert.True(t, report.SignatureVerified)
+	assert.Len(t, report.Manifest.Targets, 2)
+
+	_, err = Verify(context.Background(), runtimeTypes.RuntimeTypePodman, nil, file, Protection{Passphrase: []byte("secret"), PublicKey: otherPublic})
+	require.ErrorContains(t, err, "signature does not match")
+
+	restoreReq := Request{AppName: "app", Protection: Protection{Passphrase: []byte("secret"), PublicKey: public}}
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, restoreReq, All, file))
+	assert.Len(t, opensearch.restored, 1)
+	assert.Len(t, digitize.restored, 1)
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	sizes := []int{0, 10, encryptionChunkSize, 3*encryptionChunkSize + 5}
+	protection := Protection{Passphrase: []byte("secret")}
+
+	for _, size := range sizes {
+		plain := bytes.Repeat([]byte("x"), size)
+		var encrypted bytes.Buffer
+		require.NoError(t, writeProtected(&encrypted, protection, func(w io.Writer) error {
+			_, err := w.Write(plain)
+
+			return err
+		}))
+
+		got, err := decrypt(encrypted.Bytes(), protection.Passphrase)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, plain, got, "size %d", size)
+
+		// Dropping the last chunk is detected.
+		if size > encryptionChunkSize {
+			_, err := decrypt(encrypted.Bytes()[:encrypted.Len()-21], protection.Passphrase)
+			assert.ErrorIs(t, err, errWrongPassphrase)
+		}
+	}
+}
+
+func decrypt(data, passphrase []byte) ([]byte, error) {
+	r, err := newDecryptingReader(bytes.NewReader(data), passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
+
+// readTestArchive reads file with the reader restore and verify use.
+func readTestArchive(t *testing.T, file string) *archiveContent {
+	t.Helper()
+
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	content, err := readArchive(f, filepath.Base(file), "", nil)
+	require.NoError(t, err)
+	require.NotNil(t, content.manifest)
+
+	return content
+}
+
+func TestCountOpenSearchDocuments(t *testing.T) {
+	counts, err := CountOpenSearchDocuments("opensearch_backup/rag_docs_data.json", strings.NewReader(`[{"a":1},{"b":[1,2]},{}]`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"rag_docs": 3}, counts)
+
+	counts, err = CountOpenSearchDocuments("opensearch_backup/rag_docs_mapping.json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	assert.Nil(t, counts)
+
+	_, err = CountOpenSearchDocuments("backup/opensearch/rag_data.json", strings.NewReader(`{"hits":[]}`))
+	require.Error(t, err)
+
+	info := `{"snapshot":"backup-1","indices":{"rag_docs":3,"rag_chunks":12}}`
+	counts, err = CountOpenSearchDocuments("opensearch_snapshot/snapshot_info.json", strings.NewReader(info))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"rag_docs": 3, "rag_chunks": 12}, counts)
+
+	counts, err = CountOpenSearchDocuments("opensearch_snapshot/repository/index-0", strings.NewReader("binary"))
+	require.NoError(t, err)
+	assert.Nil(t, counts)
+}
+
+func TestOpenSearchSnapshotArchive(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "opensearch_snapshot", "repository"), defaultDirPermission))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "opensearch_snapshot", "snapshot_info.json"), []byte(`{"snapshot":"backup-1","indices":{"rag":2}}`), defaultFilePermission))
+
+	files, entries, documents, err := digestDir(src, []string{"opensearch_snapshot"}, &fakeTarget{})
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, map[string]int{"rag": 2}, documents)
+	assert.Equal(t, []string{"opensearch_snapshot/", "opensearch_snapshot/repository/", "opensearch_snapshot/snapshot_info.json"}, entries)
+
+	require.NoError(t, VerifyOpenSearchArchive(entries))
+	assert.True(t, IsOpenSearchSnapshot(src))
+	assert.False(t, IsOpenSearchSnapshot(t.TempDir()))
+}
+
+func TestSealedArchiveRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "catalog.sql"), []byte("SELECT 1;"), defaultFilePermission))
+	file := filepath.Join(t.TempDir(), "catalog.tar.gz")
+	protection := Protection{Passphrase: []byte("secret")}
+	entry := ManifestEntry{Name: "catalog", Kind: "catalog", SchemaVersion: 1}
+
+	_, err := WriteSealedArchive(src, file, []string{"catalog.sql"}, "ai-services", runtimeTypes.RuntimeTypePodman, entry, protection)
+	require.NoError(t, err)
+
+	encrypted, err := IsEncrypted(file)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	plainFile, manifest, cleanup, err := OpenSealedArchive(file, "catalog", protection)
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, "catalog", manifest.Target.Kind)
+	require.Len(t, manifest.Files, 1)
+	assert.Equal(t, "catalog.sql", manifest.Files[0].Path)
+	assert.FileExists(t, plainFile)
+
+	_, _, _, err = OpenSealedArchive(file, "opensearch", protection)
+	require.ErrorContains(t, err, "is not a opensearch backup")
+}
@@ -0,0 +1,170 @@
+package backuptarget
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+)
+
+const bytesPerMB = 1 << 20
+
+// remoteArchive is a backup archive in S3-compatible object storage.
+type remoteArchive struct {
+	client *objectstore.Client
+	key    string
+}
+
+// resolveRemoteArchive returns the object that the s3:// URL file names. A URL
+// naming a bucket or a directory gets a generated archive name, below the
+// configured prefix for a bucket. Existing objects are not overwritten.
+func resolveRemoteArchive(ctx context.Context, req Request, file, target string) (*remoteArchive, error) {
+	location, err := objectstore.ParseURL(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := objectStoreConfig(req.ObjectStore)
+	cfg.Bucket = location.Bucket
+
+	key := location.Key
+	if key == "" && cfg.Prefix != "" {
+		key = strings.TrimSuffix(cfg.Prefix, "/") + "/"
+	}
+	switch {
+	case location.IsDir():
+		key += archiveName(req.AppName, target)
+	case !strings.HasSuffix(key, archiveExtension):
+		key += archiveExtension
+	}
+
+	client, err := objectstore.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.Exists(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check backup object %s: %w", client.Location(key), err)
+	}
+	if exists {
+		return nil, fmt.Errorf("backup object already exists: %s", client.Location(key))
+	}
+
+	return &remoteArchive{client: client, key: key}, nil
+}
+
+func (a *remoteArchive) String() string {
+	return a.client.Location(a.key).String()
+}
+
+// write streams the archive that write produces into the object, encrypting it
+// on the way when protection has a passphrase.
+func (a *remoteArchive) write(ctx context.Context, protection Protection, write func(w io.Writer) error) error {
+	size, err := a.client.UploadFrom(ctx, a.key, func(w io.Writer) error {
+		return writeProtected(w, protection, write)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload backup archive: %w", err)
+	}
+
+	if len(protection.Passphrase) > 0 {
+		logger.Infoln("🔒 Backup archive encrypted")
+	}
+	logger.Infof("✓ Backup archive uploaded: %s (%.2f MB)\n", a, float64(size)/bytesPerMB)
+
+	return nil
+}
+
+// objectStoreConfig returns cfg, or the configuration from the environment when
+// cfg is nil.
+func objectStoreConfig(cfg *objectstore.Config) objectstore.Config {
+	if cfg == nil {
+		return objectstore.ConfigFromEnv()
+	}
+
+	return *cfg
+}
+
+// openArchiveSource opens the archive file for reading. Archives in object
+// storage, named by an s3:// URL, are read from the body of the download as it
+// arrives; cfg nil reads the object storage configuration from the environment.
+func openArchiveSource(ctx context.Context, cfg *objectstore.Config, file string) (io.ReadCloser, error) {
+	if !objectstore.IsURL(file) {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open backup archive: %w", err)
+		}
+
+		return f, nil
+	}
+
+	client, key, err := archiveObject(cfg, file)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Streaming backup archive from %s...\n", file)
+
+	body, err := client.Download(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download backup archive: %w", err)
+	}
+
+	return body, nil
+}
+
+// IsEncryptedArchive reports whether the archive file, a local path or an s3://
+// URL, is encrypted with a passphrase. Only the header of an archive in object
+// storage is downloaded.
+func IsEncryptedArchive(ctx context.Context, cfg *objectstore.Config, file string) (bool, error) {
+	if !objectstore.IsURL(file) {
+		return IsEncrypted(file)
+	}
+
+	client, key, err := archiveObject(cfg, file)
+	if err != nil {
+		return false, err
+	}
+
+	body, err := client.DownloadRange(ctx, key, 0, int64(len(encryptedMagic)))
+	if err != nil {
+		return false, fmt.Errorf("failed to download backup archive: %w", err)
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	header := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(body, header); err != nil {
+		return false, nil
+	}
+
+	return string(header) == encryptedMagic, nil
+}
+
+// archiveObject returns a client for the bucket of the s3:// URL file and the key
+// of the archive it names.
+func archiveObject(cfg *objectstore.Config, file string) (*objectstore.Client, string, error) {
+	location, err := objectstore.ParseURL(file)
+	if err != nil {
+		return nil, "", err
+	}
+	if location.IsDir() {
+		return nil, "", fmt.Errorf("%s does not name a backup archive", file)
+	}
+
+	storeCfg := objectStoreConfig(cfg)
+	storeCfg.Bucket = location.Bucket
+
+	client, err := objectstore.NewClient(storeCfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, location.Key, nil
+}
@@ -0,0 +1,104 @@
+package backuptarget
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// newObjectStore serves single-part uploads and whole or ranged downloads of a
+// bucket from memory.
+func newObjectStore(t *testing.T) (*objectstore.Config, map[string][]byte) {
+	t.Helper()
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := strings.TrimPrefix(r.URL.Path, "/backups/")
+		switch r.Method {
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			objects[key] = data
+		case http.MethodGet, http.MethodHead:
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+			http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(data))
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return &objectstore.Config{Endpoint: srv.URL, AccessKeyID: "minio", SecretAccessKey: "minio-secret", Prefix: "nightly"}, objects
+}
+
+func TestBackupToObjectStore(t *testing.T) {
+	opensearch, digitize := registerFakes(t)
+	cfg, objects := newObjectStore(t)
+	ctx := context.Background()
+	req := Request{AppName: "app", ObjectStore: cfg, Protection: Protection{Passphrase: []byte("secret")}}
+
+	require.NoError(t, Backup(ctx, runtimeTypes.RuntimeTypePodman, req, All, "s3://backups"))
+	require.Len(t, objects, 1)
+
+	var key string
+	for k := range objects {
+		key = k
+	}
+	assert.True(t, strings.HasPrefix(key, "nightly/app_backup_"), "bucket URLs get a generated name below the prefix, got %s", key)
+	assert.True(t, strings.HasPrefix(string(objects[key]), encryptedMagic), "the uploaded archive is encrypted")
+
+	encrypted, err := IsEncryptedArchive(ctx, cfg, "s3://backups/"+key)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	require.NoError(t, Restore(ctx, runtimeTypes.RuntimeTypePodman, req, All, "s3://backups/"+key))
+	assert.Equal(t, []string{fakeData}, opensearch.restored)
+	assert.Equal(t, []string{fakeData}, digitize.restored)
+
+	err = Backup(ctx, runtimeTypes.RuntimeTypePodman, req, "opensearch", "s3://backups/"+key)
+	require.ErrorContains(t, err, "backup object already exists")
+}
+
+func TestSingleTargetThroughObjectStore(t *testing.T) {
+	opensearch, _ := registerFakes(t)
+	cfg, objects := newObjectStore(t)
+	ctx := context.Background()
+	req := Request{AppName: "app", ObjectStore: cfg, Protection: Protection{Passphrase: []byte("secret")}}
+
+	require.NoError(t, Backup(ctx, runtimeTypes.RuntimeTypePodman, req, "opensearch", "s3://backups/manual/opensearch"))
+	require.Contains(t, objects, "manual/opensearch.tar.gz")
+	assert.True(t, strings.HasPrefix(string(objects["manual/opensearch.tar.gz"]), encryptedMagic), "the uploaded archive is encrypted")
+
+	report, err := Verify(ctx, runtimeTypes.RuntimeTypePodman, cfg, "s3://backups/manual/opensearch.tar.gz", req.Protection)
+	require.NoError(t, err)
+	assert.True(t, report.Encrypted)
+	assert.Equal(t, "opensearch", report.Manifest.Target.Name)
+	assert.Equal(t, map[string]int{"rag": 2}, report.Manifest.Target.Documents)
+
+	require.NoError(t, Restore(ctx, runtimeTypes.RuntimeTypePodman, req, "opensearch", "s3://backups/manual/opensearch.tar.gz"))
+	assert.Equal(t, []string{fakeData}, opensearch.restored)
+
+	_, err = Verify(ctx, runtimeTypes.RuntimeTypePodman, cfg, "s3://backups/manual/missing.tar.gz", req.Protection)
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+}
@@ -0,0 +1,536 @@
+package backuptarget
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+const archiveExtension = ".tar.gz"
+
+// Backup backs up the named target of the application into file. With All every
+// deployed target that supports backups is written into one combined archive with
+// a manifest. An empty file name is generated from the application and target.
+func Backup(ctx context.Context, rt runtimeTypes.RuntimeType, req Request, name, file string) error {
+	registry, err := ForRuntime(rt)
+	if err != nil {
+		return err
+	}
+
+	if name == All {
+		return backupAll(ctx, registry, rt, req, file)
+	}
+
+	t, err := registry.lookup(name, HookBackup)
+	if err != nil {
+		return err
+	}
+
+	dest, err := resolveDestination(ctx, req, file, name)
+	if err != nil {
+		return err
+	}
+
+	tempDir, cleanup, err := tempDirectory("backup-*")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	_, entries, err := registry.stageTarget(ctx, t, req, rt, tempDir)
+	if err != nil {
+		return err
+	}
+
+	err = dest.write(ctx, req.Protection, func(w io.Writer) error {
+		return commonBackup.WriteTarGz(w, tempDir, entries)
+	})
+	if err != nil {
+		return err
+	}
+	req.reportProgress(name, 1, 1)
+
+	logger.Infof("✅ Backup completed successfully: %s\n", dest)
+
+	return nil
+}
+
+// Restore restores the named target of the application from file, a local path
+// or an s3:// URL. File may be the archive of a single target or a combined
+// archive; from a combined archive All restores every target listed in the
+// manifest. The archive is read once, as a stream: encrypted archives are
+// decrypted with the request's passphrase on the way and extracted into a
+// temporary directory, and the manifest signature and checksums are verified
+// before any data is restored.
+func Restore(ctx context.Context, rt runtimeTypes.RuntimeType, req Request, name, file string) error {
+	registry, err := ForRuntime(rt)
+	if err != nil {
+		return err
+	}
+
+	tempDir, cleanup, err := tempDirectory("restore-*")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	content, _, err := readBackup(ctx, req.ObjectStore, file, req.Protection.Passphrase, tempDir, func(target string) bool {
+		return name == All || name == target
+	})
+	if err != nil {
+		return err
+	}
+
+	manifest, err := checkArchive(content, filepath.Base(file), req.Protection.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if manifest != nil && (manifest.Application != req.AppName || manifest.Runtime != string(rt)) {
+		logger.Infof("Restoring backup of application %s (%s) into application %s (%s)\n", manifest.Application, manifest.Runtime, req.AppName, rt)
+	}
+
+	if manifest != nil && manifest.Combined() {
+		return restoreFromManifest(ctx, registry, req, content, name, file, tempDir)
+	}
+
+	target, err := archiveTarget(manifest, name, file)
+	if err != nil {
+		return err
+	}
+
+	t, err := registry.lookup(target, HookRestore)
+	if err != nil {
+		return err
+	}
+
+	if err := registry.restoreTarget(ctx, t, req, tempDir, content.entries, manifest); err != nil {
+		return err
+	}
+	req.reportProgress(target, 1, 1)
+
+	return nil
+}
+
+// readBackup reads the archive file, a local path or an s3:// URL, with
+// readArchive, decrypting it under passphrase when it is encrypted. It reports
+// whether the archive was encrypted.
+func readBackup(ctx context.Context, cfg *objectstore.Config, file string, passphrase []byte, dir string, extract func(target string) bool) (*archiveContent, bool, error) {
+	src, err := openArchiveSource(ctx, cfg, file)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	plain, encrypted, err := plainReader(src, filepath.Base(file), passphrase)
+	if err != nil {
+		return nil, encrypted, err
+	}
+
+	content, err := readArchive(plain, filepath.Base(file), dir, extract)
+	if err != nil {
+		return nil, encrypted, err
+	}
+
+	return content, encrypted, nil
+}
+
+// archiveTarget returns the target to restore from the archive of a single
+// target. Archives written without a manifest cannot be restored with All.
+func archiveTarget(manifest *Manifest, name, file string) (string, error) {
+	if manifest == nil || manifest.Target == nil {
+		if name == All {
+			return "", fmt.Errorf("%s is not a combined backup archive, restore it with the target it was created for", filepath.Base(file))
+		}
+
+		return name, nil
+	}
+
+	if name != All && name != manifest.Target.Name {
+		return "", fmt.Errorf("backup archive %s holds target %s, not %s", filepath.Base(file), manifest.Target.Name, name)
+	}
+
+	return manifest.Target.Name, nil
+}
+
+// lookup returns the named target if its catalog item declares hook.
+func (r *Registry) lookup(name, hook string) (Target, error) {
+	t, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported target: %s", name)
+	}
+
+	if !r.Supports(t.Describe(), hook) {
+		return nil, fmt.Errorf("target '%s' does not support %s", name, hook)
+	}
+
+	return t, nil
+}
+
+// applicable returns the targets that declare hook and are deployed in app.
+func (r *Registry) applicable(req Request, hook string) []Target {
+	var targets []Target
+	for _, t := range r.Targets() {
+		d := t.Describe()
+		if r.Supports(d, hook) && deployedIn(req.App, d) {
+			targets = append(targets, t)
+		}
+	}
+
+	return targets
+}
+
+func backupAll(ctx context.Context, registry *Registry, rt runtimeTypes.RuntimeType, req Request, file string) error {
+	targets := registry.applicable(req, HookBackup)
+	if len(targets) == 0 {
+		return fmt.Errorf("application %s has no components or services that support backups", req.AppName)
+	}
+
+	dest, err := resolveDestination(ctx, req, file, "")
+	if err != nil {
+		return err
+	}
+
+	tempDir, cleanup, err := tempDirectory("backup-*")
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	manifest := newManifest(req, rt)
+	var targetFiles []string
+
+	for i, t := range targets {
+		d := t.Describe()
+		logger.Infof("Backing up target: %s\n", d.Name)
+
+		entry, digest, err := registry.backupTargetArchive(ctx, t, req, rt, tempDir)
+		if err != nil {
+			return fmt.Errorf("failed to back up target %s: %w", d.Name, err)
+		}
+
+		manifest.Targets = append(manifest.Targets, entry)
+		manifest.Files = append(manifest.Files, digest)
+		targetFiles = append(targetFiles, entry.File)
+		req.reportProgress(d.Name, i+1, len(targets))
+	}
+
+	entries, err := sealDir(tempDir, manifest, targetFiles, req.Protection.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	// The combined archive is streamed from the target archives to its
+	// destination.
+	err = dest.write(ctx, req.Protection, func(w io.Writer) error {
+		return commonBackup.WriteTarGz(w, tempDir, entries)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("✅ Backup of %d target(s) completed successfully: %s\n", len(targets), dest)
+
+	return nil
+}
+
+// backupTargetArchive backs up a target into its sealed archive in dir, which a
+// combined archive holds, and returns its manifest entry and checksum.
+func (r *Registry) backupTargetArchive(ctx context.Context, t Target, req Request, rt runtimeTypes.RuntimeType, dir string) (ManifestEntry, FileDigest, error) {
+	name := t.Describe().Name
+	stageDir := filepath.Join(dir, name)
+	if err := os.Mkdir(stageDir, defaultDirPermission); err != nil {
+		return ManifestEntry{}, FileDigest{}, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(stageDir); err != nil {
+			logger.Warningf("Failed to remove staging directory: %v\n", err)
+		}
+	}()
+
+	targetManifest, entries, err := r.stageTarget(ctx, t, req, rt, stageDir)
+	if err != nil {
+		return ManifestEntry{}, FileDigest{}, err
+	}
+
+	targetFile := name + archiveExtension
+	if err := commonBackup.CreateTarGzArchive(stageDir, filepath.Join(dir, targetFile), entries); err != nil {
+		return ManifestEntry{}, FileDigest{}, err
+	}
+
+	digest, err := fileDigest(filepath.Join(dir, targetFile), targetFile)
+	if err != nil {
+		return ManifestEntry{}, FileDigest{}, err
+	}
+
+	entry := *targetManifest.Target
+	entry.File = targetFile
+
+	return entry, digest, nil
+}
+
+// restoreFromManifest restores the targets of a combined archive that
+// readArchive extracted into dir.
+func restoreFromManifest(ctx context.Context, registry *Registry, req Request, content *archiveContent, name, file, dir string) error {
+	entries := content.manifest.Targets
+	if name != All {
+		entry, ok := content.manifest.Entry(name)
+		if !ok {
+			return fmt.Errorf("backup archive %s does not contain target %s", filepath.Base(file), name)
+		}
+		entries = []ManifestEntry{entry}
+	}
+
+	for i, entry := range entries {
+		t, err := registry.lookup(entry.Name, HookRestore)
+		if err != nil {
+			return err
+		}
+
+		targetContent, ok := content.targets[entry.Name]
+		if !ok {
+			return fmt.Errorf("target %s: %s is missing from the backup archive", entry.Name, entry.File)
+		}
+
+		targetManifest, err := checkArchive(targetContent, entry.File, req.Protection.PublicKey)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", entry.Name, err)
+		}
+
+		if name == All && !deployedIn(req.App, t.Describe()) {
+			logger.Warningf("Skipping target %s: not deployed in application %s\n", entry.Name, req.AppName)
+			req.reportProgress(entry.Name, i+1, len(entries))
+
+			continue
+		}
+
+		logger.Infof("Restoring target: %s\n", entry.Name)
+		if err := registry.restoreTarget(ctx, t, req, filepath.Join(dir, entry.Name), targetContent.entries, targetManifest); err != nil {
+			return fmt.Errorf("failed to restore target %s: %w", entry.Name, err)
+		}
+		req.reportProgress(entry.Name, i+1, len(entries))
+	}
+
+	return nil
+}
+
+// stageTarget runs a target's backup into dir, verifies what it wrote and seals
+// it with a manifest. The manifest records the checksum of every file and, for
+// targets that count documents, the number of documents per index. It is signed
+// when the request carries a signing key. stageTarget returns the manifest and
+// the entries of dir to archive.
+func (r *Registry) stageTarget(ctx context.Context, t Target, req Request, rt runtimeTypes.RuntimeType, dir string) (*Manifest, []string, error) {
+	if err := t.Backup(ctx, req, dir); err != nil {
+		return nil, nil, err
+	}
+
+	staged, err := stagedEntries(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counter, _ := t.(DocumentCounter)
+	files, names, documents, err := digestDir(dir, staged, counter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.Supports(t.Describe(), HookVerify) {
+		if err := t.Verify(names); err != nil {
+			return nil, nil, fmt.Errorf("backup archive failed verification: %w", err)
+		}
+	}
+
+	entry := manifestEntry(t.Describe())
+	if len(documents) > 0 {
+		entry.Documents = documents
+	}
+
+	manifest := newManifest(req, rt)
+	manifest.Target = &entry
+	manifest.Files = files
+
+	entries, err := sealDir(dir, manifest, staged, req.Protection.SigningKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return manifest, entries, nil
+}
+
+// restoreTarget verifies an archive extracted into dir before handing it to the
+// target's restore. entries names the entries of the archive and manifest is its
+// checked manifest, nil for archives without one.
+func (r *Registry) restoreTarget(ctx context.Context, t Target, req Request, dir string, entries []string, manifest *Manifest) error {
+	if err := r.verifyTarget(t, entries, manifest); err != nil {
+		return err
+	}
+
+	return t.Restore(ctx, req, dir)
+}
+
+// verifyTarget checks that this CLI supports the archive layout of a target and,
+// for targets that declare the verify hook, that the archive is well-formed.
+func (r *Registry) verifyTarget(t Target, entries []string, manifest *Manifest) error {
+	d := t.Describe()
+	if manifest != nil && manifest.Target != nil && manifest.Target.SchemaVersion > d.SchemaVersion {
+		return fmt.Errorf("backup archive of target %s uses schema version %d, this CLI supports up to %d; upgrade ai-services to restore it",
+			d.Name, manifest.Target.SchemaVersion, d.SchemaVersion)
+	}
+
+	if r.Supports(d, HookVerify) {
+		if err := t.Verify(entries); err != nil {
+			return fmt.Errorf("backup archive failed verification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkArchive checks the integrity of the archive name that readArchive read,
+// returning its manifest or nil for archives written without one, which only
+// signed archives are rejected for.
+func checkArchive(content *archiveContent, name string, publicKey ed25519.PublicKey) (*Manifest, error) {
+	manifest, _, err := checkContent(content, name, publicKey)
+	if IsNoManifest(err) {
+		if publicKey != nil {
+			return nil, fmt.Errorf("%s is not signed", name)
+		}
+		logger.Warningf("%s has no manifest, skipping the integrity check\n", name)
+
+		return nil, nil
+	}
+
+	return manifest, err
+}
+
+// tempDirectory creates a temporary directory that the returned cleanup removes.
+func tempDirectory(pattern string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warningf("Failed to remove temp directory: %v\n", err)
+		}
+	}
+
+	return dir, cleanup, nil
+}
+
+// archiveDestination is where a new archive is written.
+type archiveDestination interface {
+	// write writes the archive that write produces, encrypting it on the way
+	// when protection has a passphrase.
+	write(ctx context.Context, protection Protection, write func(w io.Writer) error) error
+	String() string
+}
+
+// resolveDestination returns the destination of a new archive of the target,
+// an object for s3:// URLs and a local file otherwise. An empty target names a
+// combined archive.
+func resolveDestination(ctx context.Context, req Request, file, target string) (archiveDestination, error) {
+	if objectstore.IsURL(file) {
+		return resolveRemoteArchive(ctx, req, file, target)
+	}
+
+	absFile, err := resolveBackupFile(file, req.AppName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return localArchive(absFile), nil
+}
+
+// localArchive is a backup archive in a local file.
+type localArchive string
+
+func (a localArchive) String() string {
+	return string(a)
+}
+
+// write writes the archive that write produces into the file. The file is
+// removed when writing fails.
+func (a localArchive) write(_ context.Context, protection Protection, write func(w io.Writer) error) error {
+	if err := writeArchiveFile(string(a), protection, write); err != nil {
+		if rmErr := os.Remove(string(a)); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			logger.Warningf("Failed to remove partial backup archive: %v\n", rmErr)
+		}
+
+		return err
+	}
+
+	if len(protection.Passphrase) > 0 {
+		logger.Infoln("🔒 Backup archive encrypted")
+	}
+	commonBackup.LogArchiveSize(string(a))
+
+	return nil
+}
+
+func writeArchiveFile(file string, protection Protection, write func(w io.Writer) error) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, defaultFilePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create backup archive: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	buffered := bufio.NewWriter(f)
+	if err := writeProtected(buffered, protection, write); err != nil {
+		return err
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	return f.Close()
+}
+
+// resolveBackupFile returns the absolute archive path, generating a name when
+// file is empty. An empty target names a combined archive.
+func resolveBackupFile(file, appName, target string) (string, error) {
+	if file == "" {
+		file = archiveName(appName, target)
+	}
+
+	if !strings.HasSuffix(file, archiveExtension) {
+		file += archiveExtension
+	}
+
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path for backup file: %w", err)
+	}
+
+	return absFile, nil
+}
+
+// archiveName generates the name of a new archive. An empty target names a
+// combined archive.
+func archiveName(appName, target string) string {
+	timestamp := time.Now().Format("20060102_150405")
+	if target == "" {
+		return fmt.Sprintf("%s_backup_%s%s", appName, timestamp, archiveExtension)
+	}
+
+	return fmt.Sprintf("%s_%s_backup_%s%s", appName, target, timestamp, archiveExtension)
+}
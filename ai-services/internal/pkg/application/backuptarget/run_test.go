@@ -0,0 +1,169 @@
+package backuptarget
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
+	catalogTypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// fakeData is the index data file every fakeTarget backs up.
+const fakeData = `[{"_id":"1"},{"_id":"2"}]`
+
+// fakeTarget writes an index data file below the given entry and records the
+// data file of every restore.
+type fakeTarget struct {
+	desc     Description
+	entry    string
+	restored []string
+}
+
+func (f *fakeTarget) Describe() Description { return f.desc }
+
+func (f *fakeTarget) Verify(entries []string) error { return RequireEntry(entries, f.entry) }
+
+func (f *fakeTarget) Backup(_ context.Context, _ Request, dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, f.entry), defaultDirPermission); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, f.entry, "rag_data.json"), []byte(fakeData), defaultFilePermission)
+}
+
+func (f *fakeTarget) CountDocuments(name string, r io.Reader) (map[string]int, error) {
+	return CountOpenSearchDocuments(name, r)
+}
+
+func (f *fakeTarget) Restore(_ context.Context, _ Request, dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, f.entry, "rag_data.json"))
+	if err != nil {
+		return err
+	}
+	f.restored = append(f.restored, string(data))
+
+	return nil
+}
+
+func registerFakes(t *testing.T) (*fakeTarget, *fakeTarget) {
+	t.Helper()
+
+	opensearch := &fakeTarget{desc: OpenSearch, entry: "opensearch_backup/"}
+	digitize := &fakeTarget{desc: Digitize, entry: "backup/cache/"}
+	PodmanRegistry = NewRegistry()
+	PodmanRegistry.Register(opensearch)
+	PodmanRegistry.Register(digitize)
+	t.Cleanup(func() { PodmanRegistry = NewRegistry() })
+
+	return opensearch, digitize
+}
+
+func TestNamesFollowCatalogHooks(t *testing.T) {
+	registerFakes(t)
+	PodmanRegistry.Register(&fakeTarget{desc: Description{Name: "chat", Kind: KindService, CatalogID: "chat"}})
+
+	assert.Equal(t, []string{"digitize", "opensearch"}, PodmanRegistry.Names(HookBackup),
+		"targets whose catalog item declares no backup hooks must not be offered")
+}
+
+func TestBackupAndRestoreAll(t *testing.T) {
+	opensearch, digitize := registerFakes(t)
+	file := filepath.Join(t.TempDir(), "app_backup.tar.gz")
+	var progress []int
+	req := Request{AppName: "app", Progress: func(_ string, done, total int) { progress = append(progress, done*100/total) }}
+
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file))
+	assert.Equal(t, []int{50, 100}, progress)
+
+	manifest, _, err := checkIntegrity(file, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ManifestVersion, manifest.Version)
+	assert.Equal(t, "app", manifest.Application)
+	assert.Equal(t, "podman", manifest.Runtime)
+	require.Len(t, manifest.Targets, 2)
+	assert.Equal(t, ManifestEntry{Name: "digitize", Kind: KindService, CatalogID: "digitize", SchemaVersion: 1, File: "digitize.tar.gz"}, manifest.Targets[0])
+	assert.Equal(t, map[string]int{"rag": 2}, manifest.Targets[1].Documents)
+	assert.Equal(t, []string{"digitize.tar.gz", "opensearch.tar.gz"}, []string{manifest.Files[0].Path, manifest.Files[1].Path})
+
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file))
+	assert.Equal(t, []string{fakeData}, opensearch.restored)
+	assert.Equal(t, []string{fakeData}, digitize.restored)
+
+	// A single target can be restored from a combined archive.
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, "digitize", file))
+	assert.Len(t, digitize.restored, 2)
+	assert.Len(t, opensearch.restored, 1)
+}
+
+func TestBackupAllOnlyDeployedTargets(t *testing.T) {
+	registerFakes(t)
+	file := filepath.Join(t.TempDir(), "app_backup.tar.gz")
+	req := Request{
+		AppName: "app",
+		App: &catalogTypes.Application{Services: []catalogTypes.ApplicationService{
+			{CatalogID: "chat", Component: []catalogTypes.ServiceComponentResp{{Provider: catalogTypes.ProviderInfo{ID: "opensearch"}}}},
+		}},
+	}
+
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file))
+
+	manifest, _, err := checkIntegrity(file, nil)
+	require.NoError(t, err)
+	require.Len(t, manifest.Targets, 1)
+	assert.Equal(t, "opensearch", manifest.Targets[0].Name)
+}
+
+func TestRestoreSingleTargetArchive(t *testing.T) {
+	opensearch, _ := registerFakes(t)
+	file := filepath.Join(t.TempDir(), "opensearch.tar.gz")
+	req := Request{AppName: "app"}
+
+	require.NoError(t, Backup(context.Background(), runtimeTypes.RuntimeTypePodman, req, "opensearch", file))
+
+	manifest, _, err := checkIntegrity(file, nil)
+	require.NoError(t, err)
+	assert.False(t, manifest.Combined())
+	require.NotNil(t, manifest.Target)
+	assert.Equal(t, "opensearch", manifest.Target.Name)
+
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, "opensearch", file))
+	assert.Len(t, opensearch.restored, 1)
+
+	// All restores the target named in the manifest.
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file))
+	assert.Len(t, opensearch.restored, 2)
+
+	err = Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, "digitize", file)
+	assert.ErrorContains(t, err, "holds target opensearch")
+}
+
+func TestRestoreArchiveWithoutManifest(t *testing.T) {
+	opensearch, _ := registerFakes(t)
+	file := filepath.Join(t.TempDir(), "opensearch.tar.gz")
+	req := Request{AppName: "app"}
+
+	// Archives written before manifests hold only the target's own entries.
+	dir := t.TempDir()
+	require.NoError(t, opensearch.Backup(context.Background(), req, dir))
+	require.NoError(t, commonBackup.CreateTarGzArchive(dir, file, []string{"opensearch_backup"}))
+
+	_, _, err := checkIntegrity(file, nil)
+	assert.True(t, IsNoManifest(err))
+
+	err = Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, All, file)
+	assert.ErrorContains(t, err, "not a combined backup archive")
+
+	require.NoError(t, Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, "opensearch", file))
+	assert.Len(t, opensearch.restored, 1)
+
+	// The archive of one target fails verification for another.
+	err = Restore(context.Background(), runtimeTypes.RuntimeTypePodman, req, "digitize", file)
+	assert.ErrorContains(t, err, "failed verification")
+}
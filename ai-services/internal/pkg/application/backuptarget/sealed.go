@@ -0,0 +1,71 @@
+package backuptarget
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// WriteSealedArchive archives entries of sourceDir into file with a manifest that
+// describes them as entry of owner, protected as protection asks. It serves data
+// that is not backed up through a target of an application, such as the catalog
+// itself. The manifest is written into sourceDir.
+func WriteSealedArchive(sourceDir, file string, entries []string, owner string, rt runtimeTypes.RuntimeType, entry ManifestEntry, protection Protection) (*Manifest, error) {
+	files, _, _, err := digestDir(sourceDir, entries, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := newManifest(Request{AppName: owner}, rt)
+	manifest.Target = &entry
+	manifest.Files = files
+
+	sealed, err := sealDir(sourceDir, manifest, entries, protection.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeArchiveFile(file, protection, func(w io.Writer) error {
+		return commonBackup.WriteTarGz(w, sourceDir, sealed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(protection.Passphrase) > 0 {
+		logger.Infoln("🔒 Backup archive encrypted")
+	}
+
+	return manifest, nil
+}
+
+// OpenSealedArchive decrypts an archive written by WriteSealedArchive and checks
+// its signature and checksums. It returns the path of the plain archive, which
+// the returned cleanup removes when it is a temporary file, and the manifest.
+// The archive must hold an entry of the given kind.
+func OpenSealedArchive(file, kind string, protection Protection) (string, *Manifest, func(), error) {
+	plainFile, cleanup, err := openArchive(file, protection.Passphrase)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	manifest, _, err := checkIntegrity(plainFile, protection.PublicKey)
+	if err == nil && (manifest.Target == nil || manifest.Target.Kind != kind) {
+		err = fmt.Errorf("%s is not a %s backup", filepath.Base(file), kind)
+	}
+	if err != nil {
+		cleanup()
+
+		if IsNoManifest(err) {
+			return "", nil, nil, fmt.Errorf("%s is not a %s backup: %w", filepath.Base(file), kind, err)
+		}
+
+		return "", nil, nil, err
+	}
+
+	return plainFile, manifest, cleanup, nil
+}
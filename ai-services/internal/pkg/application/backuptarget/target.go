@@ -0,0 +1,250 @@
+// Package backuptarget holds the registry of backup and restore targets. A target
+// covers the data of one catalog component or service, is registered per runtime
+// by the runtime's application package and is only offered for the hooks that the
+// catalog item declares under "backup" in its metadata.yaml.
+package backuptarget
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
+	catalogTypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// Hooks a catalog item can declare in its metadata.yaml.
+const (
+	HookBackup  = "backup"
+	HookRestore = "restore"
+	HookVerify  = "verify"
+)
+
+// All selects every target of an application.
+const All = "all"
+
+// Kinds of catalog items a target can cover.
+const (
+	KindComponent = "component"
+	KindService   = "service"
+)
+
+// Description identifies a target and the catalog item whose data it covers.
+type Description struct {
+	Name          string // Target name accepted by --target, e.g. "opensearch"
+	Kind          string // KindComponent or KindService
+	ComponentType string // Component type, for components only
+	CatalogID     string // Component provider ID or service catalog ID
+	Summary       string // One-line description for help output
+	SchemaVersion int    // Layout version of the target's archives, recorded in manifests
+}
+
+// Request identifies the application a target operates on.
+type Request struct {
+	AppName string
+	Runtime runtime.Runtime
+	// App holds the catalog details of the application. It is nil for runtimes
+	// whose applications are not tracked in the catalog.
+	App *catalogTypes.Application
+	// Progress, when set, is called after each target completes with the number
+	// of targets done out of total.
+	Progress func(target string, done, total int)
+	// Protection signs and encrypts archives on backup and checks them on restore.
+	Protection Protection
+	// OpenSearchMode selects how OpenSearch indices are backed up, one of
+	// OpenSearchModes. Empty means OpenSearchModeAuto.
+	OpenSearchMode string
+	// Indices selects the names OpenSearch indices are restored as and whether
+	// existing indices are skipped.
+	Indices opensearch.IndexMapping
+	// ObjectStore configures the object storage that backups named by an s3://
+	// URL are written to. Nil reads it from the environment.
+	ObjectStore *objectstore.Config
+}
+
+// Protection configures the signing and encryption of backup archives.
+type Protection struct {
+	// SigningKey signs the manifest of new archives.
+	SigningKey ed25519.PrivateKey
+	// PublicKey, when set, requires archives to be signed by the matching key.
+	PublicKey ed25519.PublicKey
+	// Passphrase encrypts new archives and decrypts encrypted ones.
+	Passphrase []byte
+}
+
+// reportProgress calls the request's progress callback if one is set.
+func (r Request) reportProgress(target string, done, total int) {
+	if r.Progress != nil {
+		r.Progress(target, done, total)
+	}
+}
+
+// Target backs up and restores the data of one component or service.
+type Target interface {
+	// Describe returns the target name and the catalog item it covers.
+	Describe() Description
+
+	// Backup writes the application's data into dir. Every entry of dir is
+	// archived.
+	Backup(ctx context.Context, req Request, dir string) error
+
+	// Restore loads the application's data from dir, which holds the entries of
+	// the target's archive.
+	Restore(ctx context.Context, req Request, dir string) error
+
+	// Verify checks that entries, the names in an archive of this target, form a
+	// well-formed archive without touching the application.
+	Verify(entries []string) error
+}
+
+// DocumentCounter is implemented by targets whose archives hold index documents.
+// The counts are recorded in the archive manifest.
+type DocumentCounter interface {
+	// CountDocuments reads the archive entry name from r and returns the number
+	// of documents of each index it holds, nil for entries without documents.
+	CountDocuments(name string, r io.Reader) (map[string]int, error)
+}
+
+// PodmanRegistry and OpenshiftRegistry hold the targets of each runtime.
+var (
+	PodmanRegistry    = NewRegistry()
+	OpenshiftRegistry = NewRegistry()
+)
+
+// ForRuntime returns the registry of the given runtime.
+func ForRuntime(rt runtimeTypes.RuntimeType) (*Registry, error) {
+	switch rt {
+	case runtimeTypes.RuntimeTypePodman:
+		return PodmanRegistry, nil
+	case runtimeTypes.RuntimeTypeOpenShift:
+		return OpenshiftRegistry, nil
+	default:
+		return nil, fmt.Errorf("unsupported runtime type: %s", rt)
+	}
+}
+
+// Registry holds targets by name.
+type Registry struct {
+	mu      sync.RWMutex
+	targets map[string]Target
+	// catalog loads the catalog the hooks of the targets are declared in. It is
+	// loaded once, on the first hook lookup, as targets register at init.
+	catalog func() (*catalog.CatalogProvider, error)
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		targets: make(map[string]Target),
+		catalog: sync.OnceValues(catalog.NewCatalogProvider),
+	}
+}
+
+// Register adds a target. A target registered under an existing name replaces it.
+func (r *Registry) Register(t Target) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets[t.Describe().Name] = t
+}
+
+// Get returns the target registered under name.
+func (r *Registry) Get(name string) (Target, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.targets[name]
+
+	return t, ok
+}
+
+// Targets returns the registered targets sorted by name.
+func (r *Registry) Targets() []Target {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	targets := make([]Target, 0, len(r.targets))
+	for _, t := range r.targets {
+		targets = append(targets, t)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Describe().Name < targets[j].Describe().Name })
+
+	return targets
+}
+
+// Names returns the sorted names of the targets whose catalog item declares hook.
+func (r *Registry) Names(hook string) []string {
+	var names []string
+	for _, t := range r.Targets() {
+		if r.Supports(t.Describe(), hook) {
+			names = append(names, t.Describe().Name)
+		}
+	}
+
+	return names
+}
+
+// Hooks returns the backup hooks declared in the catalog metadata of the item
+// covered by d.
+func Hooks(provider *catalog.CatalogProvider, d Description) ([]string, error) {
+	switch d.Kind {
+	case KindComponent:
+		comp, err := provider.LoadComponent(d.ComponentType, d.CatalogID)
+		if err != nil {
+			return nil, err
+		}
+
+		return comp.Backup, nil
+	case KindService:
+		svc, err := provider.LoadService(d.CatalogID)
+		if err != nil {
+			return nil, err
+		}
+
+		return svc.Backup, nil
+	default:
+		return nil, fmt.Errorf("unknown catalog kind '%s' for target %s", d.Kind, d.Name)
+	}
+}
+
+// Supports reports whether the catalog item covered by d declares hook.
+func (r *Registry) Supports(d Description, hook string) bool {
+	provider, err := r.catalog()
+	if err != nil {
+		logger.Warningf("Failed to load catalog: %v\n", err)
+
+		return false
+	}
+
+	hooks, err := Hooks(provider, d)
+
+	return err == nil && slices.Contains(hooks, hook)
+}
+
+// deployedIn reports whether the application contains the catalog item covered by
+// d. Without catalog details every target is assumed to be deployed.
+func deployedIn(app *catalogTypes.Application, d Description) bool {
+	if app == nil {
+		return true
+	}
+
+	for _, svc := range app.Services {
+		if d.Kind == KindService && svc.CatalogID == d.CatalogID {
+			return true
+		}
+		for _, comp := range svc.Component {
+			if d.Kind == KindComponent && comp.Provider.ID == d.CatalogID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package backuptarget
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// Report describes an archive checked by Verify.
+type Report struct {
+	// Manifest is the manifest of the archive, nil for archives written without one.
+	Manifest *Manifest
+	// Encrypted is set for archives encrypted with a passphrase.
+	Encrypted bool
+	// Signed is set for archives with a manifest signature.
+	Signed bool
+	// SignatureVerified is set when the signature was checked against a public key.
+	SignatureVerified bool
+}
+
+// Verify checks an archive without restoring it. File is a local path or an s3://
+// URL, read with the object storage configuration cfg, or the environment when
+// cfg is nil. The archive is streamed once without extracting it: Verify decrypts
+// encrypted archives, checks the manifest signature and the checksum of every
+// file, and checks that each target in the archive is registered for the runtime
+// in a schema version this CLI supports and that its archive is well-formed.
+func Verify(ctx context.Context, rt runtimeTypes.RuntimeType, cfg *objectstore.Config, file string, protection Protection) (*Report, error) {
+	registry, err := ForRuntime(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	content, encrypted, err := readBackup(ctx, cfg, file, protection.Passphrase, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Encrypted: encrypted}
+
+	manifest, signed, err := checkContent(content, filepath.Base(file), protection.PublicKey)
+	if IsNoManifest(err) && protection.PublicKey == nil {
+		return report, nil
+	}
+	if err != nil {
+		if IsNoManifest(err) {
+			return nil, fmt.Errorf("%s is not signed", filepath.Base(file))
+		}
+
+		return nil, err
+	}
+
+	report.Manifest = manifest
+	report.Signed = signed
+	report.SignatureVerified = signed && protection.PublicKey != nil
+
+	if manifest.Combined() {
+		return report, verifyCombined(registry, content, protection)
+	}
+
+	if manifest.Target == nil {
+		return nil, fmt.Errorf("manifest of %s lists no target", filepath.Base(file))
+	}
+
+	t, err := registry.lookup(manifest.Target.Name, HookRestore)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, registry.verifyTarget(t, content.entries, manifest)
+}
+
+// verifyCombined checks the archive of every target in a combined archive.
+func verifyCombined(registry *Registry, content *archiveContent, protection Protection) error {
+	for _, entry := range content.manifest.Targets {
+		t, err := registry.lookup(entry.Name, HookRestore)
+		if err != nil {
+			return err
+		}
+
+		targetContent, ok := content.targets[entry.Name]
+		if !ok {
+			return fmt.Errorf("target %s: %s is missing from the backup archive", entry.Name, entry.File)
+		}
+
+		targetManifest, err := checkArchive(targetContent, entry.File, protection.PublicKey)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", entry.Name, err)
+		}
+
+		if err := registry.verifyTarget(t, targetContent.entries, targetManifest); err != nil {
+			return fmt.Errorf("target %s: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
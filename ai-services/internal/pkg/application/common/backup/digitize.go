@@ -9,7 +9,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -121,22 +120,13 @@ func (c *DigitizeBackupClient) CallExportAPI() (*DigitizeExportResponse, error)
 	return &exportResponse, nil
 }
 
-// CreateDigitizeBackupArchive creates a backup archive from the export response.
-func CreateDigitizeBackupArchive(backupFile string, exportResponse *DigitizeExportResponse) error {
-	logger.Infoln("Creating digitize backup archive...")
-
-	tempDir, err := os.MkdirTemp("", "digitize-backup-*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	defer func() {
-		if err := os.RemoveAll(tempDir); err != nil {
-			logger.Warningf("Failed to remove temp directory: %v\n", err)
-		}
-	}()
+// WriteDigitizeBackup writes the export response into dir in the layout of
+// digitize archives.
+func WriteDigitizeBackup(dir string, exportResponse *DigitizeExportResponse) error {
+	logger.Infoln("Writing digitize backup...")
 
 	// Create backup directory structure to match restore expectations
-	backupDir := filepath.Join(tempDir, "backup")
+	backupDir := filepath.Join(dir, "backup")
 	cacheDir := filepath.Join(backupDir, "cache")
 	jobsDir := filepath.Join(cacheDir, "jobs")
 	docsDir := filepath.Join(cacheDir, "docs")
@@ -155,17 +145,7 @@ func CreateDigitizeBackupArchive(backupFile string, exportResponse *DigitizeExpo
 		return err
 	}
 
-	if err := writeDigitizeBackupInfo(backupDir); err != nil {
-		return err
-	}
-
-	if err := CreateTarGzArchive(tempDir, backupFile, []string{"backup"}); err != nil {
-		return err
-	}
-
-	LogArchiveSize(backupFile)
-
-	return nil
+	return writeDigitizeBackupInfo(backupDir)
 }
 
 func writeDigitizeJobFiles(jobsDir string, jobs []map[string]interface{}) error {
@@ -233,15 +213,18 @@ func CreateTarGzArchive(sourceDir, targetFile string, entries []string) error {
 		_ = file.Close()
 	}()
 
-	gzipWriter := gzip.NewWriter(file)
-	defer func() {
-		_ = gzipWriter.Close()
-	}()
+	if err := WriteTarGz(file, sourceDir, entries); err != nil {
+		return err
+	}
 
+	return file.Close()
+}
+
+// WriteTarGz writes a tar.gz archive of the entries of sourceDir to w, so an
+// archive can be streamed to a destination other than a local file.
+func WriteTarGz(w io.Writer, sourceDir string, entries []string) error {
+	gzipWriter := gzip.NewWriter(w)
 	tarWriter := tar.NewWriter(gzipWriter)
-	defer func() {
-		_ = tarWriter.Close()
-	}()
 
 	for _, entry := range entries {
 		fullPath := filepath.Join(sourceDir, entry)
@@ -250,6 +233,14 @@ func CreateTarGzArchive(sourceDir, targetFile string, entries []string) error {
 		}
 	}
 
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
 	return nil
 }
 
@@ -332,24 +323,29 @@ func LogArchiveSize(backupFile string) {
 	logger.Infof("✓ Tar archive created: %s\n", backupFile)
 }
 
-func GetBackupFile(backupFile string, appName string) (string, error) {
-	if backupFile == "" {
-		timestamp := time.Now().Format("20060102_150405")
-		backupFile = fmt.Sprintf("%s_digitize_backup_%s.tar.gz", appName, timestamp)
+// LogDigitizeBackupSummary logs the backup summary from the export response.
+func LogDigitizeBackupSummary(exportResponse *DigitizeExportResponse) {
+	if exportResponse == nil {
+		return
 	}
 
-	// Ensure .tar.gz extension
-	if !strings.HasSuffix(backupFile, ".tar.gz") {
-		backupFile += ".tar.gz"
+	logger.Infoln("Export summary:")
+
+	if exportResponse.Summary.Jobs.TotalExported > 0 || exportResponse.Summary.Jobs.Completed > 0 || exportResponse.Summary.Jobs.Failed > 0 {
+		logger.Infof("  Jobs - exported: %d, completed: %d, failed: %d\n",
+			exportResponse.Summary.Jobs.TotalExported,
+			exportResponse.Summary.Jobs.Completed,
+			exportResponse.Summary.Jobs.Failed)
 	}
 
-	// Get absolute path for backup file
-	absBackupFile, err := filepath.Abs(backupFile)
-	if err != nil {
-		return "", fmt.Errorf("failed to get absolute path for backup file: %w", err)
+	if exportResponse.Summary.Documents.TotalExported > 0 || exportResponse.Summary.Documents.Completed > 0 || exportResponse.Summary.Documents.Failed > 0 {
+		logger.Infof("  Documents - exported: %d, completed: %d, failed: %d\n",
+			exportResponse.Summary.Documents.TotalExported,
+			exportResponse.Summary.Documents.Completed,
+			exportResponse.Summary.Documents.Failed)
 	}
 
-	return absBackupFile, nil
+	logger.Infof("  Returned records: %d\n", exportResponse.Pagination.ReturnedRecords)
 }
 
 // Made with Bob
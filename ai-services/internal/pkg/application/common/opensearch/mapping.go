@@ -0,0 +1,92 @@
+package opensearch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IndexMapping selects the names that the indices of a backup are restored as,
+// so a backup can be restored next to existing data, e.g. when a corpus is
+// promoted from one application into another.
+type IndexMapping struct {
+	// Rename maps indices of the backup to the names they are restored as.
+	Rename map[string]string
+	// Prefix is prepended to the names of indices that Rename does not cover.
+	Prefix string
+	// SkipExisting leaves indices that already exist untouched instead of
+	// replacing them.
+	SkipExisting bool
+}
+
+// ParseRenames parses renames given as "old=new".
+func ParseRenames(renames []string) (map[string]string, error) {
+	if len(renames) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(renames))
+	for _, r := range renames {
+		from, to, ok := strings.Cut(r, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid index rename %q, expected old=new", r)
+		}
+		if _, dup := result[from]; dup {
+			return nil, fmt.Errorf("index %s is renamed more than once", from)
+		}
+		result[from] = to
+	}
+
+	return result, nil
+}
+
+// IsIdentity reports whether the mapping restores every index under its own name.
+func (m IndexMapping) IsIdentity() bool {
+	return len(m.Rename) == 0 && m.Prefix == ""
+}
+
+// Target returns the name that index is restored as.
+func (m IndexMapping) Target(index string) string {
+	if to, ok := m.Rename[index]; ok {
+		return to
+	}
+
+	return m.Prefix + index
+}
+
+// Apply returns the name each of the backup's indices is restored as. Renaming
+// an index that is not in the backup, or restoring two indices under one name,
+// is an error.
+func (m IndexMapping) Apply(indices []string) (map[string]string, error) {
+	targets := make(map[string]string, len(indices))
+	sources := make(map[string]string, len(indices))
+
+	for _, index := range indices {
+		target := m.Target(index)
+		if other, ok := sources[target]; ok {
+			return nil, fmt.Errorf("indices %s and %s would both be restored as %s", other, index, target)
+		}
+		sources[target] = index
+		targets[index] = target
+	}
+
+	var unknown []string
+	for from := range m.Rename {
+		if _, ok := targets[from]; !ok {
+			unknown = append(unknown, from)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+
+		return nil, fmt.Errorf("cannot rename indices that are not in the backup: %s", strings.Join(unknown, ", "))
+	}
+
+	return targets, nil
+}
+
+// BackedUp reports whether later backups pick up an index of this name.
+func BackedUp(index string) bool {
+	return strings.HasPrefix(index, strings.TrimSuffix(IndexPattern, "*"))
+}
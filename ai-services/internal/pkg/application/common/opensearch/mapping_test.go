@@ -0,0 +1,47 @@
+package opensearch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+)
+
+func TestParseRenames(t *testing.T) {
+	renames, err := opensearch.ParseRenames([]string{"rag_docs=rag_docs_v2", " rag_faq = rag_faq_prod "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rag_docs": "rag_docs_v2", "rag_faq": "rag_faq_prod"}, renames)
+
+	_, err = opensearch.ParseRenames([]string{"rag_docs"})
+	require.ErrorContains(t, err, "expected old=new")
+
+	_, err = opensearch.ParseRenames([]string{"rag_docs=a", "rag_docs=b"})
+	require.ErrorContains(t, err, "renamed more than once")
+}
+
+func TestIndexMappingApply(t *testing.T) {
+	indices := []string{"rag_docs", "rag_faq"}
+
+	targets, err := opensearch.IndexMapping{}.Apply(indices)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rag_docs": "rag_docs", "rag_faq": "rag_faq"}, targets)
+
+	mapping := opensearch.IndexMapping{Rename: map[string]string{"rag_docs": "rag_docs_v2"}, Prefix: "prod-"}
+	assert.False(t, mapping.IsIdentity())
+	targets, err = mapping.Apply(indices)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"rag_docs": "rag_docs_v2", "rag_faq": "prod-rag_faq"}, targets, "the prefix applies to indices that are not renamed")
+
+	_, err = opensearch.IndexMapping{Rename: map[string]string{"rag_docs": "rag_faq"}}.Apply(indices)
+	require.ErrorContains(t, err, "would both be restored as rag_faq")
+
+	_, err = opensearch.IndexMapping{Rename: map[string]string{"rag_missing": "rag_new"}}.Apply(indices)
+	require.ErrorContains(t, err, "not in the backup: rag_missing")
+}
+
+func TestBackedUp(t *testing.T) {
+	assert.True(t, opensearch.BackedUp("rag_docs_v2"))
+	assert.False(t, opensearch.BackedUp("prod-rag_docs"))
+}
@@ -0,0 +1,55 @@
+package opensearch
+
+import (
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// PlanIndices returns the name each index of a backup is restored as under
+// mapping, checking that every name is a valid index name.
+func PlanIndices(indices []string, mapping IndexMapping) (map[string]string, error) {
+	targets, err := mapping.Apply(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.IsIdentity() {
+		return targets, nil
+	}
+
+	logger.Infoln("Restoring indices as:")
+	for _, index := range indices {
+		target := targets[index]
+		if err := ValidateIndexName(target); err != nil {
+			return nil, fmt.Errorf("invalid name %s to restore index %s as: %w", target, index, err)
+		}
+
+		logger.Infof("  %s → %s\n", index, target)
+		if !BackedUp(target) {
+			logger.Warningf("Index %s does not match %s and is not included in later backups\n", target, IndexPattern)
+		}
+	}
+
+	return targets, nil
+}
+
+// ReportRestoredIndices logs the outcome of restoring total indices, failing
+// when none was restored and some failed.
+func ReportRestoredIndices(restored, skipped, total int, errs []error) error {
+	if restored == 0 && len(errs) > 0 {
+		return fmt.Errorf("failed to restore any indices: %d errors occurred", len(errs))
+	}
+
+	if skipped > 0 {
+		logger.Infof("Skipped %d existing indices\n", skipped)
+	}
+
+	if len(errs) > 0 {
+		logger.Warningf("Restore completed with %d errors. Successfully restored %d/%d indices\n", len(errs), restored, total)
+	} else {
+		logger.Infof("✓ Restore completed successfully. Restored %d indices\n", restored)
+	}
+
+	return nil
+}
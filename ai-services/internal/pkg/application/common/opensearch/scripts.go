@@ -27,8 +27,15 @@ fi
 `, osHost, indexName, osHost, indexName)
 }
 
-// GenerateCreateIndexScript generates a shell script to create an OpenSearch index with mappings and settings.
-func GenerateCreateIndexScript(osHost, backupDir, indexName string) string {
+// GenerateIndexExistsScript generates a shell script that prints the HTTP status of
+// an OpenSearch index, 200 when it exists.
+func GenerateIndexExistsScript(osHost, indexName string) string {
+	return fmt.Sprintf(`curl -k -u "admin:${OS_PASSWORD}" "https://%s/%s" -X HEAD -s -w "%%{http_code}" -o /dev/null`, osHost, indexName)
+}
+
+// GenerateCreateIndexScript generates a shell script to create the OpenSearch index
+// targetIndex with the mappings and settings of indexName in the backup.
+func GenerateCreateIndexScript(osHost, backupDir, indexName, targetIndex string) string {
 	return fmt.Sprintf(`
 MAPPING=$(cat %s/%s_mapping.json | jq -c '."%s".mappings')
 SETTINGS=$(cat %s/%s_settings.json | jq -c '."%s".settings.index | del(.creation_date, .uuid, .version, .provided_name)')
@@ -45,11 +52,12 @@ if ! echo "$BODY" | jq -e '.acknowledged == true' > /dev/null 2>&1; then
 	echo "Index creation not acknowledged. Response: $BODY" >&2
 	exit 1
 fi
-`, backupDir, indexName, indexName, backupDir, indexName, indexName, osHost, indexName)
+`, backupDir, indexName, indexName, backupDir, indexName, indexName, osHost, targetIndex)
 }
 
-// GenerateBulkIndexScript generates a shell script to bulk index documents in batches.
-func GenerateBulkIndexScript(osHost, backupDir, indexName string) string {
+// GenerateBulkIndexScript generates a shell script to bulk index the documents of
+// indexName in the backup into targetIndex in batches.
+func GenerateBulkIndexScript(osHost, backupDir, indexName, targetIndex string) string {
 	return fmt.Sprintf(`
 # Batch size for bulk indexing
 BATCH_SIZE=%d
@@ -94,7 +102,7 @@ while [ $BATCH_NUM -lt $BATCHES ]; do
 done
 
 echo "Successfully indexed all $TOTAL_DOCS documents in $BATCHES batch(es)"
-`, BatchSize, backupDir, indexName, indexName, osHost)
+`, BatchSize, backupDir, indexName, targetIndex, osHost)
 }
 
 // GenerateRefreshIndexScript generates a shell script to refresh an OpenSearch index.
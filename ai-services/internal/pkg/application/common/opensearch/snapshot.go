@@ -0,0 +1,339 @@
+package opensearch
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// Username is the OpenSearch user the backup and restore operations run as.
+	Username = "admin"
+	// RepositoryName is the snapshot repository backups are written to.
+	RepositoryName = "ai-services-backup"
+	// SnapshotPath is the directory inside the OpenSearch container listed in
+	// path.repo. Repositories are created below it, on the data volume.
+	SnapshotPath = "/usr/share/opensearch/data/snapshots"
+	// SnapshotPrefix starts the name of every snapshot taken for a backup.
+	SnapshotPrefix = "backup-"
+	// IndexPattern selects the indices that are backed up.
+	IndexPattern = "rag*"
+
+	// snapshotTimeout bounds a single request. Snapshots and restores wait for
+	// completion, so it is generous.
+	snapshotTimeout = 30 * time.Minute
+)
+
+// ErrSnapshotUnavailable is returned when the snapshot API cannot be used: the
+// instance is unreachable or rejects the repository, typically because its
+// location is not listed in path.repo.
+var ErrSnapshotUnavailable = errors.New("snapshot API is not available")
+
+// SnapshotClient talks to the OpenSearch _snapshot API over HTTP.
+type SnapshotClient struct {
+	client *resty.Client
+}
+
+// Snapshot describes a snapshot in a repository.
+type Snapshot struct {
+	Name      string   `json:"snapshot"`
+	State     string   `json:"state"`
+	Indices   []string `json:"indices"`
+	StartTime int64    `json:"start_time_in_millis"`
+	Failures  []any    `json:"failures"`
+}
+
+// NewSnapshotClient creates a client for the OpenSearch instance at baseURL. TLS
+// verification is skipped when insecure is set.
+func NewSnapshotClient(baseURL, password string, insecure bool) *SnapshotClient {
+	client := resty.New().
+		SetBaseURL(baseURL).
+		SetBasicAuth(Username, password).
+		SetTimeout(snapshotTimeout).
+		SetHeader("Content-Type", "application/json")
+
+	if insecure {
+		client.SetTLSClientConfig(&tls.Config{
+			InsecureSkipVerify: true,
+		})
+	}
+
+	return &SnapshotClient{client: client}
+}
+
+// SetHeader sets a header sent with every request, such as the token a proxy
+// route to OpenSearch requires.
+func (c *SnapshotClient) SetHeader(name, value string) *SnapshotClient {
+	c.client.SetHeader(name, value)
+
+	return c
+}
+
+// RegisterRepository registers, or updates, an fs repository at location.
+func (c *SnapshotClient) RegisterRepository(ctx context.Context, repository, location string, readonly bool) error {
+	body := map[string]any{
+		"type": "fs",
+		"settings": map[string]any{
+			"location": location,
+			"readonly": readonly,
+		},
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("repository", repository).
+		SetBody(body).
+		Put("/_snapshot/{repository}")
+	if err != nil {
+		return fmt.Errorf("%w: failed to register snapshot repository: %w", ErrSnapshotUnavailable, err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("%w: registering snapshot repository returned HTTP %d: %s", ErrSnapshotUnavailable, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// DeleteRepository unregisters a repository. The files at its location are kept.
+func (c *SnapshotClient) DeleteRepository(ctx context.Context, repository string) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("repository", repository).
+		Delete("/_snapshot/{repository}")
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot repository: %w", err)
+	}
+
+	if resp.IsError() && resp.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("deleting snapshot repository returned HTTP %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// CreateSnapshot snapshots the indices matching pattern and waits for it to
+// complete. Snapshots in a repository share unchanged segments, so every snapshot
+// after the first only writes what changed since.
+func (c *SnapshotClient) CreateSnapshot(ctx context.Context, repository, name, pattern string) (*Snapshot, error) {
+	body := map[string]any{
+		"indices":              pattern,
+		"ignore_unavailable":   true,
+		"include_global_state": false,
+	}
+
+	var result struct {
+		Snapshot Snapshot `json:"snapshot"`
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParams(map[string]string{"repository": repository, "snapshot": name}).
+		SetQueryParam("wait_for_completion", "true").
+		SetBody(body).
+		SetResult(&result).
+		Put("/_snapshot/{repository}/{snapshot}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("creating snapshot returned HTTP %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	if result.Snapshot.State != "SUCCESS" {
+		return nil, fmt.Errorf("snapshot %s finished in state %s with %d shard failure(s)", name, result.Snapshot.State, len(result.Snapshot.Failures))
+	}
+
+	return &result.Snapshot, nil
+}
+
+// ListSnapshots returns the snapshots of a repository, oldest first.
+func (c *SnapshotClient) ListSnapshots(ctx context.Context, repository string) ([]Snapshot, error) {
+	var result struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("repository", repository).
+		SetResult(&result).
+		Get("/_snapshot/{repository}/_all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("listing snapshots returned HTTP %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	sort.SliceStable(result.Snapshots, func(i, j int) bool {
+		return result.Snapshots[i].StartTime < result.Snapshots[j].StartTime
+	})
+
+	return result.Snapshots, nil
+}
+
+// DeleteSnapshot deletes a snapshot. Segments still used by other snapshots are kept.
+func (c *SnapshotClient) DeleteSnapshot(ctx context.Context, repository, name string) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParams(map[string]string{"repository": repository, "snapshot": name}).
+		Delete("/_snapshot/{repository}/{snapshot}")
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("deleting snapshot %s returned HTTP %d: %s", name, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// RestoreSnapshot restores the given indices of a snapshot and waits for the
+// restore to complete. The indices must not exist.
+func (c *SnapshotClient) RestoreSnapshot(ctx context.Context, repository, name string, indices []string) error {
+	body := map[string]any{
+		"indices":              indices,
+		"include_global_state": false,
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParams(map[string]string{"repository": repository, "snapshot": name}).
+		SetQueryParam("wait_for_completion", "true").
+		SetBody(body).
+		Post("/_snapshot/{repository}/{snapshot}/_restore")
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("restoring snapshot %s returned HTTP %d: %s", name, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// RestoreIndexAs restores one index of a snapshot under the name target and waits
+// for the restore to complete. The target index must not exist.
+func (c *SnapshotClient) RestoreIndexAs(ctx context.Context, repository, name, index, target string) error {
+	body := map[string]any{
+		"indices":              []string{index},
+		"include_global_state": false,
+		// The pattern matches the whole name of the only index restored.
+		"rename_pattern":     "(.+)",
+		"rename_replacement": target,
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParams(map[string]string{"repository": repository, "snapshot": name}).
+		SetQueryParam("wait_for_completion", "true").
+		SetBody(body).
+		Post("/_snapshot/{repository}/{snapshot}/_restore")
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("restoring index %s of snapshot %s as %s returned HTTP %d: %s", index, name, target, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// DocumentCounts returns the number of documents of every index matching pattern.
+func (c *SnapshotClient) DocumentCounts(ctx context.Context, pattern string) (map[string]int, error) {
+	var rows []struct {
+		Index string `json:"index"`
+		Count string `json:"docs.count"`
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("pattern", pattern).
+		SetQueryParams(map[string]string{"format": "json", "h": "index,docs.count"}).
+		SetResult(&rows).
+		Get("/_cat/indices/{pattern}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indices: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("listing indices returned HTTP %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		count, err := strconv.Atoi(row.Count)
+		if err != nil {
+			return nil, fmt.Errorf("invalid document count %q for index %s", row.Count, row.Index)
+		}
+		counts[row.Index] = count
+	}
+
+	return counts, nil
+}
+
+// IndexExists reports whether an index exists.
+func (c *SnapshotClient) IndexExists(ctx context.Context, index string) (bool, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("index", index).
+		Head("/{index}")
+	if err != nil {
+		return false, fmt.Errorf("failed to check index %s: %w", index, err)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("checking index %s returned HTTP %d", index, resp.StatusCode())
+	}
+}
+
+// DeleteIndex deletes an index if it exists.
+func (c *SnapshotClient) DeleteIndex(ctx context.Context, index string) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetPathParam("index", index).
+		Delete("/{index}")
+	if err != nil {
+		return fmt.Errorf("failed to delete index %s: %w", index, err)
+	}
+
+	if resp.IsError() && resp.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("deleting index %s returned HTTP %d: %s", index, resp.StatusCode(), resp.String())
+	}
+
+	return nil
+}
+
+// Layout of snapshot archives.
+const (
+	// SnapshotArchiveDir holds the snapshot repository and its info file.
+	SnapshotArchiveDir = "opensearch_snapshot"
+	// SnapshotRepositoryDir holds the files of the fs repository.
+	SnapshotRepositoryDir = "repository"
+	// SnapshotInfoFile names the snapshot and the indices it holds.
+	SnapshotInfoFile = "snapshot_info.json"
+)
+
+// SnapshotInfo is written next to the repository in snapshot archives.
+type SnapshotInfo struct {
+	Snapshot   string         `json:"snapshot"`
+	BackupDate string         `json:"backup_date"`
+	Indices    map[string]int `json:"indices"`
+}
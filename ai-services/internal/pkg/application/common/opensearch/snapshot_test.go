@@ -0,0 +1,148 @@
+package opensearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+)
+
+// fakeOpenSearch records the requests it receives and answers them from responses,
+// keyed by method and path.
+type fakeOpenSearch struct {
+	requests  []string
+	bodies    map[string]map[string]any
+	responses map[string]string
+}
+
+func newFake(t *testing.T, responses map[string]string) (*fakeOpenSearch, *opensearch.SnapshotClient) {
+	t.Helper()
+	fake := &fakeOpenSearch{bodies: map[string]map[string]any{}, responses: responses}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, opensearch.Username, user)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, "token", r.Header.Get("X-Route-Token"))
+
+		key := r.Method + " " + r.URL.Path
+		fake.requests = append(fake.requests, key)
+
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(data, &body))
+			fake.bodies[key] = body
+		}
+
+		response, ok := fake.responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	return fake, opensearch.NewSnapshotClient(srv.URL, "secret", false).SetHeader("X-Route-Token", "token")
+}
+
+func TestSnapshotBackup(t *testing.T) {
+	fake, client := newFake(t, map[string]string{
+		"PUT /_snapshot/ai-services-backup":             `{"acknowledged":true}`,
+		"GET /_cat/indices/rag*":                        `[{"index":"rag_docs","docs.count":"12"},{"index":"rag_chunks","docs.count":"40"}]`,
+		"PUT /_snapshot/ai-services-backup/backup-2":    `{"snapshot":{"snapshot":"backup-2","state":"SUCCESS","indices":["rag_docs","rag_chunks"]}}`,
+		"GET /_snapshot/ai-services-backup/_all":        `{"snapshots":[{"snapshot":"backup-2","start_time_in_millis":20},{"snapshot":"backup-1","start_time_in_millis":10}]}`,
+		"DELETE /_snapshot/ai-services-backup/backup-1": `{"acknowledged":true}`,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.RegisterRepository(ctx, opensearch.RepositoryName, "/snapshots/repo", false))
+	assert.Equal(t, map[string]any{"type": "fs", "settings": map[string]any{"location": "/snapshots/repo", "readonly": false}},
+		fake.bodies["PUT /_snapshot/ai-services-backup"])
+
+	counts, err := client.DocumentCounts(ctx, opensearch.IndexPattern)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"rag_docs": 12, "rag_chunks": 40}, counts)
+
+	snapshot, err := client.CreateSnapshot(ctx, opensearch.RepositoryName, "backup-2", opensearch.IndexPattern)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rag_docs", "rag_chunks"}, snapshot.Indices)
+	assert.Equal(t, false, fake.bodies["PUT /_snapshot/ai-services-backup/backup-2"]["include_global_state"])
+
+	snapshots, err := client.ListSnapshots(ctx, opensearch.RepositoryName)
+	require.NoError(t, err)
+	assert.Equal(t, "backup-1", snapshots[0].Name, "snapshots are sorted oldest first")
+
+	require.NoError(t, client.DeleteSnapshot(ctx, opensearch.RepositoryName, "backup-1"))
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	fake, client := newFake(t, map[string]string{
+		"PUT /_snapshot/restore":                    `{"acknowledged":true}`,
+		"DELETE /rag_docs":                          `{"acknowledged":true}`,
+		"POST /_snapshot/restore/backup-1/_restore": `{"snapshot":{"snapshot":"backup-1"}}`,
+		"DELETE /_snapshot/restore":                 `{"acknowledged":true}`,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.RegisterRepository(ctx, "restore", "/snapshots/restore-1", true))
+	require.NoError(t, client.DeleteIndex(ctx, "rag_docs"))
+	require.NoError(t, client.DeleteIndex(ctx, "rag_new"), "missing indices are not an error")
+	require.NoError(t, client.RestoreSnapshot(ctx, "restore", "backup-1", []string{"rag_docs", "rag_new"}))
+	require.NoError(t, client.DeleteRepository(ctx, "restore"))
+
+	assert.Equal(t, []any{"rag_docs", "rag_new"}, fake.bodies["POST /_snapshot/restore/backup-1/_restore"]["indices"])
+	assert.Equal(t, "DELETE /_snapshot/restore", fake.requests[len(fake.requests)-1])
+}
+
+func TestSnapshotRestoreIndexAs(t *testing.T) {
+	fake, client := newFake(t, map[string]string{
+		"HEAD /rag_docs": ``,
+		"POST /_snapshot/restore/backup-1/_restore": `{"snapshot":{"snapshot":"backup-1"}}`,
+	})
+	ctx := context.Background()
+
+	exists, err := client.IndexExists(ctx, "rag_docs")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.IndexExists(ctx, "rag_docs_v2")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.RestoreIndexAs(ctx, "restore", "backup-1", "rag_docs", "rag_docs_v2"))
+
+	body := fake.bodies["POST /_snapshot/restore/backup-1/_restore"]
+	assert.Equal(t, []any{"rag_docs"}, body["indices"])
+	assert.Equal(t, "rag_docs_v2", body["rename_replacement"])
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	_, client := newFake(t, map[string]string{
+		"PUT /_snapshot/ai-services-backup/backup-1": `{"snapshot":{"snapshot":"backup-1","state":"PARTIAL","failures":[{}]}}`,
+	})
+	ctx := context.Background()
+
+	err := client.RegisterRepository(ctx, opensearch.RepositoryName, "/not/in/path.repo", false)
+	require.ErrorIs(t, err, opensearch.ErrSnapshotUnavailable)
+
+	_, err = client.CreateSnapshot(ctx, opensearch.RepositoryName, "backup-1", opensearch.IndexPattern)
+	require.ErrorContains(t, err, "state PARTIAL with 1 shard failure(s)")
+	require.NotErrorIs(t, err, opensearch.ErrSnapshotUnavailable)
+
+	unreachable := opensearch.NewSnapshotClient("http://127.0.0.1:1", "secret", false)
+	err = unreachable.RegisterRepository(ctx, opensearch.RepositoryName, "/snapshots/repo", false)
+	require.ErrorIs(t, err, opensearch.ErrSnapshotUnavailable)
+}
@@ -251,12 +251,13 @@ func logImportWarnings(importResponse map[string]interface{}) {
 	logger.Infof("Import completed with %d warning(s)\n", len(warnings))
 }
 
-func GetDigitizeData(backupFile string) (map[string]interface{}, error) {
-	backupDir, cleanup, err := podmanrestore.ExtractAndLocateBackup(backupFile)
+// GetDigitizeData builds the Import API payload from a digitize archive
+// extracted into dir.
+func GetDigitizeData(dir string) (map[string]interface{}, error) {
+	backupDir, err := podmanrestore.LocateBackupDirectory(dir)
 	if err != nil {
 		return nil, err
 	}
-	defer cleanup()
 
 	// Construct metadata from cache files
 	importPayload, err := constructMetadataFromCache(backupDir)
@@ -10,7 +10,6 @@ import (
 
 	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/openshift/common"
-	podmanBackup "github.com/project-ai-services/ai-services/internal/pkg/application/podman/backup"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -18,8 +17,9 @@ const (
 	containerBackupPath = "/tmp/opensearch_backup"
 )
 
-// BackupOpenSearch performs OpenSearch backup for OpenShift using a sidecar pod.
-func BackupOpenSearch(ctx context.Context, applicationID, backupFile string) error {
+// BackupOpenSearch performs OpenSearch backup for OpenShift using a sidecar pod
+// and copies the exported files into backupDir.
+func BackupOpenSearch(ctx context.Context, applicationID, backupDir string) error {
 	logger.Infof("Backing up OpenSearch data for OpenShift application: %s\n", applicationID)
 	logger.Infoln("OpenSearch Backup (Sidecar Pod Approach)")
 
@@ -39,11 +39,11 @@ func BackupOpenSearch(ctx context.Context, applicationID, backupFile string) err
 	}
 
 	// Create sidecar pod and perform backup
-	return manageSidecarPod(ctx, namespace, serviceName, backupFile)
+	return manageSidecarPod(ctx, namespace, serviceName, backupDir)
 }
 
 // manageSidecarPod manages the lifecycle of a sidecar pod for backup operations.
-func manageSidecarPod(ctx context.Context, namespace, serviceName, backupFile string) error {
+func manageSidecarPod(ctx context.Context, namespace, serviceName, backupDir string) error {
 	sidecarName := common.GenerateSidecarName("opensearch-backup-sidecar")
 
 	// Create sidecar pod
@@ -58,11 +58,11 @@ func manageSidecarPod(ctx context.Context, namespace, serviceName, backupFile st
 	}
 
 	// Perform backup operations
-	return performBackup(ctx, sidecarName, namespace, serviceName, backupFile)
+	return performBackup(ctx, sidecarName, namespace, serviceName, backupDir)
 }
 
 // performBackup performs the actual backup operations in the sidecar pod.
-func performBackup(ctx context.Context, podName, namespace, serviceName, backupFile string) error {
+func performBackup(ctx context.Context, podName, namespace, serviceName, backupDir string) error {
 	// Get OpenSearch password
 	osPassword, err := common.GetOpenSearchPasswordFromSecret(namespace)
 	if err != nil {
@@ -83,9 +83,9 @@ func performBackup(ctx context.Context, podName, namespace, serviceName, backupF
 		return fmt.Errorf("backup failed: %w", err)
 	}
 
-	// Copy backup files from pod to host and create tar archive
-	if err := copyAndTarBackup(ctx, podName, namespace, containerBackupPath, backupFile); err != nil {
-		return fmt.Errorf("failed to copy and archive backup: %w", err)
+	// Copy backup files from pod to host
+	if err := copyBackup(ctx, podName, namespace, containerBackupPath, backupDir); err != nil {
+		return fmt.Errorf("failed to copy backup: %w", err)
 	}
 
 	logger.Infoln("OpenSearch backup completed!")
@@ -117,7 +117,8 @@ func performBackupWithCurl(ctx context.Context, podName, namespace, osHost, osPa
 	if len(indices) == 0 {
 		logger.Warningf("No indices found starting with 'rag'\n")
 
-		return nil
+		// The info file keeps the archive from being empty, which manifests reject.
+		return createBackupInfo(podName, namespace, backupDir)
 	}
 
 	logger.Infof("Found %d indices to backup\n", len(indices))
@@ -251,30 +252,19 @@ func createBackupInfo(podName, namespace, backupDir string) error {
 	return common.ExecInPod(podName, namespace, infoScript)
 }
 
-// copyAndTarBackup copies backup files from pod to host and creates tar archive on host.
-func copyAndTarBackup(ctx context.Context, podName, namespace, containerBackupPath, backupFile string) error {
+// copyBackup copies backup files from pod to backupDir on the host.
+func copyBackup(ctx context.Context, podName, namespace, containerBackupPath, backupDir string) error {
 	logger.Infof("Copying backup files from pod to host...")
 
-	// Create temporary directory on host for backup files
-	tempDir, err := os.MkdirTemp("", "opensearch-backup-*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	defer func() {
-		if err := os.RemoveAll(tempDir); err != nil {
-			logger.Warningf("Failed to remove temp directory: %v\n", err)
-		}
-	}()
-
 	// Copy backup_info.json from pod
-	backupInfoDest := filepath.Join(tempDir, "backup_info.json")
+	backupInfoDest := filepath.Join(backupDir, "backup_info.json")
 	cpInfoCmd := exec.CommandContext(ctx, "oc", "cp", fmt.Sprintf("%s/%s:/tmp/backup_info.json", namespace, podName), backupInfoDest, "-n", namespace)
 	if output, err := cpInfoCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to copy backup_info.json: %w, output: %s", err, string(output))
 	}
 
 	// Copy opensearch_backup directory from pod
-	backupDirDest := filepath.Join(tempDir, "opensearch_backup")
+	backupDirDest := filepath.Join(backupDir, "opensearch_backup")
 
 	const dirPerm = 0o755
 	// Create destination directory
@@ -290,15 +280,6 @@ func copyAndTarBackup(ctx context.Context, podName, namespace, containerBackupPa
 
 	logger.Infoln("✓ Backup files copied to host")
 
-	// Create tar.gz archive on host using shared function
-	logger.Infoln("Creating tar.gz archive on host...")
-
-	if err := podmanBackup.CreateTarGzArchive(tempDir, backupFile, []string{"backup_info.json", "opensearch_backup"}); err != nil {
-		return err
-	}
-
-	podmanBackup.LogArchiveSize(backupFile)
-
 	return nil
 }
 
@@ -3,9 +3,9 @@ package openshift
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
 	commonrestore "github.com/project-ai-services/ai-services/internal/pkg/application/common/restore"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/openshift/restore"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/types"
@@ -18,38 +18,37 @@ func (o *OpenshiftApplication) Restore(ctx context.Context, opts types.RestoreOp
 	logger.Infof("Target: %s\n", opts.Target)
 	logger.Infof("Backup file: %s\n", opts.BackupFile)
 
-	// For OpenShift, use the name as-is (namespace convention)
-	applicationID := opts.Name
+	req := o.backupRequest(opts.Name)
+	req.Protection = opts.Protection
+	req.Indices = opts.Indices
+	req.ObjectStore = opts.ObjectStore
 
-	// Get absolute path to backup file
-	absFilename, err := filepath.Abs(opts.BackupFile)
-	if err != nil {
-		return fmt.Errorf("failed to get absolute path for backup file: %w", err)
-	}
+	return backuptarget.Restore(ctx, o.Type(), req, opts.Target, opts.BackupFile)
+}
 
-	// Execute restore based on target
-	switch opts.Target {
-	case "opensearch":
-		return restore.RestoreOpenSearch(ctx, applicationID, absFilename)
-	case "digitize":
-		return o.restoreDigitize(ctx, opts.Name, absFilename)
-	default:
-		return fmt.Errorf("unsupported target: %s", opts.Target)
+// Restore restores OpenSearch data using a sidecar pod. For OpenShift the
+// application name is used as-is (namespace convention).
+func (openSearchTarget) Restore(ctx context.Context, req backuptarget.Request, dir string) error {
+	if backuptarget.IsOpenSearchSnapshot(dir) {
+		return fmt.Errorf("the backup is an OpenSearch snapshot archive, which can only be restored on Podman; " +
+			"back up with --portable to restore into OpenShift")
 	}
+
+	return restore.RestoreOpenSearch(ctx, req.AppName, dir, req.Indices)
 }
 
-// restoreDigitize restores digitize metadata using the Import API for OpenShift.
-func (o *OpenshiftApplication) restoreDigitize(ctx context.Context, appName, backupFile string) error {
+// Restore restores digitize metadata using the Import API for OpenShift.
+func (digitizeTarget) Restore(ctx context.Context, req backuptarget.Request, dir string) error {
 	logger.Infof("Restoring digitize metadata\n")
 	logger.Infof("Digitize Import (API-based Approach)\n")
 
-	importPayload, err := commonrestore.GetDigitizeData(backupFile)
+	importPayload, err := commonrestore.GetDigitizeData(dir)
 	if err != nil {
 		return err
 	}
 
 	// Get digitize service API URL from OpenShift routes
-	digitizeURL, err := o.getDigitizeAPIURL(ctx, appName)
+	digitizeURL, err := digitizeAPIURL(req)
 	if err != nil {
 		return err
 	}
@@ -67,12 +66,12 @@ func (o *OpenshiftApplication) restoreDigitize(ctx context.Context, appName, bac
 	return nil
 }
 
-// getDigitizeAPIURL retrieves the digitize API URL from OpenShift routes.
-func (o *OpenshiftApplication) getDigitizeAPIURL(ctx context.Context, appName string) (string, error) {
+// digitizeAPIURL retrieves the digitize API URL from OpenShift routes.
+func digitizeAPIURL(req backuptarget.Request) (string, error) {
 	logger.Infof("Fetching digitize route from OpenShift...\n")
 
 	// List all routes in the namespace using the runtime interface
-	routes, err := o.runtime.ListRoutes("")
+	routes, err := req.Runtime.ListRoutes("")
 	if err != nil {
 		return "", fmt.Errorf("failed to list routes: %w", err)
 	}
@@ -8,7 +8,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	appcommon "github.com/project-ai-services/ai-services/internal/pkg/application/common"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/openshift/common"
 	podmanRestore "github.com/project-ai-services/ai-services/internal/pkg/application/podman/restore"
@@ -19,24 +18,18 @@ const (
 	containerBackupPath = "/tmp/opensearch_backup"
 )
 
-// RestoreOpenSearch restores OpenSearch data for OpenShift runtime.
-func RestoreOpenSearch(ctx context.Context, applicationID, backupFile string) error {
+// RestoreOpenSearch restores OpenSearch data from the extracted archive in dir
+// for OpenShift runtime. The indices are restored under the names that mapping
+// selects.
+func RestoreOpenSearch(ctx context.Context, applicationID, dir string, mapping opensearch.IndexMapping) error {
 	logger.Infof("Restoring OpenSearch data for OpenShift application: %s\n", applicationID)
-	logger.Infof("Backup file: %s\n", backupFile)
 	logger.Infoln("OpenSearch Import (Sidecar Pod Approach)")
 
-	// Get absolute path to backup file
-	absFilename, err := filepath.Abs(backupFile)
-	if err != nil {
-		return fmt.Errorf("failed to get absolute path for backup file: %w", err)
-	}
-
-	// Extract and locate backup directory
-	backupDir, cleanup, err := podmanRestore.ExtractAndLocateBackup(absFilename)
+	// Locate backup directory
+	backupDir, err := podmanRestore.LocateBackupDirectory(dir)
 	if err != nil {
 		return err
 	}
-	defer cleanup()
 
 	// Find OpenSearch pod
 	namespace, podName, err := common.FindOpenSearchPod(applicationID)
@@ -54,11 +47,11 @@ func RestoreOpenSearch(ctx context.Context, applicationID, backupFile string) er
 	}
 
 	// Create sidecar pod and perform restore
-	return manageSidecarPod(ctx, namespace, serviceName, backupDir)
+	return manageSidecarPod(ctx, namespace, serviceName, backupDir, mapping)
 }
 
 // manageSidecarPod manages the lifecycle of a sidecar pod for restore operations.
-func manageSidecarPod(ctx context.Context, namespace, serviceName, backupDir string) error {
+func manageSidecarPod(ctx context.Context, namespace, serviceName, backupDir string, mapping opensearch.IndexMapping) error {
 	sidecarName := common.GenerateSidecarName("opensearch-restore-sidecar")
 
 	// Create sidecar pod
@@ -73,11 +66,11 @@ func manageSidecarPod(ctx context.Context, namespace, serviceName, backupDir str
 	}
 
 	// Perform restore operations
-	return performRestore(ctx, sidecarName, namespace, serviceName, backupDir)
+	return performRestore(ctx, sidecarName, namespace, serviceName, backupDir, mapping)
 }
 
 // performRestore performs the actual restore operations in the sidecar pod.
-func performRestore(ctx context.Context, podName, namespace, serviceName, backupDir string) error {
+func performRestore(ctx context.Context, podName, namespace, serviceName, backupDir string, mapping opensearch.IndexMapping) error {
 	// Get OpenSearch password
 	osPassword, err := common.GetOpenSearchPasswordFromSecret(namespace)
 	if err != nil {
@@ -100,7 +93,7 @@ func performRestore(ctx context.Context, podName, namespace, serviceName, backup
 	logger.Infof("OpenSearch host: %s\n", osHost)
 
 	// Perform restore with curl
-	if err := performRestoreWithCurl(ctx, podName, namespace, osHost, osPassword, containerBackupPath); err != nil {
+	if err := performRestoreWithCurl(ctx, podName, namespace, osHost, osPassword, containerBackupPath, mapping); err != nil {
 		return fmt.Errorf("restore failed: %w", err)
 	}
 
@@ -152,7 +145,7 @@ func copyBackupToSidecar(podName, namespace, backupOSDir, containerBackupPath st
 }
 
 // performRestoreWithCurl performs the OpenSearch restore using curl commands in the pod.
-func performRestoreWithCurl(ctx context.Context, podName, namespace, osHost, osPassword, backupDir string) error {
+func performRestoreWithCurl(ctx context.Context, podName, namespace, osHost, osPassword, backupDir string, mapping opensearch.IndexMapping) error {
 	// List and validate indices
 	indices, err := listBackupIndices(podName, namespace, backupDir)
 	if err != nil {
@@ -161,8 +154,13 @@ func performRestoreWithCurl(ctx context.Context, podName, namespace, osHost, osP
 
 	logger.Infof("Found %d indices to restore\n", len(indices))
 
+	targets, err := opensearch.PlanIndices(indices, mapping)
+	if err != nil {
+		return err
+	}
+
 	// Restore each index with error tracking
-	return restoreAllIndices(ctx, podName, namespace, osHost, osPassword, backupDir, indices)
+	return restoreAllIndices(ctx, podName, namespace, osHost, osPassword, backupDir, indices, targets, mapping.SkipExisting)
 }
 
 // listBackupIndices lists and validates index names from backup files.
@@ -188,7 +186,7 @@ func listBackupIndices(podName, namespace, backupDir string) ([]string, error) {
 		if indexName == "" {
 			continue
 		}
-		if err := appcommon.ValidateIndexName(indexName); err != nil {
+		if err := opensearch.ValidateIndexName(indexName); err != nil {
 			logger.Warningf("Skipping invalid index name %s: %v\n", indexName, err)
 
 			continue
@@ -203,9 +201,12 @@ func listBackupIndices(podName, namespace, backupDir string) ([]string, error) {
 	return validIndices, nil
 }
 
-// restoreAllIndices restores all indices and tracks errors.
-func restoreAllIndices(ctx context.Context, podName, namespace, osHost, osPassword, backupDir string, indices []string) error {
+// restoreAllIndices restores all indices under their target names and tracks
+// errors. With skipExisting, targets that already exist are left untouched.
+func restoreAllIndices(ctx context.Context, podName, namespace, osHost, osPassword, backupDir string,
+	indices []string, targets map[string]string, skipExisting bool) error {
 	restoredCount := 0
+	skippedCount := 0
 	var errors []error
 
 	for _, indexName := range indices {
@@ -216,7 +217,24 @@ func restoreAllIndices(ctx context.Context, podName, namespace, osHost, osPasswo
 		default:
 		}
 
-		if err := restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName); err != nil {
+		targetIndex := targets[indexName]
+		if skipExisting {
+			exists, err := indexExists(podName, namespace, osHost, osPassword, targetIndex)
+			if err != nil {
+				logger.Errorf("Failed to check index %s: %v\n", targetIndex, err)
+				errors = append(errors, fmt.Errorf("index %s: %w", targetIndex, err))
+
+				continue
+			}
+			if exists {
+				logger.Infof("  Skipping index %s: it already exists\n", targetIndex)
+				skippedCount++
+
+				continue
+			}
+		}
+
+		if err := restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName, targetIndex); err != nil {
 			logger.Errorf("Failed to restore index %s: %v\n", indexName, err)
 			errors = append(errors, fmt.Errorf("index %s: %w", indexName, err))
 
@@ -225,26 +243,33 @@ func restoreAllIndices(ctx context.Context, podName, namespace, osHost, osPasswo
 		restoredCount++
 	}
 
-	if restoredCount == 0 && len(errors) > 0 {
-		return fmt.Errorf("failed to restore any indices: %d errors occurred", len(errors))
-	}
+	return opensearch.ReportRestoredIndices(restoredCount, skippedCount, len(indices), errors)
+}
 
-	if len(errors) > 0 {
-		logger.Warningf("Restore completed with %d errors. Successfully restored %d/%d indices\n", len(errors), restoredCount, len(indices))
-	} else {
-		logger.Infof("✓ Restore completed successfully. Restored %d indices\n", restoredCount)
+// indexExists reports whether an index exists in OpenSearch.
+func indexExists(podName, namespace, osHost, osPassword, indexName string) (bool, error) {
+	script := opensearch.WrapScriptWithPassword(osPassword, opensearch.GenerateIndexExistsScript(osHost, indexName))
+
+	output, err := common.ExecInPodWithOutput(podName, namespace, script)
+	if err != nil {
+		return false, fmt.Errorf("failed to check index: %w, output: %s", err, output)
 	}
 
-	return nil
+	return strings.TrimSpace(output) == "200", nil
 }
 
-// restoreIndex restores a single index using curl in the pod.
-func restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName string) error {
-	logger.Infof("  Restoring index: %s\n", indexName)
+// restoreIndex restores a single index of the backup as targetIndex using curl in
+// the pod.
+func restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName, targetIndex string) error {
+	if targetIndex == indexName {
+		logger.Infof("  Restoring index: %s\n", indexName)
+	} else {
+		logger.Infof("  Restoring index: %s as %s\n", indexName, targetIndex)
+	}
 
 	// Step 1: Delete existing index if it exists
 	logger.Infoln("    Cleaning up existing index...")
-	deleteScript := opensearch.GenerateDeleteIndexScript(osHost, indexName)
+	deleteScript := opensearch.GenerateDeleteIndexScript(osHost, targetIndex)
 	wrappedDelete := opensearch.WrapScriptWithPassword(osPassword, deleteScript)
 	if err := common.ExecInPod(podName, namespace, wrappedDelete); err != nil {
 		logger.Warningf("    Failed to delete existing index (may not exist): %v\n", err)
@@ -254,7 +279,7 @@ func restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName s
 
 	// Step 2: Create index with settings and mappings
 	logger.Infoln("    Creating index with mappings...")
-	createScript := opensearch.GenerateCreateIndexScript(osHost, backupDir, indexName)
+	createScript := opensearch.GenerateCreateIndexScript(osHost, backupDir, indexName, targetIndex)
 	wrappedCreate := opensearch.WrapScriptWithPassword(osPassword, createScript)
 	if err := common.ExecInPod(podName, namespace, wrappedCreate); err != nil {
 		return fmt.Errorf("failed to create index: %w", err)
@@ -263,7 +288,7 @@ func restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName s
 
 	// Step 3: Insert data - Bulk index documents
 	logger.Infoln("    Inserting documents...")
-	bulkScript := opensearch.GenerateBulkIndexScript(osHost, backupDir, indexName)
+	bulkScript := opensearch.GenerateBulkIndexScript(osHost, backupDir, indexName, targetIndex)
 	wrappedBulk := opensearch.WrapScriptWithPassword(osPassword, bulkScript)
 	if err := common.ExecInPod(podName, namespace, wrappedBulk); err != nil {
 		return fmt.Errorf("failed to bulk index documents: %w", err)
@@ -271,7 +296,7 @@ func restoreIndex(podName, namespace, osHost, osPassword, backupDir, indexName s
 	logger.Infoln("    ✓ Documents inserted")
 
 	// Step 4: Refresh index to make documents searchable
-	refreshScript := opensearch.GenerateRefreshIndexScript(osHost, indexName)
+	refreshScript := opensearch.GenerateRefreshIndexScript(osHost, targetIndex)
 	wrappedRefresh := opensearch.WrapScriptWithPassword(osPassword, refreshScript)
 	if err := common.ExecInPod(podName, namespace, wrappedRefresh); err != nil {
 		return fmt.Errorf("failed to refresh index: %w", err)
@@ -2,75 +2,81 @@ package podman
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"path/filepath"
-	"strings"
-	"time"
+	"io"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
 	commonBackup "github.com/project-ai-services/ai-services/internal/pkg/application/common/backup"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/backup"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/common"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/restore"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/types"
-	catalogTypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	cliUtils "github.com/project-ai-services/ai-services/internal/pkg/cli/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
+func init() {
+	backuptarget.PodmanRegistry.Register(openSearchTarget{})
+	backuptarget.PodmanRegistry.Register(digitizeTarget{})
+}
+
 // Backup creates a backup of application data.
 func (p *PodmanApplication) Backup(ctx context.Context, opts types.BackupOptions) error {
 	logger.Infof("Starting backup for application: %s\n", opts.Name)
 	logger.Infof("Target: %s\n", opts.Target)
 
-	// Validate target
-	switch opts.Target {
-	case "opensearch":
-		return p.backupOpenSearch(ctx, opts.Name, opts.BackupFile)
-	case "digitize":
-		return p.backupDigitize(ctx, opts.Name, opts.BackupFile)
-	default:
-		return fmt.Errorf("unsupported backup target: %s", opts.Target)
+	req, err := p.backupRequest(opts.Name)
+	if err != nil {
+		return err
 	}
-}
+	req.Protection = opts.Protection
+	req.OpenSearchMode = opts.OpenSearchMode
+	req.ObjectStore = opts.ObjectStore
 
-// backupOpenSearch performs OpenSearch backup using a sidecar container.
-func (p *PodmanApplication) backupOpenSearch(ctx context.Context, appName, backupFile string) error {
-	logger.Infof("Backing up OpenSearch data for application: %s\n", appName)
-	logger.Infoln("OpenSearch Backup (Sidecar Container Approach)")
+	return backuptarget.Backup(ctx, p.Type(), req, opts.Target, opts.BackupFile)
+}
 
-	// Get application details from catalog API
+// backupRequest builds the target request from the application's catalog details.
+func (p *PodmanApplication) backupRequest(appName string) (backuptarget.Request, error) {
 	appDetails, err := cliUtils.GetAppDetailsWithComponents(appName)
 	if err != nil {
-		return fmt.Errorf("failed to get application details: %w", err)
+		return backuptarget.Request{}, fmt.Errorf("failed to get application details: %w", err)
 	}
 	logger.Infof("Application ID: %s\n", appDetails.ID)
 
-	// Get component ID for opensearch
-	componentID, err := cliUtils.GetComponentID(appDetails, "opensearch")
-	if err != nil {
-		return fmt.Errorf("failed to get component ID: %w", err)
-	}
-	logger.Infof("Component ID: %s\n", componentID)
+	return backuptarget.Request{AppName: appName, Runtime: p.runtime, App: appDetails}, nil
+}
 
-	// Generate backup filename if not provided
-	if backupFile == "" {
-		timestamp := time.Now().Format("20060102_150405")
-		backupFile = fmt.Sprintf("%s_opensearch_backup_%s.tar.gz", appName, timestamp)
-	}
+// openSearchTarget backs up and restores OpenSearch indices with the _snapshot API,
+// or by exporting every document using a sidecar container.
+type openSearchTarget struct{}
 
-	// Ensure .tar.gz extension
-	if !strings.HasSuffix(backupFile, ".tar.gz") {
-		backupFile += ".tar.gz"
-	}
+func (openSearchTarget) Describe() backuptarget.Description {
+	return backuptarget.OpenSearch
+}
+
+func (openSearchTarget) Verify(entries []string) error {
+	return backuptarget.VerifyOpenSearchArchive(entries)
+}
+
+func (openSearchTarget) CountDocuments(name string, r io.Reader) (map[string]int, error) {
+	return backuptarget.CountOpenSearchDocuments(name, r)
+}
+
+func (openSearchTarget) Backup(ctx context.Context, req backuptarget.Request, dir string) error {
+	logger.Infof("Backing up OpenSearch data for application: %s\n", req.AppName)
 
-	// Get absolute path for backup file
-	absBackupFile, err := filepath.Abs(backupFile)
+	// Get component ID for opensearch
+	componentID, err := cliUtils.GetComponentID(req.App, backuptarget.OpenSearch.CatalogID)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path for backup file: %w", err)
+		return fmt.Errorf("failed to get component ID: %w", err)
 	}
+	logger.Infof("Component ID: %s\n", componentID)
 
 	// Get the Podman context from the runtime client
-	podmanCtx, err := p.getPodmanContext()
+	podmanCtx, err := podmanContext(req.Runtime)
 	if err != nil {
 		return err
 	}
@@ -84,33 +90,43 @@ func (p *PodmanApplication) backupOpenSearch(ctx context.Context, appName, backu
 	logger.Infof("Container: %s\n", containerName)
 	logger.Infof("Pod ID: %s\n", podID)
 
-	// Perform backup using the backup package
-	if err := backup.BackupOpenSearch(podmanCtx, podID, absBackupFile); err != nil {
-		return err
+	mode := req.OpenSearchMode
+	if mode == "" {
+		mode = backuptarget.OpenSearchModeAuto
 	}
 
-	logger.Infof("✅ Backup completed successfully: %s\n", absBackupFile)
+	if mode != backuptarget.OpenSearchModeScroll {
+		logger.Infoln("OpenSearch Backup (Snapshot Repository Approach)")
 
-	return nil
+		err := backup.BackupOpenSearchSnapshot(podmanCtx, req.Runtime, containerName, podID, dir)
+		if mode == backuptarget.OpenSearchModeSnapshot || !errors.Is(err, opensearch.ErrSnapshotUnavailable) {
+			return err
+		}
+
+		logger.Warningf("Snapshot backup is not available, falling back to the scroll export: %v\n", err)
+	}
+
+	logger.Infoln("OpenSearch Backup (Sidecar Container Approach)")
+
+	return backup.BackupOpenSearch(podmanCtx, podID, dir)
 }
 
-func (p *PodmanApplication) backupDigitize(ctx context.Context, appName, backupFile string) error {
-	logger.Infof("Backing up digitize metadata for application: %s\n", appName)
-	logger.Infoln("Digitize Export (API-based Approach)")
+// digitizeTarget backs up and restores digitize metadata using the Export and Import APIs.
+type digitizeTarget struct{}
 
-	appDetails, err := cliUtils.GetAppDetailsWithComponents(appName)
-	if err != nil {
-		return fmt.Errorf("failed to get application details: %w", err)
-	}
-	logger.Infof("Application ID: %s\n", appDetails.ID)
+func (digitizeTarget) Describe() backuptarget.Description {
+	return backuptarget.Digitize
+}
 
-	// Generate backup filename if not provided
-	absBackupFile, err := commonBackup.GetBackupFile(backupFile, appName)
-	if err != nil {
-		return err
-	}
+func (digitizeTarget) Verify(entries []string) error {
+	return backuptarget.VerifyDigitizeArchive(entries)
+}
+
+func (digitizeTarget) Backup(ctx context.Context, req backuptarget.Request, dir string) error {
+	logger.Infof("Backing up digitize metadata for application: %s\n", req.AppName)
+	logger.Infoln("Digitize Export (API-based Approach)")
 
-	digitizeURL, err := restore.GetDigitizeAPIURL(appDetails)
+	digitizeURL, err := restore.GetDigitizeAPIURL(req.App)
 	if err != nil {
 		return err
 	}
@@ -124,40 +140,13 @@ func (p *PodmanApplication) backupDigitize(ctx context.Context, appName, backupF
 		return err
 	}
 
-	if err := commonBackup.CreateDigitizeBackupArchive(absBackupFile, exportResponse); err != nil {
+	if err := commonBackup.WriteDigitizeBackup(dir, exportResponse); err != nil {
 		return err
 	}
 
-	logDigitizeBackupSummary(exportResponse)
-	logger.Infof("✅ Backup completed successfully: %s\n", absBackupFile)
+	commonBackup.LogDigitizeBackupSummary(exportResponse)
 
 	return nil
 }
 
-func logDigitizeBackupSummary(exportResponse *commonBackup.DigitizeExportResponse) {
-	if exportResponse == nil {
-		return
-	}
-
-	logger.Infoln("Export summary:")
-
-	if exportResponse.Summary.Jobs.TotalExported > 0 || exportResponse.Summary.Jobs.Completed > 0 || exportResponse.Summary.Jobs.Failed > 0 {
-		logger.Infof("  Jobs - exported: %d, completed: %d, failed: %d\n",
-			exportResponse.Summary.Jobs.TotalExported,
-			exportResponse.Summary.Jobs.Completed,
-			exportResponse.Summary.Jobs.Failed)
-	}
-
-	if exportResponse.Summary.Documents.TotalExported > 0 || exportResponse.Summary.Documents.Completed > 0 || exportResponse.Summary.Documents.Failed > 0 {
-		logger.Infof("  Documents - exported: %d, completed: %d, failed: %d\n",
-			exportResponse.Summary.Documents.TotalExported,
-			exportResponse.Summary.Documents.Completed,
-			exportResponse.Summary.Documents.Failed)
-	}
-
-	logger.Infof("  Returned records: %d\n", exportResponse.Pagination.ReturnedRecords)
-}
-
-var _ *catalogTypes.Application
-
 // Made with Bob
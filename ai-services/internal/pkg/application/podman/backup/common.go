@@ -10,24 +10,13 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
-// CopyAndTarBackup copies backup files from container to host and creates tar archive on host.
-func CopyAndTarBackup(ctx context.Context, containerID, containerBackupPath, backupFile string) error {
+// CopyBackup copies backup files from container to backupDir on the host.
+func CopyBackup(ctx context.Context, containerID, containerBackupPath, backupDir string) error {
 	logger.Infof("Copying backup files from container to host...\n")
 
-	// Create temporary directory on host for backup files
-	tempDir, err := os.MkdirTemp("", "opensearch-backup-*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	defer func() {
-		if err := os.RemoveAll(tempDir); err != nil {
-			logger.Warningf("Failed to remove temp directory: %v\n", err)
-		}
-	}()
-
 	// Copy backup_info.json from container
 	backupInfoSrc := fmt.Sprintf("%s:/tmp/backup_info.json", containerID)
-	backupInfoDest := filepath.Join(tempDir, "backup_info.json")
+	backupInfoDest := filepath.Join(backupDir, "backup_info.json")
 	cpInfoCmd := exec.CommandContext(ctx, "podman", "cp", backupInfoSrc, backupInfoDest)
 	if output, err := cpInfoCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to copy backup_info.json: %w, output: %s", err, string(output))
@@ -36,7 +25,7 @@ func CopyAndTarBackup(ctx context.Context, containerID, containerBackupPath, bac
 	// Copy opensearch_backup directory from container
 	// Using "/." to copy contents of directory
 	backupDirSrc := fmt.Sprintf("%s:%s/.", containerID, containerBackupPath)
-	backupDirDest := filepath.Join(tempDir, "opensearch_backup")
+	backupDirDest := filepath.Join(backupDir, "opensearch_backup")
 
 	const dirPerm = 0o755
 	// Create destination directory
@@ -51,15 +40,6 @@ func CopyAndTarBackup(ctx context.Context, containerID, containerBackupPath, bac
 
 	logger.Infof("✓ Backup files copied to host\n")
 
-	// Create tar.gz archive on host
-	logger.Infof("Creating tar.gz archive on host...\n")
-
-	if err := CreateTarGzArchive(tempDir, backupFile, []string{"backup_info.json", "opensearch_backup"}); err != nil {
-		return err
-	}
-
-	LogArchiveSize(backupFile)
-
 	return nil
 }
 
@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// BackupOpenSearchSnapshot backs up the OpenSearch indices with the _snapshot API.
+// The snapshot is taken into an fs repository on the data volume, which keeps the
+// latest backup snapshot so the next one only writes the segments that changed,
+// and the repository is then copied into backupDir. Errors before the snapshot is
+// taken match opensearch.ErrSnapshotUnavailable.
+func BackupOpenSearchSnapshot(ctx context.Context, rt runtime.Runtime, containerName, podID, backupDir string) error {
+	client, removeRoute, err := common.NewOpenSearchSnapshotClient(ctx, rt, containerName, podID)
+	if err != nil {
+		return err
+	}
+	defer removeRoute()
+
+	location := path.Join(opensearch.SnapshotPath, opensearch.RepositoryName)
+	if err := client.RegisterRepository(ctx, opensearch.RepositoryName, location, false); err != nil {
+		return err
+	}
+
+	documents, err := client.DocumentCounts(ctx, opensearch.IndexPattern)
+	if err != nil {
+		return err
+	}
+	if len(documents) == 0 {
+		logger.Warningf("No indices found starting with 'rag'\n")
+	}
+
+	name := fmt.Sprintf("%s%d", opensearch.SnapshotPrefix, time.Now().Unix())
+	logger.Infof("Creating snapshot %s of %d indices...\n", name, len(documents))
+
+	snapshot, err := client.CreateSnapshot(ctx, opensearch.RepositoryName, name, opensearch.IndexPattern)
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	logger.Infof("✓ Snapshot %s completed with %d indices\n", snapshot.Name, len(snapshot.Indices))
+
+	pruneSnapshots(ctx, client, name)
+
+	info := opensearch.SnapshotInfo{
+		Snapshot:   name,
+		BackupDate: time.Now().Format(time.RFC3339),
+		Indices:    documents,
+	}
+
+	if err := copyRepository(ctx, containerName, location, info, backupDir); err != nil {
+		return fmt.Errorf("failed to copy snapshot: %w", err)
+	}
+
+	logger.Infoln("OpenSearch backup completed!")
+
+	return nil
+}
+
+// pruneSnapshots deletes the backup snapshots other than keep. Their segments stay
+// in the repository as long as keep references them.
+func pruneSnapshots(ctx context.Context, client *opensearch.SnapshotClient, keep string) {
+	snapshots, err := client.ListSnapshots(ctx, opensearch.RepositoryName)
+	if err != nil {
+		logger.Warningf("Failed to list old snapshots: %v\n", err)
+
+		return
+	}
+
+	for _, s := range snapshots {
+		if s.Name == keep || !strings.HasPrefix(s.Name, opensearch.SnapshotPrefix) {
+			continue
+		}
+
+		if err := client.DeleteSnapshot(ctx, opensearch.RepositoryName, s.Name); err != nil {
+			logger.Warningf("Failed to delete old snapshot %s: %v\n", s.Name, err)
+		}
+	}
+}
+
+// copyRepository copies the repository at location out of the container into
+// backupDir on the host and writes the snapshot info next to it.
+func copyRepository(ctx context.Context, containerName, location string, info opensearch.SnapshotInfo, backupDir string) error {
+	logger.Infof("Copying snapshot repository from container to host...\n")
+
+	archiveDir := filepath.Join(backupDir, opensearch.SnapshotArchiveDir)
+	if err := os.MkdirAll(archiveDir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	src := fmt.Sprintf("%s:%s", containerName, location)
+	dest := filepath.Join(archiveDir, opensearch.SnapshotRepositoryDir)
+	if output, err := exec.CommandContext(ctx, "podman", "cp", src, dest).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to copy snapshot repository: %w, output: %s", err, string(output))
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot info: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(archiveDir, opensearch.SnapshotInfoFile), append(data, '\n'), filePerm); err != nil {
+		return fmt.Errorf("failed to write snapshot info: %w", err)
+	}
+
+	logger.Infof("✓ Snapshot repository copied to host\n")
+
+	return nil
+}
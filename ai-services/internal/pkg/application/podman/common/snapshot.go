@@ -0,0 +1,209 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v5/pkg/bindings/pods"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/common/podman/caddy"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/common/podman/deploy"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/config"
+	catalogconstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	catalogUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/proxy"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	openSearchPort = "9200"
+	// snapshotRouteType is the endpoint type of the temporary OpenSearch route.
+	snapshotRouteType = "admin"
+	// snapshotRoutePrefix starts the ID of every temporary OpenSearch route. The
+	// ID continues with the Unix time the route was added at.
+	snapshotRoutePrefix = "opensearch-snapshot-"
+	// staleSnapshotRouteAge is the age past which a snapshot route is considered
+	// left behind by an interrupted operation.
+	staleSnapshotRouteAge = 24 * time.Hour
+	// snapshotTokenHeader carries the token a snapshot route requires.
+	snapshotTokenHeader = "X-Snapshot-Token"
+)
+
+// NewOpenSearchSnapshotClient returns a client for the _snapshot API of the
+// OpenSearch container. OpenSearch is not published on the host, so the client
+// reaches it through a route on the catalog's Caddy proxy that lives for the
+// duration of the operation. The route has a random host name and only matches
+// requests carrying a token known to this client. The returned function removes
+// the route. Errors setting up the route match opensearch.ErrSnapshotUnavailable.
+func NewOpenSearchSnapshotClient(ctx context.Context, rt runtime.Runtime, containerName, podID string) (*opensearch.SnapshotClient, func(), error) {
+	password, err := GetOpenSearchPasswordFromSecret(ctx, containerName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get OpenSearch password: %w", err)
+	}
+
+	podData, err := pods.Inspect(ctx, podID, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to inspect pod: %w", err)
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseURL, removeRoute, err := registerOpenSearchRoute(ctx, rt, podData.Name, token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", opensearch.ErrSnapshotUnavailable, err)
+	}
+
+	logger.Infof("OpenSearch URL: %s\n", baseURL)
+
+	creds, err := config.Load()
+	insecure := err == nil && creds.Insecure
+
+	client := opensearch.NewSnapshotClient(baseURL, password, insecure).SetHeader(snapshotTokenHeader, token)
+
+	return client, removeRoute, nil
+}
+
+// registerOpenSearchRoute adds a Caddy route to the OpenSearch pod that requires
+// token, and returns its URL and a function removing it. Routes left behind by
+// interrupted operations are removed first. Inside the catalog API server the
+// proxy is configured from the environment; on the host it is looked up from the
+// catalog deployment.
+func registerOpenSearchRoute(ctx context.Context, rt runtime.Runtime, podName, token string) (string, func(), error) {
+	proxyManager, domainSuffix, httpsPort, err := catalogProxy(rt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := proxyManager.HealthCheck(); err != nil {
+		return "", nil, fmt.Errorf("caddy health check failed: %w", err)
+	}
+
+	removeStaleSnapshotRoutes(ctx, proxyManager, time.Now())
+
+	suffix, err := randomHex(4)
+	if err != nil {
+		return "", nil, err
+	}
+
+	id := fmt.Sprintf("%s%d-%s", snapshotRoutePrefix, time.Now().Unix(), suffix)
+	route := proxy.Route{
+		ID:          id,
+		Domain:      fmt.Sprintf("%s.%s", id, domainSuffix),
+		Upstream:    fmt.Sprintf("%s:%s", podName, openSearchPort),
+		Terminal:    true,
+		Type:        snapshotRouteType,
+		UpstreamTLS: true,
+		Headers:     map[string]string{snapshotTokenHeader: token},
+	}
+
+	if err := proxyManager.RegisterRoute(ctx, route); err != nil {
+		return "", nil, fmt.Errorf("failed to register route %s: %w", route.ID, err)
+	}
+
+	remove := func() {
+		if err := proxyManager.UnregisterRoute(route.ID); err != nil && !errors.Is(err, proxy.ErrRouteNotFound) {
+			logger.Warningf("Failed to remove route %s: %v\n", route.ID, err)
+		}
+	}
+
+	return catalogUtils.BuildExternalURL(route.Domain, httpsPort), remove, nil
+}
+
+// removeStaleSnapshotRoutes removes the snapshot routes added longer than
+// staleSnapshotRouteAge before now. Failures are only logged.
+func removeStaleSnapshotRoutes(ctx context.Context, proxyManager proxy.ProxyManager, now time.Time) {
+	routes, err := proxyManager.ListRoutes(ctx)
+	if err != nil {
+		logger.Warningf("Failed to list routes: %v\n", err)
+
+		return
+	}
+
+	for _, route := range routes {
+		created, ok := snapshotRouteTime(route.ID)
+		if !ok || now.Sub(created) < staleSnapshotRouteAge {
+			continue
+		}
+
+		logger.Infof("Removing stale route %s\n", route.ID)
+		if err := proxyManager.UnregisterRoute(route.ID); err != nil && !errors.Is(err, proxy.ErrRouteNotFound) {
+			logger.Warningf("Failed to remove route %s: %v\n", route.ID, err)
+		}
+	}
+}
+
+// snapshotRouteTime returns the time a snapshot route was added at, read from
+// its ID, and false for other routes.
+func snapshotRouteTime(id string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(id, snapshotRoutePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	unix, _, _ := strings.Cut(rest, "-")
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(seconds, 0), true
+}
+
+// randomHex returns n random bytes encoded as hex.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// catalogProxy returns the catalog's proxy manager, domain suffix and HTTPS port.
+func catalogProxy(rt runtime.Runtime) (proxy.ProxyManager, string, string, error) {
+	if domainSuffix := utils.GetEnv("DOMAIN_SUFFIX", ""); domainSuffix != "" {
+		proxyManager, err := proxy.GetCaddyProxyManager()
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		return proxyManager, domainSuffix, utils.GetEnv("CADDY_HTTPS_PORT", catalogconstants.DefaultHTTPSPort), nil
+	}
+
+	catalogConfig, _, err := catalogUtils.GetCatalogPodConfig(rt)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get catalog configuration: %w", err)
+	}
+	if catalogConfig.DomainName == "" || catalogConfig.HttpsPort == 0 {
+		return nil, "", "", fmt.Errorf("catalog pod does not define DOMAIN_SUFFIX and CADDY_HTTPS_PORT")
+	}
+
+	deployCtx, err := deploy.NewDeployContext()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to create deployment context: %w", err)
+	}
+
+	caddyPodName, err := deployCtx.GetCaddyPodName()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get Caddy pod name: %w", err)
+	}
+
+	proxyManager, err := caddy.NewContext(caddyPodName, catalogConfig.DomainName).CreateProxyManager()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to create proxy manager: %w", err)
+	}
+
+	return proxyManager, catalogConfig.DomainName, strconv.Itoa(catalogConfig.HttpsPort), nil
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/proxy"
+)
+
+// fakeProxy is an in-memory proxy.ProxyManager.
+type fakeProxy struct {
+	routes []proxy.Route
+}
+
+func (f *fakeProxy) RegisterRoute(_ context.Context, route proxy.Route) error {
+	f.routes = append(f.routes, route)
+
+	return nil
+}
+
+func (f *fakeProxy) UnregisterRoute(routeID string) error {
+	for i, route := range f.routes {
+		if route.ID == routeID {
+			f.routes = append(f.routes[:i], f.routes[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return proxy.ErrRouteNotFound
+}
+
+func (f *fakeProxy) HealthCheck() error { return nil }
+
+func (f *fakeProxy) GetRouteByID(routeID string) (*proxy.Route, error) {
+	return nil, fmt.Errorf("route %s not found", routeID)
+}
+
+func (f *fakeProxy) ListRoutes(context.Context) ([]proxy.Route, error) {
+	return f.routes, nil
+}
+
+func TestRemoveStaleSnapshotRoutes(t *testing.T) {
+	now := time.Now()
+	stale := fmt.Sprintf("%s%d-0a1b2c3d", snapshotRoutePrefix, now.Add(-2*staleSnapshotRouteAge).Unix())
+	recent := fmt.Sprintf("%s%d-4e5f6a7b", snapshotRoutePrefix, now.Add(-time.Hour).Unix())
+
+	pm := &fakeProxy{routes: []proxy.Route{{ID: stale}, {ID: recent}, {ID: "opensearch-app"}, {ID: snapshotRoutePrefix + "invalid"}}}
+	removeStaleSnapshotRoutes(context.Background(), pm, now)
+
+	var ids []string
+	for _, route := range pm.routes {
+		ids = append(ids, route.ID)
+	}
+	assert.Equal(t, []string{recent, "opensearch-app", snapshotRoutePrefix + "invalid"}, ids)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/image"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
 	"github.com/project-ai-services/ai-services/internal/pkg/specs"
 	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
@@ -170,16 +171,16 @@ func (p *PodmanApplication) downloadModels(ctx context.Context, templateName, ap
 
 	logger.Infoln("Downloading models required for application template " + templateName + ":")
 
-	for _, model := range models {
-		s.UpdateMessage("Downloading model: " + model + "...")
-		err = utils.Retry(ctx, vars.RetryCount, vars.RetryInterval, nil, func() error {
-			return helpers.DownloadModel(model, utils.GetModelsPath())
+	// Every retry resumes the files where the previous attempt stopped.
+	err = utils.Retry(ctx, vars.RetryCount, vars.RetryInterval, nil, func() error {
+		return helpers.DownloadModels(ctx, models, utils.GetModelsPath(), func(p modelstore.Progress) {
+			s.UpdateMessage("Downloading models: " + p.String())
 		})
-		if err != nil {
-			s.Fail("failed to download model: " + model)
+	})
+	if err != nil {
+		s.Fail("failed to download models")
 
-			return fmt.Errorf("failed to download model: %w", err)
-		}
+		return fmt.Errorf("failed to download model: %w", err)
 	}
 
 	s.Stop("Model download completed.")
@@ -26,9 +26,9 @@ func (p *PodmanApplication) Type() types.RuntimeType {
 	return types.RuntimeTypePodman
 }
 
-// getPodmanContext extracts the Podman context from the runtime client.
-func (p *PodmanApplication) getPodmanContext() (context.Context, error) {
-	podmanClient, ok := p.runtime.(*runtimePodman.PodmanClient)
+// podmanContext extracts the Podman context from the runtime client.
+func podmanContext(rt runtime.Runtime) (context.Context, error) {
+	podmanClient, ok := rt.(*runtimePodman.PodmanClient)
 	if !ok {
 		return nil, fmt.Errorf("runtime is not a Podman client")
 	}
@@ -3,12 +3,11 @@ package podman
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
 	commonrestore "github.com/project-ai-services/ai-services/internal/pkg/application/common/restore"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/restore"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/types"
-	catalogTypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	cliUtils "github.com/project-ai-services/ai-services/internal/pkg/cli/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
@@ -19,61 +18,53 @@ func (p *PodmanApplication) Restore(ctx context.Context, opts types.RestoreOptio
 	logger.Infof("Target: %s\n", opts.Target)
 	logger.Infof("Backup file: %s\n", opts.BackupFile)
 
-	// Get application details from catalog API using existing utility
-	appDetails, err := cliUtils.GetAppDetailsWithComponents(opts.Name)
+	req, err := p.backupRequest(opts.Name)
 	if err != nil {
-		return fmt.Errorf("failed to get application details: %w", err)
+		return err
 	}
-	logger.Infof("Application ID: %s\n", appDetails.ID)
+	req.Protection = opts.Protection
+	req.Indices = opts.Indices
+	req.ObjectStore = opts.ObjectStore
 
-	// Get absolute path to backup file
-	absFilename, err := filepath.Abs(opts.BackupFile)
-	if err != nil {
-		return fmt.Errorf("failed to get absolute path for backup file: %w", err)
-	}
+	return backuptarget.Restore(ctx, p.Type(), req, opts.Target, opts.BackupFile)
+}
 
-	// Execute restore based on target
-	switch opts.Target {
-	case "opensearch":
-		// Get component ID for opensearch
-		componentID, err := cliUtils.GetComponentID(appDetails, opts.Target)
-		if err != nil {
-			return fmt.Errorf("failed to get component ID: %w", err)
-		}
-		logger.Infof("Component ID: %s\n", componentID)
-
-		return p.restoreOpenSearch(ctx, componentID, absFilename)
-	case "digitize":
-		return p.restoreDigitize(ctx, appDetails, absFilename)
-	default:
-		return fmt.Errorf("unsupported target: %s", opts.Target)
+// Restore restores OpenSearch data from a snapshot archive, or from a scroll
+// export using the podman sidecar approach.
+func (openSearchTarget) Restore(ctx context.Context, req backuptarget.Request, dir string) error {
+	// Get component ID for opensearch
+	componentID, err := cliUtils.GetComponentID(req.App, backuptarget.OpenSearch.CatalogID)
+	if err != nil {
+		return fmt.Errorf("failed to get component ID: %w", err)
 	}
-}
+	logger.Infof("Component ID: %s\n", componentID)
 
-// restoreOpenSearch restores OpenSearch data using podman sidecar approach.
-func (p *PodmanApplication) restoreOpenSearch(ctx context.Context, templateID, backupFile string) error {
 	// Get the Podman context from the runtime client
-	podmanCtx, err := p.getPodmanContext()
+	podmanCtx, err := podmanContext(req.Runtime)
 	if err != nil {
 		return err
 	}
 
+	if backuptarget.IsOpenSearchSnapshot(dir) {
+		return restore.RestoreOpenSearchSnapshot(podmanCtx, req.Runtime, componentID, dir, req.Indices)
+	}
+
 	// Call the OpenSearch-specific restore function
-	return restore.RestoreOpenSearch(podmanCtx, templateID, backupFile)
+	return restore.RestoreOpenSearch(podmanCtx, componentID, dir, req.Indices)
 }
 
-// restoreDigitize restores digitize metadata using the Import API.
-func (p *PodmanApplication) restoreDigitize(ctx context.Context, appDetails *catalogTypes.Application, backupFile string) error {
+// Restore restores digitize metadata using the Import API.
+func (digitizeTarget) Restore(ctx context.Context, req backuptarget.Request, dir string) error {
 	logger.Infoln("Restoring digitize metadata")
 	logger.Infoln("Digitize Import (API-based Approach)")
 
-	importPayload, err := commonrestore.GetDigitizeData(backupFile)
+	importPayload, err := commonrestore.GetDigitizeData(dir)
 	if err != nil {
 		return err
 	}
 
 	// Get digitize service API URL from application details
-	digitizeURL, err := restore.GetDigitizeAPIURL(appDetails)
+	digitizeURL, err := restore.GetDigitizeAPIURL(req.App)
 	if err != nil {
 		return err
 	}
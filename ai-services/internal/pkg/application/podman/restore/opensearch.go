@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	appcommon "github.com/project-ai-services/ai-services/internal/pkg/application/common"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
 	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/common"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
@@ -21,8 +20,10 @@ const (
 	containerBackupPath   = "/tmp/opensearch_backup"
 )
 
-// RestoreOpenSearch restores OpenSearch data using podman sidecar approach.
-func RestoreOpenSearch(ctx context.Context, templateID, backupFile string) error {
+// RestoreOpenSearch restores OpenSearch data from the extracted archive in dir
+// using podman sidecar approach. The indices are restored under the names that
+// mapping selects.
+func RestoreOpenSearch(ctx context.Context, templateID, dir string, mapping opensearch.IndexMapping) error {
 	logger.Infof("Restoring OpenSearch data for template: %s\n", templateID)
 	logger.Infoln("OpenSearch Import (Sidecar Container Approach)")
 
@@ -35,19 +36,18 @@ func RestoreOpenSearch(ctx context.Context, templateID, backupFile string) error
 	logger.Infof("Container: %s\n", containerName)
 	logger.Infof("Pod ID: %s\n", podID)
 
-	// Extract and locate backup directory
-	backupDir, cleanup, err := ExtractAndLocateBackup(backupFile)
+	// Locate backup directory
+	backupDir, err := LocateBackupDirectory(dir)
 	if err != nil {
 		return err
 	}
-	defer cleanup()
 
 	// Manage sidecar lifecycle and perform restore
-	return manageSidecarWithGo(ctx, podID, backupDir)
+	return manageSidecarWithGo(ctx, podID, backupDir, mapping)
 }
 
 // manageSidecarWithGo manages the lifecycle of a podman sidecar container using runtime package.
-func manageSidecarWithGo(ctx context.Context, podID, backupDir string) error {
+func manageSidecarWithGo(ctx context.Context, podID, backupDir string, mapping opensearch.IndexMapping) error {
 	sidecarName := fmt.Sprintf("opensearch-restore-sidecar-%d-%d", time.Now().Unix(), os.Getpid())
 
 	// Create podman client to use runtime methods
@@ -64,13 +64,13 @@ func manageSidecarWithGo(ctx context.Context, podID, backupDir string) error {
 		[]string{"sleep", "3600"},
 		func(ctx context.Context, containerID string) error {
 			// Prepare sidecar and perform restore
-			return prepareSidecarAndRestore(ctx, containerID, backupDir)
+			return prepareSidecarAndRestore(ctx, containerID, backupDir, mapping)
 		},
 	)
 }
 
 // prepareSidecarAndRestore prepares the sidecar container and performs the restore.
-func prepareSidecarAndRestore(ctx context.Context, containerID, backupDir string) error {
+func prepareSidecarAndRestore(ctx context.Context, containerID, backupDir string, mapping opensearch.IndexMapping) error {
 	// Create podman client once for all operations
 	pc, err := podman.NewPodmanClient()
 	if err != nil {
@@ -91,7 +91,7 @@ func prepareSidecarAndRestore(ctx context.Context, containerID, backupDir string
 		return err
 	}
 
-	if err := performRestoreWithCurl(ctx, pc, containerID, defaultOpenSearchHost, osPassword, containerBackupPath); err != nil {
+	if err := performRestoreWithCurl(ctx, pc, containerID, defaultOpenSearchHost, osPassword, containerBackupPath, mapping); err != nil {
 		return fmt.Errorf("restore failed: %w", err)
 	}
 
@@ -134,7 +134,7 @@ func execInContainer(pc *podman.PodmanClient, containerID string, cmd []string)
 }
 
 // performRestoreWithCurl performs the OpenSearch restore using curl commands in container.
-func performRestoreWithCurl(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir string) error {
+func performRestoreWithCurl(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir string, mapping opensearch.IndexMapping) error {
 	// Verify backup directory exists in container
 	if err := verifyBackupDirectory(pc, containerID, backupDir); err != nil {
 		return err
@@ -148,8 +148,13 @@ func performRestoreWithCurl(ctx context.Context, pc *podman.PodmanClient, contai
 
 	logger.Infof("Found %d indices to restore\n", len(indices))
 
+	targets, err := opensearch.PlanIndices(indices, mapping)
+	if err != nil {
+		return err
+	}
+
 	// Restore each index with error tracking
-	return restoreAllIndices(ctx, pc, containerID, osHost, osPassword, backupDir, indices)
+	return restoreAllIndices(ctx, pc, containerID, osHost, osPassword, backupDir, indices, targets, mapping.SkipExisting)
 }
 
 // verifyBackupDirectory checks if the backup directory exists in the container.
@@ -185,7 +190,7 @@ func listBackupIndices(pc *podman.PodmanClient, containerID, backupDir string) (
 		if indexName == "" {
 			continue
 		}
-		if err := appcommon.ValidateIndexName(indexName); err != nil {
+		if err := opensearch.ValidateIndexName(indexName); err != nil {
 			logger.Warningf("Skipping invalid index name %s: %v\n", indexName, err)
 
 			continue
@@ -200,9 +205,12 @@ func listBackupIndices(pc *podman.PodmanClient, containerID, backupDir string) (
 	return validIndices, nil
 }
 
-// restoreAllIndices restores all indices and tracks errors.
-func restoreAllIndices(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir string, indices []string) error {
+// restoreAllIndices restores all indices under their target names and tracks
+// errors. With skipExisting, targets that already exist are left untouched.
+func restoreAllIndices(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir string,
+	indices []string, targets map[string]string, skipExisting bool) error {
 	restoredCount := 0
+	skippedCount := 0
 	var errors []error
 
 	for _, indexName := range indices {
@@ -213,7 +221,24 @@ func restoreAllIndices(ctx context.Context, pc *podman.PodmanClient, containerID
 		default:
 		}
 
-		if err := restoreIndexWithCurl(ctx, pc, containerID, osHost, osPassword, backupDir, indexName); err != nil {
+		targetIndex := targets[indexName]
+		if skipExisting {
+			exists, err := indexExists(pc, containerID, osHost, osPassword, targetIndex)
+			if err != nil {
+				logger.Errorf("Failed to check index %s: %v\n", targetIndex, err)
+				errors = append(errors, fmt.Errorf("index %s: %w", targetIndex, err))
+
+				continue
+			}
+			if exists {
+				logger.Infof("  Skipping index %s: it already exists\n", targetIndex)
+				skippedCount++
+
+				continue
+			}
+		}
+
+		if err := restoreIndexWithCurl(ctx, pc, containerID, osHost, osPassword, backupDir, indexName, targetIndex); err != nil {
 			logger.Errorf("Failed to restore index %s: %v\n", indexName, err)
 			errors = append(errors, fmt.Errorf("index %s: %w", indexName, err))
 
@@ -222,24 +247,31 @@ func restoreAllIndices(ctx context.Context, pc *podman.PodmanClient, containerID
 		restoredCount++
 	}
 
-	if restoredCount == 0 && len(errors) > 0 {
-		return fmt.Errorf("failed to restore any indices: %d errors occurred", len(errors))
-	}
+	return opensearch.ReportRestoredIndices(restoredCount, skippedCount, len(indices), errors)
+}
 
-	if len(errors) > 0 {
-		logger.Warningf("Restore completed with %d errors. Successfully restored %d/%d indices\n", len(errors), restoredCount, len(indices))
-	} else {
-		logger.Infof("✓ Restore completed successfully. Restored %d indices\n", restoredCount)
+// indexExists reports whether an index exists in OpenSearch.
+func indexExists(pc *podman.PodmanClient, containerID, osHost, osPassword, indexName string) (bool, error) {
+	script := opensearch.WrapScriptWithPassword(osPassword, opensearch.GenerateIndexExistsScript(osHost, indexName))
+
+	output, err := pc.ExecInContainerWithOutput(containerID, []string{"sh", "-c", script})
+	if err != nil {
+		return false, fmt.Errorf("failed to check index: %w, output: %s", err, output)
 	}
 
-	return nil
+	return strings.TrimSpace(output) == "200", nil
 }
 
-// restoreIndexWithCurl restores a single index using curl in container.
-// Password is passed via environment variable to avoid exposure in process lists.
-// The restore process follows: cleanup (delete existing) -> create -> insert data.
-func restoreIndexWithCurl(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName string) error {
-	logger.Infof("  Restoring index: %s\n", indexName)
+// restoreIndexWithCurl restores a single index of the backup as targetIndex using
+// curl in container. Password is passed via environment variable to avoid exposure
+// in process lists. The restore process follows: cleanup (delete existing) ->
+// create -> insert data.
+func restoreIndexWithCurl(ctx context.Context, pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName, targetIndex string) error {
+	if targetIndex == indexName {
+		logger.Infof("  Restoring index: %s\n", indexName)
+	} else {
+		logger.Infof("  Restoring index: %s as %s\n", indexName, targetIndex)
+	}
 
 	// Verify required backup files exist
 	if err := verifyBackupFiles(pc, containerID, backupDir, indexName); err != nil {
@@ -248,7 +280,7 @@ func restoreIndexWithCurl(ctx context.Context, pc *podman.PodmanClient, containe
 
 	// Step 1: Cleanup - Delete existing index if it exists
 	logger.Infoln("    Cleaning up existing index...")
-	if err := deleteExistingIndex(pc, containerID, osHost, osPassword, indexName); err != nil {
+	if err := deleteExistingIndex(pc, containerID, osHost, osPassword, targetIndex); err != nil {
 		logger.Warningf("    Failed to delete existing index (may not exist): %v\n", err)
 	} else {
 		logger.Infof("    ✓ Existing index cleaned up\n")
@@ -256,20 +288,20 @@ func restoreIndexWithCurl(ctx context.Context, pc *podman.PodmanClient, containe
 
 	// Step 2: Create index with settings and mappings
 	logger.Infof("    Creating index with mappings...\n")
-	if err := createIndexWithMappings(pc, containerID, osHost, osPassword, backupDir, indexName); err != nil {
+	if err := createIndexWithMappings(pc, containerID, osHost, osPassword, backupDir, indexName, targetIndex); err != nil {
 		return err
 	}
 	logger.Infof("    ✓ Index created\n")
 
 	// Step 3: Insert data - Bulk index documents
 	logger.Infof("    Inserting documents...\n")
-	if err := bulkIndexDocuments(pc, containerID, osHost, osPassword, backupDir, indexName); err != nil {
+	if err := bulkIndexDocuments(pc, containerID, osHost, osPassword, backupDir, indexName, targetIndex); err != nil {
 		return err
 	}
 	logger.Infof("    ✓ Documents inserted\n")
 
 	// Step 4: Refresh index to make documents searchable
-	if err := refreshIndex(pc, containerID, osHost, osPassword, indexName); err != nil {
+	if err := refreshIndex(pc, containerID, osHost, osPassword, targetIndex); err != nil {
 		return err
 	}
 
@@ -307,10 +339,11 @@ func deleteExistingIndex(pc *podman.PodmanClient, containerID, osHost, osPasswor
 	return pc.ExecInContainerWithEnv(containerID, map[string]string{"OS_PASSWORD": osPassword}, wrappedScript)
 }
 
-// createIndexWithMappings creates an index with settings and mappings.
-func createIndexWithMappings(pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName string) error {
+// createIndexWithMappings creates targetIndex with the settings and mappings of
+// indexName in the backup.
+func createIndexWithMappings(pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName, targetIndex string) error {
 	// Generate create index script using common function
-	createScript := opensearch.GenerateCreateIndexScript(osHost, backupDir, indexName)
+	createScript := opensearch.GenerateCreateIndexScript(osHost, backupDir, indexName, targetIndex)
 
 	// Wrap with password environment variable
 	wrappedScript := opensearch.WrapScriptWithPassword(osPassword, createScript)
@@ -322,10 +355,11 @@ func createIndexWithMappings(pc *podman.PodmanClient, containerID, osHost, osPas
 	return nil
 }
 
-// bulkIndexDocuments performs bulk indexing of documents.
-func bulkIndexDocuments(pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName string) error {
+// bulkIndexDocuments performs bulk indexing of the documents of indexName in the
+// backup into targetIndex.
+func bulkIndexDocuments(pc *podman.PodmanClient, containerID, osHost, osPassword, backupDir, indexName, targetIndex string) error {
 	// Generate bulk index script using common function
-	bulkScript := opensearch.GenerateBulkIndexScript(osHost, backupDir, indexName)
+	bulkScript := opensearch.GenerateBulkIndexScript(osHost, backupDir, indexName, targetIndex)
 
 	// Wrap with password environment variable
 	wrappedScript := opensearch.WrapScriptWithPassword(osPassword, bulkScript)
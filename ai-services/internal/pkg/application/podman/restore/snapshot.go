@@ -0,0 +1,214 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/podman/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+)
+
+// restoreRepositoryName is the read-only repository an archived repository is
+// registered as while its snapshot is restored.
+const restoreRepositoryName = opensearch.RepositoryName + "-restore"
+
+// RestoreOpenSearchSnapshot restores OpenSearch indices from a snapshot archive
+// extracted into dir. The archived repository is copied next to the backup
+// repository, registered read-only and its snapshot restored with the _snapshot
+// API under the names that mapping selects, replacing existing indices unless
+// mapping skips them.
+func RestoreOpenSearchSnapshot(ctx context.Context, rt runtime.Runtime, templateID, dir string, mapping opensearch.IndexMapping) error {
+	logger.Infof("Restoring OpenSearch data for template: %s\n", templateID)
+	logger.Infoln("OpenSearch Snapshot Restore")
+
+	containerName, podID, err := common.FindContainerAndPod(ctx, templateID)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Container: %s\n", containerName)
+	logger.Infof("Pod ID: %s\n", podID)
+
+	archiveDir, info, err := readSnapshotArchive(dir)
+	if err != nil {
+		return err
+	}
+
+	indices, err := snapshotIndices(info)
+	if err != nil {
+		return err
+	}
+
+	targets, err := opensearch.PlanIndices(indices, mapping)
+	if err != nil {
+		return err
+	}
+
+	client, removeRoute, err := common.NewOpenSearchSnapshotClient(ctx, rt, containerName, podID)
+	if err != nil {
+		return err
+	}
+	defer removeRoute()
+
+	pc, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to create podman client: %w", err)
+	}
+
+	location := path.Join(opensearch.SnapshotPath, fmt.Sprintf("restore-%d", time.Now().Unix()))
+	if err := copyRepositoryToContainer(pc, containerName, filepath.Join(archiveDir, opensearch.SnapshotRepositoryDir), location); err != nil {
+		return err
+	}
+	defer func() {
+		if err := pc.ExecInContainer(containerName, []string{"rm", "-rf", location}); err != nil {
+			logger.Warningf("Failed to remove %s from container: %v\n", location, err)
+		}
+	}()
+
+	if err := client.RegisterRepository(ctx, restoreRepositoryName, location, true); err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.DeleteRepository(ctx, restoreRepositoryName); err != nil {
+			logger.Warningf("Failed to unregister repository %s: %v\n", restoreRepositoryName, err)
+		}
+	}()
+
+	restore, err := selectSnapshotIndices(ctx, client, indices, targets, mapping.SkipExisting)
+	if err != nil {
+		return err
+	}
+	if len(restore) == 0 {
+		logger.Infoln("Every index of the snapshot already exists, nothing to restore")
+
+		return nil
+	}
+
+	logger.Infof("Restoring %d indices from snapshot %s...\n", len(restore), info.Snapshot)
+
+	if err := restoreSnapshotIndices(ctx, client, info.Snapshot, restore, targets); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	for _, index := range restore {
+		logger.Infof("  ✓ %s (%d documents)\n", targets[index], info.Indices[index])
+	}
+
+	logger.Infoln("OpenSearch snapshot restore completed!")
+
+	return nil
+}
+
+// selectSnapshotIndices returns the indices to restore and deletes their targets.
+// With skipExisting, indices whose targets exist are left out instead.
+func selectSnapshotIndices(ctx context.Context, client *opensearch.SnapshotClient, indices []string, targets map[string]string, skipExisting bool) ([]string, error) {
+	restore := make([]string, 0, len(indices))
+	for _, index := range indices {
+		target := targets[index]
+		if skipExisting {
+			exists, err := client.IndexExists(ctx, target)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				logger.Infof("  Skipping index %s: it already exists\n", target)
+
+				continue
+			}
+		} else if err := client.DeleteIndex(ctx, target); err != nil {
+			return nil, err
+		}
+		restore = append(restore, index)
+	}
+
+	return restore, nil
+}
+
+// restoreSnapshotIndices restores the indices kept under their own names in one
+// request and each renamed index in a request of its own.
+func restoreSnapshotIndices(ctx context.Context, client *opensearch.SnapshotClient, snapshot string, indices []string, targets map[string]string) error {
+	var unchanged []string
+	for _, index := range indices {
+		if targets[index] == index {
+			unchanged = append(unchanged, index)
+
+			continue
+		}
+
+		if err := client.RestoreIndexAs(ctx, restoreRepositoryName, snapshot, index, targets[index]); err != nil {
+			return err
+		}
+	}
+
+	if len(unchanged) == 0 {
+		return nil
+	}
+
+	return client.RestoreSnapshot(ctx, restoreRepositoryName, snapshot, unchanged)
+}
+
+// readSnapshotArchive returns the snapshot directory of a snapshot archive
+// extracted into dir and its info file.
+func readSnapshotArchive(dir string) (string, *opensearch.SnapshotInfo, error) {
+	archiveDir := filepath.Join(dir, opensearch.SnapshotArchiveDir)
+
+	data, err := os.ReadFile(filepath.Join(archiveDir, opensearch.SnapshotInfoFile))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read snapshot info: %w", err)
+	}
+
+	var info opensearch.SnapshotInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return "", nil, fmt.Errorf("failed to parse snapshot info: %w", err)
+	}
+
+	return archiveDir, &info, nil
+}
+
+// snapshotIndices returns the sorted, validated indices recorded in the snapshot info.
+func snapshotIndices(info *opensearch.SnapshotInfo) ([]string, error) {
+	if info.Snapshot == "" {
+		return nil, fmt.Errorf("snapshot info does not name a snapshot")
+	}
+
+	indices := make([]string, 0, len(info.Indices))
+	for index := range info.Indices {
+		if err := opensearch.ValidateIndexName(index); err != nil {
+			return nil, fmt.Errorf("invalid index name %s in snapshot info: %w", index, err)
+		}
+		indices = append(indices, index)
+	}
+
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no indices found in snapshot %s", info.Snapshot)
+	}
+
+	sort.Strings(indices)
+
+	return indices, nil
+}
+
+// copyRepositoryToContainer copies the archived repository into the OpenSearch
+// container at location.
+func copyRepositoryToContainer(pc *podman.PodmanClient, containerName, repositoryDir, location string) error {
+	logger.Infoln("Copying snapshot repository to container...")
+
+	if err := pc.ExecInContainer(containerName, []string{"mkdir", "-p", location}); err != nil {
+		return fmt.Errorf("failed to create %s in container: %w", location, err)
+	}
+
+	if err := pc.CopyDirToContainer(containerName, repositoryDir, location); err != nil {
+		return fmt.Errorf("failed to copy snapshot repository: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,10 @@ package types
 import (
 	"time"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
 	"github.com/project-ai-services/ai-services/internal/pkg/image"
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
 )
 
 // CreateOptions contains parameters for creating an application.
@@ -77,6 +80,11 @@ type RestoreOptions struct {
 	Target     string // opensearch, digitize, etc.
 	BackupFile string
 	AutoYes    bool
+	Protection backuptarget.Protection // Passphrase and public key to check the archive with
+	// Indices renames or prefixes the restored OpenSearch indices and can skip existing ones
+	Indices opensearch.IndexMapping
+	// ObjectStore configures the object storage for s3:// backup files (environment when nil)
+	ObjectStore *objectstore.Config
 }
 
 // BackupOptions contains parameters for backing up application data.
@@ -84,6 +92,11 @@ type BackupOptions struct {
 	Name       string
 	Target     string // opensearch, digitize, etc.
 	BackupFile string
+	Protection backuptarget.Protection // Signing key and passphrase for the archive
+	// OpenSearchMode selects snapshot or scroll backups of OpenSearch (auto when empty)
+	OpenSearchMode string
+	// ObjectStore configures the object storage for s3:// backup files (environment when nil)
+	ObjectStore *objectstore.Config
 }
 
 // ApplicationInfo represents information about a deployed application.
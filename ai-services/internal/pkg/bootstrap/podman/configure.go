@@ -23,45 +23,56 @@ func (p *PodmanBootstrap) Configure() error {
 
 	ctx := context.Background()
 
-	// 1. Install and configure Podman if not done
+	// 1. Install Podman if not done
 	if err := ensurePodmanInstalled(ctx); err != nil {
 		return err
 	}
 
-	if err := configurePodman(ctx); err != nil {
+	// 2. Configure user groups (sentient group)
+	if err := ensureUsergroupConfigured(ctx); err != nil {
 		return err
 	}
 
-	// 2. Configure user groups (sentient group)
-	if err := ensureUsergroupConfigured(ctx); err != nil {
+	// 3. Configure subordinate IDs, lingering, cgroup delegation and data
+	// directories for rootless podman, then enable the podman services
+	if err := ensureRootlessConfigured(ctx); err != nil {
+		return err
+	}
+
+	if err := configurePodman(ctx); err != nil {
 		return err
 	}
 
-	// 3. Spyre cards – validate and repair spyre configurations
+	// 4. Spyre cards – validate and repair spyre configurations
 	if err := ensureSpyreConfigured(ctx); err != nil {
 		return err
 	}
 
-	// 4. Configure ulimits (memlock and nofile)
+	// 5. Configure ulimits (memlock and nofile)
 	if err := ensureUlimitsConfigured(ctx); err != nil {
 		return err
 	}
 
-	// 5. Configure systemd user slice limits for rootless podman
+	// 6. Configure systemd user slice limits for rootless podman
 	if err := ensureSystemdSliceLimitsConfigured(ctx); err != nil {
 		return err
 	}
 
-	// 6. Configure SMT level to 2 and persist via systemd
+	// 7. Configure SMT level to 2 and persist via systemd
 	if err := ensureSMTConfigured(ctx); err != nil {
 		return err
 	}
 
-	// 7. Configure SELinux policy for Podman socket access
+	// 8. Configure SELinux policy for Podman socket access
 	if err := ensureSELinuxPolicyConfigured(ctx); err != nil {
 		return err
 	}
 
+	// 9. Install the systemd units starting application pods on boot
+	if err := ensureAutostartConfigured(ctx); err != nil {
+		return err
+	}
+
 	logger.Infoln("LPAR configured successfully")
 
 	return nil
@@ -15,6 +15,8 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+	aiutils "github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils/selinux"
 )
 
@@ -743,3 +745,26 @@ func ensureSELinuxPolicyConfigured(ctx context.Context) error {
 
 	return nil
 }
+
+// ensureAutostartConfigured installs the path unit that installs the systemd units
+// the catalog writes to start application pods on boot.
+func ensureAutostartConfigured(ctx context.Context) error {
+	s := spinner.New("Configuring application autostart")
+	s.Start(ctx)
+
+	executable, err := os.Executable()
+	if err != nil {
+		s.Fail("failed to configure application autostart")
+
+		return fmt.Errorf("failed to get the ai-services executable: %w", err)
+	}
+
+	if err := systemd.InstallSyncUnit(executable, aiutils.GetSystemdUnitsPath()); err != nil {
+		s.Fail("failed to configure application autostart")
+
+		return err
+	}
+	s.Stop("Application autostart configured successfully")
+
+	return nil
+}
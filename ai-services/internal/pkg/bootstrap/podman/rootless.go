@@ -0,0 +1,64 @@
+package podman
+
+import (
+	"context"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/rootless"
+	"github.com/project-ai-services/ai-services/internal/pkg/spinner"
+)
+
+// configureRootless prepares the host for the user running rootless podman:
+// subordinate IDs, lingering, cgroup delegation and the ownership of the base
+// directory. Nothing is done when podman runs as root.
+func configureRootless() error {
+	u, err := rootless.User()
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		logger.Debugln("Podman runs as root, skipping rootless configuration")
+
+		return nil
+	}
+
+	if err := rootless.AddSubIDs(u); err != nil {
+		return err
+	}
+
+	if !rootless.Lingering(u) {
+		if err := rootless.EnableLinger(u); err != nil {
+			return err
+		}
+	}
+
+	missing, err := rootless.MissingControllers(u)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		if err := rootless.ConfigureDelegation(); err != nil {
+			return err
+		}
+		logger.Infof("Delegated cgroup controllers %s to user managers; they apply once %s logs in again\n", strings.Join(missing, ", "), u.Username)
+	}
+
+	return rootless.OwnDataDirs(u)
+}
+
+// ensureRootlessConfigured configures the host for rootless podman when bootstrap
+// is run with sudo by a non-root user.
+func ensureRootlessConfigured(ctx context.Context) error {
+	s := spinner.New("Configuring rootless podman")
+	s.Start(ctx)
+
+	if err := configureRootless(); err != nil {
+		s.Fail("failed to configure rootless podman")
+
+		return err
+	}
+	s.Stop("Rootless podman configured successfully")
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 type CheckResult interface {
 	String() string
 	GetStatus() bool
+	GetDescription() string
 }
 
 // Check represents a basic validation check.
@@ -32,6 +33,11 @@ func (c *Check) GetStatus() bool {
 	return c.Status
 }
 
+// GetDescription returns the description of the check.
+func (c *Check) GetDescription() string {
+	return c.Description
+}
+
 // String returns a string representation of the check.
 func (c *Check) String() string {
 	status := "PASS"
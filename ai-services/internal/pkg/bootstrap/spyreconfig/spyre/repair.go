@@ -42,7 +42,7 @@ type RepairResult struct {
 
 // Repair attempts to fix all failed Spyre checks.
 func Repair(checks []check.CheckResult) []RepairResult {
-	const checkResultsLen = 7
+	const checkResultsLen = 8
 	results := make([]RepairResult, 0, checkResultsLen)
 
 	// Create a map for easy lookup.
@@ -59,26 +59,14 @@ func Repair(checks []check.CheckResult) []RepairResult {
 	results = append(results, fixVFIOPermissions(checkMap))
 	results = append(results, fixSELinuxVFIOPolicy())
 	results = append(results, fixPodmanServiceSupplementaryGroups(checkMap))
+	results = append(results, fixRootlessUserGroup(checkMap))
 
 	return results
 }
 
 // getCheckDescription extracts the description from a check.
 func getCheckDescription(chk check.CheckResult) string {
-	switch c := chk.(type) {
-	case *check.Check:
-		return c.Description
-	case *check.ConfigCheck:
-		return c.Description
-	case *check.ConfigurationFileCheck:
-		return c.Description
-	case *check.PackageCheck:
-		return c.Description
-	case *check.FilesCheck:
-		return c.Description
-	default:
-		return ""
-	}
+	return chk.GetDescription()
 }
 
 // getCheckFromMap retrieves a check from the map and returns early if skipped.
@@ -477,6 +465,41 @@ func fixPodmanServiceSupplementaryGroups(checkMap map[string]check.CheckResult)
 	}
 }
 
+// fixRootlessUserGroup adds the user running rootless podman to the sentient
+// group. Its user manager picks the group up once the user logs in again; the
+// system-wide podman services are left alone since rootless podman does not use them.
+func fixRootlessUserGroup(checkMap map[string]check.CheckResult) RepairResult {
+	chk, ok := getCheckFromMap(checkMap, rootlessGroupCheckName)
+	if !ok {
+		return RepairResult{CheckName: rootlessGroupCheckName, Status: StatusSkipped}
+	}
+
+	groupCheck, ok := chk.(*check.ConfigCheck)
+	if !ok {
+		return RepairResult{CheckName: rootlessGroupCheckName, Status: StatusNotFixable, Message: "unexpected check type"}
+	}
+
+	for username := range groupCheck.Configs {
+		exitCode, _, stderr, err := utils.ExecuteCommand("usermod", "-aG", sentientGroup, username)
+		if err != nil || exitCode != 0 {
+			err = fmt.Errorf("failed to add %s to the %s group: %v, stderr: %s", username, sentientGroup, err, stderr)
+
+			return RepairResult{
+				CheckName: rootlessGroupCheckName,
+				Status:    StatusFailedToFix,
+				Error:     err,
+				Message:   err.Error(),
+			}
+		}
+	}
+
+	return RepairResult{
+		CheckName: rootlessGroupCheckName,
+		Status:    StatusFixed,
+		Message:   "log in again for the group membership to apply",
+	}
+}
+
 func createPodmanServiceDropIn() error {
 	dropInContent := "[Service]\nSupplementaryGroups=sentient\n"
 
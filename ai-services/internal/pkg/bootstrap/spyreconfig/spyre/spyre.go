@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/user"
 	"path/filepath"
 	"regexp"
 	"slices"
@@ -12,6 +13,7 @@ import (
 	"github.com/jaypipes/ghw"
 	"github.com/project-ai-services/ai-services/internal/pkg/bootstrap/spyreconfig/check"
 	"github.com/project-ai-services/ai-services/internal/pkg/bootstrap/spyreconfig/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/rootless"
 )
 
 const (
@@ -20,6 +22,8 @@ const (
 	spyreDeviceIDRev2 = "06a8"
 	sentientGroup     = "sentient"
 	vfioConfigFile    = "/etc/modprobe.d/vfio-pci.conf"
+
+	rootlessGroupCheckName = "Rootless podman user sentient group membership"
 )
 
 // Package-level regex patterns compiled once for performance.
@@ -72,7 +76,7 @@ func RunChecks() []check.CheckResult {
 		checkVfioModule(),
 		checkVfioAccessPermission(),
 		checkSELinuxVFIOPolicy(),
-		checkPodmanServiceSupplementaryGroups(),
+		checkPodmanGroups(),
 	}
 }
 
@@ -340,6 +344,38 @@ func checkVfioDevicePermission(path string, expectedGid int) (bool, error) {
 	return fileGid == expectedGid && utils.IsReadWriteToOwnerGroupUsers(path), nil
 }
 
+// checkPodmanGroups validates that podman reaches the VFIO devices through the
+// sentient group: root podman through the SupplementaryGroups of its services,
+// rootless podman through the group membership of its user, whose user manager
+// runs with the groups of the user and ignores the system-wide services.
+func checkPodmanGroups() check.CheckResult {
+	u, err := rootless.User()
+	if err != nil {
+		log.Printf("Failed to get the podman user: %v", err)
+	}
+	if u != nil {
+		return checkRootlessUserGroup(u.Username)
+	}
+
+	return checkPodmanServiceSupplementaryGroups()
+}
+
+// checkRootlessUserGroup validates that the user running rootless podman is a
+// member of the sentient group.
+func checkRootlessUserGroup(username string) *check.ConfigCheck {
+	groupCheck := check.NewConfigCheck(rootlessGroupCheckName)
+
+	member := false
+	if u, err := user.Lookup(username); err != nil {
+		log.Printf("Failed to lookup user %s: %v", username, err)
+	} else if member, err = rootless.InGroup(u, sentientGroup); err != nil {
+		log.Printf("Failed to check groups of %s: %v", username, err)
+	}
+	groupCheck.AddConfig(username, member)
+
+	return groupCheck
+}
+
 // checkPodmanServiceSupplementaryGroups validates that both podman.service and podman-restart.service
 // have SupplementaryGroups=sentient configured.
 //
@@ -25,6 +25,21 @@
 //	@tag.name					Catalog
 //	@tag.description			Catalog endpoints for architectures and services
 //
+//	@tag.name					Accelerators
+//	@tag.description			Accelerator inventory endpoints
+//
+//	@tag.name					Backups
+//	@tag.description			Application backup and restore jobs and backup schedules
+//
+//	@tag.name					Models
+//	@tag.description			Model cache inventory, verification and pruning, and custom model registration
+//
+//	@tag.name					Images
+//	@tag.description			Pruning of the images no application refers to
+//
+//	@tag.name					Volumes
+//	@tag.description			Pruning of the volumes of deleted applications and components
+//
 //	@securityDefinitions.apikey	BearerAuth
 //	@in							header
 //	@name						Authorization
@@ -35,9 +50,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	acceleratorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/accelerator"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	backupsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/backup"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	eventsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	modelcachesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/modelcache"
+	prunesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/prune"
+	registeredmodelsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/registeredmodel"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/gateway"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
@@ -46,12 +68,19 @@ import (
 // APIServerOptions defines the configuration options for the API server such as the port to listen
 // on and the authentication provider.
 type APIServerOptions struct {
-	Port               int
-	AuthService        auth.Service
-	TokenManager       *auth.TokenManager
-	Blacklist          repository.TokenBlacklist
-	ApplicationService repository.ApplicationServiceInterface
-	BundleService      bundlesvc.BundleServiceInterface
+	Port                   int
+	AuthService            auth.Service
+	TokenManager           *auth.TokenManager
+	Blacklist              repository.TokenBlacklist
+	CatalogProvider        *catalog.CatalogProvider
+	ApplicationService     repository.ApplicationServiceInterface
+	BundleService          bundlesvc.BundleServiceInterface
+	AcceleratorService     acceleratorsvc.ServiceInterface
+	BackupService          backupsvc.ServiceInterface
+	EventService           eventsvc.ServiceInterface
+	ModelService           modelcachesvc.ServiceInterface
+	RegisteredModelService registeredmodelsvc.ServiceInterface
+	PruneService           prunesvc.ServiceInterface
 
 	// WorkerGatewayPort is the port the gRPC worker gateway listens on.
 	// Defaults to 9090 when zero.
@@ -63,12 +92,19 @@ type APIServerOptions struct {
 
 // APIserver represents the API server instance, holding the configuration and authentication provider.
 type APIserver struct {
-	port               int
-	authService        auth.Service
-	tokenManager       *auth.TokenManager
-	blacklist          repository.TokenBlacklist
-	applicationService repository.ApplicationServiceInterface
-	bundleService      bundlesvc.BundleServiceInterface
+	port                   int
+	authService            auth.Service
+	tokenManager           *auth.TokenManager
+	blacklist              repository.TokenBlacklist
+	catalogProvider        *catalog.CatalogProvider
+	applicationService     repository.ApplicationServiceInterface
+	bundleService          bundlesvc.BundleServiceInterface
+	acceleratorService     acceleratorsvc.ServiceInterface
+	backupService          backupsvc.ServiceInterface
+	eventService           eventsvc.ServiceInterface
+	modelService           modelcachesvc.ServiceInterface
+	registeredModelService registeredmodelsvc.ServiceInterface
+	pruneService           prunesvc.ServiceInterface
 
 	workerGatewayPort int
 	workerRegistry    *registry.Registry
@@ -85,14 +121,21 @@ func NewAPIserver(options APIServerOptions) *APIserver {
 	}
 
 	return &APIserver{
-		port:               options.Port,
-		authService:        options.AuthService,
-		tokenManager:       options.TokenManager,
-		blacklist:          options.Blacklist,
-		applicationService: options.ApplicationService,
-		bundleService:      options.BundleService,
-		workerGatewayPort:  options.WorkerGatewayPort,
-		workerRegistry:     options.WorkerRegistry,
+		port:                   options.Port,
+		authService:            options.AuthService,
+		tokenManager:           options.TokenManager,
+		blacklist:              options.Blacklist,
+		catalogProvider:        options.CatalogProvider,
+		applicationService:     options.ApplicationService,
+		bundleService:          options.BundleService,
+		acceleratorService:     options.AcceleratorService,
+		backupService:          options.BackupService,
+		eventService:           options.EventService,
+		modelService:           options.ModelService,
+		registeredModelService: options.RegisteredModelService,
+		pruneService:           options.PruneService,
+		workerGatewayPort:      options.WorkerGatewayPort,
+		workerRegistry:         options.WorkerRegistry,
 	}
 }
 
@@ -113,7 +156,7 @@ func (a *APIserver) Start(ctx context.Context) error {
 	}
 	logger.InfofCtx(ctx, "Worker gateway started on %s", gatewayAddr)
 
-	r := CreateRouter(a.authService, a.tokenManager, a.blacklist, a.applicationService, a.workerRegistry, a.bundleService)
+	r := CreateRouter(a.authService, a.tokenManager, a.blacklist, a.catalogProvider, a.applicationService, a.workerRegistry, a.bundleService, a.acceleratorService, a.backupService, a.eventService, a.modelService, a.registeredModelService, a.pruneService)
 
 	if err := r.Run(fmt.Sprintf(":%d", a.port)); err != nil {
 		return err
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	acceleratorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/accelerator"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// AcceleratorHandler handles accelerator inventory requests.
+type AcceleratorHandler struct {
+	service acceleratorsvc.ServiceInterface
+}
+
+// NewAcceleratorHandler creates a new AcceleratorHandler backed by the given service.
+func NewAcceleratorHandler(svc acceleratorsvc.ServiceInterface) *AcceleratorHandler {
+	return &AcceleratorHandler{service: svc}
+}
+
+// ListAccelerators godoc
+//
+//	@Summary		List accelerators
+//	@Description	Lists every Spyre card on the catalog host with its PCI address, NUMA node, driver binding,
+//	@Description	health and the application, service or component holding it. Cards on registered workers are not listed.
+//	@Tags			Accelerators
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	types.AcceleratorListResponse
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/accelerators [get]
+func (h *AcceleratorHandler) ListAccelerators(c *gin.Context) {
+	var resp *types.AcceleratorListResponse
+	resp, err := h.service.List(c.Request.Context())
+	if err != nil {
+		logger.ErrorfCtx(c.Request.Context(), "Could not list accelerators: %v", err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to list accelerators: %v", err),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -162,7 +162,8 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 // CreateApplication godoc
 //
 //	@Summary		Create new application
-//	@Description	Creates a new application (architecture or service) with optional custom parameters
+//	@Description	Creates a new application (architecture or service) with optional custom parameters.
+//	@Description	The application is rejected when it does not fit in the remaining host capacity unless ignore_capacity is set.
 //	@Tags			Applications
 //	@Accept			json
 //	@Produce		json
@@ -172,7 +173,7 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 //	@Failure		400		{object}	ErrorResponse						"Invalid request body or validation errors"
 //	@Failure		401		{object}	ErrorResponse						"Unauthorized"
 //	@Failure		409		{object}	ErrorResponse						"Application name already exists"
-//	@Failure		422		{object}	ErrorResponse						"Parameter validation failed or invalid template"
+//	@Failure		422		{object}	ErrorResponse						"Parameter validation failed, invalid template, insufficient host capacity or images rejected by the image policy"
 //	@Failure		500		{object}	ErrorResponse						"Internal Server Error"
 //	@Router			/applications [post]
 func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
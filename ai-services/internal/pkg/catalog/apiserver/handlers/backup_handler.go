@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	backupsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/backup"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// ErrInvalidBackupIDParameter is returned when the backup_id path parameter is not a UUID.
+var ErrInvalidBackupIDParameter = ErrorResponse{Error: "Invalid backup ID format"}
+
+// BackupHandler handles backup and restore requests for catalog-managed applications.
+type BackupHandler struct {
+	service backupsvc.ServiceInterface
+}
+
+// NewBackupHandler creates a new BackupHandler backed by the given service.
+func NewBackupHandler(svc backupsvc.ServiceInterface) *BackupHandler {
+	return &BackupHandler{service: svc}
+}
+
+// CreateBackup godoc
+//
+//	@Summary		Create backup
+//	@Description	Starts an async backup of the application into the server-side backup volume and returns
+//	@Description	the backup job immediately. Poll the job to follow its status and progress.
+//	@Tags			Backups
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Application ID (UUID)"
+//	@Param			request	body		types.CreateBackupRequest	false	"Backup target (defaults to 'all')"
+//	@Success		202		{object}	types.BackupJob
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID or unsupported target"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"Application not found"
+//	@Failure		409		{object}	ErrorResponse	"Application is not Running or a job is already in progress"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backups [post]
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	var req types.CreateBackupRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	job, err := h.service.CreateBackup(c.Request.Context(), appID, req, c.GetString(middleware.CtxUserIDKey))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListBackups godoc
+//
+//	@Summary		List backup jobs
+//	@Description	Lists the backup and restore jobs of the application, newest first.
+//	@Tags			Backups
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Application ID (UUID)"
+//	@Success		200	{object}	types.BackupJobListResponse
+//	@Failure		400	{object}	ErrorResponse	"Invalid application ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"Application not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backups [get]
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	resp, err := h.service.ListJobs(c.Request.Context(), appID)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBackup godoc
+//
+//	@Summary		Get backup job
+//	@Description	Returns a single backup or restore job of the application with its status and progress.
+//	@Tags			Backups
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Application ID (UUID)"
+//	@Param			backup_id	path		string	true	"Job ID (UUID)"
+//	@Success		200			{object}	types.BackupJob
+//	@Failure		400			{object}	ErrorResponse	"Invalid application or job ID"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"Job not found"
+//	@Failure		500			{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backups/{backup_id} [get]
+func (h *BackupHandler) GetBackup(c *gin.Context) {
+	appID, backupID, ok := parseBackupParams(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.service.GetJob(c.Request.Context(), appID, backupID)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DownloadBackup godoc
+//
+//	@Summary		Download backup archive
+//	@Description	Streams the archive of a completed backup job.
+//	@Tags			Backups
+//	@Produce		application/gzip
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Application ID (UUID)"
+//	@Param			backup_id	path		string	true	"Backup job ID (UUID)"
+//	@Success		200			{file}		file	"Backup archive (.tar.gz)"
+//	@Failure		400			{object}	ErrorResponse	"Invalid application or backup ID"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"Backup not found"
+//	@Failure		409			{object}	ErrorResponse	"Job is not a completed backup"
+//	@Failure		410			{object}	ErrorResponse	"Archive no longer available"
+//	@Failure		500			{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backups/{backup_id}/download [get]
+func (h *BackupHandler) DownloadBackup(c *gin.Context) {
+	appID, backupID, ok := parseBackupParams(c)
+	if !ok {
+		return
+	}
+
+	path, err := h.service.ArchivePath(c.Request.Context(), appID, backupID)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.FileAttachment(path, filepath.Base(path))
+}
+
+// RestoreBackup godoc
+//
+//	@Summary		Restore backup
+//	@Description	Starts an async restore of a completed backup into the application and returns the
+//	@Description	restore job immediately. With source_application_id the backup of another application
+//	@Description	is restored; OpenSearch indices can be restored under new names or next to existing ones.
+//	@Tags			Backups
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string						true	"Application ID (UUID)"
+//	@Param			backup_id	path		string						true	"Backup job ID (UUID)"
+//	@Param			request		body		types.RestoreBackupRequest	false	"Target, source application and index mapping of the restore"
+//	@Success		202			{object}	types.BackupJob
+//	@Failure		400			{object}	ErrorResponse	"Invalid ID, unsupported target or invalid index name"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"Application or backup not found"
+//	@Failure		409			{object}	ErrorResponse	"Backup not completed, application not Running or a job is already in progress"
+//	@Failure		500			{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backups/{backup_id}/restore [post]
+func (h *BackupHandler) RestoreBackup(c *gin.Context) {
+	appID, backupID, ok := parseBackupParams(c)
+	if !ok {
+		return
+	}
+
+	var req types.RestoreBackupRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	job, err := h.service.Restore(c.Request.Context(), appID, backupID, req, c.GetString(middleware.CtxUserIDKey))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// CreateBackupSchedule godoc
+//
+//	@Summary		Create backup schedule
+//	@Description	Adds a cron backup schedule to the application. The API server starts a backup job
+//	@Description	whenever the schedule is due and then prunes the schedule's older backups per its
+//	@Description	retention policy. Failed runs are reported as application events.
+//	@Tags			Backups
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Application ID (UUID)"
+//	@Param			request	body		types.CreateBackupScheduleRequest	true	"Schedule"
+//	@Success		201		{object}	types.BackupSchedule
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID, target, cron expression or retention"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"Application not found"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backup-schedules [post]
+func (h *BackupHandler) CreateBackupSchedule(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	var req types.CreateBackupScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(c.Request.Context(), appID, req, c.GetString(middleware.CtxUserIDKey))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ListBackupSchedules godoc
+//
+//	@Summary		List backup schedules
+//	@Description	Lists the backup schedules of the application with their next run and last outcome.
+//	@Tags			Backups
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Application ID (UUID)"
+//	@Success		200	{object}	types.BackupScheduleListResponse
+//	@Failure		400	{object}	ErrorResponse	"Invalid application ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"Application not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backup-schedules [get]
+func (h *BackupHandler) ListBackupSchedules(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	resp, err := h.service.ListSchedules(c.Request.Context(), appID)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateBackupSchedule godoc
+//
+//	@Summary		Update backup schedule
+//	@Description	Changes the target, cron expression, retention or enabled state of a backup schedule.
+//	@Tags			Backups
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string								true	"Application ID (UUID)"
+//	@Param			schedule_id	path		string								true	"Schedule ID (UUID)"
+//	@Param			request		body		types.UpdateBackupScheduleRequest	true	"Fields to change"
+//	@Success		200			{object}	types.BackupSchedule
+//	@Failure		400			{object}	ErrorResponse	"Invalid ID, target, cron expression or retention"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"Schedule not found"
+//	@Failure		500			{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backup-schedules/{schedule_id} [put]
+func (h *BackupHandler) UpdateBackupSchedule(c *gin.Context) {
+	appID, scheduleID, ok := parseScheduleParams(c)
+	if !ok {
+		return
+	}
+
+	var req types.UpdateBackupScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(c.Request.Context(), appID, scheduleID, req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteBackupSchedule godoc
+//
+//	@Summary		Delete backup schedule
+//	@Description	Removes a backup schedule. Backups it created are kept.
+//	@Tags			Backups
+//	@Security		BearerAuth
+//	@Param			id			path	string	true	"Application ID (UUID)"
+//	@Param			schedule_id	path	string	true	"Schedule ID (UUID)"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse	"Invalid application or schedule ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404	{object}	ErrorResponse	"Schedule not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/backup-schedules/{schedule_id} [delete]
+func (h *BackupHandler) DeleteBackupSchedule(c *gin.Context) {
+	appID, scheduleID, ok := parseScheduleParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(c.Request.Context(), appID, scheduleID); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseScheduleParams parses the application and schedule IDs from the path,
+// writing a 400 response when either is invalid.
+func parseScheduleParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("schedule_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid schedule ID format"})
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return appID, scheduleID, true
+}
+
+// parseBackupParams parses the application and backup job IDs from the path,
+// writing a 400 response when either is invalid.
+func parseBackupParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	backupID, err := uuid.Parse(c.Param("backup_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidBackupIDParameter)
+
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return appID, backupID, true
+}
+
+// bindOptionalJSON binds the request body into obj if one was sent, writing a 400
+// response when it is not valid JSON.
+func bindOptionalJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+
+		return false
+	}
+
+	return true
+}
+
+// mapServiceError writes a ValidationError with its own status code and any other error as 500.
+func (h *BackupHandler) mapServiceError(c *gin.Context, err error) {
+	if valErr, ok := err.(*validators.ValidationError); ok {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+
+	logger.ErrorfCtx(c.Request.Context(), "Backup request failed: %v", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
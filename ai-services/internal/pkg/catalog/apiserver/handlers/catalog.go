@@ -15,14 +15,8 @@ type CatalogHandler struct {
 	provider *catalog.CatalogProvider
 }
 
-// NewCatalogHandler creates a new catalog handler.
-func NewCatalogHandler() *CatalogHandler {
-	provider, err := catalog.NewCatalogProvider()
-	if err != nil {
-		// Log error but don't fail - let individual requests handle it
-		panic(fmt.Sprintf("Failed to initialize catalog provider: %v", err))
-	}
-
+// NewCatalogHandler creates a new catalog handler serving the items of provider.
+func NewCatalogHandler(provider *catalog.CatalogProvider) *CatalogHandler {
 	return &CatalogHandler{
 		provider: provider,
 	}
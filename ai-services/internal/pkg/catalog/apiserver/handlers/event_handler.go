@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	eventsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// defaultEventLimit is the number of events returned when no limit is given.
+const defaultEventLimit = 100
+
+// Ensure types package is imported for Swagger documentation.
+var _ types.ApplicationEventListResponse
+
+// EventHandler handles application event requests.
+type EventHandler struct {
+	service eventsvc.ServiceInterface
+}
+
+// NewEventHandler creates a new EventHandler backed by the given service.
+func NewEventHandler(svc eventsvc.ServiceInterface) *EventHandler {
+	return &EventHandler{service: svc}
+}
+
+// ListEvents godoc
+//
+//	@Summary		List application events
+//	@Description	Lists the most recent events of an application, newest first. Events report background
+//	@Description	activity such as backup and restore jobs and scheduled backup failures.
+//	@Tags			Applications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Application ID (UUID)"
+//	@Param			limit	query		int		false	"Maximum number of events (default: 100)"
+//	@Success		200		{object}	types.ApplicationEventListResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID or limit"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"Application not found"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/{id}/events [get]
+func (h *EventHandler) ListEvents(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	limit := defaultEventLimit
+	if limitParam := c.Query("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid limit parameter: must be a positive integer"})
+
+			return
+		}
+	}
+
+	resp, err := h.service.List(c.Request.Context(), appID, limit)
+	if err != nil {
+		if valErr, ok := err.(*validators.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		logger.ErrorfCtx(c.Request.Context(), "Failed to list events for application %s: %v", appID, err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
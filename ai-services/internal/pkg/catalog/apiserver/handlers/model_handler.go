@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	modelcachesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/modelcache"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// ModelHandler handles model cache requests.
+type ModelHandler struct {
+	service modelcachesvc.ServiceInterface
+}
+
+// NewModelHandler creates a new ModelHandler backed by the given service.
+func NewModelHandler(svc modelcachesvc.ServiceInterface) *ModelHandler {
+	return &ModelHandler{service: svc}
+}
+
+// ListModels godoc
+//
+//	@Summary		List cached models
+//	@Description	Lists the models in the models directory of the catalog host with their size, revision,
+//	@Description	download date and the application components configured to serve them.
+//	@Tags			Models
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	types.ModelListResponse
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501	{object}	ErrorResponse	"Runtime without a model cache"
+//	@Router			/models [get]
+func (h *ModelHandler) ListModels(c *gin.Context) {
+	resp, err := h.service.List(c.Request.Context())
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VerifyModels godoc
+//
+//	@Summary		Verify cached models
+//	@Description	Checks every file of the cached models against the checksums recorded when they were
+//	@Description	downloaded or imported. Models downloaded by older releases are checked against the
+//	@Description	checksums Hugging Face reported for them.
+//	@Tags			Models
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		types.ModelVerifyRequest	false	"Only verify this model"
+//	@Success		200		{object}	types.ModelVerifyResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		404		{object}	ErrorResponse	"Model not cached"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Runtime without a model cache"
+//	@Router			/models/verify [post]
+func (h *ModelHandler) VerifyModels(c *gin.Context) {
+	var req types.ModelVerifyRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.Verify(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PruneModels godoc
+//
+//	@Summary		Prune unreferenced models
+//	@Description	Removes the cached models that no application component is configured to serve. Registered
+//	@Description	custom models are kept; unregister them instead.
+//	@Description	Refused while an application is downloading or deploying.
+//	@Tags			Models
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		types.ModelPruneRequest	false	"Set dry_run to only report what would be removed"
+//	@Success		200		{object}	types.ModelPruneResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		409		{object}	ErrorResponse	"An application is downloading or deploying"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Runtime without a model cache"
+//	@Router			/models/prune [post]
+func (h *ModelHandler) PruneModels(c *gin.Context) {
+	var req types.ModelPruneRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.Prune(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// mapServiceError writes a ValidationError with its own status code and any other error as 500.
+func (h *ModelHandler) mapServiceError(c *gin.Context, err error) {
+	if valErr, ok := err.(*validators.ValidationError); ok {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+
+	logger.ErrorfCtx(c.Request.Context(), "Model cache request failed: %v", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
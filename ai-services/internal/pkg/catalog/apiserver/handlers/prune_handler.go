@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	prunesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/prune"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// PruneHandler handles image and volume prune requests.
+type PruneHandler struct {
+	service prunesvc.ServiceInterface
+}
+
+// NewPruneHandler creates a new PruneHandler backed by the given service.
+func NewPruneHandler(svc prunesvc.ServiceInterface) *PruneHandler {
+	return &PruneHandler{service: svc}
+}
+
+// PruneImages godoc
+//
+//	@Summary		Prune unreferenced images
+//	@Description	Removes the local images of the catalog templates and of the configured registry mirrors that
+//	@Description	no container uses and that are neither in the templates of the deployed applications, nor the
+//	@Description	digests their images are pinned to, nor the tool image, nor an imported offline bundle.
+//	@Description	Images are matched under their own name and that of their registry mirror. Other images are
+//	@Description	never removed.
+//	@Description	Refused while an application is downloading or deploying.
+//	@Tags			Images
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		types.PruneRequest	false	"Set dry_run to only report what would be removed"
+//	@Success		200		{object}	types.ImagePruneResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		409		{object}	ErrorResponse	"An application is downloading or deploying"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Runtime other than podman"
+//	@Router			/images/prune [post]
+func (h *PruneHandler) PruneImages(c *gin.Context) {
+	var req types.PruneRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.PruneImages(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// PruneVolumes godoc
+//
+//	@Summary		Prune orphaned volumes
+//	@Description	Removes the unused volumes named after an application or component instance that no longer
+//	@Description	exists, such as those kept when an application was deleted. Other volumes are never removed.
+//	@Description	Refused while an application is downloading or deploying.
+//	@Tags			Volumes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		types.PruneRequest	false	"Set dry_run to only report what would be removed"
+//	@Success		200		{object}	types.VolumePruneResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		409		{object}	ErrorResponse	"An application is downloading or deploying"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Runtime other than podman"
+//	@Router			/volumes/prune [post]
+func (h *PruneHandler) PruneVolumes(c *gin.Context) {
+	var req types.PruneRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.PruneVolumes(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// mapServiceError writes a ValidationError with its own status code and any other error as 500.
+func (h *PruneHandler) mapServiceError(c *gin.Context, err error) {
+	if valErr, ok := err.(*validators.ValidationError); ok {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+
+	logger.ErrorfCtx(c.Request.Context(), "Prune request failed: %v", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
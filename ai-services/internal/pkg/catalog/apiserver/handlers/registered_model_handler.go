@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	registeredmodelsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/registeredmodel"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// RegisteredModelHandler handles the registration of custom models.
+type RegisteredModelHandler struct {
+	service registeredmodelsvc.ServiceInterface
+}
+
+// NewRegisteredModelHandler creates a new RegisteredModelHandler backed by the given service.
+func NewRegisteredModelHandler(svc registeredmodelsvc.ServiceInterface) *RegisteredModelHandler {
+	return &RegisteredModelHandler{service: svc}
+}
+
+// RegisterModel godoc
+//
+//	@Summary		Register custom model
+//	@Description	Registers a fine-tuned or private model, either from a directory below the base directory
+//	@Description	of the catalog host or from an OCI artifact or modelcar image. The model files are placed in
+//	@Description	the models directory in the background; poll the model until it is ready. Ready models can
+//	@Description	be selected as the model of the providers of their component type that accept custom models.
+//	@Tags			Models
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		types.RegisterModelRequest	true	"Model name, component type and source"
+//	@Success		202		{object}	types.RegisteredModel
+//	@Failure		400		{object}	ErrorResponse	"Invalid request"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		409		{object}	ErrorResponse	"Model name already in use"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Runtime without a model cache"
+//	@Router			/models/registered [post]
+func (h *RegisteredModelHandler) RegisterModel(c *gin.Context) {
+	var req types.RegisterModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	model, err := h.service.Register(c.Request.Context(), req, c.GetString(middleware.CtxUserIDKey))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, model)
+}
+
+// ListRegisteredModels godoc
+//
+//	@Summary		List custom models
+//	@Description	Lists the registered custom models with their source and status.
+//	@Tags			Models
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	types.RegisteredModelListResponse
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/models/registered [get]
+func (h *RegisteredModelHandler) ListRegisteredModels(c *gin.Context) {
+	resp, err := h.service.List(c.Request.Context())
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetRegisteredModel godoc
+//
+//	@Summary		Get custom model
+//	@Description	Returns a registered custom model. The name may contain a slash, e.g. acme/granite-support.
+//	@Tags			Models
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			name	path		string	true	"Model name"
+//	@Success		200		{object}	types.RegisteredModel
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		404		{object}	ErrorResponse	"Model not registered"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/models/registered/{name} [get]
+func (h *RegisteredModelHandler) GetRegisteredModel(c *gin.Context) {
+	model, err := h.service.Get(c.Request.Context(), modelNameParam(c))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model)
+}
+
+// DeleteRegisteredModel godoc
+//
+//	@Summary		Unregister custom model
+//	@Description	Unregisters a custom model and removes its files from the models directory. Refused while
+//	@Description	the model is being registered or an application component is configured to serve it.
+//	@Tags			Models
+//	@Security		BearerAuth
+//	@Param			name	path	string	true	"Model name"
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized - Invalid or missing access token"
+//	@Failure		404	{object}	ErrorResponse	"Model not registered"
+//	@Failure		409	{object}	ErrorResponse	"Model is registering or in use"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501	{object}	ErrorResponse	"Runtime without a model cache"
+//	@Router			/models/registered/{name} [delete]
+func (h *RegisteredModelHandler) DeleteRegisteredModel(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), modelNameParam(c)); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// modelNameParam returns the model name of the catch-all name path parameter.
+func modelNameParam(c *gin.Context) string {
+	return strings.TrimPrefix(c.Param("name"), "/")
+}
+
+// mapServiceError writes a ValidationError with its own status code and any other error as 500.
+func (h *RegisteredModelHandler) mapServiceError(c *gin.Context, err error) {
+	if valErr, ok := err.(*validators.ValidationError); ok {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+
+	logger.ErrorfCtx(c.Request.Context(), "Registered model request failed: %v", err)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
@@ -6,7 +6,13 @@ type CreateApplicationRequest struct {
 	CatalogID string    `json:"catalog_id" binding:"required"`
 	Version   string    `json:"version" binding:"required"`
 	Services  []Service `json:"services" binding:"required,dive"`
-	CreatedBy string    `json:"-"` // Set from auth context, not from request body
+	// IgnoreCapacity deploys the application even when admission finds that it
+	// does not fit in the remaining host capacity.
+	IgnoreCapacity bool `json:"ignore_capacity,omitempty"`
+	// Autostart generates systemd units that start the pods of the application
+	// when the host boots. Defaults to true; ignored on OpenShift.
+	Autostart *bool  `json:"autostart,omitempty"`
+	CreatedBy string `json:"-"` // Set from auth context, not from request body
 }
 
 // Service represents a service configuration in the application.
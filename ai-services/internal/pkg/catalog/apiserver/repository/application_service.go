@@ -34,6 +34,7 @@ func NewApplicationService(
 	serviceRepo dbrepo.ServiceRepository,
 	componentRepo dbrepo.ComponentRepository,
 	serviceDependencyRepo dbrepo.ServiceDependencyRepository,
+	appImageRepo dbrepo.ApplicationImageRepository,
 	provider *catalog.CatalogProvider,
 	runtimeType runtimeTypes.RuntimeType,
 ) ApplicationServiceInterface {
@@ -42,8 +43,9 @@ func NewApplicationService(
 		ServiceRepo:           serviceRepo,
 		ComponentRepo:         componentRepo,
 		ServiceDependencyRepo: serviceDependencyRepo,
+		AppImageRepo:          appImageRepo,
 		Provider:              provider,
-		DeploymentPlanner:     deployment.NewDeploymentPlanner(provider, componentRepo),
+		DeploymentPlanner:     deployment.NewDeploymentPlanner(provider, appRepo, componentRepo),
 		DeploymentExecutor:    deployment.NewDeploymentExecutor(provider, appRepo, serviceRepo, componentRepo),
 		DeletionExecutor:      deletion.NewDeletionExecutor(appRepo, serviceRepo, componentRepo, serviceDependencyRepo),
 		Validator:             validators.NewApplicationValidator(provider),
@@ -2,6 +2,7 @@ package applicationservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -12,14 +13,17 @@ import (
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deletion"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	deploymenttypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
 	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	consts "github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/policy"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
@@ -85,6 +89,7 @@ type ApplicationServiceBase struct {
 	ServiceRepo           dbrepo.ServiceRepository
 	ComponentRepo         dbrepo.ComponentRepository
 	ServiceDependencyRepo dbrepo.ServiceDependencyRepository
+	AppImageRepo          dbrepo.ApplicationImageRepository
 	Provider              *catalog.CatalogProvider
 	DeploymentPlanner     *deployment.DeploymentPlanner
 	DeploymentExecutor    *deployment.DeploymentExecutor
@@ -288,6 +293,7 @@ func (s *ApplicationServiceBase) loadApplicationServices(ctx context.Context, se
 			Endpoints: service.Endpoints,
 			Version:   service.Version,
 			Status:    string(service.Status),
+			Resources: toResourceLimitsResponse(service.Resources),
 			CreatedAt: service.CreatedAt.Format(constants.RFC3339WithTimezone),
 			UpdatedAt: service.UpdatedAt.Format(constants.RFC3339WithTimezone),
 		}
@@ -309,6 +315,15 @@ func (s *ApplicationServiceBase) loadApplicationServices(ctx context.Context, se
 	return appServices, nil
 }
 
+// toResourceLimitsResponse converts the resources enforced on the pods of a service or component.
+func toResourceLimitsResponse(limits *models.ResourceLimits) *types.ResourceLimits {
+	if limits == nil {
+		return nil
+	}
+
+	return &types.ResourceLimits{CPU: limits.CPU, MemoryBytes: limits.MemoryBytes}
+}
+
 // loadServiceComponents extracts component details from service dependencies.
 func (s *ApplicationServiceBase) loadServiceComponents(ctx context.Context, sd []models.ServiceDependency) ([]types.ServiceComponentResp, error) {
 	components := []types.ServiceComponentResp{}
@@ -343,9 +358,10 @@ func (s *ApplicationServiceBase) loadServiceComponents(ctx context.Context, sd [
 					ID:   component.Provider,
 					Name: providerName,
 				},
-				Status:   string(component.Status),
-				Message:  component.Message,
-				Metadata: component.Metadata,
+				Status:    string(component.Status),
+				Message:   component.Message,
+				Metadata:  component.Metadata,
+				Resources: toResourceLimitsResponse(component.Resources),
 			}
 			components = append(components, temp)
 		}
@@ -445,6 +461,13 @@ func (s *ApplicationServiceBase) InsertDeploymentRecords(
 		return err
 	}
 
+	// 4. Record the images the application is pinned to
+	if len(plan.Images) > 0 {
+		if err := s.AppImageRepo.Insert(ctx, plan.ApplicationID, plan.Images); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -489,12 +512,13 @@ func (s *ApplicationServiceBase) insertComponentRecords(
 		}
 
 		component := &models.Component{
-			ID:       instanceUUID,
-			Type:     comp.ComponentType,
-			Provider: comp.ProviderID,
-			Status:   models.ComponentStatusInitializing,
-			Version:  comp.Version,
-			Metadata: metadata,
+			ID:        instanceUUID,
+			Type:      comp.ComponentType,
+			Provider:  comp.ProviderID,
+			Status:    models.ComponentStatusInitializing,
+			Version:   comp.Version,
+			Metadata:  metadata,
+			Resources: comp.Resources,
 		}
 
 		if err := s.ComponentRepo.Insert(ctx, component); err != nil {
@@ -521,6 +545,7 @@ func (s *ApplicationServiceBase) insertServiceRecords(
 			CatalogID: svc.CatalogID,
 			Status:    models.ServiceStatusInitializing,
 			Version:   svc.Version,
+			Resources: svc.Resources,
 		}
 
 		if err := s.ServiceRepo.Insert(ctx, service); err != nil {
@@ -641,6 +666,21 @@ func (s *ApplicationServiceBase) CreateApplication(ctx context.Context, req apim
 	// Phase 3: create deployment plan
 	plan, err := s.DeploymentPlanner.PlanDeployment(ctx, req, runtimeType.String())
 	if err != nil {
+		var capacityErr *deploymenttypes.ErrInsufficientCapacity
+		if errors.As(err, &capacityErr) {
+			return nil, &ValidationError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: capacityErr.Error(),
+			}
+		}
+		var policyErr *policy.ViolationError
+		if errors.As(err, &policyErr) {
+			return nil, &ValidationError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: policyErr.Error(),
+			}
+		}
+
 		return nil, fmt.Errorf("failed to create deployment plan: %w", err)
 	}
 
@@ -779,10 +819,16 @@ func (s *ApplicationServiceBase) processServiceResources(
 	return nil
 }
 
-func addAllocatedResources(runtimeMetadata *clitemplates.AppMetadata, totals *resourceTotals) {
-	if runtimeMetadata.Resources != nil {
-		totals.allocatedCPU += runtimeMetadata.Resources.CPU
-		totals.allocatedMemory += runtimeMetadata.Resources.Memory
+// addAllocatedResources adds the CPU and memory enforced on the pods of a service
+// or component; entries deployed before the limits were enforced have none and
+// add those of their runtime metadata.
+func addAllocatedResources(limits *models.ResourceLimits, runtimeMetadata *clitemplates.AppMetadata, totals *resourceTotals) {
+	if limits == nil {
+		limits = resources.FromMetadata(runtimeMetadata)
+	}
+	if limits != nil {
+		totals.allocatedCPU += limits.CPU
+		totals.allocatedMemory += int(limits.MemoryBytes)
 	}
 }
 
@@ -797,7 +843,7 @@ func (s *ApplicationServiceBase) addServiceResources(
 		return fmt.Errorf("failed to load service runtime metadata for catalog ID %s: %w", service.CatalogID, err)
 	}
 
-	addAllocatedResources(runtimeMetadata, totals)
+	addAllocatedResources(service.Resources, runtimeMetadata, totals)
 
 	if err := addUsedResourcesByTemplateID(service.ID.String(), runtimeClient, totals); err != nil {
 		return fmt.Errorf("failed to get service used resources: %w", err)
@@ -851,7 +897,7 @@ func (s *ApplicationServiceBase) processComponentResources(
 		return fmt.Errorf("failed to load runtime metadata for component %s/%s: %w", component.Type, component.Provider, err)
 	}
 
-	addAllocatedResources(runtimeMetadata, totals)
+	addAllocatedResources(component.Resources, runtimeMetadata, totals)
 
 	if err := addUsedResourcesByTemplateID(component.ID.String(), runtimeClient, totals); err != nil {
 		return fmt.Errorf("failed to get component used resources for %s: %w", component.ID, err)
@@ -6,18 +6,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/project-ai-services/ai-services/docs" // Import generated docs
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/handlers"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	acceleratorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/accelerator"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	backupsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/backup"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	eventsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	modelcachesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/modelcache"
+	prunesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/prune"
+	registeredmodelsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/registeredmodel"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // CreateRouter sets up the Gin router with the necessary routes and authentication middleware for the API server.
-func CreateRouter(authSvc auth.Service, tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist, appService repository.ApplicationServiceInterface, workerReg *registry.Registry, bundleService bundlesvc.BundleServiceInterface) *gin.Engine {
+func CreateRouter(authSvc auth.Service, tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist, catalogProvider *catalog.CatalogProvider, appService repository.ApplicationServiceInterface, workerReg *registry.Registry, bundleService bundlesvc.BundleServiceInterface, acceleratorService acceleratorsvc.ServiceInterface, backupService backupsvc.ServiceInterface, eventService eventsvc.ServiceInterface, modelService modelcachesvc.ServiceInterface, registeredModelService registeredmodelsvc.ServiceInterface, pruneService prunesvc.ServiceInterface) *gin.Engine {
 	if mode := os.Getenv("GIN_MODE"); mode != "" {
 		gin.SetMode(mode)
 	}
@@ -35,10 +42,15 @@ func CreateRouter(authSvc auth.Service, tokenMgr *auth.TokenManager, blacklist r
 	registerAuthRoutes(v1, handlers.NewAuthHandler(authSvc), tokenMgr, blacklist)
 
 	auth := middleware.AuthMiddleware(tokenMgr, blacklist)
-	registerCatalogRoutes(v1, handlers.NewCatalogHandler(), handlers.NewResourcesHandler(), auth)
+	registerCatalogRoutes(v1, handlers.NewCatalogHandler(catalogProvider), handlers.NewResourcesHandler(), auth)
 	registerApplicationRoutes(v1, handlers.NewApplicationHandler(appService), auth)
 	registerWorkerRoutes(v1, handlers.NewWorkerHandler(workerReg), auth)
 	registerBundleRoutes(v1, handlers.NewBundleHandler(bundleService), auth)
+	registerAcceleratorRoutes(v1, handlers.NewAcceleratorHandler(acceleratorService), auth)
+	registerBackupRoutes(v1, handlers.NewBackupHandler(backupService), auth)
+	registerEventRoutes(v1, handlers.NewEventHandler(eventService), auth)
+	registerModelRoutes(v1, handlers.NewModelHandler(modelService), handlers.NewRegisteredModelHandler(registeredModelService), auth)
+	registerPruneRoutes(v1, handlers.NewPruneHandler(pruneService), auth)
 
 	return router
 }
@@ -112,3 +124,65 @@ func registerWorkerRoutes(v1 *gin.RouterGroup, h *handlers.WorkerHandler, authMw
 		g.DELETE("/:id", h.DeleteWorker)
 	}
 }
+
+func registerAcceleratorRoutes(v1 *gin.RouterGroup, h *handlers.AcceleratorHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("accelerators")
+	g.Use(authMw)
+	{
+		g.GET("", h.ListAccelerators)
+	}
+}
+
+func registerBackupRoutes(v1 *gin.RouterGroup, h *handlers.BackupHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("applications/:id/backups")
+	g.Use(authMw)
+	{
+		g.POST("", h.CreateBackup)
+		g.GET("", h.ListBackups)
+		g.GET("/:backup_id", h.GetBackup)
+		g.GET("/:backup_id/download", h.DownloadBackup)
+		g.POST("/:backup_id/restore", h.RestoreBackup)
+	}
+
+	schedules := v1.Group("applications/:id/backup-schedules")
+	schedules.Use(authMw)
+	{
+		schedules.POST("", h.CreateBackupSchedule)
+		schedules.GET("", h.ListBackupSchedules)
+		schedules.PUT("/:schedule_id", h.UpdateBackupSchedule)
+		schedules.DELETE("/:schedule_id", h.DeleteBackupSchedule)
+	}
+}
+
+func registerEventRoutes(v1 *gin.RouterGroup, h *handlers.EventHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("applications/:id/events")
+	g.Use(authMw)
+	{
+		g.GET("", h.ListEvents)
+	}
+}
+
+func registerModelRoutes(v1 *gin.RouterGroup, h *handlers.ModelHandler, rh *handlers.RegisteredModelHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("models")
+	g.Use(authMw)
+	{
+		g.GET("", h.ListModels)
+		g.POST("/verify", h.VerifyModels)
+		g.POST("/prune", h.PruneModels)
+
+		// Model names contain a slash, hence the catch-all parameter.
+		g.POST("/registered", rh.RegisterModel)
+		g.GET("/registered", rh.ListRegisteredModels)
+		g.GET("/registered/*name", rh.GetRegisteredModel)
+		g.DELETE("/registered/*name", rh.DeleteRegisteredModel)
+	}
+}
+
+func registerPruneRoutes(v1 *gin.RouterGroup, h *handlers.PruneHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("")
+	g.Use(authMw)
+	{
+		g.POST("/images/prune", h.PruneImages)
+		g.POST("/volumes/prune", h.PruneVolumes)
+	}
+}
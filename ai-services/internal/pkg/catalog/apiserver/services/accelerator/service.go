@@ -0,0 +1,283 @@
+// Package accelerator reports the accelerator inventory of the control-plane
+// host and resolves which application, service or component currently holds
+// each card. Cards attached to registered workers are not reported.
+package accelerator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/inventory"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// spyreType is the accelerator type reported for Spyre cards.
+const spyreType = "spyre"
+
+// ServiceInterface is the dependency injected into AcceleratorHandler.
+type ServiceInterface interface {
+	// List returns every accelerator on the local host. When the inventory cannot
+	// be collected the reason is reported in Errors instead of failing the whole
+	// request.
+	List(ctx context.Context) (*catalogtypes.AcceleratorListResponse, error)
+}
+
+// service implements ServiceInterface.
+type service struct {
+	appRepo  repository.ApplicationRepository
+	compRepo repository.ComponentRepository
+}
+
+// NewService creates an accelerator inventory service.
+func NewService(appRepo repository.ApplicationRepository, compRepo repository.ComponentRepository) ServiceInterface {
+	return &service{appRepo: appRepo, compRepo: compRepo}
+}
+
+// hostInventory is the raw inventory of one host before owner resolution.
+type hostInventory struct {
+	host  string
+	cards []inventory.Card
+}
+
+// List implements ServiceInterface.
+func (s *service) List(ctx context.Context) (*catalogtypes.AcceleratorListResponse, error) {
+	resp := &catalogtypes.AcceleratorListResponse{Accelerators: []catalogtypes.Accelerator{}}
+
+	hosts := []hostInventory{}
+	local, err := s.localInventory(ctx)
+	if err != nil {
+		logger.WarningfCtx(ctx, "accelerator inventory: local host: %v", err)
+		resp.Errors = append(resp.Errors, fmt.Sprintf("%s: %v", catalogtypes.LocalHostName, err))
+	} else {
+		hosts = append(hosts, local)
+	}
+
+	owners, err := s.newOwnerResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range hosts {
+		for _, card := range h.cards {
+			acc := toAccelerator(h, card)
+			acc.Owner = owners.resolve(card)
+			if acc.Owner != nil {
+				resp.InUse++
+			}
+			resp.Accelerators = append(resp.Accelerators, acc)
+		}
+	}
+
+	sort.SliceStable(resp.Accelerators, func(i, j int) bool {
+		a, b := resp.Accelerators[i], resp.Accelerators[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+
+		return a.PCIAddress < b.PCIAddress
+	})
+	resp.Total = len(resp.Accelerators)
+
+	return resp, nil
+}
+
+// localInventory collects the cards attached to the control-plane host and
+// annotates them with the pods currently holding them.
+func (s *service) localInventory(ctx context.Context) (hostInventory, error) {
+	h := hostInventory{host: catalogtypes.LocalHostName}
+
+	rt, err := vars.RuntimeFactory.Create("")
+	if err != nil {
+		return h, fmt.Errorf("failed to create runtime client: %w", err)
+	}
+
+	// On OpenShift the cards live on cluster nodes, not on the host running the
+	// API server; only the allocations visible through pods can be reported.
+	if rt.Type() == runtimeTypes.RuntimeTypePodman {
+		cards, err := inventory.Discover(ctx)
+		if err != nil {
+			return h, err
+		}
+		h.cards = cards
+	}
+
+	allocations, err := podAllocations(ctx, rt)
+	if err != nil {
+		return h, err
+	}
+	h.cards = mergeAllocations(h.cards, allocations)
+
+	return h, nil
+}
+
+// podAllocation is a card held by a catalog-managed pod.
+type podAllocation struct {
+	application string
+	templateID  string
+}
+
+// podAllocations maps PCI addresses to the catalog-managed pod holding them.
+func podAllocations(ctx context.Context, rt runtime.Runtime) (map[string]podAllocation, error) {
+	pods, err := rt.ListPods(map[string][]string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	allocations := make(map[string]podAllocation)
+	for _, pod := range pods {
+		templateID, ok := pod.Labels[constants.ApplicationTemplateKey]
+		if !ok {
+			continue
+		}
+
+		resources, err := rt.GetPodResources(pod.Name)
+		if err != nil {
+			// A pod that disappears or cannot be inspected should not hide the rest of the inventory.
+			logger.WarningfCtx(ctx, "accelerator inventory: failed to get resources for pod %s: %v", pod.Name, err)
+
+			continue
+		}
+
+		for _, addr := range resources.SpyreCards {
+			allocations[addr] = podAllocation{
+				application: pod.Labels[constants.ApplicationAnnotationKey],
+				templateID:  templateID,
+			}
+		}
+	}
+
+	return allocations, nil
+}
+
+// mergeAllocations attaches pod allocations to discovered cards. Allocated
+// addresses that were not discovered (e.g. on OpenShift) are appended with
+// unknown topology so that every held card is still reported.
+func mergeAllocations(cards []inventory.Card, allocations map[string]podAllocation) []inventory.Card {
+	seen := make(map[string]bool, len(cards))
+	for i := range cards {
+		seen[cards[i].PCIAddress] = true
+		if alloc, ok := allocations[cards[i].PCIAddress]; ok {
+			cards[i].Application = alloc.application
+			cards[i].TemplateID = alloc.templateID
+		}
+	}
+
+	for addr, alloc := range allocations {
+		if seen[addr] {
+			continue
+		}
+		cards = append(cards, inventory.Card{
+			PCIAddress:  addr,
+			NUMANode:    inventory.UnknownNUMANode,
+			Health:      inventory.HealthHealthy,
+			Application: alloc.application,
+			TemplateID:  alloc.templateID,
+		})
+	}
+
+	return cards
+}
+
+func toAccelerator(h hostInventory, card inventory.Card) catalogtypes.Accelerator {
+	return catalogtypes.Accelerator{
+		Host:       h.host,
+		Type:       spyreType,
+		PCIAddress: card.PCIAddress,
+		NUMANode:   card.NUMANode,
+		Driver:     card.Driver,
+		VFIOBound:  card.VFIOBound,
+		Health:     string(card.Health),
+		Problems:   card.Problems,
+	}
+}
+
+// ownerResolver maps pod template IDs to the services and components stored in the DB.
+type ownerResolver struct {
+	services   map[uuid.UUID]*catalogtypes.AcceleratorOwner
+	components map[uuid.UUID]models.Component
+	appsByName map[string]models.Application
+}
+
+func (s *service) newOwnerResolver(ctx context.Context) (*ownerResolver, error) {
+	apps, err := s.appRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	components, err := s.compRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+
+	r := &ownerResolver{
+		services:   make(map[uuid.UUID]*catalogtypes.AcceleratorOwner),
+		components: make(map[uuid.UUID]models.Component, len(components)),
+		appsByName: make(map[string]models.Application, len(apps)),
+	}
+
+	for _, app := range apps {
+		r.appsByName[app.Name] = app
+		for _, svc := range app.Services {
+			r.services[svc.ID] = &catalogtypes.AcceleratorOwner{
+				ApplicationID:   app.ID.String(),
+				ApplicationName: app.Name,
+				Kind:            catalogtypes.AcceleratorOwnerService,
+				ID:              svc.ID.String(),
+				Name:            svc.CatalogID,
+			}
+		}
+	}
+
+	for _, comp := range components {
+		r.components[comp.ID] = comp
+	}
+
+	return r, nil
+}
+
+// resolve returns the owner of a card, or nil when the card is free.
+// Cards held by pods that are not tracked in the DB still report the pod labels.
+func (r *ownerResolver) resolve(card inventory.Card) *catalogtypes.AcceleratorOwner {
+	if card.TemplateID == "" && card.Application == "" {
+		return nil
+	}
+
+	id, err := uuid.Parse(card.TemplateID)
+	if err == nil {
+		if owner, ok := r.services[id]; ok {
+			resolved := *owner
+
+			return &resolved
+		}
+
+		if comp, ok := r.components[id]; ok {
+			owner := &catalogtypes.AcceleratorOwner{
+				ApplicationName: card.Application,
+				Kind:            catalogtypes.AcceleratorOwnerComponent,
+				ID:              comp.ID.String(),
+				Name:            fmt.Sprintf("%s/%s", comp.Type, comp.Provider),
+			}
+			if app, ok := r.appsByName[card.Application]; ok {
+				owner.ApplicationID = app.ID.String()
+			}
+
+			return owner
+		}
+	}
+
+	owner := &catalogtypes.AcceleratorOwner{ApplicationName: card.Application, ID: card.TemplateID}
+	if app, ok := r.appsByName[card.Application]; ok {
+		owner.ApplicationID = app.ID.String()
+	}
+
+	return owner
+}
@@ -0,0 +1,83 @@
+package accelerator
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/inventory"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+)
+
+func TestMergeAllocations(t *testing.T) {
+	cards := []inventory.Card{
+		{PCIAddress: "0182:60:00.0", NUMANode: 0, Driver: inventory.VFIODriver, VFIOBound: true, Health: inventory.HealthHealthy},
+		{PCIAddress: "0183:70:00.0", NUMANode: 1, Driver: inventory.VFIODriver, VFIOBound: true, Health: inventory.HealthHealthy},
+	}
+	allocations := map[string]podAllocation{
+		"0182:60:00.0": {application: "rag-dev", templateID: "tmpl-1"},
+		"0190:10:00.0": {application: "rag-dev", templateID: "tmpl-2"},
+	}
+
+	merged := mergeAllocations(cards, allocations)
+	require.Len(t, merged, 3)
+
+	assert.Equal(t, "rag-dev", merged[0].Application)
+	assert.Equal(t, "tmpl-1", merged[0].TemplateID)
+	assert.Empty(t, merged[1].TemplateID, "unallocated card must stay free")
+
+	// Allocated but undiscovered cards are still reported.
+	assert.Equal(t, "0190:10:00.0", merged[2].PCIAddress)
+	assert.Equal(t, inventory.UnknownNUMANode, merged[2].NUMANode)
+	assert.Equal(t, "tmpl-2", merged[2].TemplateID)
+}
+
+func TestOwnerResolver(t *testing.T) {
+	appID, svcID, compID := uuid.New(), uuid.New(), uuid.New()
+	app := models.Application{
+		ID:       appID,
+		Name:     "rag-dev",
+		Services: []models.Service{{ID: svcID, AppID: appID, CatalogID: "chat"}},
+	}
+
+	r := &ownerResolver{
+		services: map[uuid.UUID]*catalogtypes.AcceleratorOwner{
+			svcID: {ApplicationID: appID.String(), ApplicationName: app.Name, Kind: catalogtypes.AcceleratorOwnerService, ID: svcID.String(), Name: "chat"},
+		},
+		components: map[uuid.UUID]models.Component{
+			compID: {ID: compID, Type: "llm", Provider: "vllm-spyre"},
+		},
+		appsByName: map[string]models.Application{app.Name: app},
+	}
+
+	t.Run("free card", func(t *testing.T) {
+		assert.Nil(t, r.resolve(inventory.Card{PCIAddress: "0182:60:00.0"}))
+	})
+
+	t.Run("service", func(t *testing.T) {
+		owner := r.resolve(inventory.Card{Application: "rag-dev", TemplateID: svcID.String()})
+		require.NotNil(t, owner)
+		assert.Equal(t, catalogtypes.AcceleratorOwnerService, owner.Kind)
+		assert.Equal(t, "chat", owner.Name)
+		assert.Equal(t, appID.String(), owner.ApplicationID)
+	})
+
+	t.Run("component", func(t *testing.T) {
+		owner := r.resolve(inventory.Card{Application: "rag-dev", TemplateID: compID.String()})
+		require.NotNil(t, owner)
+		assert.Equal(t, catalogtypes.AcceleratorOwnerComponent, owner.Kind)
+		assert.Equal(t, "llm/vllm-spyre", owner.Name)
+		assert.Equal(t, appID.String(), owner.ApplicationID)
+	})
+
+	t.Run("untracked pod", func(t *testing.T) {
+		owner := r.resolve(inventory.Card{Application: "legacy-app", TemplateID: "legacy-app"})
+		require.NotNil(t, owner)
+		assert.Empty(t, owner.Kind)
+		assert.Equal(t, "legacy-app", owner.ApplicationName)
+		assert.Empty(t, owner.ApplicationID)
+	})
+}
@@ -0,0 +1,223 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+const (
+	backupDirPermission = 0o750
+	progressComplete    = 100
+)
+
+// start inserts the job and runs it in the background. Only one job per
+// application runs at a time; a second request is rejected with 409. Restore
+// jobs restore OpenSearch indices as indices maps them.
+func (s *service) start(ctx context.Context, app *catalogtypes.Application, job *models.BackupJob, file string, indices opensearch.IndexMapping) (*catalogtypes.BackupJob, error) {
+	appID := *job.AppID
+	if !s.acquire(appID) {
+		return nil, &validators.ValidationError{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("a backup or restore of application %s is already in progress", app.Name),
+		}
+	}
+
+	if err := s.jobRepo.Insert(ctx, job); err != nil {
+		s.release(appID)
+
+		return nil, err
+	}
+
+	jobCtx := context.Background()
+	if requestID, ok := ctx.Value(logger.RequestIDKey).(string); ok && requestID != "" {
+		jobCtx = context.WithValue(jobCtx, logger.RequestIDKey, requestID)
+	}
+
+	go s.run(jobCtx, app, *job, file, indices)
+
+	resp := toResponse(job)
+
+	return &resp, nil
+}
+
+// run executes a backup or restore job and records its outcome. The application
+// is released before the final status is written so a client that sees the job
+// finished can start the next one right away.
+func (s *service) run(ctx context.Context, app *catalogtypes.Application, job models.BackupJob, file string, indices opensearch.IndexMapping) {
+	err := s.runJob(ctx, app, &job, file, indices)
+	s.release(*job.AppID)
+	s.finish(ctx, &job, file, err)
+}
+
+// runJob marks the job running and executes it, converting a panic into an error.
+func (s *service) runJob(ctx context.Context, app *catalogtypes.Application, job *models.BackupJob, file string, indices opensearch.IndexMapping) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in %s job %s: %v", job.Type, job.ID, r)
+			err = fmt.Errorf("%s panic: %v", job.Type, r)
+		}
+	}()
+
+	running := models.BackupJobStatusRunning
+	s.update(ctx, job.ID, models.BackupJobUpdate{Status: &running})
+	logger.InfofCtx(ctx, "Starting %s job %s of target %s for application %s", job.Type, job.ID, job.Target, app.Name)
+
+	req, err := s.targetRequest(ctx, app, job.ID)
+	if err != nil {
+		return err
+	}
+	req.Indices = indices
+
+	return s.execute(ctx, req, job, file)
+}
+
+// execute runs the backup or restore hooks of the job's target.
+func (s *service) execute(ctx context.Context, req backuptarget.Request, job *models.BackupJob, file string) error {
+	if job.Type == models.BackupJobTypeRestore {
+		return backuptarget.Restore(ctx, s.runtimeType, req, job.Target, file)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), backupDirPermission); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	if err := backuptarget.Backup(ctx, s.runtimeType, req, job.Target, file); err != nil {
+		_ = os.Remove(file) // best-effort cleanup of a partial archive
+
+		return err
+	}
+
+	return nil
+}
+
+// targetRequest builds the backup target request for the application. OpenShift
+// applications are addressed by their namespace.
+func (s *service) targetRequest(ctx context.Context, app *catalogtypes.Application, jobID uuid.UUID) (backuptarget.Request, error) {
+	appID, err := uuid.Parse(app.ID)
+	if err != nil {
+		return backuptarget.Request{}, fmt.Errorf("invalid application ID: %w", err)
+	}
+
+	appName, namespace := app.Name, ""
+	if s.runtimeType == runtimeTypes.RuntimeTypeOpenShift {
+		namespace = catalogutils.AppNamespace(appID)
+		appName = namespace
+	}
+
+	rt, err := s.newRuntime(namespace)
+	if err != nil {
+		return backuptarget.Request{}, fmt.Errorf("failed to create runtime client: %w", err)
+	}
+
+	return backuptarget.Request{
+		AppName: appName,
+		Runtime: rt,
+		App:     app,
+		Progress: func(target string, done, total int) {
+			progress := done * progressComplete / total
+			message := fmt.Sprintf("Processed target %s (%d/%d)", target, done, total)
+			s.update(ctx, jobID, models.BackupJobUpdate{Progress: &progress, Message: &message})
+		},
+	}, nil
+}
+
+// finish marks the job completed or failed depending on err.
+func (s *service) finish(ctx context.Context, job *models.BackupJob, file string, err error) {
+	now := time.Now()
+	upd := models.BackupJobUpdate{CompletedAt: &now}
+
+	if err != nil {
+		logger.ErrorfCtx(ctx, "%s job %s for application %s failed: %v", job.Type, job.ID, job.AppName, err)
+
+		status, message := models.BackupJobStatusFailed, err.Error()
+		upd.Status, upd.Message = &status, &message
+		s.update(ctx, job.ID, upd)
+		s.recordOutcome(ctx, job, err)
+
+		return
+	}
+
+	status, progress := models.BackupJobStatusCompleted, progressComplete
+	message := fmt.Sprintf("%s of target %s completed successfully", job.Type, job.Target)
+	upd.Status, upd.Progress, upd.Message = &status, &progress, &message
+
+	if job.Type == models.BackupJobTypeBackup {
+		if info, statErr := os.Stat(file); statErr == nil {
+			size := info.Size()
+			upd.SizeBytes = &size
+		}
+	}
+
+	s.update(ctx, job.ID, upd)
+	logger.InfofCtx(ctx, "%s job %s for application %s completed", job.Type, job.ID, job.AppName)
+	s.recordOutcome(ctx, job, nil)
+}
+
+// recordOutcome reports a finished job as an application event and, for scheduled
+// backups, on the schedule.
+func (s *service) recordOutcome(ctx context.Context, job *models.BackupJob, err error) {
+	if job.ScheduleID != nil {
+		s.scheduledRunFinished(ctx, *job.ScheduleID, *job.AppID, err)
+		if err != nil {
+			// scheduledRunFinished already recorded the failure.
+			return
+		}
+	}
+
+	kind := "Backup"
+	if job.Type == models.BackupJobTypeRestore {
+		kind = "Restore"
+	}
+
+	if err != nil {
+		s.events.Record(ctx, *job.AppID, models.ApplicationEventWarning, kind+"Failed",
+			fmt.Sprintf("%s job %s of target %s failed: %v", job.Type, job.ID, job.Target, err))
+
+		return
+	}
+
+	s.events.Record(ctx, *job.AppID, models.ApplicationEventNormal, kind+"Completed",
+		fmt.Sprintf("%s job %s of target %s completed", job.Type, job.ID, job.Target))
+}
+
+// update writes a job update, logging instead of failing the job on DB errors.
+func (s *service) update(ctx context.Context, id uuid.UUID, upd models.BackupJobUpdate) {
+	if err := s.jobRepo.Update(ctx, id, upd); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to update backup job %s: %v", id, err)
+	}
+}
+
+// acquire reserves the application for a job. It returns false if a job is already running.
+func (s *service) acquire(appID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.busy[appID] {
+		return false
+	}
+	s.busy[appID] = true
+
+	return true
+}
+
+// release frees the application for the next job.
+func (s *service) release(appID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.busy, appID)
+}
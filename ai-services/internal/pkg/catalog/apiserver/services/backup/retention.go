@@ -0,0 +1,57 @@
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// expiredBackups returns the backups that no retention rule selects. backups must
+// be ordered newest first. A policy with every rule 0 expires nothing.
+func expiredBackups(backups []models.BackupJob, policy models.BackupRetention) []models.BackupJob {
+	if policy.KeepLast == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 {
+		return nil
+	}
+
+	keep := make([]bool, len(backups))
+	for i := 0; i < len(backups) && i < policy.KeepLast; i++ {
+		keep[i] = true
+	}
+
+	keepNewestPerPeriod(backups, keep, policy.KeepDaily, func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	})
+	keepNewestPerPeriod(backups, keep, policy.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	var expired []models.BackupJob
+	for i, b := range backups {
+		if !keep[i] {
+			expired = append(expired, b)
+		}
+	}
+
+	return expired
+}
+
+// keepNewestPerPeriod marks the newest backup of each of the n most recent periods
+// that contain a backup. Periods are evaluated in the server's local time.
+func keepNewestPerPeriod(backups []models.BackupJob, keep []bool, n int, period func(time.Time) string) {
+	seen := map[string]bool{}
+
+	for i, b := range backups {
+		if len(seen) == n {
+			return
+		}
+
+		p := period(b.CreatedAt.Local())
+		if !seen[p] {
+			seen[p] = true
+			keep[i] = true
+		}
+	}
+}
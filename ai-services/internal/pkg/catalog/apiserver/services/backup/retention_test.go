@@ -0,0 +1,40 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	// Newest first: two backups today, one yesterday, one a week ago and one two weeks ago.
+	now := time.Date(2026, time.October, 14, 18, 0, 0, 0, time.Local)
+	ages := []time.Duration{0, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, 14 * 24 * time.Hour}
+	backups := make([]models.BackupJob, len(ages))
+	for i, age := range ages {
+		backups[i] = models.BackupJob{ID: uuid.New(), CreatedAt: now.Add(-age)}
+	}
+
+	expiredIdx := func(policy models.BackupRetention) []int {
+		var idx []int
+		for _, e := range expiredBackups(backups, policy) {
+			for i, b := range backups {
+				if b.ID == e.ID {
+					idx = append(idx, i)
+				}
+			}
+		}
+
+		return idx
+	}
+
+	assert.Empty(t, expiredIdx(models.BackupRetention{}), "a policy without rules keeps everything")
+	assert.Equal(t, []int{2, 3, 4}, expiredIdx(models.BackupRetention{KeepLast: 2}))
+	assert.Equal(t, []int{1, 3, 4}, expiredIdx(models.BackupRetention{KeepDaily: 2}))
+	assert.Equal(t, []int{1, 2, 4}, expiredIdx(models.BackupRetention{KeepWeekly: 2}))
+	assert.Equal(t, []int{4}, expiredIdx(models.BackupRetention{KeepLast: 2, KeepDaily: 2, KeepWeekly: 2}))
+}
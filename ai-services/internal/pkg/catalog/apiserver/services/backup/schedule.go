@@ -0,0 +1,395 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils/cron"
+)
+
+const (
+	// DefaultScheduleInterval is how often the scheduler looks for due backup schedules.
+	DefaultScheduleInterval = time.Minute
+
+	// schedulerUser is recorded as the creator of scheduled backup jobs.
+	schedulerUser = "scheduler"
+
+	reasonScheduledBackupFailed = "ScheduledBackupFailed"
+	reasonBackupsPruned         = "BackupsPruned"
+)
+
+// CreateSchedule implements ServiceInterface.
+func (s *service) CreateSchedule(ctx context.Context, appID uuid.UUID, req catalogtypes.CreateBackupScheduleRequest, userID string) (*catalogtypes.BackupSchedule, error) {
+	if _, err := s.apps.GetApplicationByID(ctx, appID); err != nil {
+		return nil, err
+	}
+
+	sched := &models.BackupSchedule{
+		AppID:     appID,
+		Target:    req.Target,
+		CronExpr:  req.Cron,
+		Retention: models.BackupRetention(req.Retention),
+		Enabled:   req.Enabled == nil || *req.Enabled,
+		CreatedBy: userID,
+	}
+	if sched.Target == "" {
+		sched.Target = catalogtypes.BackupTargetAll
+	}
+
+	next, err := s.validateSchedule(sched)
+	if err != nil {
+		return nil, err
+	}
+	if sched.Enabled {
+		sched.NextRunAt = &next
+	}
+
+	if err := s.scheduleRepo.Insert(ctx, sched); err != nil {
+		return nil, err
+	}
+
+	resp := toScheduleResponse(sched)
+
+	return &resp, nil
+}
+
+// ListSchedules implements ServiceInterface.
+func (s *service) ListSchedules(ctx context.Context, appID uuid.UUID) (*catalogtypes.BackupScheduleListResponse, error) {
+	if _, err := s.apps.GetApplicationByID(ctx, appID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.scheduleRepo.ListByApp(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.BackupScheduleListResponse{Schedules: make([]catalogtypes.BackupSchedule, 0, len(schedules)), Total: len(schedules)}
+	for i := range schedules {
+		resp.Schedules = append(resp.Schedules, toScheduleResponse(&schedules[i]))
+	}
+
+	return resp, nil
+}
+
+// UpdateSchedule implements ServiceInterface.
+func (s *service) UpdateSchedule(ctx context.Context, appID, scheduleID uuid.UUID, req catalogtypes.UpdateBackupScheduleRequest) (*catalogtypes.BackupSchedule, error) {
+	sched, err := s.appSchedule(ctx, appID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	upd := models.BackupScheduleUpdate{Target: req.Target, CronExpr: req.Cron, Enabled: req.Enabled}
+	if req.Target != nil {
+		sched.Target = *req.Target
+	}
+	if req.Cron != nil {
+		sched.CronExpr = *req.Cron
+	}
+	if req.Retention != nil {
+		sched.Retention = models.BackupRetention(*req.Retention)
+		upd.Retention = &sched.Retention
+	}
+	if req.Enabled != nil {
+		sched.Enabled = *req.Enabled
+	}
+
+	next, err := s.validateSchedule(sched)
+	if err != nil {
+		return nil, err
+	}
+	if sched.Enabled {
+		upd.NextRunAt = &next
+	} else {
+		upd.ClearNextRun = true
+	}
+
+	if err := s.scheduleRepo.Update(ctx, scheduleID, upd); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.appSchedule(ctx, appID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toScheduleResponse(updated)
+
+	return &resp, nil
+}
+
+// DeleteSchedule implements ServiceInterface. Backups created by the schedule are kept.
+func (s *service) DeleteSchedule(ctx context.Context, appID, scheduleID uuid.UUID) error {
+	if _, err := s.appSchedule(ctx, appID, scheduleID); err != nil {
+		return err
+	}
+
+	return s.scheduleRepo.Delete(ctx, scheduleID)
+}
+
+// RunDueSchedules implements ServiceInterface.
+func (s *service) RunDueSchedules(ctx context.Context, now time.Time) {
+	schedules, err := s.scheduleRepo.ListDue(ctx, now)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to list due backup schedules: %v", err)
+
+		return
+	}
+
+	for i := range schedules {
+		s.runSchedule(ctx, &schedules[i], now)
+	}
+}
+
+// runSchedule advances the schedule to its next activation and starts its backup.
+// Runs that cannot start are recorded on the schedule and as a Warning event.
+func (s *service) runSchedule(ctx context.Context, sched *models.BackupSchedule, now time.Time) {
+	upd := models.BackupScheduleUpdate{LastRunAt: &now}
+	if next := s.nextRun(sched, now); next.IsZero() {
+		upd.ClearNextRun = true
+	} else {
+		upd.NextRunAt = &next
+	}
+	if err := s.scheduleRepo.Update(ctx, sched.ID, upd); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to advance backup schedule %s: %v", sched.ID, err)
+
+		return
+	}
+
+	logger.InfofCtx(ctx, "Running backup schedule %s (%s) of target %s for application %s", sched.ID, sched.CronExpr, sched.Target, sched.AppID)
+
+	if err := s.startScheduled(ctx, sched); err != nil {
+		s.scheduledRunFinished(ctx, sched.ID, sched.AppID, fmt.Errorf("scheduled backup could not start: %w", err))
+	}
+}
+
+// startScheduled starts the backup job of a schedule.
+func (s *service) startScheduled(ctx context.Context, sched *models.BackupSchedule) error {
+	app, err := s.runnableApp(ctx, sched.AppID)
+	if err != nil {
+		return err
+	}
+
+	job := &models.BackupJob{
+		AppID:      &sched.AppID,
+		AppName:    app.Name,
+		Type:       models.BackupJobTypeBackup,
+		Target:     sched.Target,
+		FileName:   archiveName(sched.AppID, app.Name, sched.Target),
+		ScheduleID: &sched.ID,
+		CreatedBy:  schedulerUser,
+	}
+
+	_, err = s.start(ctx, app, job, s.archiveFile(job), opensearch.IndexMapping{})
+
+	return err
+}
+
+// scheduledRunFinished records the outcome of a scheduled run on its schedule and,
+// after a successful backup, prunes the schedule's backups per its retention policy.
+func (s *service) scheduledRunFinished(ctx context.Context, scheduleID, appID uuid.UUID, runErr error) {
+	status, lastError := models.BackupJobStatusCompleted, ""
+	if runErr != nil {
+		status, lastError = models.BackupJobStatusFailed, errorMessage(runErr)
+	}
+
+	if err := s.scheduleRepo.Update(ctx, scheduleID, models.BackupScheduleUpdate{LastStatus: &status, LastError: &lastError}); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to record run of backup schedule %s: %v", scheduleID, err)
+	}
+
+	if runErr != nil {
+		s.events.Record(ctx, appID, models.ApplicationEventWarning, reasonScheduledBackupFailed,
+			fmt.Sprintf("Backup schedule %s failed: %s", scheduleID, lastError))
+
+		return
+	}
+
+	s.applyRetention(ctx, scheduleID, appID)
+}
+
+// applyRetention deletes the archives and job rows of the schedule's expired backups.
+func (s *service) applyRetention(ctx context.Context, scheduleID, appID uuid.UUID) {
+	sched, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil || sched == nil {
+		return
+	}
+
+	backups, err := s.jobRepo.ListCompletedBySchedule(ctx, scheduleID)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to list backups of schedule %s: %v", scheduleID, err)
+
+		return
+	}
+
+	pruned := 0
+	for _, b := range expiredBackups(backups, sched.Retention) {
+		if err := os.Remove(s.archiveFile(&b)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.WarningfCtx(ctx, "Failed to remove expired backup archive %s: %v", b.FileName, err)
+
+			continue
+		}
+		if err := s.jobRepo.Delete(ctx, b.ID); err != nil {
+			logger.WarningfCtx(ctx, "Failed to delete expired backup job %s: %v", b.ID, err)
+
+			continue
+		}
+		pruned++
+	}
+
+	if pruned > 0 {
+		logger.InfofCtx(ctx, "Pruned %d backup(s) of schedule %s", pruned, scheduleID)
+		s.events.Record(ctx, appID, models.ApplicationEventNormal, reasonBackupsPruned,
+			fmt.Sprintf("Pruned %d backup(s) of schedule %s per its retention policy", pruned, scheduleID))
+	}
+}
+
+// validateSchedule checks the target, cron expression and retention of a schedule
+// and returns its next activation.
+func (s *service) validateSchedule(sched *models.BackupSchedule) (time.Time, error) {
+	if err := s.validateTarget(sched.Target, backuptarget.HookBackup); err != nil {
+		return time.Time{}, err
+	}
+
+	r := sched.Retention
+	if r.KeepLast < 0 || r.KeepDaily < 0 || r.KeepWeekly < 0 {
+		return time.Time{}, &validators.ValidationError{Code: http.StatusBadRequest, Message: "retention counts must not be negative"}
+	}
+
+	c, err := cron.Parse(sched.CronExpr)
+	if err != nil {
+		return time.Time{}, &validators.ValidationError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
+	next := c.Next(time.Now())
+	if next.IsZero() {
+		return time.Time{}, &validators.ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("cron expression %q never runs", sched.CronExpr),
+		}
+	}
+
+	return next, nil
+}
+
+// nextRun returns the activation of the schedule after now, or the zero time if
+// its expression can no longer be parsed or never runs again.
+func (s *service) nextRun(sched *models.BackupSchedule, now time.Time) time.Time {
+	c, err := cron.Parse(sched.CronExpr)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return c.Next(now)
+}
+
+// appSchedule returns the schedule if it belongs to the application.
+func (s *service) appSchedule(ctx context.Context, appID, scheduleID uuid.UUID) (*models.BackupSchedule, error) {
+	sched, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sched == nil || sched.AppID != appID {
+		return nil, &validators.ValidationError{Code: http.StatusNotFound, Message: "backup schedule not found"}
+	}
+
+	return sched, nil
+}
+
+// errorMessage returns the message of a ValidationError or the error text.
+func errorMessage(err error) string {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		return valErr.Message
+	}
+
+	return err.Error()
+}
+
+// toScheduleResponse converts a backup_schedules row to its API representation.
+func toScheduleResponse(sched *models.BackupSchedule) catalogtypes.BackupSchedule {
+	resp := catalogtypes.BackupSchedule{
+		ID:            sched.ID.String(),
+		ApplicationID: sched.AppID.String(),
+		Target:        sched.Target,
+		Cron:          sched.CronExpr,
+		Retention:     catalogtypes.BackupRetention(sched.Retention),
+		Enabled:       sched.Enabled,
+		LastError:     sched.LastError,
+		CreatedBy:     sched.CreatedBy,
+		CreatedAt:     sched.CreatedAt.Format(constants.RFC3339WithTimezone),
+		UpdatedAt:     sched.UpdatedAt.Format(constants.RFC3339WithTimezone),
+	}
+	if sched.NextRunAt != nil {
+		resp.NextRunAt = sched.NextRunAt.Format(constants.RFC3339WithTimezone)
+	}
+	if sched.LastRunAt != nil {
+		resp.LastRunAt = sched.LastRunAt.Format(constants.RFC3339WithTimezone)
+	}
+	if sched.LastStatus != nil {
+		resp.LastStatus = string(*sched.LastStatus)
+	}
+
+	return resp
+}
+
+// Scheduler periodically starts the backups of due schedules.
+type Scheduler struct {
+	service  ServiceInterface
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewScheduler creates a scheduler that checks for due schedules every interval
+// (DefaultScheduleInterval when zero).
+func NewScheduler(svc ServiceInterface, interval time.Duration) *Scheduler {
+	if interval == 0 {
+		interval = DefaultScheduleInterval
+	}
+
+	return &Scheduler{service: svc, interval: interval, stopChan: make(chan struct{})}
+}
+
+// Start begins the scheduler goroutine.
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.loop(ctx)
+	logger.InfolnCtx(ctx, "Backup scheduler started")
+}
+
+// Stop gracefully stops the scheduler goroutine. Running jobs are not interrupted.
+func (s *Scheduler) Stop(ctx context.Context) {
+	close(s.stopChan)
+	logger.InfolnCtx(ctx, "Backup scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in backup scheduler goroutine: %v", r)
+		}
+	}()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.service.RunDueSchedules(ctx, now)
+		case <-s.stopChan:
+			return
+		}
+	}
+}
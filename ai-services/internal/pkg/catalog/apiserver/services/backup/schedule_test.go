@@ -0,0 +1,126 @@
+package backup
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// dueSchedule creates a schedule through the service and makes it due.
+func dueSchedule(t *testing.T, s *service, appID uuid.UUID, retention catalogtypes.BackupRetention) uuid.UUID {
+	t.Helper()
+
+	created, err := s.CreateSchedule(context.Background(), appID, catalogtypes.CreateBackupScheduleRequest{
+		Target:    "opensearch",
+		Cron:      "@daily",
+		Retention: retention,
+	}, "admin")
+	require.NoError(t, err)
+	require.NotEmpty(t, created.NextRunAt)
+
+	id := uuid.MustParse(created.ID)
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, s.scheduleRepo.Update(context.Background(), id, models.BackupScheduleUpdate{NextRunAt: &past}))
+
+	return id
+}
+
+func TestRunDueSchedulesPrunesExpiredBackups(t *testing.T) {
+	s, target, appID := newTestService(t, models.ApplicationStatusRunning)
+	ctx := context.Background()
+	scheduleID := dueSchedule(t, s, appID, catalogtypes.BackupRetention{KeepLast: 1})
+
+	// An older backup of the same schedule, which keep_last=1 expires once the next one completes.
+	old := &models.BackupJob{
+		AppID: &appID, AppName: "rag-dev", Type: models.BackupJobTypeBackup, Target: "opensearch",
+		FileName: filepath.Join(appID.String(), "old.tar.gz"), ScheduleID: &scheduleID,
+	}
+	require.NoError(t, s.jobRepo.Insert(ctx, old))
+	completed := models.BackupJobStatusCompleted
+	require.NoError(t, s.jobRepo.Update(ctx, old.ID, models.BackupJobUpdate{Status: &completed}))
+	s.jobRepo.(*fakeJobRepo).jobs[old.ID].CreatedAt = time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.MkdirAll(filepath.Dir(s.archiveFile(old)), backupDirPermission))
+	require.NoError(t, os.WriteFile(s.archiveFile(old), []byte("old"), 0o600))
+
+	close(target.release)
+	s.RunDueSchedules(ctx, time.Now())
+
+	events := s.events.(*fakeEvents)
+	require.Eventually(t, func() bool { return len(events.reasons()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{reasonBackupsPruned, "BackupCompleted"}, events.reasons())
+
+	backups, err := s.jobRepo.ListCompletedBySchedule(ctx, scheduleID)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.NotEqual(t, old.ID, backups[0].ID)
+	assert.Equal(t, schedulerUser, backups[0].CreatedBy)
+	assert.NoFileExists(t, s.archiveFile(old))
+	assert.FileExists(t, s.archiveFile(&backups[0]))
+
+	sched, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	require.NoError(t, err)
+	require.NotNil(t, sched.LastStatus)
+	assert.Equal(t, models.BackupJobStatusCompleted, *sched.LastStatus)
+	require.NotNil(t, sched.NextRunAt)
+	assert.True(t, sched.NextRunAt.After(time.Now()), "next run advances past now")
+
+	// The schedule is no longer due.
+	s.RunDueSchedules(ctx, time.Now())
+	list, err := s.ListJobs(ctx, appID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+}
+
+func TestRunDueSchedulesRecordsFailures(t *testing.T) {
+	s, _, appID := newTestService(t, models.ApplicationStatusDeploying)
+	ctx := context.Background()
+	scheduleID := dueSchedule(t, s, appID, catalogtypes.BackupRetention{})
+
+	s.RunDueSchedules(ctx, time.Now())
+
+	sched, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	require.NoError(t, err)
+	require.NotNil(t, sched.LastStatus)
+	assert.Equal(t, models.BackupJobStatusFailed, *sched.LastStatus)
+	assert.NotEmpty(t, sched.LastError)
+
+	events := s.events.(*fakeEvents)
+	require.Len(t, events.events, 1)
+	assert.Equal(t, models.ApplicationEventWarning, events.events[0].Type)
+	assert.Equal(t, reasonScheduledBackupFailed, events.events[0].Reason)
+}
+
+func TestCreateScheduleRejectsInvalidRequests(t *testing.T) {
+	s, _, appID := newTestService(t, models.ApplicationStatusRunning)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  catalogtypes.CreateBackupScheduleRequest
+	}{
+		{name: "invalid cron", req: catalogtypes.CreateBackupScheduleRequest{Cron: "61 * * * *"}},
+		{name: "cron that never runs", req: catalogtypes.CreateBackupScheduleRequest{Cron: "0 0 30 2 *"}},
+		{name: "unsupported target", req: catalogtypes.CreateBackupScheduleRequest{Cron: "@daily", Target: "chat"}},
+		{name: "negative retention", req: catalogtypes.CreateBackupScheduleRequest{Cron: "@daily", Retention: catalogtypes.BackupRetention{KeepLast: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateSchedule(ctx, appID, tt.req, "admin")
+			var valErr *validators.ValidationError
+			require.ErrorAs(t, err, &valErr)
+			assert.Equal(t, http.StatusBadRequest, valErr.Code)
+		})
+	}
+}
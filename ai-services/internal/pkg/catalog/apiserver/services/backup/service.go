@@ -0,0 +1,365 @@
+// Package backup runs backup and restore jobs for catalog-managed applications on
+// the API server. Archives are written to the backup volume and every job is
+// tracked in the backup_jobs table so clients can follow progress and history.
+// Backups can also run on cron schedules that prune older backups per a
+// retention policy; job outcomes are reported as application events.
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	"github.com/project-ai-services/ai-services/internal/pkg/application/common/opensearch"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const (
+	// backupStorageRoot is the mount point of the catalog-backups volume.
+	backupStorageRoot = "/data/backups"
+
+	fileTimestampFormat = "20060102_150405"
+)
+
+// ServiceInterface is the dependency injected into BackupHandler.
+type ServiceInterface interface {
+	// CreateBackup starts an async backup job for the application and returns it in status pending.
+	CreateBackup(ctx context.Context, appID uuid.UUID, req catalogtypes.CreateBackupRequest, userID string) (*catalogtypes.BackupJob, error)
+
+	// ListJobs returns the backup and restore jobs of the application, newest first.
+	ListJobs(ctx context.Context, appID uuid.UUID) (*catalogtypes.BackupJobListResponse, error)
+
+	// GetJob returns a single job of the application.
+	GetJob(ctx context.Context, appID, jobID uuid.UUID) (*catalogtypes.BackupJob, error)
+
+	// ArchivePath returns the path of a completed backup archive on the backup volume.
+	ArchivePath(ctx context.Context, appID, backupID uuid.UUID) (string, error)
+
+	// Restore starts an async restore job of a completed backup into the application.
+	Restore(ctx context.Context, appID, backupID uuid.UUID, req catalogtypes.RestoreBackupRequest, userID string) (*catalogtypes.BackupJob, error)
+
+	// CreateSchedule adds a cron backup schedule with a retention policy to the application.
+	CreateSchedule(ctx context.Context, appID uuid.UUID, req catalogtypes.CreateBackupScheduleRequest, userID string) (*catalogtypes.BackupSchedule, error)
+
+	// ListSchedules returns the backup schedules of the application.
+	ListSchedules(ctx context.Context, appID uuid.UUID) (*catalogtypes.BackupScheduleListResponse, error)
+
+	// UpdateSchedule changes the target, cron expression, retention or enabled state of a schedule.
+	UpdateSchedule(ctx context.Context, appID, scheduleID uuid.UUID, req catalogtypes.UpdateBackupScheduleRequest) (*catalogtypes.BackupSchedule, error)
+
+	// DeleteSchedule removes a schedule; the backups it created are kept.
+	DeleteSchedule(ctx context.Context, appID, scheduleID uuid.UUID) error
+
+	// RunDueSchedules starts the backups of every enabled schedule due at now. It is
+	// called periodically by the Scheduler.
+	RunDueSchedules(ctx context.Context, now time.Time)
+}
+
+// service implements ServiceInterface.
+type service struct {
+	jobRepo      repository.BackupJobRepository
+	scheduleRepo repository.BackupScheduleRepository
+	apps         apirepository.ApplicationServiceInterface
+	events       events.ServiceInterface
+	runtimeType  runtimeTypes.RuntimeType
+	storageRoot  string
+	newRuntime   func(namespace string) (runtime.Runtime, error)
+
+	// busy holds the IDs of applications with a job in flight; jobs of one
+	// application never run concurrently.
+	mu   sync.Mutex
+	busy map[uuid.UUID]bool
+}
+
+// NewService creates a backup service for the given runtime.
+func NewService(
+	jobRepo repository.BackupJobRepository,
+	scheduleRepo repository.BackupScheduleRepository,
+	apps apirepository.ApplicationServiceInterface,
+	eventService events.ServiceInterface,
+	runtimeType runtimeTypes.RuntimeType,
+) ServiceInterface {
+	return &service{
+		jobRepo:      jobRepo,
+		scheduleRepo: scheduleRepo,
+		apps:         apps,
+		events:       eventService,
+		runtimeType:  runtimeType,
+		storageRoot:  backupStorageRoot,
+		newRuntime:   func(namespace string) (runtime.Runtime, error) { return vars.RuntimeFactory.Create(namespace) },
+		busy:         map[uuid.UUID]bool{},
+	}
+}
+
+// CreateBackup implements ServiceInterface.
+func (s *service) CreateBackup(ctx context.Context, appID uuid.UUID, req catalogtypes.CreateBackupRequest, userID string) (*catalogtypes.BackupJob, error) {
+	target := req.Target
+	if target == "" {
+		target = catalogtypes.BackupTargetAll
+	}
+	if err := s.validateTarget(target, backuptarget.HookBackup); err != nil {
+		return nil, err
+	}
+
+	app, err := s.runnableApp(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.BackupJob{
+		AppID:     &appID,
+		AppName:   app.Name,
+		Type:      models.BackupJobTypeBackup,
+		Target:    target,
+		FileName:  archiveName(appID, app.Name, target),
+		CreatedBy: userID,
+	}
+
+	return s.start(ctx, app, job, s.archiveFile(job), opensearch.IndexMapping{})
+}
+
+// Restore implements ServiceInterface.
+func (s *service) Restore(ctx context.Context, appID, backupID uuid.UUID, req catalogtypes.RestoreBackupRequest, userID string) (*catalogtypes.BackupJob, error) {
+	sourceAppID := appID
+	if req.SourceApplicationID != "" {
+		id, err := uuid.Parse(req.SourceApplicationID)
+		if err != nil {
+			return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: "invalid source_application_id"}
+		}
+		sourceAppID = id
+	}
+
+	source, err := s.completedBackup(ctx, sourceAppID, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	indices, err := indexMapping(req)
+	if err != nil {
+		return nil, err
+	}
+
+	target := req.Target
+	if target == "" {
+		target = source.Target
+	}
+	if err := s.validateTarget(target, backuptarget.HookRestore); err != nil {
+		return nil, err
+	}
+
+	app, err := s.runnableApp(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.BackupJob{
+		AppID:          &appID,
+		AppName:        app.Name,
+		Type:           models.BackupJobTypeRestore,
+		Target:         target,
+		SourceBackupID: &source.ID,
+		CreatedBy:      userID,
+	}
+
+	return s.start(ctx, app, job, s.archiveFile(source), indices)
+}
+
+// indexMapping returns the OpenSearch index mapping of a restore request.
+func indexMapping(req catalogtypes.RestoreBackupRequest) (opensearch.IndexMapping, error) {
+	for from, to := range req.RenameIndices {
+		if err := opensearch.ValidateIndexName(to); err != nil {
+			return opensearch.IndexMapping{}, &validators.ValidationError{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("invalid new name for index %s: %v", from, err),
+			}
+		}
+	}
+	if req.IndexPrefix != "" {
+		// The prefix must itself start a valid index name.
+		if err := opensearch.ValidateIndexName(req.IndexPrefix + "index"); err != nil {
+			return opensearch.IndexMapping{}, &validators.ValidationError{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("invalid index_prefix: %v", err),
+			}
+		}
+	}
+
+	return opensearch.IndexMapping{
+		Rename:       req.RenameIndices,
+		Prefix:       req.IndexPrefix,
+		SkipExisting: req.SkipExistingIndices,
+	}, nil
+}
+
+// ListJobs implements ServiceInterface.
+func (s *service) ListJobs(ctx context.Context, appID uuid.UUID) (*catalogtypes.BackupJobListResponse, error) {
+	if _, err := s.apps.GetApplicationByID(ctx, appID); err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.ListByApp(ctx, appID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.BackupJobListResponse{Jobs: make([]catalogtypes.BackupJob, 0, len(jobs)), Total: len(jobs)}
+	for i := range jobs {
+		resp.Jobs = append(resp.Jobs, toResponse(&jobs[i]))
+	}
+
+	return resp, nil
+}
+
+// GetJob implements ServiceInterface.
+func (s *service) GetJob(ctx context.Context, appID, jobID uuid.UUID) (*catalogtypes.BackupJob, error) {
+	job, err := s.appJob(ctx, appID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toResponse(job)
+
+	return &resp, nil
+}
+
+// ArchivePath implements ServiceInterface.
+func (s *service) ArchivePath(ctx context.Context, appID, backupID uuid.UUID) (string, error) {
+	job, err := s.completedBackup(ctx, appID, backupID)
+	if err != nil {
+		return "", err
+	}
+
+	path := s.archiveFile(job)
+	if _, err := os.Stat(path); err != nil {
+		return "", &validators.ValidationError{
+			Code:    http.StatusGone,
+			Message: fmt.Sprintf("backup archive %s is no longer available on the backup volume", job.FileName),
+		}
+	}
+
+	return path, nil
+}
+
+// archiveName returns the archive path of a new backup relative to the backup
+// volume. Archives are grouped in one directory per application ID.
+func archiveName(appID uuid.UUID, appName, target string) string {
+	name := fmt.Sprintf("%s_%s_backup_%s.tar.gz", appName, target, time.Now().UTC().Format(fileTimestampFormat))
+
+	return filepath.Join(appID.String(), name)
+}
+
+// archiveFile returns the absolute path of a backup job's archive.
+func (s *service) archiveFile(job *models.BackupJob) string {
+	return filepath.Join(s.storageRoot, job.FileName)
+}
+
+// validateTarget rejects targets that are not registered for the runtime or do not support hook.
+func (s *service) validateTarget(target, hook string) error {
+	registry, err := backuptarget.ForRuntime(s.runtimeType)
+	if err != nil {
+		return err
+	}
+
+	names := append(registry.Names(hook), backuptarget.All)
+	if !slices.Contains(names, target) {
+		return &validators.ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("unsupported %s target %q, supported targets: %v", hook, target, names),
+		}
+	}
+
+	return nil
+}
+
+// runnableApp returns the application if it is in a state that allows backup and restore.
+func (s *service) runnableApp(ctx context.Context, appID uuid.UUID) (*catalogtypes.Application, error) {
+	app, err := s.apps.GetApplicationByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.Status != string(models.ApplicationStatusRunning) {
+		return nil, &validators.ValidationError{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("application %s is %s, backups and restores require a Running application", app.Name, app.Status),
+		}
+	}
+
+	return app, nil
+}
+
+// appJob returns the job if it belongs to the application.
+func (s *service) appJob(ctx context.Context, appID, jobID uuid.UUID) (*models.BackupJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil || job.AppID == nil || *job.AppID != appID {
+		return nil, &validators.ValidationError{Code: http.StatusNotFound, Message: "backup job not found"}
+	}
+
+	return job, nil
+}
+
+// completedBackup returns the job if it is a completed backup of the application.
+func (s *service) completedBackup(ctx context.Context, appID, backupID uuid.UUID) (*models.BackupJob, error) {
+	job, err := s.appJob(ctx, appID, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Type != models.BackupJobTypeBackup || job.Status != models.BackupJobStatusCompleted {
+		return nil, &validators.ValidationError{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("job %s is not a completed backup", backupID),
+		}
+	}
+
+	return job, nil
+}
+
+// toResponse converts a backup_jobs row to its API representation.
+func toResponse(j *models.BackupJob) catalogtypes.BackupJob {
+	resp := catalogtypes.BackupJob{
+		ID:              j.ID.String(),
+		ApplicationName: j.AppName,
+		Type:            string(j.Type),
+		Target:          j.Target,
+		Status:          string(j.Status),
+		Progress:        j.Progress,
+		Message:         j.Message,
+		FileName:        j.FileName,
+		SizeBytes:       j.SizeBytes,
+		CreatedBy:       j.CreatedBy,
+		CreatedAt:       j.CreatedAt.Format(constants.RFC3339WithTimezone),
+		UpdatedAt:       j.UpdatedAt.Format(constants.RFC3339WithTimezone),
+	}
+	if j.AppID != nil {
+		resp.ApplicationID = j.AppID.String()
+	}
+	if j.SourceBackupID != nil {
+		resp.SourceBackupID = j.SourceBackupID.String()
+	}
+	if j.CompletedAt != nil {
+		resp.CompletedAt = j.CompletedAt.Format(constants.RFC3339WithTimezone)
+	}
+
+	return resp
+}
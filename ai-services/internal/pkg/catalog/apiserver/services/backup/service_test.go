@@ -0,0 +1,403 @@
+package backup
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// fakeJobRepo is an in-memory BackupJobRepository.
+type fakeJobRepo struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*models.BackupJob
+}
+
+func (r *fakeJobRepo) Insert(_ context.Context, j *models.BackupJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j.ID, j.Status, j.CreatedAt, j.UpdatedAt = uuid.New(), models.BackupJobStatusPending, time.Now(), time.Now()
+	stored := *j
+	r.jobs[j.ID] = &stored
+
+	return nil
+}
+
+func (r *fakeJobRepo) GetByID(_ context.Context, id uuid.UUID) (*models.BackupJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *j
+
+	return &copied, nil
+}
+
+func (r *fakeJobRepo) Update(_ context.Context, id uuid.UUID, upd models.BackupJobUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j := r.jobs[id]
+	if upd.Status != nil {
+		j.Status = *upd.Status
+	}
+	if upd.Progress != nil {
+		j.Progress = *upd.Progress
+	}
+	if upd.Message != nil {
+		j.Message = *upd.Message
+	}
+	if upd.SizeBytes != nil {
+		j.SizeBytes = upd.SizeBytes
+	}
+	if upd.CompletedAt != nil {
+		j.CompletedAt = upd.CompletedAt
+	}
+
+	return nil
+}
+
+func (r *fakeJobRepo) ListByApp(_ context.Context, appID uuid.UUID, _ *repository.BackupJobFilters) ([]models.BackupJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []models.BackupJob
+	for _, j := range r.jobs {
+		if j.AppID != nil && *j.AppID == appID {
+			jobs = append(jobs, *j)
+		}
+	}
+
+	return jobs, nil
+}
+
+func (r *fakeJobRepo) FailUnfinished(context.Context, string) (int64, error) { return 0, nil }
+
+func (r *fakeJobRepo) ListCompletedBySchedule(_ context.Context, scheduleID uuid.UUID) ([]models.BackupJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []models.BackupJob
+	for _, j := range r.jobs {
+		if j.ScheduleID != nil && *j.ScheduleID == scheduleID && j.Status == models.BackupJobStatusCompleted {
+			jobs = append(jobs, *j)
+		}
+	}
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].CreatedAt.After(jobs[b].CreatedAt) })
+
+	return jobs, nil
+}
+
+func (r *fakeJobRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.jobs, id)
+
+	return nil
+}
+
+// fakeScheduleRepo is an in-memory BackupScheduleRepository.
+type fakeScheduleRepo struct {
+	mu        sync.Mutex
+	schedules map[uuid.UUID]*models.BackupSchedule
+}
+
+func (r *fakeScheduleRepo) Insert(_ context.Context, sched *models.BackupSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sched.ID, sched.CreatedAt, sched.UpdatedAt = uuid.New(), time.Now(), time.Now()
+	stored := *sched
+	r.schedules[sched.ID] = &stored
+
+	return nil
+}
+
+func (r *fakeScheduleRepo) GetByID(_ context.Context, id uuid.UUID) (*models.BackupSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sched, ok := r.schedules[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *sched
+
+	return &copied, nil
+}
+
+func (r *fakeScheduleRepo) ListByApp(_ context.Context, appID uuid.UUID) ([]models.BackupSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var schedules []models.BackupSchedule
+	for _, sched := range r.schedules {
+		if sched.AppID == appID {
+			schedules = append(schedules, *sched)
+		}
+	}
+
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) ListDue(_ context.Context, now time.Time) ([]models.BackupSchedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var schedules []models.BackupSchedule
+	for _, sched := range r.schedules {
+		if sched.Enabled && sched.NextRunAt != nil && !sched.NextRunAt.After(now) {
+			schedules = append(schedules, *sched)
+		}
+	}
+
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) Update(_ context.Context, id uuid.UUID, upd models.BackupScheduleUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sched := r.schedules[id]
+	if upd.Target != nil {
+		sched.Target = *upd.Target
+	}
+	if upd.CronExpr != nil {
+		sched.CronExpr = *upd.CronExpr
+	}
+	if upd.Retention != nil {
+		sched.Retention = *upd.Retention
+	}
+	if upd.Enabled != nil {
+		sched.Enabled = *upd.Enabled
+	}
+	if upd.NextRunAt != nil {
+		sched.NextRunAt = upd.NextRunAt
+	}
+	if upd.ClearNextRun {
+		sched.NextRunAt = nil
+	}
+	if upd.LastRunAt != nil {
+		sched.LastRunAt = upd.LastRunAt
+	}
+	if upd.LastStatus != nil {
+		sched.LastStatus = upd.LastStatus
+	}
+	if upd.LastError != nil {
+		sched.LastError = *upd.LastError
+	}
+
+	return nil
+}
+
+func (r *fakeScheduleRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.schedules, id)
+
+	return nil
+}
+
+// fakeEvents records events in memory.
+type fakeEvents struct {
+	mu     sync.Mutex
+	events []models.ApplicationEvent
+}
+
+func (f *fakeEvents) Record(_ context.Context, appID uuid.UUID, eventType models.ApplicationEventType, reason, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, models.ApplicationEvent{AppID: appID, Type: eventType, Reason: reason, Message: message})
+}
+
+func (f *fakeEvents) List(context.Context, uuid.UUID, int) (*catalogtypes.ApplicationEventListResponse, error) {
+	return &catalogtypes.ApplicationEventListResponse{}, nil
+}
+
+func (f *fakeEvents) reasons() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reasons := make([]string, 0, len(f.events))
+	for _, e := range f.events {
+		reasons = append(reasons, e.Reason)
+	}
+
+	return reasons
+}
+
+// fakeApps returns a fixed application from GetApplicationByID.
+type fakeApps struct {
+	apirepository.ApplicationServiceInterface
+	app *catalogtypes.Application
+}
+
+func (f *fakeApps) GetApplicationByID(_ context.Context, id uuid.UUID) (*catalogtypes.Application, error) {
+	if id.String() != f.app.ID {
+		return nil, &validators.ValidationError{Code: http.StatusNotFound, Message: "application not found"}
+	}
+
+	return f.app, nil
+}
+
+// fakeTarget stands in for the OpenSearch target. Backups block until release is closed.
+type fakeTarget struct {
+	release  chan struct{}
+	restored chan backuptarget.Request
+}
+
+func (f *fakeTarget) Describe() backuptarget.Description { return backuptarget.OpenSearch }
+
+func (f *fakeTarget) Verify(entries []string) error {
+	return backuptarget.VerifyOpenSearchArchive(entries)
+}
+
+func (f *fakeTarget) Backup(_ context.Context, _ backuptarget.Request, dir string) error {
+	<-f.release
+
+	if err := os.MkdirAll(filepath.Join(dir, "opensearch_backup"), backupDirPermission); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "opensearch_backup", "rag.json"), []byte("[]"), 0o600)
+}
+
+func (f *fakeTarget) Restore(_ context.Context, req backuptarget.Request, _ string) error {
+	f.restored <- req
+
+	return nil
+}
+
+func newTestService(t *testing.T, status models.ApplicationStatus) (*service, *fakeTarget, uuid.UUID) {
+	t.Helper()
+
+	target := &fakeTarget{release: make(chan struct{}), restored: make(chan backuptarget.Request, 1)}
+	backuptarget.PodmanRegistry = backuptarget.NewRegistry()
+	backuptarget.PodmanRegistry.Register(target)
+	t.Cleanup(func() { backuptarget.PodmanRegistry = backuptarget.NewRegistry() })
+
+	appID := uuid.New()
+	app := &catalogtypes.Application{ID: appID.String(), Name: "rag-dev", Status: string(status)}
+	s := NewService(
+		&fakeJobRepo{jobs: map[uuid.UUID]*models.BackupJob{}},
+		&fakeScheduleRepo{schedules: map[uuid.UUID]*models.BackupSchedule{}},
+		&fakeApps{app: app},
+		&fakeEvents{},
+		runtimeTypes.RuntimeTypePodman,
+	).(*service)
+	s.storageRoot = t.TempDir()
+	s.newRuntime = func(string) (runtime.Runtime, error) { return nil, nil }
+
+	return s, target, appID
+}
+
+func waitForStatus(t *testing.T, s *service, appID, jobID uuid.UUID, status string) *catalogtypes.BackupJob {
+	t.Helper()
+
+	var job *catalogtypes.BackupJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = s.GetJob(context.Background(), appID, jobID)
+		require.NoError(t, err)
+
+		return job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestBackupAndRestoreJobs(t *testing.T) {
+	s, target, appID := newTestService(t, models.ApplicationStatusRunning)
+	ctx := context.Background()
+
+	job, err := s.CreateBackup(ctx, appID, catalogtypes.CreateBackupRequest{Target: "opensearch"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.BackupJobStatusPending), job.Status)
+	assert.Equal(t, "admin", job.CreatedBy)
+
+	// A second job for the same application is rejected while the first runs.
+	_, err = s.CreateBackup(ctx, appID, catalogtypes.CreateBackupRequest{}, "admin")
+	var valErr *validators.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusConflict, valErr.Code)
+
+	close(target.release)
+	backupID := uuid.MustParse(job.ID)
+	done := waitForStatus(t, s, appID, backupID, string(models.BackupJobStatusCompleted))
+	assert.Equal(t, progressComplete, done.Progress)
+	require.NotNil(t, done.SizeBytes)
+	assert.Positive(t, *done.SizeBytes)
+
+	path, err := s.ArchivePath(ctx, appID, backupID)
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+	assert.Equal(t, filepath.Join(s.storageRoot, appID.String()), filepath.Dir(path))
+
+	_, err = s.Restore(ctx, appID, backupID, catalogtypes.RestoreBackupRequest{IndexPrefix: "_promoted"}, "admin")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusBadRequest, valErr.Code, "indices cannot be restored under invalid names")
+
+	restore, err := s.Restore(ctx, appID, backupID, catalogtypes.RestoreBackupRequest{
+		RenameIndices:       map[string]string{"rag_docs": "rag_docs_v2"},
+		SkipExistingIndices: true,
+	}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "opensearch", restore.Target, "restore defaults to the backup's target")
+	assert.Equal(t, job.ID, restore.SourceBackupID)
+	req := <-target.restored
+	assert.Equal(t, "rag-dev", req.AppName)
+	assert.Equal(t, "rag_docs_v2", req.Indices.Target("rag_docs"))
+	assert.True(t, req.Indices.SkipExisting)
+	waitForStatus(t, s, appID, uuid.MustParse(restore.ID), string(models.BackupJobStatusCompleted))
+
+	list, err := s.ListJobs(ctx, appID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+
+	// A restore job cannot be used as the source of another restore.
+	_, err = s.Restore(ctx, appID, uuid.MustParse(restore.ID), catalogtypes.RestoreBackupRequest{}, "admin")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusConflict, valErr.Code)
+}
+
+func TestCreateBackupRejectsInvalidRequests(t *testing.T) {
+	s, _, appID := newTestService(t, models.ApplicationStatusDeploying)
+	ctx := context.Background()
+	var valErr *validators.ValidationError
+
+	_, err := s.CreateBackup(ctx, appID, catalogtypes.CreateBackupRequest{Target: "chat"}, "admin")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusBadRequest, valErr.Code)
+
+	_, err = s.CreateBackup(ctx, appID, catalogtypes.CreateBackupRequest{}, "admin")
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusConflict, valErr.Code, "applications that are not Running cannot be backed up")
+
+	_, err = s.GetJob(ctx, appID, uuid.New())
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusNotFound, valErr.Code)
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/proxy"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 )
 
 // PodmanDeletion handles application deletion operations.
@@ -170,10 +172,16 @@ func (s *PodmanDeletion) deleteServices(ctx context.Context, services []models.S
 	return errorMessages
 }
 
-// deletePods deletes all pods and returns any error messages.
+// deletePods deletes all pods and their systemd units and returns any error messages.
 func (s *PodmanDeletion) deletePods(ctx context.Context, pods []runtimeTypes.Pod, forceDelete bool) []string {
 	var podErrors []string
 	for _, pod := range pods {
+		// Remove the unit first, so the host does not start the pod again on boot.
+		if err := systemd.Remove(utils.GetSystemdUnitsPath(), []string{pod.Name}); err != nil {
+			podErrors = append(podErrors, fmt.Sprintf("failed to delete pod %s: %s", pod.ID, err))
+
+			continue
+		}
 		if err := s.rt.DeletePod(pod.ID, &forceDelete); err != nil {
 			// Ignore "not found" errors - pod already deleted or never existed
 			if catalogutils.IsNotFoundError(err) {
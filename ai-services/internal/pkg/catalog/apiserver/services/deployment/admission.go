@@ -0,0 +1,210 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// admit checks that the planned application fits on the host: the resources it
+// requires are compared against host capacity minus the resources reserved by
+// existing applications. With ignoreCapacity set a shortfall is only logged.
+func (p *DeploymentPlanner) admit(ctx context.Context, plan *DeploymentPlan, ignoreCapacity bool) error {
+	required, err := p.planRequirements(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("failed to calculate required resources: %w", err)
+	}
+
+	allocated, err := p.allocatedResources(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to calculate allocated resources: %w", err)
+	}
+
+	runtimeClient, err := vars.RuntimeFactory.Create("")
+	if err != nil {
+		return fmt.Errorf("failed to create runtime client: %w", err)
+	}
+
+	sysInfo, err := runtimeClient.GetSystemInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get system information: %w", err)
+	}
+
+	checks := capacityChecks(required, allocated, sysInfo)
+
+	fits := true
+	for _, c := range checks {
+		logger.DebugfCtx(ctx, "Admission for application '%s': %s\n", plan.ApplicationName, c)
+		fits = fits && c.Fits()
+	}
+
+	if fits {
+		return nil
+	}
+
+	denied := &types.ErrInsufficientCapacity{ApplicationName: plan.ApplicationName, Checks: checks}
+	if ignoreCapacity {
+		logger.WarningfCtx(ctx, "Deploying despite capacity shortfall: %v\n", denied)
+
+		return nil
+	}
+
+	return denied
+}
+
+// planRequirements sums the resources of every planned service and component:
+// the CPU and memory enforced on their pods and the accelerators of their runtime
+// metadata. For architectures the declared minimum requirements are applied on
+// top, per resource.
+func (p *DeploymentPlanner) planRequirements(ctx context.Context, plan *DeploymentPlan) (types.ResourceAmounts, error) {
+	var required types.ResourceAmounts
+
+	for _, comp := range plan.Components {
+		metadata, err := p.catalogProvider.LoadComponentRuntimeMetadata(comp.ComponentType, comp.ProviderID)
+		if err != nil {
+			return required, fmt.Errorf("failed to load runtime metadata for component %s/%s: %w", comp.ComponentType, comp.ProviderID, err)
+		}
+		required = required.Add(enforcedResources(metadata, comp.Resources))
+	}
+
+	for _, svc := range plan.Services {
+		metadata, err := p.catalogProvider.LoadServiceRuntimeMetadata(svc.CatalogID)
+		if err != nil {
+			return required, fmt.Errorf("failed to load runtime metadata for service %s: %w", svc.CatalogID, err)
+		}
+		required = required.Add(enforcedResources(metadata, svc.Resources))
+	}
+
+	if plan.IsArchitecture {
+		arch, err := p.catalogProvider.LoadArchitecture(plan.CatalogID)
+		if err != nil {
+			return required, fmt.Errorf("failed to load architecture %s: %w", plan.CatalogID, err)
+		}
+
+		if arch.Requirements != nil {
+			required = required.Max(types.ResourceAmounts{
+				CPU:         arch.Requirements.CPU,
+				MemoryBytes: int64(arch.Requirements.Memory),
+				SpyreCards:  arch.Requirements.Accelerators[constants.SpyreResourceName],
+			})
+		}
+	}
+
+	logger.DebugfCtx(ctx, "Application '%s' requires %d CPUs, %d bytes of memory and %d Spyre cards\n",
+		plan.ApplicationName, required.CPU, required.MemoryBytes, required.SpyreCards)
+
+	return required, nil
+}
+
+// allocatedResources sums the resources reserved by the services and components
+// of all existing applications, with the CPU and memory enforced on their pods.
+// Entries whose catalog item can no longer be loaded are skipped.
+func (p *DeploymentPlanner) allocatedResources(ctx context.Context) (types.ResourceAmounts, error) {
+	var allocated types.ResourceAmounts
+
+	apps, err := p.appRepo.GetAll(ctx, nil)
+	if err != nil {
+		return allocated, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	for _, app := range apps {
+		for _, svc := range app.Services {
+			metadata, err := p.catalogProvider.LoadServiceRuntimeMetadata(svc.CatalogID)
+			if err != nil {
+				logger.WarningfCtx(ctx, "Skipping service %s of application %s in admission: %v\n", svc.CatalogID, app.Name, err)
+
+				continue
+			}
+			allocated = allocated.Add(enforcedResources(metadata, svc.Resources))
+		}
+	}
+
+	components, err := p.componentRepo.GetAll(ctx)
+	if err != nil {
+		return allocated, fmt.Errorf("failed to list components: %w", err)
+	}
+
+	for _, comp := range components {
+		metadata, err := p.catalogProvider.LoadComponentRuntimeMetadata(comp.Type, comp.Provider)
+		if err != nil {
+			logger.WarningfCtx(ctx, "Skipping component %s/%s in admission: %v\n", comp.Type, comp.Provider, err)
+
+			continue
+		}
+		allocated = allocated.Add(enforcedResources(metadata, comp.Resources))
+	}
+
+	return allocated, nil
+}
+
+// resourcesFromMetadata converts the resources block of runtime metadata.
+func resourcesFromMetadata(metadata *clitemplates.AppMetadata) types.ResourceAmounts {
+	if metadata == nil || metadata.Resources == nil {
+		return types.ResourceAmounts{}
+	}
+
+	return types.ResourceAmounts{
+		CPU:         metadata.Resources.CPU,
+		MemoryBytes: int64(metadata.Resources.Memory),
+		SpyreCards:  metadata.Resources.Accelerators[constants.SpyreResourceName],
+	}
+}
+
+// enforcedResources converts the resources block of runtime metadata, with the
+// CPU and memory replaced by those enforced on the pods. Entries deployed before
+// the limits were enforced have none and keep the metadata values.
+func enforcedResources(metadata *clitemplates.AppMetadata, limits *dbmodels.ResourceLimits) types.ResourceAmounts {
+	amounts := resourcesFromMetadata(metadata)
+	if limits != nil {
+		amounts.CPU = limits.CPU
+		amounts.MemoryBytes = limits.MemoryBytes
+	}
+
+	return amounts
+}
+
+// capacityChecks builds the per-resource admission breakdown. Resources the host
+// does not report are not checked. For Spyre cards the number of cards actually
+// free on the host caps what is available, since cards may also be held outside
+// the catalog.
+func capacityChecks(required, allocated types.ResourceAmounts, sysInfo *models.SystemInfo) []types.ResourceCheck {
+	var checks []types.ResourceCheck
+
+	if sysInfo.CPU != nil {
+		capacity := int64(sysInfo.CPU.Total)
+		checks = append(checks, newResourceCheck(types.ResourceCPU, int64(required.CPU), capacity, int64(allocated.CPU)))
+	}
+
+	if sysInfo.Memory != nil {
+		checks = append(checks, newResourceCheck(types.ResourceMemory, required.MemoryBytes, sysInfo.Memory.TotalBytes, allocated.MemoryBytes))
+	}
+
+	spyre, ok := sysInfo.Accelerators[constants.SpyreResourceName]
+	if required.SpyreCards > 0 || ok {
+		check := newResourceCheck(types.ResourceSpyreCards, int64(required.SpyreCards), 0, int64(allocated.SpyreCards))
+		if ok {
+			check = newResourceCheck(types.ResourceSpyreCards, int64(required.SpyreCards), int64(spyre.Total), int64(allocated.SpyreCards))
+			check.Available = min(check.Available, int64(spyre.Available))
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func newResourceCheck(resource string, required, capacity, allocated int64) types.ResourceCheck {
+	return types.ResourceCheck{
+		Resource:  resource,
+		Required:  required,
+		Capacity:  capacity,
+		Allocated: allocated,
+		Available: max(capacity-allocated, 0),
+	}
+}
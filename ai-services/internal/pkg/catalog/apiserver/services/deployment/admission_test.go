@@ -0,0 +1,83 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+const gib = int64(1 << 30)
+
+func TestCapacityChecks(t *testing.T) {
+	sysInfo := &models.SystemInfo{
+		CPU:    &models.CPUInfo{Total: 64},
+		Memory: &models.MemoryInfo{TotalBytes: 512 * gib},
+		Accelerators: map[string]*models.AcceleratorInfo{
+			constants.SpyreResourceName: {Total: 8, Available: 2},
+		},
+	}
+	allocated := types.ResourceAmounts{CPU: 40, MemoryBytes: 200 * gib, SpyreCards: 4}
+	required := types.ResourceAmounts{CPU: 20, MemoryBytes: 300 * gib, SpyreCards: 4}
+
+	checks := capacityChecks(required, allocated, sysInfo)
+	require.Len(t, checks, 3)
+
+	assert.Equal(t, types.ResourceCheck{Resource: types.ResourceCPU, Required: 20, Capacity: 64, Allocated: 40, Available: 24}, checks[0])
+	assert.True(t, checks[0].Fits())
+
+	assert.Equal(t, 312*gib, checks[1].Available)
+	assert.True(t, checks[1].Fits())
+
+	// Only two cards are actually free, even though metadata accounts for four.
+	assert.Equal(t, int64(2), checks[2].Available)
+	assert.False(t, checks[2].Fits())
+
+	err := &types.ErrInsufficientCapacity{ApplicationName: "rag-dev", Checks: checks}
+	assert.Equal(t,
+		"insufficient host capacity for application 'rag-dev': spyre_cards: required 4, available 2 (capacity 8, allocated 4); set ignore_capacity to deploy anyway",
+		err.Error())
+}
+
+func TestCapacityChecksWithoutAccelerators(t *testing.T) {
+	sysInfo := &models.SystemInfo{
+		CPU:    &models.CPUInfo{Total: 16},
+		Memory: &models.MemoryInfo{TotalBytes: 64 * gib},
+	}
+
+	t.Run("spyre not required", func(t *testing.T) {
+		checks := capacityChecks(types.ResourceAmounts{CPU: 4, MemoryBytes: 8 * gib}, types.ResourceAmounts{}, sysInfo)
+		assert.Len(t, checks, 2)
+	})
+
+	t.Run("spyre required on a host without cards", func(t *testing.T) {
+		checks := capacityChecks(types.ResourceAmounts{SpyreCards: 1}, types.ResourceAmounts{}, sysInfo)
+		require.Len(t, checks, 3)
+		assert.False(t, checks[2].Fits())
+	})
+
+	t.Run("overcommitted host", func(t *testing.T) {
+		checks := capacityChecks(types.ResourceAmounts{CPU: 1}, types.ResourceAmounts{CPU: 20, MemoryBytes: 80 * gib}, sysInfo)
+		assert.Equal(t, int64(0), checks[0].Available)
+		assert.Equal(t, "memory: required 0.0Gi, available 0.0Gi (capacity 64.0Gi, allocated 80.0Gi)", checks[1].String())
+	})
+}
+
+func TestEnforcedResources(t *testing.T) {
+	metadata := &clitemplates.AppMetadata{Resources: &clitemplates.RuntimeResources{
+		CPU:          8,
+		Memory:       int(150 * gib),
+		Accelerators: map[string]int{constants.SpyreResourceName: 4},
+	}}
+
+	assert.Equal(t, types.ResourceAmounts{CPU: 8, MemoryBytes: 150 * gib, SpyreCards: 4}, enforcedResources(metadata, nil),
+		"entries deployed before the limits were enforced keep the metadata values")
+	assert.Equal(t, types.ResourceAmounts{CPU: 4, MemoryBytes: 64 * gib, SpyreCards: 4},
+		enforcedResources(metadata, &dbmodels.ResourceLimits{CPU: 4, MemoryBytes: 64 * gib}))
+}
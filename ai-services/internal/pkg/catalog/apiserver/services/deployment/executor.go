@@ -34,7 +34,7 @@ func NewDeploymentExecutor(
 	componentRepo repository.ComponentRepository,
 ) *DeploymentExecutor {
 	return &DeploymentExecutor{
-		planner:         NewDeploymentPlanner(catalogProvider, componentRepo),
+		planner:         NewDeploymentPlanner(catalogProvider, appRepo, componentRepo),
 		catalogProvider: catalogProvider,
 		appRepo:         appRepo,
 		serviceRepo:     serviceRepo,
@@ -0,0 +1,63 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/repository/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/policy"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// enforceImagePolicy checks the images of the planned application against the
+// image policy of the host before anything is recorded or pulled. Template images
+// that were verified in their registry, because they must be signed or digest
+// pinning is on, are resolved to the digests they were verified at and the plan
+// pulls and runs exactly those, so a tag moved after the check is never used.
+// The tool image is checked but not pinned, as housekeeping tasks refer to it by
+// tag; its local image is checked against the verified digest when it is pulled.
+func (p *DeploymentPlanner) enforceImagePolicy(ctx context.Context, plan *DeploymentPlan) error {
+	config, err := policy.Load()
+	if err != nil {
+		return err
+	}
+	if !config.Enabled() {
+		return nil
+	}
+
+	imageSet, err := podman.CollectTemplateImages(ctx, p.catalogProvider, plan)
+	if err != nil {
+		return fmt.Errorf("failed to collect images: %w", err)
+	}
+	images := []string{vars.ToolImage}
+	for img := range imageSet {
+		if img != vars.ToolImage {
+			images = append(images, img)
+		}
+	}
+
+	digests, err := config.Enforce(ctx, images)
+	if err != nil {
+		return err
+	}
+
+	pins := make(map[string]string, len(digests))
+	for img := range imageSet {
+		dgst, ok := digests[img]
+		if !ok || img == vars.ToolImage {
+			continue
+		}
+		pinned, err := policy.Pin(img, dgst)
+		if err != nil {
+			return err
+		}
+		logger.DebugfCtx(ctx, "Pinned image %s to %s\n", img, pinned)
+		pins[img] = pinned
+	}
+	if len(pins) > 0 {
+		plan.Images = pins
+	}
+
+	return nil
+}
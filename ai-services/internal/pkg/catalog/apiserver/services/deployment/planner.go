@@ -3,13 +3,17 @@ package deployment
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/inventory"
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/topology"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/params"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
@@ -19,9 +23,11 @@ import (
 // DeploymentPlanner plans the deployment of applications by:
 // 1. Collecting parameters for each service and component
 // 2. Deduplicating components (same type + provider + params = single deployment)
-// 3. Creating deployment plan with shared components.
+// 3. Creating deployment plan with shared components
+// 4. Admitting the plan against the remaining host capacity.
 type DeploymentPlanner struct {
 	catalogProvider *catalog.CatalogProvider
+	appRepo         repository.ApplicationRepository
 	componentRepo   repository.ComponentRepository
 	paramBuilder    *params.ParamBuilder
 }
@@ -29,10 +35,12 @@ type DeploymentPlanner struct {
 // NewDeploymentPlanner creates a new deployment planner.
 func NewDeploymentPlanner(
 	provider *catalog.CatalogProvider,
+	appRepo repository.ApplicationRepository,
 	componentRepo repository.ComponentRepository,
 ) *DeploymentPlanner {
 	return &DeploymentPlanner{
 		catalogProvider: provider,
+		appRepo:         appRepo,
 		componentRepo:   componentRepo,
 		paramBuilder:    params.NewParamBuilder(provider),
 	}
@@ -73,6 +81,7 @@ func (p *DeploymentPlanner) PlanDeployment(
 		IsArchitecture:  isArchitecture,
 		Components:      make(map[string]*ComponentPlan),
 		Services:        make(map[string]*ServicePlan),
+		Autostart:       req.Autostart == nil || *req.Autostart,
 	}
 
 	// Process each service from request
@@ -82,6 +91,18 @@ func (p *DeploymentPlanner) PlanDeployment(
 		}
 	}
 
+	// Reject applications that do not fit in what is left of the host, unless overridden.
+	if err := p.admit(ctx, plan, req.IgnoreCapacity); err != nil {
+		return nil, err
+	}
+
+	// Check images against the image policy and pin them before anything is pulled. Only needed for Podman.
+	if runtimeType == runtimeTypes.RuntimeTypePodman.String() {
+		if err := p.enforceImagePolicy(ctx, plan); err != nil {
+			return nil, err
+		}
+	}
+
 	// Calculate and allocate Spyre cards after all components are planned. Only needed for Podman.
 	if runtimeType == runtimeTypes.RuntimeTypePodman.String() {
 		if err := p.calculateAndAllocateSpyreCards(ctx, plan); err != nil {
@@ -105,11 +126,21 @@ func (p *DeploymentPlanner) processService(
 		return fmt.Errorf("failed to get service catalog path: %w", err)
 	}
 
+	metadata, err := p.catalogProvider.LoadServiceRuntimeMetadata(svc.CatalogID)
+	if err != nil {
+		return fmt.Errorf("failed to load service runtime metadata: %w", err)
+	}
+	limits, err := resources.Effective(metadata, svc.Params)
+	if err != nil {
+		return fmt.Errorf("invalid resources of service '%s': %w", svc.CatalogID, err)
+	}
+
 	servicePlan := &ServicePlan{
 		CatalogID:     svc.CatalogID,
 		CatalogPath:   fmt.Sprintf("%s/%s", servicePath, runtimeType),
 		Version:       svc.Version,
 		ComponentRefs: make([]string, 0),
+		Resources:     limits,
 	}
 
 	// Process each component in the service
@@ -178,6 +209,15 @@ func (p *DeploymentPlanner) processComponent(
 		return "", fmt.Errorf("failed to get component catalog path: %w", err)
 	}
 
+	metadata, err := p.catalogProvider.LoadComponentRuntimeMetadata(comp.ComponentType, comp.ProviderID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load component runtime metadata: %w", err)
+	}
+	limits, err := resources.Effective(metadata, comp.Params)
+	if err != nil {
+		return "", fmt.Errorf("invalid resources of component '%s': %w", comp.ComponentType, err)
+	}
+
 	// Create new component plan
 	compPlan := &ComponentPlan{
 		Hash:           componentHash,
@@ -187,6 +227,7 @@ func (p *DeploymentPlanner) processComponent(
 		Version:        comp.Version,
 		Params:         comp.Params,
 		UsedByServices: []string{catalogID},
+		Resources:      limits,
 	}
 
 	// Add to plan
@@ -234,13 +275,52 @@ func (p *DeploymentPlanner) calculateAndAllocateSpyreCards(ctx context.Context,
 	}
 
 	// Create pool with available addresses and store in plan
+	numaNodes, topo := discoverSpyreTopology(ctx, pciAddresses)
 	plan.SpyreCardPool = &types.SpyreCardPool{
 		Addresses: pciAddresses,
+		NUMANodes: numaNodes,
+		Topology:  topo,
 	}
 
 	return nil
 }
 
+// discoverSpyreTopology looks up the NUMA node of each free card and the host NUMA
+// layout so that allocation can keep a component's cards, CPUs and memory together.
+// Topology is best effort: on failure cards are allocated without NUMA preference.
+func discoverSpyreTopology(ctx context.Context, addresses []string) (map[string]int, *topology.Topology) {
+	cardNodes, err := inventory.NUMANodes()
+	if err != nil {
+		logger.WarningfCtx(ctx, "Failed to read Spyre card NUMA affinity, allocating without NUMA preference: %v\n", err)
+
+		return nil, nil
+	}
+
+	// Free card addresses are keyed as returned by FindFreeSpyreCards, which may
+	// carry surrounding whitespace.
+	numaNodes := make(map[string]int, len(addresses))
+	for _, addr := range addresses {
+		if node, ok := cardNodes[strings.TrimSpace(addr)]; ok {
+			numaNodes[addr] = node
+		}
+	}
+
+	topo, err := topology.Discover()
+	if err != nil {
+		logger.WarningfCtx(ctx, "Failed to read NUMA topology, pods will not be pinned: %v\n", err)
+
+		return numaNodes, nil
+	}
+
+	if !topo.IsNUMA() {
+		return numaNodes, nil
+	}
+
+	logger.InfofCtx(ctx, "Host has %d NUMA nodes, placing Spyre cards and CPUs per node\n", len(topo.Nodes))
+
+	return numaNodes, topo
+}
+
 // getRequiredSpyreCardsForComponent calculates Spyre cards needed for a component.
 func (p *DeploymentPlanner) getRequiredSpyreCardsForComponent(ctx context.Context, comp *ComponentPlan) (int, error) {
 	// Load component templates using catalog provider
@@ -0,0 +1,105 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// rootPodmanURI is the socket of root podman, which the units use by default.
+const rootPodmanURI = "unix:///run/podman/podman.sock"
+
+// writeAutostartUnits writes the systemd units starting the pods of the plan on
+// boot to the units directory, from which the host installs them.
+func (d *PodmanDeployer) writeAutostartUnits(ctx context.Context, plan *DeploymentPlan) error {
+	serviceLayers, err := d.catalogProvider.GetDeploymentOrder(slices.Sorted(maps.Keys(plan.Services)))
+	if err != nil {
+		return fmt.Errorf("failed to get deployment order: %w", err)
+	}
+
+	units := autostartUnits(plan, serviceLayers)
+
+	// The units run on the host; pods of rootless podman are reached through the
+	// socket of its user, mounted into the catalog at the same path.
+	uri, err := utils.ResolvePodmanURI()
+	if err != nil {
+		return err
+	}
+	if uri != rootPodmanURI {
+		for i := range units {
+			units[i].URL = uri
+		}
+	}
+
+	if err := systemd.Write(utils.GetSystemdUnitsPath(), units); err != nil {
+		return err
+	}
+	logger.InfofCtx(ctx, "Wrote %d systemd unit(s) for application '%s'\n", len(units), plan.ApplicationName)
+
+	return nil
+}
+
+// autostartUnits returns the units of the pods of the plan. Component pods start
+// first; the pods of each layer of services start after those of the previous
+// layer and of the components the service uses. The pods of a component or
+// service follow the order of its pod template executions.
+func autostartUnits(plan *DeploymentPlan, serviceLayers [][]string) []systemd.Unit {
+	var units []systemd.Unit
+
+	componentPods := make(map[string][]string, len(plan.Components))
+	for _, hash := range slices.Sorted(maps.Keys(plan.Components)) {
+		comp := plan.Components[hash]
+		units = append(units, layeredUnits(comp.PodLayers, nil)...)
+		componentPods[hash] = slices.Concat(comp.PodLayers...)
+	}
+
+	var previous []string
+	for _, layer := range serviceLayers {
+		var current []string
+		for _, id := range layer {
+			svc, ok := plan.Services[id]
+			if !ok {
+				continue
+			}
+
+			after := slices.Clone(previous)
+			for _, hash := range svc.ComponentRefs {
+				after = append(after, componentPods[hash]...)
+			}
+			units = append(units, layeredUnits(svc.PodLayers, after)...)
+			current = append(current, slices.Concat(svc.PodLayers...)...)
+		}
+		previous = current
+	}
+
+	return units
+}
+
+// layeredUnits returns the units of pods deployed in layers: the first layer
+// starts after the pods in after, every other layer after the previous one.
+func layeredUnits(layers [][]string, after []string) []systemd.Unit {
+	var units []systemd.Unit
+	for _, layer := range layers {
+		for _, pod := range layer {
+			units = append(units, systemd.Unit{Pod: pod, After: slices.Clone(after)})
+		}
+		after = layer
+	}
+
+	return units
+}
+
+// appendPodLayer appends the pods deployed for one pod template execution layer,
+// skipping layers that deployed no pod.
+func appendPodLayer(layers [][]string, pods []string) [][]string {
+	if len(pods) == 0 {
+		return layers
+	}
+
+	return append(layers, pods)
+}
@@ -0,0 +1,32 @@
+package podman
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+)
+
+func TestAutostartUnits(t *testing.T) {
+	plan := &DeploymentPlan{
+		Components: map[string]*ComponentPlan{
+			"db":  {PodLayers: [][]string{{"rag--opensearch"}}},
+			"llm": {PodLayers: [][]string{{"rag--vllm-init"}, {"rag--vllm"}}},
+		},
+		Services: map[string]*ServicePlan{
+			"digitize": {ComponentRefs: []string{"db"}, PodLayers: [][]string{{"rag--digitize"}}},
+			"chat":     {ComponentRefs: []string{"llm", "db"}, PodLayers: [][]string{{"rag--chat-backend"}, {"rag--chat-ui"}}},
+		},
+	}
+
+	units := autostartUnits(plan, [][]string{{"digitize"}, {"chat", "removed"}})
+	assert.Equal(t, []systemd.Unit{
+		{Pod: "rag--opensearch"},
+		{Pod: "rag--vllm-init"},
+		{Pod: "rag--vllm", After: []string{"rag--vllm-init"}},
+		{Pod: "rag--digitize", After: []string{"rag--opensearch"}},
+		{Pod: "rag--chat-backend", After: []string{"rag--digitize", "rag--vllm-init", "rag--vllm", "rag--opensearch"}},
+		{Pod: "rag--chat-ui", After: []string{"rag--chat-backend"}},
+	}, units)
+}
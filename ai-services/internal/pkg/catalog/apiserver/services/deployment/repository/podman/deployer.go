@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
@@ -18,6 +20,7 @@ import (
 	catalogconstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
@@ -25,8 +28,10 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/image"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/policy"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	podmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
 	"github.com/project-ai-services/ai-services/internal/pkg/proxy"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/specs"
@@ -35,6 +40,10 @@ import (
 	k8syaml "sigs.k8s.io/yaml"
 )
 
+// modelProgressInterval is how often model download progress is written to the
+// application status message.
+const modelProgressInterval = 5 * time.Second
+
 // ComponentInfo holds the information derived from a deployed component.
 type ComponentInfo struct {
 	Endpoint string
@@ -84,7 +93,8 @@ func NewPodmanDeployer(
 // 3. Calculate and allocate Spyre cards if needed
 // 4. Deploy components
 // 5. Deploy services
-// 6. Update database with endpoints and final status
+// 6. Write systemd units starting the pods on boot, when autostart is on
+// 7. Update database with endpoints and final status
 //
 // Note: Application, service, and component records are already created by ApplicationService
 // before this method is called. This method only updates endpoints and status.
@@ -124,7 +134,16 @@ func (d *PodmanDeployer) ExecuteDeployment(
 		return fmt.Errorf("failed to register application routes: %w", err)
 	}
 
-	// Step 5: Update application status to Running.
+	// Step 5: Have systemd start the pods on boot
+	if plan.Autostart {
+		if err := d.writeAutostartUnits(ctx, plan); err != nil {
+			catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Failed to write systemd units", err)
+
+			return fmt.Errorf("failed to write systemd units: %w", err)
+		}
+	}
+
+	// Step 6: Update application status to Running.
 	// Skip if the context was cancelled — deletion is now in charge of the status.
 	if ctx.Err() == nil {
 		if err := catalogutils.UpdateApplicationStatus(ctx, d.appRepo, plan.ApplicationID, models.ApplicationStatusRunning, "Deployment completed successfully"); err != nil {
@@ -178,7 +197,7 @@ func (d *PodmanDeployer) downloadModelsForDeployment(ctx context.Context, plan *
 		return nil
 	}
 
-	if err := d.downloadModels(ctx, modelSet); err != nil {
+	if err := d.downloadModels(ctx, plan, modelSet); err != nil {
 		return err
 	}
 
@@ -216,19 +235,25 @@ func (d *PodmanDeployer) extractModelsFromParams(params map[string]any, modelSet
 	}
 }
 
-// downloadModels downloads all models in the provided set.
-func (d *PodmanDeployer) downloadModels(ctx context.Context, modelSet map[string]bool) error {
-	modelsPath := utils.GetModelsPath()
+// downloadModels downloads all models in the provided set in parallel, reporting
+// the byte progress in the application status message.
+func (d *PodmanDeployer) downloadModels(ctx context.Context, plan *DeploymentPlan, modelSet map[string]bool) error {
+	modelNames := slices.Sorted(maps.Keys(modelSet))
+	logger.InfofCtx(ctx, "Downloading models: %s\n", strings.Join(modelNames, ", "))
 
-	for modelName := range modelSet {
-		logger.InfofCtx(ctx, "Downloading model: %s\n", modelName)
+	var lastUpdate time.Time
+	progress := func(p modelstore.Progress) {
+		if time.Since(lastUpdate) < modelProgressInterval || ctx.Err() != nil {
+			return
+		}
+		lastUpdate = time.Now()
 
-		if err := helpers.DownloadModelContainer(ctx, modelName, modelsPath); err != nil {
-			return fmt.Errorf("failed to download model %s: %w", modelName, err)
+		if err := catalogutils.UpdateApplicationStatus(ctx, d.appRepo, plan.ApplicationID, models.ApplicationStatusDownloading, "Downloading models: "+p.String()); err != nil {
+			logger.WarningfCtx(ctx, "Failed to report model download progress: %v\n", err)
 		}
 	}
 
-	return nil
+	return helpers.DownloadModels(ctx, modelNames, utils.GetModelsPath(), progress)
 }
 
 // pullImagesForDeployment pulls all container images required for components and services.
@@ -246,7 +271,7 @@ func (d *PodmanDeployer) pullImagesForDeployment(ctx context.Context, plan *Depl
 		return nil
 	}
 
-	if err := d.pullImages(ctx, imageSet); err != nil {
+	if err := d.pullImages(ctx, imageSet, plan.Images); err != nil {
 		return err
 	}
 
@@ -257,21 +282,32 @@ func (d *PodmanDeployer) pullImagesForDeployment(ctx context.Context, plan *Depl
 
 // collectImagesFromPlan collects all unique container images from the deployment plan.
 func (d *PodmanDeployer) collectImagesFromPlan(ctx context.Context, plan *DeploymentPlan) (map[string]bool, error) {
-	imageSet := make(map[string]bool)
+	imageSet, err := CollectTemplateImages(ctx, d.catalogProvider, plan)
+	if err != nil {
+		return nil, err
+	}
 
 	// Include tool image which is used for all housekeeping tasks
 	imageSet[vars.ToolImage] = true
 
+	return imageSet, nil
+}
+
+// CollectTemplateImages collects the unique container images the component and
+// service templates of the deployment plan run.
+func CollectTemplateImages(ctx context.Context, provider *catalog.CatalogProvider, plan *DeploymentPlan) (map[string]bool, error) {
+	imageSet := make(map[string]bool)
+
 	// Extract images from component templates
 	for _, comp := range plan.Components {
-		if err := d.extractImagesFromComponent(ctx, comp, imageSet); err != nil {
+		if err := extractImagesFromComponent(ctx, provider, comp, imageSet); err != nil {
 			return nil, err
 		}
 	}
 
 	// Extract images from service templates
 	for _, svc := range plan.Services {
-		if err := d.extractImagesFromService(ctx, svc, imageSet); err != nil {
+		if err := extractImagesFromService(ctx, provider, svc, imageSet); err != nil {
 			return nil, err
 		}
 	}
@@ -280,15 +316,15 @@ func (d *PodmanDeployer) collectImagesFromPlan(ctx context.Context, plan *Deploy
 }
 
 // extractImagesFromComponent extracts container images from a component's templates.
-func (d *PodmanDeployer) extractImagesFromComponent(ctx context.Context, comp *ComponentPlan, imageSet map[string]bool) error {
+func extractImagesFromComponent(ctx context.Context, provider *catalog.CatalogProvider, comp *ComponentPlan, imageSet map[string]bool) error {
 	// Load component templates
-	templates, err := d.catalogProvider.LoadComponentTemplates(comp.ComponentType, comp.ProviderID)
+	templates, err := provider.LoadComponentTemplates(comp.ComponentType, comp.ProviderID)
 	if err != nil {
 		return fmt.Errorf("failed to load component templates for %s/%s: %w", comp.ComponentType, comp.ProviderID, err)
 	}
 
 	// Extract images from templates with custom values directly into imageSet
-	if err := d.catalogProvider.CollectImagesFromTemplates(ctx, templates, comp.Values, imageSet); err != nil {
+	if err := provider.CollectImagesFromTemplates(ctx, templates, comp.Values, imageSet); err != nil {
 		return fmt.Errorf("failed to extract images from component %s/%s: %w", comp.ComponentType, comp.ProviderID, err)
 	}
 
@@ -296,15 +332,15 @@ func (d *PodmanDeployer) extractImagesFromComponent(ctx context.Context, comp *C
 }
 
 // extractImagesFromService extracts container images from a service's templates.
-func (d *PodmanDeployer) extractImagesFromService(ctx context.Context, svc *ServicePlan, imageSet map[string]bool) error {
+func extractImagesFromService(ctx context.Context, provider *catalog.CatalogProvider, svc *ServicePlan, imageSet map[string]bool) error {
 	// Load service templates
-	templates, err := d.catalogProvider.LoadServiceTemplates(svc.CatalogID)
+	templates, err := provider.LoadServiceTemplates(svc.CatalogID)
 	if err != nil {
 		return fmt.Errorf("failed to load service templates for %s: %w", svc.CatalogID, err)
 	}
 
 	// Extract images from templates with custom values directly into imageSet
-	if err := d.catalogProvider.CollectImagesFromTemplates(ctx, templates, svc.Values, imageSet); err != nil {
+	if err := provider.CollectImagesFromTemplates(ctx, templates, svc.Values, imageSet); err != nil {
 		return fmt.Errorf("failed to extract images from service %s: %w", svc.CatalogID, err)
 	}
 
@@ -312,11 +348,15 @@ func (d *PodmanDeployer) extractImagesFromService(ctx context.Context, svc *Serv
 }
 
 // pullImages pulls only missing images from the provided set using the runtime.
-// Images that are already present locally are skipped.
-func (d *PodmanDeployer) pullImages(ctx context.Context, imageSet map[string]bool) error {
+// Images that are already present locally are skipped. Pinned images are pulled
+// by the digest they are pinned to.
+func (d *PodmanDeployer) pullImages(ctx context.Context, imageSet map[string]bool, pins map[string]string) error {
 	// Convert map to slice
 	images := make([]string, 0, len(imageSet))
 	for img := range imageSet {
+		if pinned, ok := pins[img]; ok {
+			img = pinned
+		}
 		images = append(images, img)
 	}
 
@@ -511,6 +551,11 @@ func (d *PodmanDeployer) deployComponentPods(
 	// Use the loaded Values from the component plan (includes defaults from values.yaml + overrides)
 	values := comp.Values
 
+	alloc, err := d.allocateResources(ctx, comp.Resources, tmpls, values)
+	if err != nil {
+		return fmt.Errorf("failed to allocate resources of component %s: %w", comp.ComponentType, err)
+	}
+
 	// Initialize component endpoints map to store extracted endpoint info
 	componentEndpoints := make(map[string]any)
 
@@ -518,6 +563,7 @@ func (d *PodmanDeployer) deployComponentPods(
 	if len(metadata.PodTemplateExecutions) > 0 {
 		// Execute each pod template in the component following the defined order
 		for _, layer := range metadata.PodTemplateExecutions {
+			var pods []string
 			for _, podTemplateName := range layer {
 				// Prepare initialParams for the template
 				initialParams := map[string]any{
@@ -526,17 +572,24 @@ func (d *PodmanDeployer) deployComponentPods(
 					"BaseDir":      utils.GetBaseDir(),
 					"Values":       values,
 					"env":          map[string]map[string]string{},
+					"placement":    map[string]map[string]string{},
 				}
 
 				// Pass componentEndpoints to collect endpoint info, use component type as ID
-				if err := d.deployComponentTemplate(ctx, podTemplateName, tmpls, plan, initialParams, componentEndpoints, comp.ComponentType); err != nil {
+				podName, err := d.deployComponentTemplate(ctx, podTemplateName, tmpls, plan, initialParams, componentEndpoints, comp.ComponentType, alloc)
+				if err != nil {
 					return fmt.Errorf("failed to deploy pod template %s: %w", podTemplateName, err)
 				}
+				if podName != "" {
+					pods = append(pods, podName)
+				}
 			}
+			comp.PodLayers = appendPodLayer(comp.PodLayers, pods)
 		}
 	} else {
 		// If no PodTemplateExecutions defined, deploy all templates
 		logger.InfofCtx(ctx, "No PodTemplateExecutions defined for %s, deploying all templates\n", componentPath)
+		var pods []string
 		for templateName := range tmpls {
 			// Prepare initialParams for the template
 			initialParams := map[string]any{
@@ -545,13 +598,19 @@ func (d *PodmanDeployer) deployComponentPods(
 				"BaseDir":      utils.GetBaseDir(),
 				"Values":       values,
 				"env":          map[string]map[string]string{},
+				"placement":    map[string]map[string]string{},
 			}
 
 			// Pass componentEndpoints to collect endpoint info, use component type as ID
-			if err := d.deployComponentTemplate(ctx, templateName, tmpls, plan, initialParams, componentEndpoints, comp.ComponentType); err != nil {
+			podName, err := d.deployComponentTemplate(ctx, templateName, tmpls, plan, initialParams, componentEndpoints, comp.ComponentType, alloc)
+			if err != nil {
 				return fmt.Errorf("failed to deploy pod template %s: %w", templateName, err)
 			}
+			if podName != "" {
+				pods = append(pods, podName)
+			}
 		}
+		comp.PodLayers = appendPodLayer(comp.PodLayers, pods)
 	}
 
 	// Store extracted endpoints in the component plan for use by services
@@ -618,7 +677,7 @@ func (d *PodmanDeployer) deployService(ctx context.Context, plan *DeploymentPlan
 	}
 
 	// Deploy service pods
-	if err := d.deployServicePods(ctx, plan.ApplicationID, svc, serviceAppMetadata, tmpls); err != nil {
+	if err := d.deployServicePods(ctx, plan, svc, serviceAppMetadata, tmpls); err != nil {
 		return fmt.Errorf("failed to deploy service pods: %w", err)
 	}
 
@@ -630,7 +689,7 @@ func (d *PodmanDeployer) deployService(ctx context.Context, plan *DeploymentPlan
 // deployServicePods deploys all pods for a service and collects routes annotations.
 func (d *PodmanDeployer) deployServicePods(
 	ctx context.Context,
-	applicationID uuid.UUID,
+	plan *DeploymentPlan,
 	svc *ServicePlan,
 	metadata *templates.AppMetadata,
 	tmpls map[string]*template.Template,
@@ -643,27 +702,33 @@ func (d *PodmanDeployer) deployServicePods(
 		svc.Routes = make(map[string]string)
 	}
 
+	alloc, err := d.allocateResources(ctx, svc.Resources, tmpls, values)
+	if err != nil {
+		return fmt.Errorf("failed to allocate resources of service %s: %w", svc.CatalogID, err)
+	}
+
 	// If PodTemplateExecutions is defined, use it for ordered deployment
 	if len(metadata.PodTemplateExecutions) > 0 {
-		return d.deployPodTemplatesInOrder(ctx, applicationID, svc, metadata, tmpls, values)
+		return d.deployPodTemplatesInOrder(ctx, plan, svc, metadata, tmpls, values, alloc)
 	}
 
 	// If no PodTemplateExecutions defined, deploy all templates
-	return d.deployAllPodTemplates(ctx, applicationID, svc, tmpls, values)
+	return d.deployAllPodTemplates(ctx, plan, svc, tmpls, values, alloc)
 }
 
 // deployPodTemplatesInOrder deploys pod templates following the defined execution order.
 func (d *PodmanDeployer) deployPodTemplatesInOrder(
 	ctx context.Context,
-	applicationID uuid.UUID,
+	plan *DeploymentPlan,
 	svc *ServicePlan,
 	metadata *templates.AppMetadata,
 	tmpls map[string]*template.Template,
 	values map[string]any,
+	alloc *resources.Allocation,
 ) error {
 	// Execute each pod template in the service following the defined order
 	for _, layer := range metadata.PodTemplateExecutions {
-		if err := d.deployPodTemplateLayer(ctx, applicationID, svc, layer, tmpls, values); err != nil {
+		if err := d.deployPodTemplateLayer(ctx, plan, svc, layer, tmpls, values, alloc); err != nil {
 			return err
 		}
 	}
@@ -674,16 +739,18 @@ func (d *PodmanDeployer) deployPodTemplatesInOrder(
 // deployPodTemplateLayer deploys all pod templates in a single layer.
 func (d *PodmanDeployer) deployPodTemplateLayer(
 	ctx context.Context,
-	applicationID uuid.UUID,
+	plan *DeploymentPlan,
 	svc *ServicePlan,
 	layer []string,
 	tmpls map[string]*template.Template,
 	values map[string]any,
+	alloc *resources.Allocation,
 ) error {
+	var pods []string
 	for _, podTemplateName := range layer {
-		initialParams := d.buildInitialParams(applicationID, svc.DatabaseID, values)
+		initialParams := d.buildInitialParams(plan.ApplicationID, svc.DatabaseID, values)
 
-		_, podName, routes, err := d.deployPodTemplate(ctx, podTemplateName, tmpls, initialParams)
+		_, podName, routes, err := d.deployPodTemplate(ctx, podTemplateName, tmpls, initialParams, plan.Images, alloc)
 		if err != nil {
 			return fmt.Errorf("failed to deploy pod template %s: %w", podTemplateName, err)
 		}
@@ -691,7 +758,11 @@ func (d *PodmanDeployer) deployPodTemplateLayer(
 		if routes != "" {
 			svc.Routes[podName] = routes
 		}
+		if podName != "" {
+			pods = append(pods, podName)
+		}
 	}
+	svc.PodLayers = appendPodLayer(svc.PodLayers, pods)
 
 	return nil
 }
@@ -699,15 +770,17 @@ func (d *PodmanDeployer) deployPodTemplateLayer(
 // deployAllPodTemplates deploys all pod templates without a specific order.
 func (d *PodmanDeployer) deployAllPodTemplates(
 	ctx context.Context,
-	applicationID uuid.UUID,
+	plan *DeploymentPlan,
 	svc *ServicePlan,
 	tmpls map[string]*template.Template,
 	values map[string]any,
+	alloc *resources.Allocation,
 ) error {
+	var pods []string
 	for templateName := range tmpls {
-		initialParams := d.buildInitialParams(applicationID, svc.DatabaseID, values)
+		initialParams := d.buildInitialParams(plan.ApplicationID, svc.DatabaseID, values)
 
-		_, podName, routes, err := d.deployPodTemplate(ctx, templateName, tmpls, initialParams)
+		_, podName, routes, err := d.deployPodTemplate(ctx, templateName, tmpls, initialParams, plan.Images, alloc)
 		if err != nil {
 			return fmt.Errorf("failed to deploy pod template %s: %w", templateName, err)
 		}
@@ -715,7 +788,11 @@ func (d *PodmanDeployer) deployAllPodTemplates(
 		if routes != "" {
 			svc.Routes[podName] = routes
 		}
+		if podName != "" {
+			pods = append(pods, podName)
+		}
 	}
+	svc.PodLayers = appendPodLayer(svc.PodLayers, pods)
 
 	return nil
 }
@@ -731,7 +808,8 @@ func (d *PodmanDeployer) buildInitialParams(applicationID uuid.UUID, databaseID
 	}
 }
 
-// deployComponentTemplate deploys a component pod template.
+// deployComponentTemplate deploys a component pod template and returns the name of
+// its pod, empty when the template renders no pod.
 // This is a generic method to deploy all component templates with Spyre card support.
 // The serviceParams map is updated with the component's endpoint information (host and port).
 func (d *PodmanDeployer) deployComponentTemplate(
@@ -742,43 +820,44 @@ func (d *PodmanDeployer) deployComponentTemplate(
 	initialParams map[string]any,
 	serviceParams map[string]any,
 	componentID string,
-) error {
+	alloc *resources.Allocation,
+) (string, error) {
 	logger.InfofCtx(ctx, "Deploying component template '%s'...\n", podTemplateName)
 
 	podTemplate, ok := tmpls[podTemplateName]
 	if !ok {
-		return fmt.Errorf("pod template '%s' not found", podTemplateName)
+		return "", fmt.Errorf("pod template '%s' not found", podTemplateName)
 	}
 
 	// Render and parse initial template
 	podSpec, err := d.renderAndParsePodTemplate(podTemplate, podTemplateName, initialParams)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Get environment parameters and render final template
 	finalPodSpec, renderedBytes, err := d.renderFinalPodTemplate(ctx, podTemplate, podTemplateName, initialParams, podSpec, plan)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if strings.TrimSpace(string(renderedBytes)) == "" {
 		// skip deploy if there is nothing to apply
-		return nil
+		return "", nil
 	}
 
 	// Check if pod already exists
 	if exists, err := d.runtime.PodExists(finalPodSpec.Name); err != nil {
-		return fmt.Errorf("failed to check pod existence: %w", err)
+		return "", fmt.Errorf("failed to check pod existence: %w", err)
 	} else if exists {
 		logger.InfofCtx(ctx, "Pod '%s' already exists, skipping deployment\n", podSpec.Name)
 
-		return nil
+		return finalPodSpec.Name, nil
 	}
 
 	// Deploy the pod using rendered bytes directly
-	if err := d.deployPodSpec(ctx, finalPodSpec, renderedBytes, podTemplateName); err != nil {
-		return err
+	if err := d.deployPodSpec(ctx, finalPodSpec, renderedBytes, podTemplateName, plan.Images, alloc); err != nil {
+		return "", err
 	}
 
 	logger.InfofCtx(ctx, "Component template '%s' deployed successfully\n", podTemplateName)
@@ -786,7 +865,7 @@ func (d *PodmanDeployer) deployComponentTemplate(
 	// Update service params with endpoint information
 	d.updateServiceParamsWithEndpoint(ctx, serviceParams, componentID, finalPodSpec)
 
-	return nil
+	return finalPodSpec.Name, nil
 }
 
 // renderAndParsePodTemplate renders a pod template and parses it into a PodSpec.
@@ -818,13 +897,14 @@ func (d *PodmanDeployer) renderFinalPodTemplate(
 	podSpec *podmodels.PodSpec,
 	plan *DeploymentPlan,
 ) (*podmodels.PodSpec, []byte, error) {
-	env, err := d.getEnvParamsForComponent(ctx, podSpec, plan)
+	env, placement, err := d.getEnvParamsForComponent(ctx, podSpec, plan)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get env params: %w", err)
 	}
 
 	// Always set/overwrite the env to ensure Spyre card PCI addresses are included
 	initialParams["env"] = env
+	initialParams["placement"] = placement
 
 	var finalRendered bytes.Buffer
 	if err := podTemplate.Execute(&finalRendered, initialParams); err != nil {
@@ -842,11 +922,24 @@ func (d *PodmanDeployer) renderFinalPodTemplate(
 	return &finalPodSpec, renderedBytes, nil
 }
 
-// deployPodSpec deploys a pod using the rendered YAML bytes directly.
-func (d *PodmanDeployer) deployPodSpec(ctx context.Context, podSpec *podmodels.PodSpec, renderedBytes []byte, templateName string) error {
+// deployPodSpec deploys a pod using the rendered YAML bytes directly, with its
+// images replaced by the digests they are pinned to and the resources of its
+// component or service enforced on its containers.
+func (d *PodmanDeployer) deployPodSpec(
+	ctx context.Context,
+	podSpec *podmodels.PodSpec,
+	renderedBytes []byte,
+	templateName string,
+	pins map[string]string,
+	alloc *resources.Allocation,
+) error {
 	// Use the rendered bytes directly instead of marshaling PodSpec
+	manifest, err := alloc.Apply(policy.PinManifest(renderedBytes, pins))
+	if err != nil {
+		return fmt.Errorf("failed to enforce resources on pod %s: %w", podSpec.Name, err)
+	}
 
-	reader := bytes.NewReader(renderedBytes)
+	reader := bytes.NewReader(manifest)
 	podAnnotations := specs.FetchPodAnnotations(*podSpec)
 	podDeployOptions := clipodman.ConstructPodDeployOptions(podAnnotations)
 
@@ -924,6 +1017,8 @@ func (d *PodmanDeployer) deployPodTemplate(
 	podTemplateName string,
 	tmpls map[string]*template.Template,
 	initialParams map[string]any,
+	pins map[string]string,
+	alloc *resources.Allocation,
 ) (map[string]string, string, string, error) {
 	logger.InfofCtx(ctx, "Deploying service template '%s'...\n", podTemplateName)
 
@@ -970,7 +1065,7 @@ func (d *PodmanDeployer) deployPodTemplate(
 	}
 
 	// Deploy using rendered bytes directly (same as components)
-	if err := d.deployPodSpec(ctx, &podSpec, renderedBytes, podTemplateName); err != nil {
+	if err := d.deployPodSpec(ctx, &podSpec, renderedBytes, podTemplateName, pins, alloc); err != nil {
 		return nil, "", "", err
 	}
 
@@ -1019,9 +1114,15 @@ func (d *PodmanDeployer) fetchSpyreCardsFromPodAnnotations(annotations map[strin
 	return spyreCards, spyreCardContainerMap, nil
 }
 
-// getEnvParamsForComponent returns environment parameters for a component including Spyre card PCI addresses.
-func (d *PodmanDeployer) getEnvParamsForComponent(ctx context.Context, podSpec *podmodels.PodSpec, plan *DeploymentPlan) (map[string]map[string]string, error) {
+// getEnvParamsForComponent returns environment parameters for a component including Spyre card PCI addresses,
+// and the per-container cpuset and memory-node placement matching the NUMA nodes of the allocated cards.
+func (d *PodmanDeployer) getEnvParamsForComponent(
+	ctx context.Context,
+	podSpec *podmodels.PodSpec,
+	plan *DeploymentPlan,
+) (map[string]map[string]string, map[string]map[string]string, error) {
 	env := make(map[string]map[string]string)
+	placement := make(map[string]map[string]string)
 
 	// Get container names from pod spec
 	for _, container := range podSpec.Spec.Containers {
@@ -1029,31 +1130,31 @@ func (d *PodmanDeployer) getEnvParamsForComponent(ctx context.Context, podSpec *
 	}
 
 	if plan.SpyreCardPool == nil {
-		return env, nil
+		return env, placement, nil
 	}
 
 	// Fetch Spyre card requirements from annotations
 	spyreCards, spyreCardContainerMap, err := d.fetchSpyreCardsFromPodAnnotations(podSpec.Annotations)
 	if err != nil {
-		return env, err
+		return env, placement, err
 	}
 
 	if spyreCards == 0 {
-		return env, nil
+		return env, placement, nil
 	}
 
 	// Allocate PCI addresses to containers that need them
 	for containerName, spyreCount := range spyreCardContainerMap {
 		if spyreCount != 0 {
 			// Allocate addresses from the pool (thread-safe)
-			allocatedAddresses, err := plan.SpyreCardPool.Allocate(spyreCount)
+			allocation, err := plan.SpyreCardPool.Allocate(spyreCount)
 			if err != nil {
-				return env, fmt.Errorf("failed to allocate Spyre cards for container %s: %w", containerName, err)
+				return env, placement, fmt.Errorf("failed to allocate Spyre cards for container %s: %w", containerName, err)
 			}
 
 			// Join addresses with space separator
 			pciAddressStr := ""
-			for i, addr := range allocatedAddresses {
+			for i, addr := range allocation.Addresses {
 				if i > 0 {
 					pciAddressStr += " "
 				}
@@ -1064,10 +1165,20 @@ func (d *PodmanDeployer) getEnvParamsForComponent(ctx context.Context, podSpec *
 
 			logger.DebugfCtx(ctx, "Allocated %d Spyre cards to container '%s' in pod '%s': %s\n",
 				spyreCount, containerName, podSpec.Name, pciAddressStr)
+
+			if allocation.Placement != nil {
+				placement[containerName] = map[string]string{
+					"cpuset":      allocation.Placement.CPUs,
+					"memoryNodes": allocation.Placement.MemoryNodes,
+				}
+
+				logger.DebugfCtx(ctx, "Pinned container '%s' in pod '%s' to CPUs %s and memory nodes %s\n",
+					containerName, podSpec.Name, allocation.Placement.CPUs, allocation.Placement.MemoryNodes)
+			}
 		}
 	}
 
-	return env, nil
+	return env, placement, nil
 }
 
 // registerApplicationRoutes registers routes for all services with Caddy proxy and updates endpoints in database.
@@ -0,0 +1,43 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	podmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+// allocateResources spreads the resources enforced on a component or service
+// over the containers of the pods its templates render with values. It returns
+// nil when no resources are enforced.
+func (d *PodmanDeployer) allocateResources(
+	ctx context.Context,
+	limits *models.ResourceLimits,
+	tmpls map[string]*template.Template,
+	values map[string]any,
+) (*resources.Allocation, error) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	var pods []*podmodels.PodSpec
+	err := d.catalogProvider.ProcessTemplates(ctx, tmpls, values, "resource-allocation", func(_ string, podSpec *podmodels.PodSpec) error {
+		if podSpec.Kind == "Pod" {
+			pods = append(pods, podSpec)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render pod templates: %w", err)
+	}
+
+	logger.InfofCtx(ctx, "Limiting the containers of %d pod(s) to %d CPUs and %d bytes of memory\n",
+		len(pods), limits.CPU, limits.MemoryBytes)
+
+	return resources.Allocate(limits, pods), nil
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource names used in admission breakdowns.
+const (
+	ResourceCPU        = "cpu"
+	ResourceMemory     = "memory"
+	ResourceSpyreCards = "spyre_cards"
+)
+
+// ResourceAmounts is an amount of CPU, memory and Spyre cards.
+type ResourceAmounts struct {
+	CPU         int   // CPU cores
+	MemoryBytes int64 // Memory in bytes
+	SpyreCards  int
+}
+
+// Add returns the sum of both amounts.
+func (r ResourceAmounts) Add(o ResourceAmounts) ResourceAmounts {
+	return ResourceAmounts{
+		CPU:         r.CPU + o.CPU,
+		MemoryBytes: r.MemoryBytes + o.MemoryBytes,
+		SpyreCards:  r.SpyreCards + o.SpyreCards,
+	}
+}
+
+// Max returns the per-resource maximum of both amounts.
+func (r ResourceAmounts) Max(o ResourceAmounts) ResourceAmounts {
+	return ResourceAmounts{
+		CPU:         max(r.CPU, o.CPU),
+		MemoryBytes: max(r.MemoryBytes, o.MemoryBytes),
+		SpyreCards:  max(r.SpyreCards, o.SpyreCards),
+	}
+}
+
+// ResourceCheck is the admission result for a single resource.
+type ResourceCheck struct {
+	Resource  string
+	Required  int64
+	Capacity  int64 // Host total
+	Allocated int64 // Reserved by existing applications
+	Available int64 // What is left for the new application
+}
+
+// Fits reports whether the required amount is available.
+func (c ResourceCheck) Fits() bool {
+	return c.Required <= c.Available
+}
+
+func (c ResourceCheck) String() string {
+	format := func(v int64) string { return fmt.Sprintf("%d", v) }
+	if c.Resource == ResourceMemory {
+		format = formatBytes
+	}
+
+	return fmt.Sprintf("%s: required %s, available %s (capacity %s, allocated %s)",
+		c.Resource, format(c.Required), format(c.Available), format(c.Capacity), format(c.Allocated))
+}
+
+// ErrInsufficientCapacity is returned by admission when an application does not fit on the host.
+type ErrInsufficientCapacity struct {
+	ApplicationName string
+	// Checks holds the breakdown of every checked resource, including those that fit.
+	Checks []ResourceCheck
+}
+
+func (e *ErrInsufficientCapacity) Error() string {
+	var short []string
+	for _, c := range e.Checks {
+		if !c.Fits() {
+			short = append(short, c.String())
+		}
+	}
+
+	return fmt.Sprintf("insufficient host capacity for application '%s': %s; set ignore_capacity to deploy anyway",
+		e.ApplicationName, strings.Join(short, "; "))
+}
+
+// formatBytes renders a byte count in GiB, the unit used by the catalog metadata.
+func formatBytes(b int64) string {
+	const gib = 1 << 30
+
+	return fmt.Sprintf("%.1fGi", float64(b)/gib)
+}
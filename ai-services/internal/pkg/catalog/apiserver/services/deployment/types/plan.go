@@ -5,6 +5,8 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/topology"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 )
 
 // DeploymentPlan represents the complete deployment plan for an application.
@@ -17,41 +19,61 @@ type DeploymentPlan struct {
 	Components      map[string]*ComponentPlan // Key: component hash, Value: component plan
 	Services        map[string]*ServicePlan   // Key: service ID, Value: service plan
 	SpyreCardPool   *SpyreCardPool            // Allocated Spyre card pool (set after allocation)
+	Images          map[string]string         // Template image -> image pinned by digest (set when digest pinning is on)
+	Autostart       bool                      // Generate systemd units starting the pods on boot (podman only)
 }
 
 // ComponentPlan represents a single component deployment.
 type ComponentPlan struct {
-	Hash           string         // Unique hash identifying this component configuration
-	ComponentType  string         // e.g., "vector_db", "llm", "embedding"
-	ProviderID     string         // e.g., "opensearch", "vllm"
-	CatalogPath    string         // Dynamic catalog path (e.g., "components/llm/vllm-cpu/podman")
-	DatabaseID     uuid.UUID      // Database UUID for this component record (set after DB insertion)
-	Version        string         // Component version
-	Params         map[string]any // Component parameters
-	UsedByServices []string       // List of service IDs that use this component
-	Values         map[string]any // Structured values from LoadComponentValues
-	Endpoints      map[string]any // Extracted endpoints after deployment (populated by deployer)
+	Hash           string                 // Unique hash identifying this component configuration
+	ComponentType  string                 // e.g., "vector_db", "llm", "embedding"
+	ProviderID     string                 // e.g., "opensearch", "vllm"
+	CatalogPath    string                 // Dynamic catalog path (e.g., "components/llm/vllm-cpu/podman")
+	DatabaseID     uuid.UUID              // Database UUID for this component record (set after DB insertion)
+	Version        string                 // Component version
+	Params         map[string]any         // Component parameters
+	UsedByServices []string               // List of service IDs that use this component
+	Values         map[string]any         // Structured values from LoadComponentValues
+	Endpoints      map[string]any         // Extracted endpoints after deployment (populated by deployer)
+	PodLayers      [][]string             // Pods deployed, in the order of their pod template executions (populated by deployer)
+	Resources      *models.ResourceLimits // CPU and memory enforced on the pods: runtime metadata with the params override
 }
 
 // ServicePlan represents a single service deployment.
 type ServicePlan struct {
-	CatalogID     string            // Service catalog ID (e.g., "chat", "digitize")
-	CatalogPath   string            // Dynamic catalog path (e.g., "services/chat/podman")
-	DatabaseID    uuid.UUID         // Database UUID for this service record (set after DB insertion)
-	Version       string            // Service version
-	ComponentRefs []string          // List of component hashes this service uses
-	Values        map[string]any    // Structured values from LoadServiceValues + component values
-	Routes        map[string]string // Routes extracted during deployment: podName -> routes annotation
+	CatalogID     string                 // Service catalog ID (e.g., "chat", "digitize")
+	CatalogPath   string                 // Dynamic catalog path (e.g., "services/chat/podman")
+	DatabaseID    uuid.UUID              // Database UUID for this service record (set after DB insertion)
+	Version       string                 // Service version
+	ComponentRefs []string               // List of component hashes this service uses
+	Values        map[string]any         // Structured values from LoadServiceValues + component values
+	Routes        map[string]string      // Routes extracted during deployment: podName -> routes annotation
+	PodLayers     [][]string             // Pods deployed, in the order of their pod template executions (populated by deployer)
+	Resources     *models.ResourceLimits // CPU and memory enforced on the pods: runtime metadata with the params override
 }
 
 // SpyreCardPool manages allocation of PCI addresses to components.
 type SpyreCardPool struct {
 	Addresses []string
-	mutex     sync.Mutex
+	// NUMANodes maps card addresses to their NUMA node. Cards missing from the map
+	// have unknown affinity.
+	NUMANodes map[string]int
+	// Topology is the host NUMA layout used to pin pods next to their cards.
+	// Nil disables CPU and memory pinning.
+	Topology *topology.Topology
+	mutex    sync.Mutex
 }
 
-// Allocate takes n addresses from the pool and returns them.
-func (p *SpyreCardPool) Allocate(n int) ([]string, error) {
+// SpyreAllocation is the result of allocating cards from the pool.
+type SpyreAllocation struct {
+	Addresses []string
+	// Placement constrains CPUs and memory to the NUMA nodes of the allocated
+	// cards. Nil when the host is not NUMA or the cards' affinity is unknown.
+	Placement *topology.Placement
+}
+
+// Allocate takes n addresses from the pool, preferring cards that share a NUMA node.
+func (p *SpyreCardPool) Allocate(n int) (*SpyreAllocation, error) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -59,11 +81,28 @@ func (p *SpyreCardPool) Allocate(n int) ([]string, error) {
 		return nil, ErrInsufficientSpyreCards{Need: n, Have: len(p.Addresses)}
 	}
 
-	allocated := make([]string, n)
-	copy(allocated, p.Addresses[:n])
-	p.Addresses = p.Addresses[n:]
+	selected, nodes := topology.SelectCards(p.Addresses, p.NUMANodes, n)
+
+	taken := make(map[string]bool, len(selected))
+	for _, addr := range selected {
+		taken[addr] = true
+	}
+	remaining := make([]string, 0, len(p.Addresses)-len(selected))
+	for _, addr := range p.Addresses {
+		if !taken[addr] {
+			remaining = append(remaining, addr)
+		}
+	}
+	p.Addresses = remaining
+
+	allocation := &SpyreAllocation{Addresses: selected}
+	if p.Topology.IsNUMA() {
+		if placement, ok := p.Topology.PlacementFor(nodes); ok {
+			allocation.Placement = &placement
+		}
+	}
 
-	return allocated, nil
+	return allocation, nil
 }
 
 // ErrInsufficientSpyreCards is returned when there are not enough Spyre cards available.
@@ -0,0 +1,70 @@
+// Package events records and lists application events. Events report background
+// activity such as scheduled backups without touching the application status,
+// which the sync service derives from the running pods.
+package events
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// ServiceInterface is the dependency injected into EventHandler and into services that emit events.
+type ServiceInterface interface {
+	// Record stores an event for the application. Failures are logged, never returned,
+	// so recording an event cannot fail the operation it reports on.
+	Record(ctx context.Context, appID uuid.UUID, eventType models.ApplicationEventType, reason, message string)
+
+	// List returns the most recent events of the application, newest first.
+	List(ctx context.Context, appID uuid.UUID, limit int) (*catalogtypes.ApplicationEventListResponse, error)
+}
+
+// service implements ServiceInterface.
+type service struct {
+	repo repository.ApplicationEventRepository
+	apps apirepository.ApplicationServiceInterface
+}
+
+// NewService creates an application event service.
+func NewService(repo repository.ApplicationEventRepository, apps apirepository.ApplicationServiceInterface) ServiceInterface {
+	return &service{repo: repo, apps: apps}
+}
+
+// Record implements ServiceInterface.
+func (s *service) Record(ctx context.Context, appID uuid.UUID, eventType models.ApplicationEventType, reason, message string) {
+	event := &models.ApplicationEvent{AppID: appID, Type: eventType, Reason: reason, Message: message}
+	if err := s.repo.Insert(ctx, event); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to record %s event for application %s: %v", reason, appID, err)
+	}
+}
+
+// List implements ServiceInterface.
+func (s *service) List(ctx context.Context, appID uuid.UUID, limit int) (*catalogtypes.ApplicationEventListResponse, error) {
+	if _, err := s.apps.GetApplicationByID(ctx, appID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListByApp(ctx, appID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.ApplicationEventListResponse{Events: make([]catalogtypes.ApplicationEvent, 0, len(events)), Total: len(events)}
+	for _, e := range events {
+		resp.Events = append(resp.Events, catalogtypes.ApplicationEvent{
+			ID:        e.ID.String(),
+			Type:      string(e.Type),
+			Reason:    e.Reason,
+			Message:   e.Message,
+			CreatedAt: e.CreatedAt.Format(constants.RFC3339WithTimezone),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,333 @@
+// Package modelcache reports the models cached in the models directory of the
+// catalog host, which applications use them, and verifies and prunes them.
+package modelcache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// watsonxProvider serves its models remotely; its model params never refer to the cache.
+const watsonxProvider = "watsonx"
+
+// ServiceInterface is the dependency injected into ModelHandler.
+type ServiceInterface interface {
+	// List returns the cached models with their size, revision, download date and
+	// the application components configured to serve them.
+	List(ctx context.Context) (*catalogtypes.ModelListResponse, error)
+	// Verify checks the files of the cached models, or of one model, against their
+	// recorded checksums.
+	Verify(ctx context.Context, req catalogtypes.ModelVerifyRequest) (*catalogtypes.ModelVerifyResponse, error)
+	// Prune removes the cached models no application component refers to and no
+	// pod of the host serves.
+	Prune(ctx context.Context, req catalogtypes.ModelPruneRequest) (*catalogtypes.ModelPruneResponse, error)
+}
+
+// host is the podman host whose pods serve the cached models.
+type host interface {
+	ListPods(filters map[string][]string) ([]runtimeTypes.Pod, error)
+	InspectContainer(nameOrID string) (*runtimeTypes.Container, error)
+}
+
+// service implements ServiceInterface.
+type service struct {
+	appRepo     repository.ApplicationRepository
+	compRepo    repository.ComponentRepository
+	svcDepRepo  repository.ServiceDependencyRepository
+	runtimeType runtimeTypes.RuntimeType
+	modelsDir   string
+
+	// connect returns the host whose pods are checked; replaced in tests.
+	connect func() (host, error)
+}
+
+// NewService creates a model cache service for the models in modelsDir.
+func NewService(appRepo repository.ApplicationRepository, compRepo repository.ComponentRepository,
+	svcDepRepo repository.ServiceDependencyRepository, runtimeType runtimeTypes.RuntimeType, modelsDir string) ServiceInterface {
+	return &service{
+		appRepo:     appRepo,
+		compRepo:    compRepo,
+		svcDepRepo:  svcDepRepo,
+		runtimeType: runtimeType,
+		modelsDir:   modelsDir,
+		connect: func() (host, error) {
+			return podman.NewPodmanClient()
+		},
+	}
+}
+
+// List implements ServiceInterface.
+func (s *service) List(ctx context.Context) (*catalogtypes.ModelListResponse, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	cached, err := modelstore.List(s.modelsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.references(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.ModelListResponse{Models: make([]catalogtypes.CachedModel, 0, len(cached))}
+	for _, m := range cached {
+		model := toCachedModel(m, refs[m.Model])
+		resp.TotalSize += model.Size
+		if len(model.UsedBy) == 0 {
+			resp.UnreferencedSize += model.Size
+		}
+		resp.Models = append(resp.Models, model)
+	}
+	resp.Total = len(resp.Models)
+
+	return resp, nil
+}
+
+// Verify implements ServiceInterface.
+func (s *service) Verify(ctx context.Context, req catalogtypes.ModelVerifyRequest) (*catalogtypes.ModelVerifyResponse, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	cached, err := modelstore.List(s.modelsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.ModelVerifyResponse{Models: []catalogtypes.ModelVerification{}, Healthy: true}
+	for _, m := range cached {
+		if req.Model != "" && m.Model != req.Model {
+			continue
+		}
+
+		verification := catalogtypes.ModelVerification{Model: m.Model}
+		result, err := modelstore.VerifyModel(s.modelsDir, m.Model)
+		if err != nil {
+			logger.WarningfCtx(ctx, "model cache: failed to verify %s: %v", m.Model, err)
+			verification.Error = err.Error()
+		} else {
+			verification.VerifiedAgainst = result.Against
+			verification.Checked = result.Checked
+			verification.Failed = result.Failed
+			verification.Unverifiable = result.Unverifiable
+		}
+		if verification.Error != "" || len(verification.Failed) > 0 {
+			resp.Healthy = false
+		}
+		resp.Models = append(resp.Models, verification)
+	}
+
+	if req.Model != "" && len(resp.Models) == 0 {
+		return nil, &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("model %s is not cached", req.Model)}
+	}
+
+	return resp, nil
+}
+
+// Prune implements ServiceInterface. It refuses to run while an application is
+// downloading or deploying, since its models may not be referenced in the DB yet.
+func (s *service) Prune(ctx context.Context, req catalogtypes.ModelPruneRequest) (*catalogtypes.ModelPruneResponse, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	apps, err := s.appRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		if app.Status == dbmodels.ApplicationStatusDownloading || app.Status == dbmodels.ApplicationStatusDeploying {
+			return nil, &validators.ValidationError{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("application %s is %s; retry once it has finished", app.Name, strings.ToLower(string(app.Status))),
+			}
+		}
+	}
+
+	listed, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.ModelPruneResponse{DryRun: req.DryRun, Removed: []catalogtypes.CachedModel{}}
+	for _, m := range listed.Models {
+		// Registered models are removed by unregistering them.
+		if len(m.UsedBy) > 0 || m.Source == modelstore.SourceLocal || m.Source == modelstore.SourceOCI {
+			continue
+		}
+
+		if !req.DryRun {
+			if err := modelstore.Remove(s.modelsDir, m.Model); err != nil {
+				return nil, err
+			}
+			logger.InfofCtx(ctx, "model cache: removed unreferenced model %s", m.Model)
+		}
+		resp.Removed = append(resp.Removed, m)
+		resp.Reclaimed += m.Size
+	}
+
+	return resp, nil
+}
+
+// checkRuntime rejects requests on runtimes without a models directory on the
+// catalog host; on OpenShift models live in persistent volumes.
+func (s *service) checkRuntime() error {
+	if s.runtimeType != runtimeTypes.RuntimeTypePodman {
+		return &validators.ValidationError{
+			Code:    http.StatusNotImplemented,
+			Message: fmt.Sprintf("model cache management is not supported for runtime %s", s.runtimeType),
+		}
+	}
+
+	return nil
+}
+
+// references maps model ids to the application components whose params name them
+// and to the pods of the host that serve them.
+func (s *service) references(ctx context.Context) (map[string][]catalogtypes.ModelReference, error) {
+	apps, err := s.appRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	components, err := s.compRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+
+	appsByService := make(map[uuid.UUID]dbmodels.Application)
+	for _, app := range apps {
+		for _, svc := range app.Services {
+			appsByService[svc.ID] = app
+		}
+	}
+
+	refs := make(map[string][]catalogtypes.ModelReference)
+	for _, comp := range components {
+		modelIDs := modelsFromMetadata(comp)
+		if len(modelIDs) == 0 {
+			continue
+		}
+
+		serviceIDs, err := s.svcDepRepo.GetServicesByDependency(ctx, comp.ID, dbmodels.DependencyTypeComponent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get services using component %s: %w", comp.ID, err)
+		}
+
+		ref := catalogtypes.ModelReference{
+			ComponentID: comp.ID.String(),
+			Component:   fmt.Sprintf("%s/%s", comp.Type, comp.Provider),
+		}
+		for _, id := range serviceIDs {
+			if app, ok := appsByService[id]; ok {
+				ref.ApplicationID = app.ID.String()
+				ref.ApplicationName = app.Name
+
+				break
+			}
+		}
+
+		for _, model := range modelIDs {
+			refs[model] = append(refs[model], ref)
+		}
+	}
+
+	if err := s.podReferences(refs); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// podReferences adds the pods of the host's applications that serve a model to
+// refs. It covers the applications created with "application create", which are
+// not in the catalog; stopped pods count, as they mount the models again on start.
+func (s *service) podReferences(refs map[string][]catalogtypes.ModelReference) error {
+	h, err := s.connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	pods, err := h.ListPods(map[string][]string{"label": {constants.ApplicationAnnotationKey}})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		models := make(map[string]bool)
+		for _, c := range pod.Containers {
+			container, err := h.InspectContainer(c.ID)
+			if err != nil {
+				return fmt.Errorf("failed to inspect container %s of pod %s: %w", c.Name, pod.Name, err)
+			}
+			for key, value := range container.Annotations {
+				if strings.HasPrefix(key, constants.ModelAnnotationKey) && value != "" {
+					models[value] = true
+				}
+			}
+		}
+
+		for model := range models {
+			refs[model] = append(refs[model], catalogtypes.ModelReference{
+				ApplicationName: pod.Labels[constants.ApplicationAnnotationKey],
+				Pod:             pod.Name,
+			})
+		}
+	}
+
+	return nil
+}
+
+// modelsFromMetadata returns the models a component serves, read from its params
+// the same way the deployer collects the models to download.
+func modelsFromMetadata(comp dbmodels.Component) []string {
+	if strings.EqualFold(comp.Provider, watsonxProvider) {
+		return nil
+	}
+
+	var modelIDs []string
+	for key, value := range comp.Metadata {
+		if !strings.Contains(strings.ToLower(key), "model") {
+			continue
+		}
+		if model, ok := value.(string); ok && model != "" {
+			modelIDs = append(modelIDs, model)
+		}
+	}
+	sort.Strings(modelIDs)
+
+	return modelIDs
+}
+
+func toCachedModel(m modelstore.CachedModel, refs []catalogtypes.ModelReference) catalogtypes.CachedModel {
+	if refs == nil {
+		refs = []catalogtypes.ModelReference{}
+	}
+
+	return catalogtypes.CachedModel{
+		Model:    m.Model,
+		Revision: m.Revision,
+		Source:   m.Source,
+		Size:     m.Size,
+		StoredAt: m.StoredAt,
+		UsedBy:   refs,
+	}
+}
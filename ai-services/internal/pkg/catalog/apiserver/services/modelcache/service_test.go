@@ -0,0 +1,208 @@
+package modelcache
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+type fakeAppRepo struct {
+	repository.ApplicationRepository
+	apps []dbmodels.Application
+}
+
+func (f *fakeAppRepo) GetAll(context.Context, *repository.ApplicationFilters) ([]dbmodels.Application, error) {
+	return f.apps, nil
+}
+
+type fakeCompRepo struct {
+	repository.ComponentRepository
+	components []dbmodels.Component
+}
+
+func (f *fakeCompRepo) GetAll(context.Context) ([]dbmodels.Component, error) {
+	return f.components, nil
+}
+
+type fakeDepRepo struct {
+	repository.ServiceDependencyRepository
+	services map[uuid.UUID][]uuid.UUID
+}
+
+func (f *fakeDepRepo) GetServicesByDependency(_ context.Context, id uuid.UUID, _ dbmodels.DependencyType) ([]uuid.UUID, error) {
+	return f.services[id], nil
+}
+
+type fakeHost struct {
+	pods       []runtimeTypes.Pod
+	containers map[string]*runtimeTypes.Container
+}
+
+func (f *fakeHost) ListPods(map[string][]string) ([]runtimeTypes.Pod, error) {
+	return f.pods, nil
+}
+
+func (f *fakeHost) InspectContainer(id string) (*runtimeTypes.Container, error) {
+	return f.containers[id], nil
+}
+
+func placeModel(t *testing.T, modelsDir, model string) {
+	t.Helper()
+
+	dir := modelstore.Dir(modelsDir, model)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "weights.bin"), []byte("weights"), 0o644))
+	files, err := modelstore.Scan(dir)
+	require.NoError(t, err)
+	require.NoError(t, modelstore.WriteMetadata(modelsDir, &modelstore.Metadata{Model: model, Files: files}))
+}
+
+func newTestService(t *testing.T, status dbmodels.ApplicationStatus) (*service, string) {
+	t.Helper()
+
+	modelsDir := t.TempDir()
+	placeModel(t, modelsDir, "ibm-granite/granite-3.3-8b-instruct")
+	placeModel(t, modelsDir, "ibm-granite/granite-embedding")
+	placeModel(t, modelsDir, "old/unused")
+
+	appID, svcID, llmID, embedID, watsonxID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	apps := []dbmodels.Application{{
+		ID: appID, Name: "rag-dev", Status: status,
+		Services: []dbmodels.Service{{ID: svcID, AppID: appID, CatalogID: "chat"}},
+	}}
+	components := []dbmodels.Component{
+		{ID: llmID, Type: "llm", Provider: "vllm-spyre", Metadata: map[string]any{"model": "ibm-granite/granite-3.3-8b-instruct", "max_tokens": 100}},
+		{ID: embedID, Type: "embedding", Provider: "vllm-cpu", Metadata: map[string]any{"embedding_model": "ibm-granite/granite-embedding"}},
+		// watsonx serves its models remotely; the same id must not count as a reference.
+		{ID: watsonxID, Type: "llm", Provider: "watsonx", Metadata: map[string]any{"model": "old/unused"}},
+	}
+	deps := map[uuid.UUID][]uuid.UUID{llmID: {svcID}, watsonxID: {svcID}}
+
+	svc := &service{
+		appRepo:     &fakeAppRepo{apps: apps},
+		compRepo:    &fakeCompRepo{components: components},
+		svcDepRepo:  &fakeDepRepo{services: deps},
+		runtimeType: runtimeTypes.RuntimeTypePodman,
+		modelsDir:   modelsDir,
+		connect:     func() (host, error) { return &fakeHost{}, nil },
+	}
+
+	return svc, modelsDir
+}
+
+func TestList(t *testing.T) {
+	svc, _ := newTestService(t, dbmodels.ApplicationStatusRunning)
+
+	resp, err := svc.List(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, resp.Total)
+
+	llm := resp.Models[0]
+	require.Len(t, llm.UsedBy, 1)
+	assert.Equal(t, "rag-dev", llm.UsedBy[0].ApplicationName)
+	assert.Equal(t, "llm/vllm-spyre", llm.UsedBy[0].Component)
+
+	// A component without a service dependency is still a reference.
+	embedding := resp.Models[1]
+	require.Len(t, embedding.UsedBy, 1)
+	assert.Empty(t, embedding.UsedBy[0].ApplicationName)
+
+	assert.Empty(t, resp.Models[2].UsedBy)
+	assert.Equal(t, resp.Models[2].Size, resp.UnreferencedSize)
+}
+
+func TestPrune(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		svc, modelsDir := newTestService(t, dbmodels.ApplicationStatusRunning)
+
+		resp, err := svc.Prune(context.Background(), catalogtypes.ModelPruneRequest{DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, resp.Removed, 1)
+		assert.Equal(t, "old/unused", resp.Removed[0].Model)
+		assert.Positive(t, resp.Reclaimed)
+		assert.DirExists(t, modelstore.Dir(modelsDir, "old/unused"))
+	})
+
+	t.Run("removes unreferenced models", func(t *testing.T) {
+		svc, modelsDir := newTestService(t, dbmodels.ApplicationStatusRunning)
+
+		resp, err := svc.Prune(context.Background(), catalogtypes.ModelPruneRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Removed, 1)
+		assert.NoDirExists(t, modelstore.Dir(modelsDir, "old/unused"))
+		assert.DirExists(t, modelstore.Dir(modelsDir, "ibm-granite/granite-embedding"))
+	})
+
+	t.Run("keeps registered models", func(t *testing.T) {
+		svc, modelsDir := newTestService(t, dbmodels.ApplicationStatusRunning)
+		metadata, err := modelstore.ReadMetadata(modelsDir, "old/unused")
+		require.NoError(t, err)
+		metadata.Source = modelstore.SourceLocal
+		require.NoError(t, modelstore.WriteMetadata(modelsDir, metadata))
+
+		resp, err := svc.Prune(context.Background(), catalogtypes.ModelPruneRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Removed)
+		assert.DirExists(t, modelstore.Dir(modelsDir, "old/unused"))
+	})
+
+	t.Run("keeps models served by pods", func(t *testing.T) {
+		svc, modelsDir := newTestService(t, dbmodels.ApplicationStatusRunning)
+		// A pod of an application created with "application create", outside the catalog.
+		podHost := &fakeHost{
+			pods: []runtimeTypes.Pod{{
+				Name:       "rag-vllm-server",
+				Labels:     map[string]string{constants.ApplicationAnnotationKey: "rag"},
+				Containers: []runtimeTypes.Container{{ID: "infra"}, {ID: "instruct"}},
+			}},
+			containers: map[string]*runtimeTypes.Container{
+				"infra":    {ID: "infra"},
+				"instruct": {ID: "instruct", Annotations: map[string]string{constants.ModelAnnotationKey + "3": "old/unused"}},
+			},
+		}
+		svc.connect = func() (host, error) { return podHost, nil }
+
+		listed, err := svc.List(context.Background())
+		require.NoError(t, err)
+		require.Len(t, listed.Models[2].UsedBy, 1)
+		assert.Equal(t, catalogtypes.ModelReference{ApplicationName: "rag", Pod: "rag-vllm-server"}, listed.Models[2].UsedBy[0])
+
+		resp, err := svc.Prune(context.Background(), catalogtypes.ModelPruneRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Removed)
+		assert.DirExists(t, modelstore.Dir(modelsDir, "old/unused"))
+	})
+
+	t.Run("refused while deploying", func(t *testing.T) {
+		svc, _ := newTestService(t, dbmodels.ApplicationStatusDownloading)
+
+		_, err := svc.Prune(context.Background(), catalogtypes.ModelPruneRequest{})
+		var valErr *validators.ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, http.StatusConflict, valErr.Code)
+	})
+}
+
+func TestUnsupportedRuntime(t *testing.T) {
+	svc, _ := newTestService(t, dbmodels.ApplicationStatusRunning)
+	svc.runtimeType = runtimeTypes.RuntimeTypeOpenShift
+
+	_, err := svc.List(context.Background())
+	var valErr *validators.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusNotImplemented, valErr.Code)
+}
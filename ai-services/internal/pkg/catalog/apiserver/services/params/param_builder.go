@@ -7,6 +7,7 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 )
@@ -64,9 +65,10 @@ func (b *ParamBuilder) BuildServiceParams(
 		ArgParams: make(map[string]string),
 	}
 
-	// Flatten service-level params (without prefix) and add to argParams
+	// Flatten service-level params (without prefix) and add to argParams; the
+	// resources override is enforced by the deployer rather than templated.
 	if len(svcReq.Params) > 0 {
-		serviceArgParams := utils.FlattenMapWithValues(svcReq.Params, "")
+		serviceArgParams := utils.FlattenMapWithValues(resources.Strip(svcReq.Params), "")
 		maps.Copy(params.ArgParams, serviceArgParams)
 	}
 
@@ -101,7 +103,8 @@ func (b *ParamBuilder) buildComponentParams(
 	// Flatten component params with component_type prefix for service-level argParams
 	// Example: llm.model = "granite-3.3-8b-instruct" -> accessible as .Values.llm.model
 	prefix := compReq.ComponentType
-	flatParams := utils.FlattenMapWithValues(compReq.Params, prefix)
+	templateParams := resources.Strip(compReq.Params)
+	flatParams := utils.FlattenMapWithValues(templateParams, prefix)
 
 	compParams := &ComponentParams{
 		ComponentType: compReq.ComponentType,
@@ -112,7 +115,7 @@ func (b *ParamBuilder) buildComponentParams(
 
 	// Load component values from values.yaml with argParams applied
 	// Note: We pass flatParams without prefix since LoadComponentValues expects flat keys
-	componentArgParams := utils.FlattenMapWithValues(compReq.Params, "")
+	componentArgParams := utils.FlattenMapWithValues(templateParams, "")
 	values, err := b.catalogProvider.LoadComponentValues(compReq.ComponentType, compReq.ProviderID, componentArgParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load component values: %w", err)
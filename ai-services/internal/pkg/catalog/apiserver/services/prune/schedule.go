@@ -0,0 +1,97 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils/cron"
+)
+
+// Scheduler prunes unreferenced images and orphaned volumes on a cron schedule.
+type Scheduler struct {
+	service  ServiceInterface
+	schedule *cron.Schedule
+	stopChan chan struct{}
+}
+
+// NewScheduler creates a scheduler pruning on the five-field cron expression expr.
+func NewScheduler(svc ServiceInterface, expr string) (*Scheduler, error) {
+	schedule, err := cron.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("prune schedule %q never runs", expr)
+	}
+
+	return &Scheduler{service: svc, schedule: schedule, stopChan: make(chan struct{})}, nil
+}
+
+// Start begins the scheduler goroutine.
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.loop(ctx)
+	logger.InfofCtx(ctx, "Prune scheduler started with schedule %q", s.schedule)
+}
+
+// Stop stops the scheduler goroutine. A running prune is not interrupted.
+func (s *Scheduler) Stop(ctx context.Context) {
+	close(s.stopChan)
+	logger.InfolnCtx(ctx, "Prune scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in prune scheduler goroutine: %v", r)
+		}
+	}()
+
+	for {
+		next := s.schedule.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-timer.C:
+			s.run(ctx)
+		case <-s.stopChan:
+			timer.Stop()
+
+			return
+		}
+	}
+}
+
+// run prunes images and volumes, skipping the run while an application is
+// being deployed.
+func (s *Scheduler) run(ctx context.Context) {
+	images, err := s.service.PruneImages(ctx, catalogtypes.PruneRequest{})
+	if err != nil {
+		logSkipped(ctx, "images", err)
+	} else if len(images.Removed) > 0 {
+		logger.InfofCtx(ctx, "Scheduled prune removed %d image(s), %s reclaimed", len(images.Removed), utils.FormatBytes(images.Reclaimed))
+	}
+
+	volumes, err := s.service.PruneVolumes(ctx, catalogtypes.PruneRequest{})
+	if err != nil {
+		logSkipped(ctx, "volumes", err)
+	} else if len(volumes.Removed) > 0 {
+		logger.InfofCtx(ctx, "Scheduled prune removed %d volume(s), %s reclaimed", len(volumes.Removed), utils.FormatBytes(volumes.Reclaimed))
+	}
+}
+
+func logSkipped(ctx context.Context, what string, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		logger.InfofCtx(ctx, "Scheduled prune of %s skipped: %s", what, valErr.Message)
+
+		return
+	}
+
+	logger.ErrorfCtx(ctx, "Scheduled prune of %s failed: %v", what, err)
+}
@@ -0,0 +1,409 @@
+// Package prune removes the images and volumes on the catalog host that no
+// deployed application refers to any more, such as the images of templates
+// replaced by a catalog upgrade and the volumes of applications deleted with
+// their data kept.
+package prune
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.podman.io/image/v5/docker/reference"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/offline"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// instanceVolume matches the volumes the catalog templates create per application
+// or component, named after its instance slug, e.g. "opensearch-1a2b3c4d5e".
+var instanceVolume = regexp.MustCompile(`-([0-9a-f]{10})$`)
+
+// ServiceInterface is the dependency injected into PruneHandler.
+type ServiceInterface interface {
+	// PruneImages removes the local images of the catalog templates and of the
+	// configured registry mirrors that no application refers to and no container
+	// uses.
+	PruneImages(ctx context.Context, req catalogtypes.PruneRequest) (*catalogtypes.ImagePruneResponse, error)
+	// PruneVolumes removes the unused volumes of catalog applications and
+	// components that no longer exist.
+	PruneVolumes(ctx context.Context, req catalogtypes.PruneRequest) (*catalogtypes.VolumePruneResponse, error)
+}
+
+// host is the podman host whose images and volumes are pruned.
+type host interface {
+	ImageDiskUsage() ([]runtimeTypes.ImageUsage, error)
+	RemoveImage(id string) error
+	VolumeDiskUsage() ([]runtimeTypes.VolumeUsage, error)
+	DeleteVolume(name string) error
+}
+
+// service implements ServiceInterface.
+type service struct {
+	appRepo      repository.ApplicationRepository
+	compRepo     repository.ComponentRepository
+	appImageRepo repository.ApplicationImageRepository
+	runtimeType  runtimeTypes.RuntimeType
+
+	// catalogImages returns the images of a catalog template.
+	catalogImages func(ctx context.Context, catalogID string) ([]string, error)
+	// templates returns the IDs of the catalog templates.
+	templates func() ([]string, error)
+	// connect returns the host to prune; replaced in tests.
+	connect func() (host, error)
+
+	// mu keeps a scheduled prune and a requested one from running together.
+	mu sync.Mutex
+}
+
+// NewService creates a prune service for the images and volumes of the catalog host.
+func NewService(appRepo repository.ApplicationRepository, compRepo repository.ComponentRepository,
+	appImageRepo repository.ApplicationImageRepository, provider *catalog.CatalogProvider, runtimeType runtimeTypes.RuntimeType) ServiceInterface {
+	return &service{
+		appRepo:       appRepo,
+		compRepo:      compRepo,
+		appImageRepo:  appImageRepo,
+		runtimeType:   runtimeType,
+		catalogImages: provider.GetCatalogImages,
+		templates: func() ([]string, error) {
+			return catalogTemplates(provider)
+		},
+		connect: func() (host, error) {
+			return podman.NewPodmanClient()
+		},
+	}
+}
+
+// PruneImages implements ServiceInterface. Only images of a repository of the
+// catalog templates or of a configured registry mirror are considered; other
+// images on the host are never removed. An image is referenced by the templates
+// of the deployed applications, the digests they are pinned to, the tool image
+// and the imported offline bundles, under its own name or that of its registry
+// mirror.
+func (s *service) PruneImages(ctx context.Context, req catalogtypes.PruneRequest) (*catalogtypes.ImagePruneResponse, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apps, err := s.settledApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors, err := mirror.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.imageReferences(ctx, apps, mirrors)
+	if err != nil {
+		return nil, err
+	}
+
+	managed, err := s.managedImages(ctx, mirrors)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := s.connect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	images, err := h.ImageDiskUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.ImagePruneResponse{DryRun: req.DryRun, Removed: []catalogtypes.PrunedImage{}}
+	for _, img := range images {
+		names := append(append([]string{}, img.RepoTags...), img.RepoDigests...)
+		if img.Containers > 0 || !managed.contains(names) || referenced(names, refs) {
+			continue
+		}
+
+		if !req.DryRun {
+			if err := h.RemoveImage(img.ID); err != nil {
+				logger.WarningfCtx(ctx, "prune: %v", err)
+				resp.Errors = append(resp.Errors, err.Error())
+
+				continue
+			}
+			logger.InfofCtx(ctx, "prune: removed unreferenced image %s %v", img.ID, names)
+		}
+		resp.Removed = append(resp.Removed, catalogtypes.PrunedImage{ID: img.ID, Names: names, Size: img.Size})
+		resp.Reclaimed += img.Size
+	}
+	sort.Slice(resp.Removed, func(i, j int) bool { return resp.Removed[i].Size > resp.Removed[j].Size })
+
+	return resp, nil
+}
+
+// PruneVolumes implements ServiceInterface. Only volumes named after the instance
+// of an application or component are considered; volumes created otherwise are
+// never removed.
+func (s *service) PruneVolumes(ctx context.Context, req catalogtypes.PruneRequest) (*catalogtypes.VolumePruneResponse, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apps, err := s.settledApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := s.compRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+
+	// Application pods are named after the application, component pods after the component.
+	slugs := make(map[string]bool, len(apps)+len(components))
+	for _, app := range apps {
+		slugs[catalogutils.GenerateInstanceSlug(app.ID.String())] = true
+	}
+	for _, comp := range components {
+		slugs[catalogutils.GenerateInstanceSlug(comp.ID.String())] = true
+	}
+
+	h, err := s.connect()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	volumes, err := h.VolumeDiskUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.VolumePruneResponse{DryRun: req.DryRun, Removed: []catalogtypes.PrunedVolume{}}
+	for _, v := range volumes {
+		m := instanceVolume.FindStringSubmatch(v.Name)
+		if v.Containers > 0 || m == nil || slugs[m[1]] {
+			continue
+		}
+
+		if !req.DryRun {
+			if err := h.DeleteVolume(v.Name); err != nil {
+				logger.WarningfCtx(ctx, "prune: %v", err)
+				resp.Errors = append(resp.Errors, err.Error())
+
+				continue
+			}
+			logger.InfofCtx(ctx, "prune: removed orphaned volume %s", v.Name)
+		}
+		resp.Removed = append(resp.Removed, catalogtypes.PrunedVolume{Name: v.Name, Size: v.Size})
+		resp.Reclaimed += v.Size
+	}
+	sort.Slice(resp.Removed, func(i, j int) bool { return resp.Removed[i].Name < resp.Removed[j].Name })
+
+	return resp, nil
+}
+
+// checkRuntime rejects requests on runtimes whose images and volumes are not
+// managed on the catalog host.
+func (s *service) checkRuntime() error {
+	if s.runtimeType != runtimeTypes.RuntimeTypePodman {
+		return &validators.ValidationError{
+			Code:    http.StatusNotImplemented,
+			Message: fmt.Sprintf("pruning images and volumes is not supported for runtime %s", s.runtimeType),
+		}
+	}
+
+	return nil
+}
+
+// settledApplications returns the applications in the DB. It refuses while an
+// application is downloading or deploying, since its images and volumes may not
+// be in use yet.
+func (s *service) settledApplications(ctx context.Context) ([]dbmodels.Application, error) {
+	apps, err := s.appRepo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		if app.Status == dbmodels.ApplicationStatusDownloading || app.Status == dbmodels.ApplicationStatusDeploying {
+			return nil, &validators.ValidationError{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("application %s is %s; retry once it has finished", app.Name, strings.ToLower(string(app.Status))),
+			}
+		}
+	}
+
+	return apps, nil
+}
+
+// imageReferences returns the normalized references of the images apps and the
+// imported offline bundles refer to.
+func (s *service) imageReferences(ctx context.Context, apps []dbmodels.Application, mirrors *mirror.Config) (map[string]bool, error) {
+	images := []string{vars.ToolImage}
+
+	seen := make(map[string]bool)
+	for _, app := range apps {
+		if seen[app.CatalogID] {
+			continue
+		}
+		seen[app.CatalogID] = true
+
+		catalogImages, err := s.catalogImages(ctx, app.CatalogID)
+		if err != nil {
+			// The template is gone from the catalog; the containers of the
+			// application still keep its images.
+			logger.WarningfCtx(ctx, "prune: failed to get images of template %s of application %s: %v", app.CatalogID, app.Name, err)
+
+			continue
+		}
+		images = append(images, catalogImages...)
+	}
+
+	pinned, err := s.appImageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range pinned {
+		images = append(images, img.Pinned)
+	}
+
+	// Imported images wait for applications of their template to be created.
+	imported, err := offline.ImportedImages(utils.GetOfflineManifestsPath())
+	if err != nil {
+		return nil, err
+	}
+	images = append(images, imported...)
+
+	refs := make(map[string]bool, 2*len(images))
+	for _, img := range images {
+		refs[normalize(img)] = true
+		refs[normalize(mirrors.Rewrite(img))] = true
+	}
+
+	return refs, nil
+}
+
+// managedImages holds the repositories of the images of the catalog templates,
+// and the registries they are mirrored to.
+type managedImages struct {
+	repositories map[string]bool
+	registries   []string
+}
+
+// managedImages returns the repositories and registries whose images are pruned.
+func (s *service) managedImages(ctx context.Context, mirrors *mirror.Config) (*managedImages, error) {
+	templates, err := s.templates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog templates: %w", err)
+	}
+
+	managed := &managedImages{repositories: map[string]bool{repositoryOf(vars.ToolImage): true}}
+	for _, id := range templates {
+		images, err := s.catalogImages(ctx, id)
+		if err != nil {
+			logger.WarningfCtx(ctx, "prune: failed to get images of template %s: %v", id, err)
+
+			continue
+		}
+		for _, img := range images {
+			managed.repositories[repositoryOf(img)] = true
+			managed.repositories[repositoryOf(mirrors.Rewrite(img))] = true
+		}
+	}
+	for _, m := range mirrors.Mirrors {
+		managed.registries = append(managed.registries, strings.TrimSuffix(m.Mirror, "/")+"/")
+	}
+
+	return managed, nil
+}
+
+// contains reports whether any of the names of an image is in a managed
+// repository or registry. Images without names cannot be attributed and are kept.
+func (m *managedImages) contains(names []string) bool {
+	for _, name := range names {
+		if m.repositories[repositoryOf(name)] {
+			return true
+		}
+		for _, registry := range m.registries {
+			if strings.HasPrefix(normalize(name), registry) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// catalogTemplates returns the IDs of the architectures and services of the catalog.
+func catalogTemplates(provider *catalog.CatalogProvider) ([]string, error) {
+	architectures, err := provider.ListArchitectures()
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := provider.ListServices()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(architectures)+len(services))
+	for _, arch := range architectures {
+		ids = append(ids, arch.ID)
+	}
+	for _, svc := range services {
+		ids = append(ids, svc.ID)
+	}
+
+	return ids, nil
+}
+
+// referenced reports whether any of the names of an image is in refs.
+func referenced(names []string, refs map[string]bool) bool {
+	for _, name := range names {
+		if refs[normalize(name)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize returns ref with short names expanded, as podman names local images,
+// e.g. docker.io/library/postgres:16 for postgres:16.
+func normalize(ref string) string {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return ref
+	}
+
+	return named.String()
+}
+
+// repositoryOf returns the normalized repository of ref, without tag or digest,
+// e.g. docker.io/library/postgres for postgres:16.
+func repositoryOf(ref string) string {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return ref
+	}
+
+	return named.Name()
+}
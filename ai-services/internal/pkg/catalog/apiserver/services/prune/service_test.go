@@ -0,0 +1,191 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const pinnedDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+type fakeAppRepo struct {
+	repository.ApplicationRepository
+	apps []dbmodels.Application
+}
+
+func (f *fakeAppRepo) GetAll(context.Context, *repository.ApplicationFilters) ([]dbmodels.Application, error) {
+	return f.apps, nil
+}
+
+type fakeCompRepo struct {
+	repository.ComponentRepository
+	components []dbmodels.Component
+}
+
+func (f *fakeCompRepo) GetAll(context.Context) ([]dbmodels.Component, error) {
+	return f.components, nil
+}
+
+type fakeAppImageRepo struct {
+	repository.ApplicationImageRepository
+	images []dbmodels.ApplicationImage
+}
+
+func (f *fakeAppImageRepo) GetAll(context.Context) ([]dbmodels.ApplicationImage, error) {
+	return f.images, nil
+}
+
+type fakeHost struct {
+	images         []runtimeTypes.ImageUsage
+	volumes        []runtimeTypes.VolumeUsage
+	removedImages  []string
+	removedVolumes []string
+}
+
+func (f *fakeHost) ImageDiskUsage() ([]runtimeTypes.ImageUsage, error) { return f.images, nil }
+
+func (f *fakeHost) VolumeDiskUsage() ([]runtimeTypes.VolumeUsage, error) { return f.volumes, nil }
+
+func (f *fakeHost) RemoveImage(id string) error {
+	f.removedImages = append(f.removedImages, id)
+
+	return nil
+}
+
+func (f *fakeHost) DeleteVolume(name string) error {
+	f.removedVolumes = append(f.removedVolumes, name)
+
+	return nil
+}
+
+func newTestService(t *testing.T, status dbmodels.ApplicationStatus) (*service, *fakeHost, dbmodels.Application, dbmodels.Component) {
+	t.Helper()
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+
+	app := dbmodels.Application{ID: uuid.New(), Name: "rag-dev", CatalogID: "rag", Status: status}
+	comp := dbmodels.Component{ID: uuid.New(), Type: "vector_db", Provider: "opensearch"}
+	h := &fakeHost{}
+	s := &service{
+		appRepo:      &fakeAppRepo{apps: []dbmodels.Application{app, {ID: uuid.New(), Name: "gone", CatalogID: "removed"}}},
+		compRepo:     &fakeCompRepo{components: []dbmodels.Component{comp}},
+		appImageRepo: &fakeAppImageRepo{images: []dbmodels.ApplicationImage{{AppID: app.ID, Image: "icr.io/ai-services/vllm:v1", Pinned: "icr.io/ai-services/vllm@" + pinnedDigest}}},
+		runtimeType:  runtimeTypes.RuntimeTypePodman,
+		catalogImages: func(_ context.Context, catalogID string) ([]string, error) {
+			if catalogID != "rag" {
+				return nil, errors.New("template not found")
+			}
+
+			return []string{"icr.io/ai-services/chat:v2", "postgres:16", "quay.io/ai-services/opensearch:3"}, nil
+		},
+		templates: func() ([]string, error) { return []string{"rag", "removed"}, nil },
+		connect:   func() (host, error) { return h, nil },
+	}
+
+	return s, h, app, comp
+}
+
+func TestPruneImages(t *testing.T) {
+	s, h, _, _ := newTestService(t, dbmodels.ApplicationStatusRunning)
+	require.NoError(t, (&mirror.Config{Mirrors: []mirror.Mirror{{Source: "quay.io", Mirror: "registry.local"}}}).Save())
+	// A bundle imported for an application that is not created yet.
+	manifestsDir := utils.GetOfflineManifestsPath()
+	require.NoError(t, os.MkdirAll(manifestsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "rag.json"), []byte(`{"template": "rag", "images": ["icr.io/ai-services/chat:v3"]}`), 0o644))
+
+	h.images = []runtimeTypes.ImageUsage{
+		{ID: "tool", RepoTags: []string{vars.ToolImage}, Size: 1},
+		{ID: "chat", RepoTags: []string{"icr.io/ai-services/chat:v2"}, Size: 2},
+		{ID: "postgres", RepoTags: []string{"docker.io/library/postgres:16"}, Size: 3},
+		{ID: "mirrored", RepoTags: []string{"registry.local/ai-services/opensearch:3"}, Size: 4},
+		{ID: "pinned", RepoDigests: []string{"icr.io/ai-services/vllm@" + pinnedDigest}, Size: 5},
+		{ID: "in-use", RepoTags: []string{"localhost/custom:latest"}, Containers: 1, Size: 6},
+		{ID: "mirrored-other", RepoTags: []string{"registry.local/team/tool:1"}, Size: 60},
+		{ID: "old-chat", RepoTags: []string{"icr.io/ai-services/chat:v1"}, Size: 70},
+		// Images unrelated to the catalog are never removed.
+		{ID: "dangling", Size: 80},
+		{ID: "unrelated", RepoTags: []string{"docker.io/library/nginx:latest"}, Size: 90},
+		{ID: "imported", RepoTags: []string{"icr.io/ai-services/chat:v3"}, Size: 100},
+	}
+
+	resp, err := s.PruneImages(context.Background(), catalogtypes.PruneRequest{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	require.Len(t, resp.Removed, 2)
+	assert.Equal(t, "old-chat", resp.Removed[0].ID)
+	assert.Equal(t, []string{"icr.io/ai-services/chat:v1"}, resp.Removed[0].Names)
+	assert.Equal(t, "mirrored-other", resp.Removed[1].ID)
+	assert.Equal(t, int64(130), resp.Reclaimed)
+	assert.Empty(t, h.removedImages)
+
+	resp, err = s.PruneImages(context.Background(), catalogtypes.PruneRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Removed, 2)
+	assert.ElementsMatch(t, []string{"old-chat", "mirrored-other"}, h.removedImages)
+}
+
+func TestPruneVolumes(t *testing.T) {
+	s, h, app, comp := newTestService(t, dbmodels.ApplicationStatusError)
+
+	appSlug := catalogutils.GenerateInstanceSlug(app.ID.String())
+	compSlug := catalogutils.GenerateInstanceSlug(comp.ID.String())
+	h.volumes = []runtimeTypes.VolumeUsage{
+		{Name: "postgres-digitize-" + appSlug, Size: 1},
+		{Name: "opensearch-" + compSlug, Size: 2},
+		{Name: "opensearch-0123456789", Size: 30},
+		{Name: "postgres-digitize-abcdef0123", Size: 40},
+		{Name: "digitize-cache-fedcba9876", Containers: 1, Size: 5},
+		{Name: "postgres-catalog", Size: 6},
+		{Name: "user-data", Size: 7},
+	}
+
+	resp, err := s.PruneVolumes(context.Background(), catalogtypes.PruneRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []catalogtypes.PrunedVolume{
+		{Name: "opensearch-0123456789", Size: 30},
+		{Name: "postgres-digitize-abcdef0123", Size: 40},
+	}, resp.Removed)
+	assert.Equal(t, int64(70), resp.Reclaimed)
+	assert.Equal(t, []string{"opensearch-0123456789", "postgres-digitize-abcdef0123"}, h.removedVolumes)
+}
+
+func TestPruneRefusals(t *testing.T) {
+	t.Run("refused while deploying", func(t *testing.T) {
+		s, h, _, _ := newTestService(t, dbmodels.ApplicationStatusDeploying)
+
+		_, err := s.PruneImages(context.Background(), catalogtypes.PruneRequest{})
+		var valErr *validators.ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, http.StatusConflict, valErr.Code)
+
+		_, err = s.PruneVolumes(context.Background(), catalogtypes.PruneRequest{})
+		require.ErrorAs(t, err, &valErr)
+		assert.Empty(t, h.removedVolumes)
+	})
+
+	t.Run("not supported on openshift", func(t *testing.T) {
+		s, _, _, _ := newTestService(t, dbmodels.ApplicationStatusRunning)
+		s.runtimeType = runtimeTypes.RuntimeTypeOpenShift
+
+		_, err := s.PruneImages(context.Background(), catalogtypes.PruneRequest{})
+		var valErr *validators.ValidationError
+		require.ErrorAs(t, err, &valErr)
+		assert.Equal(t, http.StatusNotImplemented, valErr.Code)
+	})
+}
@@ -0,0 +1,403 @@
+// Package registeredmodel registers custom models in the catalog. A registered
+// model is copied from a directory on the catalog host, or pulled from an OCI
+// artifact, into the models directory and can then be selected as the model of
+// the providers of its component type that accept custom models.
+package registeredmodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// modelNamePattern accepts Hugging Face style model ids: a name, optionally
+// below an organisation.
+var modelNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)?$`)
+
+// ServiceInterface is the dependency injected into RegisteredModelHandler.
+type ServiceInterface interface {
+	// Register validates the request, records the model and places its files in
+	// the background. The model is returned in status registering.
+	Register(ctx context.Context, req catalogtypes.RegisterModelRequest, userID string) (*catalogtypes.RegisteredModel, error)
+	// List returns the registered models ordered by name.
+	List(ctx context.Context) (*catalogtypes.RegisteredModelListResponse, error)
+	// Get returns a registered model by name.
+	Get(ctx context.Context, name string) (*catalogtypes.RegisteredModel, error)
+	// Delete unregisters a model no application component serves and removes its files.
+	Delete(ctx context.Context, name string) error
+
+	catalog.ModelRegistry
+}
+
+// catalogModels is the part of the catalog provider the service needs.
+type catalogModels interface {
+	ListComponents() ([]catalogtypes.Component, error)
+	GetComponentModels(ctx context.Context, componentType, providerID string) ([]string, error)
+}
+
+// service implements ServiceInterface.
+type service struct {
+	repo        repository.RegisteredModelRepository
+	compRepo    repository.ComponentRepository
+	provider    catalogModels
+	runtimeType runtimeTypes.RuntimeType
+	modelsDir   string
+	// importRoot is the directory local models are registered from; the API
+	// server only sees the base directory of the catalog host.
+	importRoot string
+
+	importDir func(modelsDir, model, srcDir string) (*modelstore.Metadata, error)
+	pull      func(ctx context.Context, modelsDir, model, image string, opts modelstore.PullOptions) (*modelstore.Metadata, error)
+}
+
+// NewService creates a registered model service storing models in modelsDir.
+func NewService(repo repository.RegisteredModelRepository, compRepo repository.ComponentRepository,
+	provider catalogModels, runtimeType runtimeTypes.RuntimeType, modelsDir string) ServiceInterface {
+	return &service{
+		repo:        repo,
+		compRepo:    compRepo,
+		provider:    provider,
+		runtimeType: runtimeType,
+		modelsDir:   modelsDir,
+		importRoot:  utils.GetBaseDir(),
+		importDir:   modelstore.ImportDir,
+		pull:        modelstore.Pull,
+	}
+}
+
+// Register implements ServiceInterface.
+func (s *service) Register(ctx context.Context, req catalogtypes.RegisterModelRequest, userID string) (*catalogtypes.RegisteredModel, error) {
+	if err := s.checkRuntime(); err != nil {
+		return nil, err
+	}
+
+	model, err := s.validate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	model.CreatedBy = userID
+
+	if err := s.repo.Insert(ctx, model); err != nil {
+		if errors.Is(err, repository.ErrRegisteredModelExists) {
+			return nil, &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("model %s is already registered", req.Name)}
+		}
+
+		return nil, err
+	}
+
+	jobCtx := context.Background()
+	if requestID, ok := ctx.Value(logger.RequestIDKey).(string); ok && requestID != "" {
+		jobCtx = context.WithValue(jobCtx, logger.RequestIDKey, requestID)
+	}
+
+	pullOpts := modelstore.PullOptions{Username: req.Username, Password: req.Password, TLSVerify: !req.SkipTLSVerify}
+	go s.place(jobCtx, *model, pullOpts)
+
+	resp := toResponse(model)
+
+	return &resp, nil
+}
+
+// validate checks a registration request and returns the model to record.
+func (s *service) validate(ctx context.Context, req catalogtypes.RegisterModelRequest) (*dbmodels.RegisteredModel, error) {
+	badRequest := func(format string, args ...any) error {
+		return &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if !modelNamePattern.MatchString(req.Name) || strings.Contains(req.Name, "..") {
+		return nil, badRequest("invalid model name %q: use <name> or <organisation>/<name> of letters, digits, '.', '_' and '-'", req.Name)
+	}
+
+	model := &dbmodels.RegisteredModel{Name: req.Name, Description: req.Description, ComponentType: req.ComponentType}
+	switch {
+	case req.Path != "" && req.Image != "":
+		return nil, badRequest("set either path or image, not both")
+	case req.Path != "":
+		if err := s.checkPath(req.Path); err != nil {
+			return nil, err
+		}
+		model.SourceType, model.Source = dbmodels.RegisteredModelSourceLocal, filepath.Clean(req.Path)
+	case req.Image != "":
+		model.SourceType, model.Source = dbmodels.RegisteredModelSourceOCI, req.Image
+	default:
+		return nil, badRequest("set the path of a model directory or the image of an OCI artifact")
+	}
+
+	if err := s.checkName(ctx, req.Name, req.ComponentType); err != nil {
+		return nil, err
+	}
+	if modelstore.Complete(s.modelsDir, req.Name) {
+		return nil, &validators.ValidationError{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("model %s is already in the models directory; register it under another name", req.Name),
+		}
+	}
+
+	return model, nil
+}
+
+// checkPath verifies that a local model directory can be read by the API server
+// and is not part of the models directory.
+func (s *service) checkPath(path string) error {
+	if !filepath.IsAbs(path) {
+		return &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("path must be absolute: %s", path)}
+	}
+
+	if !within(s.importRoot, path) || within(s.modelsDir, path) {
+		return &validators.ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("path must be a directory below %s outside of the models directory: %s", s.importRoot, path),
+		}
+	}
+
+	return nil
+}
+
+// within reports whether path is dir or below it.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && filepath.IsLocal(rel) || rel == "."
+}
+
+// checkName verifies that componentType has providers accepting custom models
+// and that name does not shadow a model the catalog offers.
+func (s *service) checkName(ctx context.Context, name, componentType string) error {
+	components, err := s.provider.ListComponents()
+	if err != nil {
+		return fmt.Errorf("failed to list components: %w", err)
+	}
+
+	var accepting []string
+	for _, comp := range components {
+		if comp.ComponentType != componentType || !comp.CustomModels {
+			continue
+		}
+		accepting = append(accepting, comp.ID)
+
+		offered, err := s.provider.GetComponentModels(ctx, comp.ComponentType, comp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get models of %s/%s: %w", comp.ComponentType, comp.ID, err)
+		}
+		if slices.Contains(offered, name) {
+			return &validators.ValidationError{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("model %s is offered by the catalog for %s/%s; register it under another name", name, comp.ComponentType, comp.ID),
+			}
+		}
+	}
+
+	if len(accepting) == 0 {
+		return &validators.ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("no provider of component type %q accepts custom models", componentType),
+		}
+	}
+
+	return nil
+}
+
+// place copies or pulls the files of model and records the outcome.
+func (s *service) place(ctx context.Context, model dbmodels.RegisteredModel, opts modelstore.PullOptions) {
+	metadata, err := s.fetch(ctx, model, opts)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Registration of model %s from %s failed: %v", model.Name, model.Source, err)
+
+		status, message := dbmodels.RegisteredModelStatusFailed, err.Error()
+		s.update(ctx, model, dbmodels.RegisteredModelUpdate{Status: &status, Error: &message})
+
+		return
+	}
+
+	status, size := dbmodels.RegisteredModelStatusReady, metadata.Size()
+	upd := dbmodels.RegisteredModelUpdate{Status: &status, SizeBytes: &size}
+	if model.SourceType == dbmodels.RegisteredModelSourceOCI {
+		upd.Digest = &metadata.Revision
+	}
+	s.update(ctx, model, upd)
+	logger.InfofCtx(ctx, "Registered model %s from %s", model.Name, model.Source)
+}
+
+// fetch places the files of model, converting a panic into an error.
+func (s *service) fetch(ctx context.Context, model dbmodels.RegisteredModel, opts modelstore.PullOptions) (metadata *modelstore.Metadata, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered registering model %s: %v", model.Name, r)
+			err = fmt.Errorf("registration panic: %v", r)
+		}
+	}()
+
+	if model.SourceType == dbmodels.RegisteredModelSourceOCI {
+		return s.pull(ctx, s.modelsDir, model.Name, model.Source, opts)
+	}
+
+	return s.importDir(s.modelsDir, model.Name, model.Source)
+}
+
+// update writes a model update, logging instead of failing on DB errors.
+func (s *service) update(ctx context.Context, model dbmodels.RegisteredModel, upd dbmodels.RegisteredModelUpdate) {
+	if err := s.repo.Update(ctx, model.ID, upd); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to update registered model %s: %v", model.Name, err)
+	}
+}
+
+// List implements ServiceInterface.
+func (s *service) List(ctx context.Context) (*catalogtypes.RegisteredModelListResponse, error) {
+	registered, err := s.repo.GetAll(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &catalogtypes.RegisteredModelListResponse{Models: make([]catalogtypes.RegisteredModel, 0, len(registered))}
+	for i := range registered {
+		resp.Models = append(resp.Models, toResponse(&registered[i]))
+	}
+	resp.Total = len(resp.Models)
+
+	return resp, nil
+}
+
+// Get implements ServiceInterface.
+func (s *service) Get(ctx context.Context, name string) (*catalogtypes.RegisteredModel, error) {
+	model, err := s.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toResponse(model)
+
+	return &resp, nil
+}
+
+// Delete implements ServiceInterface. The files are only removed for ready
+// models; a failed registration never placed any.
+func (s *service) Delete(ctx context.Context, name string) error {
+	model, err := s.get(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if model.Status == dbmodels.RegisteredModelStatusRegistering {
+		return &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("model %s is still being registered", name)}
+	}
+
+	if model.Status == dbmodels.RegisteredModelStatusReady {
+		if err := s.checkUnused(ctx, name); err != nil {
+			return err
+		}
+		if err := modelstore.Remove(s.modelsDir, name); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, model.ID); err != nil {
+		return err
+	}
+	logger.InfofCtx(ctx, "Unregistered model %s", name)
+
+	return nil
+}
+
+// checkUnused rejects unregistering a model an application component serves.
+func (s *service) checkUnused(ctx context.Context, name string) error {
+	components, err := s.compRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list components: %w", err)
+	}
+
+	for _, comp := range components {
+		for key, value := range comp.Metadata {
+			if !strings.Contains(strings.ToLower(key), "model") || value != name {
+				continue
+			}
+
+			return &validators.ValidationError{
+				Code:    http.StatusConflict,
+				Message: fmt.Sprintf("model %s is served by component %s/%s (%s); delete its application first", name, comp.Type, comp.Provider, comp.ID),
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegisteredModels implements catalog.ModelRegistry. Only podman hosts have a
+// models directory to register models in.
+func (s *service) RegisteredModels(ctx context.Context, componentType string) ([]catalogtypes.RegisteredModel, error) {
+	if s.runtimeType != runtimeTypes.RuntimeTypePodman {
+		return nil, nil
+	}
+
+	registered, err := s.repo.GetAll(ctx, &repository.RegisteredModelFilters{
+		ComponentType: componentType,
+		Status:        dbmodels.RegisteredModelStatusReady,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]catalogtypes.RegisteredModel, 0, len(registered))
+	for i := range registered {
+		models = append(models, toResponse(&registered[i]))
+	}
+
+	return models, nil
+}
+
+func (s *service) get(ctx context.Context, name string) (*dbmodels.RegisteredModel, error) {
+	model, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if model == nil {
+		return nil, &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("model %s is not registered", name)}
+	}
+
+	return model, nil
+}
+
+// checkRuntime rejects registrations on runtimes without a models directory on
+// the catalog host.
+func (s *service) checkRuntime() error {
+	if s.runtimeType != runtimeTypes.RuntimeTypePodman {
+		return &validators.ValidationError{
+			Code:    http.StatusNotImplemented,
+			Message: fmt.Sprintf("model registration is not supported for runtime %s", s.runtimeType),
+		}
+	}
+
+	return nil
+}
+
+func toResponse(m *dbmodels.RegisteredModel) catalogtypes.RegisteredModel {
+	return catalogtypes.RegisteredModel{
+		ID:            m.ID.String(),
+		Name:          m.Name,
+		Description:   m.Description,
+		ComponentType: m.ComponentType,
+		SourceType:    string(m.SourceType),
+		Source:        m.Source,
+		Digest:        m.Digest,
+		Status:        string(m.Status),
+		SizeBytes:     m.SizeBytes,
+		Error:         m.Error,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
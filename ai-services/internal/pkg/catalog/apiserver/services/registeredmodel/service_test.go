@@ -0,0 +1,274 @@
+package registeredmodel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+type fakeRepo struct {
+	repository.RegisteredModelRepository
+	mu     sync.Mutex
+	models map[string]*dbmodels.RegisteredModel
+}
+
+func (f *fakeRepo) Insert(_ context.Context, m *dbmodels.RegisteredModel) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.models[m.Name]; ok {
+		return repository.ErrRegisteredModelExists
+	}
+	m.ID, m.Status = uuid.New(), dbmodels.RegisteredModelStatusRegistering
+	stored := *m
+	f.models[m.Name] = &stored
+
+	return nil
+}
+
+func (f *fakeRepo) GetByName(_ context.Context, name string) (*dbmodels.RegisteredModel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if m, ok := f.models[name]; ok {
+		copied := *m
+
+		return &copied, nil
+	}
+
+	return nil, nil
+}
+
+func (f *fakeRepo) GetAll(_ context.Context, filters *repository.RegisteredModelFilters) ([]dbmodels.RegisteredModel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var models []dbmodels.RegisteredModel
+	for _, m := range f.models {
+		if filters != nil && (filters.ComponentType != m.ComponentType || filters.Status != m.Status) {
+			continue
+		}
+		models = append(models, *m)
+	}
+
+	return models, nil
+}
+
+func (f *fakeRepo) Update(_ context.Context, id uuid.UUID, upd dbmodels.RegisteredModelUpdate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, m := range f.models {
+		if m.ID != id {
+			continue
+		}
+		if upd.Status != nil {
+			m.Status = *upd.Status
+		}
+		if upd.Digest != nil {
+			m.Digest = *upd.Digest
+		}
+		if upd.SizeBytes != nil {
+			m.SizeBytes = upd.SizeBytes
+		}
+		if upd.Error != nil {
+			m.Error = *upd.Error
+		}
+
+		return nil
+	}
+
+	return repository.ErrRegisteredModelNotFound
+}
+
+func (f *fakeRepo) Delete(_ context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for name, m := range f.models {
+		if m.ID == id {
+			delete(f.models, name)
+		}
+	}
+
+	return nil
+}
+
+type fakeCompRepo struct {
+	repository.ComponentRepository
+	components []dbmodels.Component
+}
+
+func (f *fakeCompRepo) GetAll(context.Context) ([]dbmodels.Component, error) {
+	return f.components, nil
+}
+
+type fakeProvider struct{}
+
+func (fakeProvider) ListComponents() ([]catalogtypes.Component, error) {
+	return []catalogtypes.Component{
+		{ID: "vllm-spyre", ComponentType: "llm", CustomModels: true},
+		{ID: "watsonx", ComponentType: "llm"},
+		{ID: "opensearch", ComponentType: "vector_db"},
+	}, nil
+}
+
+func (fakeProvider) GetComponentModels(_ context.Context, componentType, providerID string) ([]string, error) {
+	return []string{"ibm-granite/granite-3.3-8b-instruct"}, nil
+}
+
+func newTestService(t *testing.T) (*service, *fakeRepo, *fakeCompRepo) {
+	t.Helper()
+
+	repo := &fakeRepo{models: map[string]*dbmodels.RegisteredModel{}}
+	compRepo := &fakeCompRepo{}
+	svc := NewService(repo, compRepo, fakeProvider{}, runtimeTypes.RuntimeTypePodman, t.TempDir()).(*service)
+	svc.importRoot = os.TempDir()
+
+	return svc, repo, compRepo
+}
+
+// modelDir writes a minimal model directory.
+func modelDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.safetensors"), []byte("weights"), 0o644))
+
+	return dir
+}
+
+func waitForStatus(t *testing.T, svc *service, name string, status dbmodels.RegisteredModelStatus) *catalogtypes.RegisteredModel {
+	t.Helper()
+
+	var model *catalogtypes.RegisteredModel
+	require.Eventually(t, func() bool {
+		var err error
+		model, err = svc.Get(context.Background(), name)
+		require.NoError(t, err)
+
+		return model.Status == string(status)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return model
+}
+
+func requireStatusCode(t *testing.T, err error, code int) {
+	t.Helper()
+
+	var valErr *validators.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, code, valErr.Code, valErr.Message)
+}
+
+func TestRegisterValidation(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	dir := modelDir(t)
+
+	tests := []struct {
+		name string
+		req  catalogtypes.RegisterModelRequest
+		code int
+	}{
+		{"invalid name", catalogtypes.RegisterModelRequest{Name: "../etc", ComponentType: "llm", Path: dir}, http.StatusBadRequest},
+		{"path and image", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Path: dir, Image: "quay.io/acme/ft:1"}, http.StatusBadRequest},
+		{"no source", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm"}, http.StatusBadRequest},
+		{"relative path", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Path: "models/ft"}, http.StatusBadRequest},
+		{"path outside the base directory", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Path: "/etc"}, http.StatusBadRequest},
+		{"path in the models directory", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Path: svc.modelsDir + "/other"}, http.StatusBadRequest},
+		{"component type without custom models", catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "vector_db", Path: dir}, http.StatusBadRequest},
+		{"catalog model name", catalogtypes.RegisterModelRequest{Name: "ibm-granite/granite-3.3-8b-instruct", ComponentType: "llm", Path: dir}, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Register(context.Background(), tt.req, "admin")
+			requireStatusCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestRegisterLocalDirectory(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	req := catalogtypes.RegisterModelRequest{Name: "acme/granite-support", ComponentType: "llm", Path: modelDir(t), Description: "Support tickets"}
+
+	resp, err := svc.Register(context.Background(), req, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "registering", resp.Status)
+	assert.Equal(t, "local", resp.SourceType)
+
+	model := waitForStatus(t, svc, req.Name, dbmodels.RegisteredModelStatusReady)
+	require.NotNil(t, model.SizeBytes)
+	assert.Positive(t, *model.SizeBytes)
+	assert.True(t, modelstore.Complete(svc.modelsDir, req.Name))
+
+	offered, err := svc.RegisteredModels(context.Background(), "llm")
+	require.NoError(t, err)
+	require.Len(t, offered, 1)
+	assert.Equal(t, req.Name, offered[0].Name)
+
+	_, err = svc.Register(context.Background(), req, "admin")
+	requireStatusCode(t, err, http.StatusConflict)
+}
+
+func TestRegisterOCIFailure(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	svc.pull = func(context.Context, string, string, string, modelstore.PullOptions) (*modelstore.Metadata, error) {
+		return nil, errors.New("manifest unknown")
+	}
+
+	_, err := svc.Register(context.Background(), catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Image: "quay.io/acme/ft:1"}, "admin")
+	require.NoError(t, err)
+
+	model := waitForStatus(t, svc, "acme/ft", dbmodels.RegisteredModelStatusFailed)
+	assert.Contains(t, model.Error, "manifest unknown")
+
+	offered, err := svc.RegisteredModels(context.Background(), "llm")
+	require.NoError(t, err)
+	assert.Empty(t, offered, "failed models are not offered")
+
+	require.NoError(t, svc.Delete(context.Background(), "acme/ft"))
+}
+
+func TestDelete(t *testing.T) {
+	svc, repo, compRepo := newTestService(t)
+	name := "acme/granite-support"
+
+	_, err := svc.Register(context.Background(), catalogtypes.RegisterModelRequest{Name: name, ComponentType: "llm", Path: modelDir(t)}, "admin")
+	require.NoError(t, err)
+	waitForStatus(t, svc, name, dbmodels.RegisteredModelStatusReady)
+
+	compRepo.components = []dbmodels.Component{{ID: uuid.New(), Type: "llm", Provider: "vllm-spyre", Metadata: map[string]any{"model": name}}}
+	requireStatusCode(t, svc.Delete(context.Background(), name), http.StatusConflict)
+
+	compRepo.components = nil
+	require.NoError(t, svc.Delete(context.Background(), name))
+	assert.NoDirExists(t, modelstore.Dir(svc.modelsDir, name))
+	assert.Empty(t, repo.models)
+
+	requireStatusCode(t, svc.Delete(context.Background(), name), http.StatusNotFound)
+}
+
+func TestRegisterUnsupportedRuntime(t *testing.T) {
+	repo := &fakeRepo{models: map[string]*dbmodels.RegisteredModel{}}
+	svc := NewService(repo, &fakeCompRepo{}, fakeProvider{}, runtimeTypes.RuntimeTypeOpenShift, t.TempDir())
+
+	_, err := svc.Register(context.Background(), catalogtypes.RegisterModelRequest{Name: "acme/ft", ComponentType: "llm", Path: "/models/ft"}, "admin")
+	requireStatusCode(t, err, http.StatusNotImplemented)
+}
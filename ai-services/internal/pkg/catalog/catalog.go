@@ -30,7 +30,11 @@ type catalogItem struct {
 }
 
 // CatalogProvider provides access to catalog items.
-type CatalogProvider struct{}
+type CatalogProvider struct {
+	// models is consulted for the schemas of providers accepting custom models.
+	// It is only set in the API server, where registered models are stored.
+	models ModelRegistry
+}
 
 var (
 	sharedItems map[string]*catalogItem
@@ -53,6 +57,15 @@ func NewCatalogProvider() (*CatalogProvider, error) {
 	return &CatalogProvider{}, nil
 }
 
+// WithModelRegistry returns a provider that offers the models of registry for the
+// providers accepting custom models.
+func (p *CatalogProvider) WithModelRegistry(registry ModelRegistry) *CatalogProvider {
+	provider := *p
+	provider.models = registry
+
+	return &provider
+}
+
 // loadCatalogItems loads all catalog items into the provided map.
 func loadCatalogItems(ctx context.Context, items map[string]*catalogItem) error {
 	// Walk the catalog filesystem to find all metadata.yaml files
@@ -1,8 +1,11 @@
 package catalog
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 )
 
 func TestListArchitectures(t *testing.T) {
@@ -218,4 +221,54 @@ func TestLoadComponent(t *testing.T) {
 	}
 }
 
+type fakeModelRegistry struct {
+	models map[string][]types.RegisteredModel
+}
+
+func (r fakeModelRegistry) RegisteredModels(_ context.Context, componentType string) ([]types.RegisteredModel, error) {
+	return r.models[componentType], nil
+}
+
+func TestRegisteredModels(t *testing.T) {
+	provider := (&CatalogProvider{}).WithModelRegistry(fakeModelRegistry{models: map[string][]types.RegisteredModel{
+		"llm": {
+			{Name: "acme/granite-support", Description: "Fine-tuned for support tickets"},
+			{Name: "ibm-granite/granite-3.3-8b-instruct"},
+		},
+	}})
+
+	schema := func() map[string]any {
+		return map[string]any{"properties": map[string]any{
+			"model": map[string]any{"oneOf": []any{
+				map[string]any{"const": "ibm-granite/granite-3.3-8b-instruct"},
+			}},
+			"apiKey": map[string]any{"type": "string"},
+		}}
+	}
+
+	llm := schema()
+	provider.addRegisteredModels(context.Background(), "llm", llm)
+	oneOf := llm["properties"].(map[string]any)["model"].(map[string]any)["oneOf"].([]any)
+	if len(oneOf) != 2 {
+		t.Fatalf("expected the catalog model and one registered model, got %v", oneOf)
+	}
+	option := oneOf[1].(map[string]any)
+	if option["const"] != "acme/granite-support" || option[RegisteredModelKey] != true {
+		t.Errorf("unexpected registered model option %v", option)
+	}
+
+	// Registered models are offered but never downloaded.
+	models := make(map[string]bool)
+	extractModelsFromSchema(llm, models)
+	if len(models) != 1 || !models["ibm-granite/granite-3.3-8b-instruct"] {
+		t.Errorf("extractModelsFromSchema() = %v, want only the catalog model", models)
+	}
+
+	embedding := schema()
+	provider.addRegisteredModels(context.Background(), "embedding", embedding)
+	if n := len(embedding["properties"].(map[string]any)["model"].(map[string]any)["oneOf"].([]any)); n != 1 {
+		t.Errorf("models registered for llm must not be offered for embedding, got %d options", n)
+	}
+}
+
 // Made with Bob
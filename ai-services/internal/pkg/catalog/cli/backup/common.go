@@ -0,0 +1,41 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	catalogPodman "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/podman"
+	backupUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// Backup writes a backup of the catalog control plane.
+func Backup(ctx context.Context, opts backupUtils.BackupOptions) error {
+	switch opts.Runtime {
+	case types.RuntimeTypePodman:
+		return catalogPodman.BackupCatalog(ctx, opts)
+	case types.RuntimeTypeOpenShift:
+		return errOpenShift()
+	default:
+		return fmt.Errorf("unsupported runtime type: %s", opts.Runtime)
+	}
+}
+
+// Restore restores a backup of the catalog control plane.
+func Restore(ctx context.Context, opts backupUtils.RestoreOptions) error {
+	switch opts.Runtime {
+	case types.RuntimeTypePodman:
+		return catalogPodman.RestoreCatalog(ctx, opts)
+	case types.RuntimeTypeOpenShift:
+		return errOpenShift()
+	default:
+		return fmt.Errorf("unsupported runtime type: %s", opts.Runtime)
+	}
+}
+
+// errOpenShift explains how to protect the catalog on OpenShift, where its
+// database, bundles and secrets live in the cluster.
+func errOpenShift() error {
+	return fmt.Errorf("catalog backup is not supported on OpenShift; back up the catalog namespace " +
+		"(catalog-db statefulset volume, catalog-bundles PVC and catalog secrets) with the cluster's backup tooling")
+}
@@ -0,0 +1,143 @@
+package podman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	backupUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/utils"
+	catalogConstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// BackupCatalog writes the catalog database, bundles, secrets and Caddy
+// configuration into an archive. The catalog pod is stopped meanwhile so the
+// database dump and the bundle files match.
+func BackupCatalog(ctx context.Context, opts backupUtils.BackupOptions) (err error) {
+	rt, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to initialize podman client: %w", err)
+	}
+
+	db, err := findDatabase(rt)
+	if err != nil {
+		return err
+	}
+
+	stagingDir, err := os.MkdirTemp("", "catalog-backup-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(stagingDir)
+	}()
+
+	startCatalog, err := stopCatalog(rt)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, startCatalog())
+	}()
+
+	entries, err := stageCatalog(ctx, rt, db, stagingDir)
+	if err != nil {
+		return err
+	}
+
+	entry := backuptarget.ManifestEntry{
+		Name:          backupUtils.Kind,
+		Kind:          backupUtils.Kind,
+		CatalogID:     catalogConstants.CatalogAppTemplate,
+		SchemaVersion: backupUtils.SchemaVersion,
+	}
+	if _, err := backuptarget.WriteSealedArchive(stagingDir, opts.File, entries, catalogConstants.CatalogAppName, types.RuntimeTypePodman, entry, opts.Protection); err != nil {
+		_ = os.Remove(opts.File) // best-effort cleanup of a partial archive
+
+		return fmt.Errorf("failed to write catalog backup archive: %w", err)
+	}
+
+	logger.Infof("✅ Catalog backup completed successfully: %s\n", opts.File)
+
+	return nil
+}
+
+// stageCatalog writes the parts of the catalog into dir and returns the archive
+// entries they make up.
+func stageCatalog(ctx context.Context, rt *podman.PodmanClient, db *database, dir string) ([]string, error) {
+	logger.Infof("Dumping catalog database %s...\n", db.name)
+	if err := dumpDatabase(ctx, rt, db, filepath.Join(dir, backupUtils.DatabaseFile)); err != nil {
+		return nil, err
+	}
+	entries := []string{backupUtils.DatabaseFile}
+
+	logger.Infof("Exporting volume %s...\n", backupUtils.BundlesVolume)
+	if err := exportBundles(rt, filepath.Join(dir, backupUtils.BundlesFile)); err != nil {
+		return nil, err
+	}
+	entries = append(entries, backupUtils.BundlesFile)
+
+	for _, name := range backupUtils.Secrets {
+		data, labels, err := rt.SecretData(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := backupUtils.WriteSecret(dir, backupUtils.Secret{Name: name, Labels: labels, Data: data}); err != nil {
+			return nil, err
+		}
+	}
+	entries = append(entries, backupUtils.SecretsDir)
+
+	caddyfile, autosave := caddyPaths(rt)
+	if err := backupUtils.CopyFile(caddyfile, filepath.Join(dir, backupUtils.CaddyDir, backupUtils.Caddyfile), caddyFilePerm); err != nil {
+		return nil, fmt.Errorf("failed to back up Caddyfile: %w", err)
+	}
+	err := backupUtils.CopyFile(autosave, filepath.Join(dir, backupUtils.CaddyDir, backupUtils.CaddyAutosave), caddyFilePerm)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Warningf("%s not found, Caddy routes are not backed up\n", autosave)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to back up Caddy routes: %w", err)
+	}
+	entries = append(entries, backupUtils.CaddyDir)
+
+	return entries, nil
+}
+
+// dumpDatabase streams a dump of the catalog database into file.
+func dumpDatabase(ctx context.Context, rt *podman.PodmanClient, db *database, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create database dump: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := rt.ExecInContainerWithIO(ctx, db.containerID, db.dumpCommand(), nil, f); err != nil {
+		return fmt.Errorf("failed to dump catalog database: %w", err)
+	}
+
+	return f.Close()
+}
+
+// exportBundles writes the bundle volume into file as a tar archive.
+func exportBundles(rt *podman.PodmanClient, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle export: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := rt.ExportVolume(backupUtils.BundlesVolume, f); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,120 @@
+package podman
+
+import (
+	"fmt"
+	"path/filepath"
+
+	backupUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/utils"
+	catalogConstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	catalogUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// Pods and containers of the catalog, as named by the catalog templates.
+const (
+	catalogPodSuffix = "catalog"
+	dbPodSuffix      = "db"
+	caddyPodSuffix   = "caddy"
+	dbContainerName  = "postgresql"
+	defaultDBUser    = "postgres"
+	defaultDBName    = "ai_services"
+	envPostgresUser  = "POSTGRES_USER"
+	envPostgresDB    = "POSTGRES_DB"
+	caddyFilePerm    = 0o644
+)
+
+// catalogPod returns the name of a catalog pod.
+func catalogPod(suffix string) string {
+	return catalogConstants.CatalogAppName + "--" + suffix
+}
+
+// database is the catalog database container and how to connect to it.
+type database struct {
+	containerID string
+	user        string
+	name        string
+}
+
+// findDatabase locates the database container of the catalog. The user and
+// database come from the container environment the template sets.
+func findDatabase(rt *podman.PodmanClient) (*database, error) {
+	podName := catalogPod(dbPodSuffix)
+	pod, err := rt.InspectPod(podName)
+	if err != nil {
+		return nil, fmt.Errorf("catalog database pod %s not found, is the catalog configured? %w", podName, err)
+	}
+
+	// Podman names the containers of a kube pod <pod>-<container>.
+	containerName := podName + "-" + dbContainerName
+	for _, c := range pod.Containers {
+		if c.Name != containerName {
+			continue
+		}
+
+		info, err := rt.InspectContainer(c.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect container %s: %w", c.Name, err)
+		}
+		if info.Status != "running" {
+			return nil, fmt.Errorf("catalog database container %s is %s, it must be running", c.Name, info.Status)
+		}
+
+		db := &database{containerID: c.ID, user: defaultDBUser, name: defaultDBName}
+		if user := info.Env[envPostgresUser]; user != "" {
+			db.user = user
+		}
+		if name := info.Env[envPostgresDB]; name != "" {
+			db.name = name
+		}
+
+		return db, nil
+	}
+
+	return nil, fmt.Errorf("container %s not found in pod %s", containerName, podName)
+}
+
+// caddyPaths returns the Caddyfile and Caddy's saved configuration on the host.
+func caddyPaths(rt *podman.PodmanClient) (string, string) {
+	baseDir := utils.GetBaseDir()
+	if config, _, err := catalogUtils.GetCatalogPodConfig(rt); err != nil {
+		logger.Warningf("Failed to retrieve BaseDir from catalog pod: %v. Using default BaseDir.\n", err)
+	} else if config.BaseDir != "" {
+		baseDir = config.BaseDir
+	}
+
+	return filepath.Join(baseDir, "common", "caddy", backupUtils.Caddyfile),
+		filepath.Join(baseDir, "common", "caddy-config", "caddy", backupUtils.CaddyAutosave)
+}
+
+// stopCatalog stops the catalog pod so that the API server does not write to the
+// database or the bundle volume, and returns a function that starts it again.
+func stopCatalog(rt *podman.PodmanClient) (func() error, error) {
+	podName := catalogPod(catalogPodSuffix)
+	logger.Infof("Stopping %s...\n", podName)
+
+	if err := rt.StopPod(podName); err != nil {
+		return nil, fmt.Errorf("failed to stop catalog pod: %w", err)
+	}
+
+	return func() error {
+		logger.Infof("Starting %s...\n", podName)
+		if err := rt.StartPod(podName); err != nil {
+			return fmt.Errorf("failed to start catalog pod %s, start it with 'podman pod start %s': %w", podName, podName, err)
+		}
+
+		return nil
+	}, nil
+}
+
+// psqlCommand returns the psql command line that runs SQL read from stdin.
+func (db *database) psqlCommand() []string {
+	return []string{"psql", "-U", db.user, "-d", db.name, "-v", "ON_ERROR_STOP=1", "--single-transaction", "-q"}
+}
+
+// dumpCommand returns the pg_dump command line that writes a plain SQL dump which
+// replaces the objects of the database it is loaded into.
+func (db *database) dumpCommand() []string {
+	return []string{"pg_dump", "-U", db.user, "-d", db.name, "--clean", "--if-exists", "--no-owner", "--no-privileges"}
+}
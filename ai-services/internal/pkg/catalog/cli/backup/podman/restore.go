@@ -0,0 +1,163 @@
+package podman
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	backupUtils "github.com/project-ai-services/ai-services/internal/pkg/catalog/cli/backup/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// RestoreCatalog restores a catalog backup onto the configured catalog. The
+// database, bundles and secrets of the catalog are replaced while the catalog pod
+// is stopped. Starting it again runs pending migrations on the restored database
+// and a sync of every application against the pods that exist on this host.
+func RestoreCatalog(ctx context.Context, opts backupUtils.RestoreOptions) (err error) {
+	rt, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to initialize podman client: %w", err)
+	}
+
+	plainFile, manifest, cleanup, err := backuptarget.OpenSealedArchive(opts.File, backupUtils.Kind, opts.Protection)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if manifest.Target.SchemaVersion > backupUtils.SchemaVersion {
+		return fmt.Errorf("%s was written by a newer CLI (%s); upgrade ai-services to restore it", filepath.Base(opts.File), manifest.CLIVersion)
+	}
+	logger.Infof("Restoring catalog backup taken at %s with CLI %s\n", manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"), manifest.CLIVersion)
+
+	db, err := findDatabase(rt)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "catalog-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	if err := utils.ExtractTarGz(plainFile, dir); err != nil {
+		return fmt.Errorf("failed to extract catalog backup: %w", err)
+	}
+
+	startCatalog, err := stopCatalog(rt)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, startCatalog())
+		if err == nil {
+			logger.Infoln("The catalog reconciles the restored applications with the pods on this host once it is up")
+		}
+	}()
+
+	if err := restoreDatabase(ctx, rt, db, dir); err != nil {
+		return err
+	}
+
+	if err := restoreBundles(rt, dir); err != nil {
+		return err
+	}
+
+	for _, name := range backupUtils.Secrets {
+		secret, err := backupUtils.ReadSecret(dir, name)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("Restoring secret %s...\n", name)
+		if err := rt.ReplaceSecret(secret.Name, secret.Data, secret.Labels); err != nil {
+			return err
+		}
+	}
+
+	if err := restoreCaddy(rt, dir, opts.RestoreRoutes); err != nil {
+		return err
+	}
+
+	logger.Infoln("✅ Catalog restore completed successfully")
+
+	return nil
+}
+
+// restoreDatabase loads the dump into the catalog database, replacing its
+// contents, and fails the operations that were in flight at backup time.
+func restoreDatabase(ctx context.Context, rt *podman.PodmanClient, db *database, dir string) error {
+	logger.Infof("Restoring catalog database %s...\n", db.name)
+
+	dump, err := os.Open(filepath.Join(dir, backupUtils.DatabaseFile))
+	if err != nil {
+		return fmt.Errorf("failed to open database dump: %w", err)
+	}
+	defer func() {
+		_ = dump.Close()
+	}()
+
+	if err := rt.ExecInContainerWithIO(ctx, db.containerID, db.psqlCommand(), dump, nil); err != nil {
+		return fmt.Errorf("failed to restore catalog database: %w", err)
+	}
+
+	if err := rt.ExecInContainerWithIO(ctx, db.containerID, db.psqlCommand(), strings.NewReader(backupUtils.InterruptedSQL), nil); err != nil {
+		return fmt.Errorf("failed to mark interrupted operations: %w", err)
+	}
+
+	return nil
+}
+
+// restoreBundles imports the bundle files into the bundle volume.
+func restoreBundles(rt *podman.PodmanClient, dir string) error {
+	logger.Infof("Restoring volume %s...\n", backupUtils.BundlesVolume)
+
+	f, err := os.Open(filepath.Join(dir, backupUtils.BundlesFile))
+	if err != nil {
+		return fmt.Errorf("failed to open bundle export: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return rt.ImportVolume(backupUtils.BundlesVolume, f)
+}
+
+// restoreCaddy restores the Caddyfile and, with routes, Caddy's saved routes, then
+// restarts Caddy to load them.
+func restoreCaddy(rt *podman.PodmanClient, dir string, routes bool) error {
+	caddyfile, autosave := caddyPaths(rt)
+
+	if err := backupUtils.CopyFile(filepath.Join(dir, backupUtils.CaddyDir, backupUtils.Caddyfile), caddyfile, caddyFilePerm); err != nil {
+		return fmt.Errorf("failed to restore Caddyfile: %w", err)
+	}
+
+	if routes {
+		err := backupUtils.CopyFile(filepath.Join(dir, backupUtils.CaddyDir, backupUtils.CaddyAutosave), autosave, caddyFilePerm)
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warningln("The backup has no Caddy routes")
+		} else if err != nil {
+			return fmt.Errorf("failed to restore Caddy routes: %w", err)
+		}
+	}
+
+	podName := catalogPod(caddyPodSuffix)
+	logger.Infof("Restarting %s...\n", podName)
+	if err := rt.StopPod(podName); err != nil {
+		return fmt.Errorf("failed to stop Caddy pod: %w", err)
+	}
+	if err := rt.StartPod(podName); err != nil {
+		return fmt.Errorf("failed to start Caddy pod: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/application/backuptarget"
+	catalogConstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// Layout of a catalog backup archive.
+const (
+	// Kind is the manifest kind of catalog backups.
+	Kind = "catalog"
+	// SchemaVersion is the layout version of catalog backups.
+	SchemaVersion = 1
+
+	// DatabaseFile is the plain SQL dump of the catalog database.
+	DatabaseFile = "catalog.sql"
+	// BundlesFile is the tar export of the catalog-bundles volume.
+	BundlesFile = "bundles.tar"
+	// SecretsDir holds one JSON file per backed up secret.
+	SecretsDir = "secrets"
+	// CaddyDir holds the Caddyfile and Caddy's saved route configuration.
+	CaddyDir = "caddy"
+	// Caddyfile is the static Caddy configuration.
+	Caddyfile = "Caddyfile"
+	// CaddyAutosave is the configuration Caddy saves with the routes of the
+	// catalog and its applications.
+	CaddyAutosave = "autosave.json"
+
+	dirPermission    = 0o750
+	secretPermission = 0o600
+)
+
+// BundlesVolume is the volume the catalog keeps uploaded bundles in.
+const BundlesVolume = "catalog-bundles"
+
+// Secrets lists the secrets a catalog backup carries: the key connector
+// credentials are encrypted with in the database, and the admin password. The
+// database password is not restored; the database of the target catalog keeps its own.
+var Secrets = []string{catalogConstants.CatalogConnectorSecretName, catalogConstants.CatalogSecretName}
+
+// InterruptedMessage is recorded on resources whose operation was in flight when
+// the backup was taken.
+const InterruptedMessage = "Interrupted by catalog restore"
+
+// InterruptedSQL marks applications, services, components and backup jobs that
+// were in flight when the backup was taken as failed. No deployment or job of
+// the restored catalog will finish them, and the sync service only reconciles
+// resources in a Running or Error state.
+var InterruptedSQL = strings.Join([]string{
+	"UPDATE applications SET status = 'Error', message = '" + InterruptedMessage + "' WHERE status IN ('Downloading', 'Deploying', 'Deleting');",
+	"UPDATE services SET status = 'Error', message = '" + InterruptedMessage + "' WHERE status = 'Initializing';",
+	"UPDATE components SET status = 'Error', message = '" + InterruptedMessage + "' WHERE status = 'Initializing';",
+	"UPDATE backup_jobs SET status = 'failed', message = '" + InterruptedMessage + "', completed_at = NOW() WHERE status IN ('pending', 'running');",
+}, "\n")
+
+// BackupOptions contains the configuration for backing up the catalog.
+type BackupOptions struct {
+	Runtime types.RuntimeType
+	// File is the archive to write.
+	File       string
+	Protection backuptarget.Protection
+}
+
+// RestoreOptions contains the configuration for restoring the catalog.
+type RestoreOptions struct {
+	Runtime types.RuntimeType
+	// File is the archive to restore.
+	File       string
+	Protection backuptarget.Protection
+	// RestoreRoutes also restores Caddy's saved routes. Routes point at the host
+	// the backup was taken on, so they are only useful when restoring there.
+	RestoreRoutes bool
+}
+
+// Secret is a secret as stored in a catalog backup.
+type Secret struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Data   string            `json:"data"`
+}
+
+// WriteSecret writes a secret into the secrets directory below dir.
+func WriteSecret(dir string, secret Secret) error {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret %s: %w", secret.Name, err)
+	}
+
+	secretsDir := filepath.Join(dir, SecretsDir)
+	if err := os.MkdirAll(secretsDir, dirPermission); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(secretsDir, secret.Name+".json"), data, secretPermission); err != nil {
+		return fmt.Errorf("failed to write secret %s: %w", secret.Name, err)
+	}
+
+	return nil
+}
+
+// ReadSecret reads the named secret from the secrets directory below dir.
+func ReadSecret(dir, name string) (Secret, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SecretsDir, name+".json"))
+	if err != nil {
+		return Secret{}, fmt.Errorf("failed to read secret %s from backup: %w", name, err)
+	}
+
+	var secret Secret
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return Secret{}, fmt.Errorf("failed to parse secret %s from backup: %w", name, err)
+	}
+
+	return secret, nil
+}
+
+// CopyFile copies src to dst with the given permissions, creating the directory
+// of dst. A missing src is reported through os.ErrNotExist.
+func CopyFile(src, dst string, perm os.FileMode) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), dirPermission); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", filepath.Base(dst), err)
+	}
+
+	if err := os.WriteFile(dst, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(dst), err)
+	}
+
+	return nil
+}
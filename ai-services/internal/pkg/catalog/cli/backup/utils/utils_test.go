@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	secret := Secret{Name: "catalog-secret", Labels: map[string]string{"ai-services.io/application": "ai-services"}, Data: "s3cr3t"}
+
+	require.NoError(t, WriteSecret(dir, secret))
+
+	got, err := ReadSecret(dir, "catalog-secret")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+
+	_, err = ReadSecret(dir, "missing")
+	require.Error(t, err)
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "Caddyfile")
+	require.NoError(t, os.WriteFile(src, []byte(":80"), 0o600))
+
+	dst := filepath.Join(dir, "caddy", "nested", "Caddyfile")
+	require.NoError(t, CopyFile(src, dst, 0o644))
+
+	data, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, ":80", string(data))
+
+	err = CopyFile(filepath.Join(dir, "missing"), dst, 0o644)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInterruptedSQL(t *testing.T) {
+	for _, table := range []string{"applications", "services", "components", "backup_jobs"} {
+		assert.Contains(t, InterruptedSQL, "UPDATE "+table+" ")
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"fmt"
+
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const acceleratorsRoute = "/api/v1/accelerators"
+
+// ListAccelerators returns the accelerator inventory of the catalog host.
+func (c *Client) ListAccelerators() (*catalogtypes.AcceleratorListResponse, error) {
+	var result catalogtypes.AcceleratorListResponse
+
+	resp, err := c.httpClient.R().SetResult(&result).Get(acceleratorsRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list accelerators: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list accelerators: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
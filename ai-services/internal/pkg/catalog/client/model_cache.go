@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	modelsRoute      = "/api/v1/models"
+	modelVerifyRoute = "/api/v1/models/verify"
+	modelPruneRoute  = "/api/v1/models/prune"
+)
+
+// ListCachedModels returns the models cached on the catalog host and the
+// applications using them.
+func (c *Client) ListCachedModels() (*catalogtypes.ModelListResponse, error) {
+	var result catalogtypes.ModelListResponse
+	resp, err := c.httpClient.R().
+		SetResult(&result).
+		Get(modelsRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list cached models: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list cached models: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// VerifyCachedModels verifies the files of the cached models against their
+// recorded checksums. When model is non-empty only that model is verified.
+func (c *Client) VerifyCachedModels(model string) (*catalogtypes.ModelVerifyResponse, error) {
+	var result catalogtypes.ModelVerifyResponse
+	resp, err := c.httpClient.R().
+		SetBody(catalogtypes.ModelVerifyRequest{Model: model}).
+		SetResult(&result).
+		Post(modelVerifyRoute)
+	if err != nil {
+		return nil, fmt.Errorf("verify cached models: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("verify cached models: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// PruneCachedModels removes the cached models no application uses. With dryRun
+// set it only reports what would be removed.
+func (c *Client) PruneCachedModels(dryRun bool) (*catalogtypes.ModelPruneResponse, error) {
+	var result catalogtypes.ModelPruneResponse
+	resp, err := c.httpClient.R().
+		SetBody(catalogtypes.ModelPruneRequest{DryRun: dryRun}).
+		SetResult(&result).
+		Post(modelPruneRoute)
+	if err != nil {
+		return nil, fmt.Errorf("prune cached models: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("prune cached models: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	imagePruneRoute  = "/api/v1/images/prune"
+	volumePruneRoute = "/api/v1/volumes/prune"
+)
+
+// PruneImages removes the images on the catalog host no application refers
+// to. With dryRun set it only reports what would be removed.
+func (c *Client) PruneImages(dryRun bool) (*catalogtypes.ImagePruneResponse, error) {
+	var result catalogtypes.ImagePruneResponse
+	resp, err := c.httpClient.R().
+		SetBody(catalogtypes.PruneRequest{DryRun: dryRun}).
+		SetResult(&result).
+		Post(imagePruneRoute)
+	if err != nil {
+		return nil, fmt.Errorf("prune images: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("prune images: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// PruneVolumes removes the volumes on the catalog host left by deleted
+// applications and components. With dryRun set it only reports what would be
+// removed.
+func (c *Client) PruneVolumes(dryRun bool) (*catalogtypes.VolumePruneResponse, error) {
+	var result catalogtypes.VolumePruneResponse
+	resp, err := c.httpClient.R().
+		SetBody(catalogtypes.PruneRequest{DryRun: dryRun}).
+		SetResult(&result).
+		Post(volumePruneRoute)
+	if err != nil {
+		return nil, fmt.Errorf("prune volumes: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("prune volumes: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
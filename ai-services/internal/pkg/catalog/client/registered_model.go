@@ -0,0 +1,82 @@
+package client
+
+import (
+	"fmt"
+
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const registeredModelsRoute = "/api/v1/models/registered"
+
+// RegisterModel registers a custom model in the catalog. The model files are
+// placed in the background; the returned model is in status registering.
+func (c *Client) RegisterModel(req catalogtypes.RegisterModelRequest) (*catalogtypes.RegisteredModel, error) {
+	var result catalogtypes.RegisteredModel
+	resp, err := c.httpClient.R().
+		SetBody(req).
+		SetResult(&result).
+		Post(registeredModelsRoute)
+	if err != nil {
+		return nil, fmt.Errorf("register model: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("register model: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// ListRegisteredModels returns the custom models registered in the catalog.
+func (c *Client) ListRegisteredModels() (*catalogtypes.RegisteredModelListResponse, error) {
+	var result catalogtypes.RegisteredModelListResponse
+	resp, err := c.httpClient.R().
+		SetResult(&result).
+		Get(registeredModelsRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list registered models: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list registered models: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// GetRegisteredModel returns the custom model registered under name.
+func (c *Client) GetRegisteredModel(name string) (*catalogtypes.RegisteredModel, error) {
+	var result catalogtypes.RegisteredModel
+	resp, err := c.httpClient.R().
+		SetResult(&result).
+		Get(registeredModelsRoute + "/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("get registered model: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("get registered model: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// UnregisterModel unregisters a custom model and removes its files.
+func (c *Client) UnregisterModel(name string) error {
+	resp, err := c.httpClient.R().
+		Delete(registeredModelsRoute + "/" + name)
+	if err != nil {
+		return fmt.Errorf("unregister model: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("unregister model: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE backup_job_type AS ENUM (
+    'backup',
+    'restore'
+);
+
+CREATE TYPE backup_job_status AS ENUM (
+    'pending',
+    'running',
+    'completed',
+    'failed'
+);
+
+-- Backup and restore jobs started through the API. Backup archives live on the
+-- catalog-backups volume as /data/backups/<file_name>.
+CREATE TABLE backup_jobs (
+    id               UUID               PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Nullable so the backup history (and the archives) outlive the application.
+    app_id           UUID               REFERENCES applications(id) ON DELETE SET NULL,
+    app_name         VARCHAR(100)       NOT NULL,
+
+    type             backup_job_type    NOT NULL,
+    -- Backup target, e.g. "opensearch", "digitize" or "all".
+    target           VARCHAR(100)       NOT NULL,
+    status           backup_job_status  NOT NULL DEFAULT 'pending',
+    -- Percentage of targets processed, 0-100.
+    progress         SMALLINT           NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    message          TEXT,
+
+    -- Backup jobs: archive path relative to the backup volume and its size once completed.
+    file_name        VARCHAR(255),
+    size_bytes       BIGINT,
+
+    -- Restore jobs: the backup job whose archive is restored.
+    source_backup_id UUID               REFERENCES backup_jobs(id) ON DELETE SET NULL,
+
+    created_by       VARCHAR(100),
+    created_at       TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    completed_at     TIMESTAMPTZ
+);
+
+CREATE INDEX idx_backup_jobs_app_id ON backup_jobs (app_id, created_at DESC);
+
+-- Reuse the update_updated_at_column() trigger function created in
+-- 20260430094502_create_applications_table.sql.
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON backup_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON backup_jobs;
+DROP INDEX   IF EXISTS idx_backup_jobs_app_id;
+DROP TABLE   IF EXISTS backup_jobs;
+DROP TYPE    IF EXISTS backup_job_status;
+DROP TYPE    IF EXISTS backup_job_type;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Backup schedules run by the API server. Each schedule backs up one target of
+-- an application on a cron expression and prunes its own older backups.
+CREATE TABLE backup_schedules (
+    id           UUID               PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id       UUID               NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+
+    -- Backup target, e.g. "opensearch", "digitize" or "all".
+    target       VARCHAR(100)       NOT NULL,
+    -- Standard five-field cron expression, evaluated in the API server's time zone.
+    cron_expr    VARCHAR(100)       NOT NULL,
+
+    -- Retention: completed backups of this schedule that are kept. A backup is kept
+    -- if any rule selects it; all rules 0 keeps everything.
+    keep_last    INTEGER            NOT NULL DEFAULT 0 CHECK (keep_last >= 0),
+    keep_daily   INTEGER            NOT NULL DEFAULT 0 CHECK (keep_daily >= 0),
+    keep_weekly  INTEGER            NOT NULL DEFAULT 0 CHECK (keep_weekly >= 0),
+
+    enabled      BOOLEAN            NOT NULL DEFAULT TRUE,
+    next_run_at  TIMESTAMPTZ,
+    last_run_at  TIMESTAMPTZ,
+    last_status  backup_job_status,
+    last_error   TEXT,
+
+    created_by   VARCHAR(100),
+    created_at   TIMESTAMPTZ        NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_backup_schedules_app_id ON backup_schedules (app_id);
+CREATE INDEX idx_backup_schedules_next_run_at ON backup_schedules (next_run_at) WHERE enabled;
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON backup_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Backups created by a schedule; retention only ever prunes these.
+ALTER TABLE backup_jobs
+    ADD COLUMN schedule_id UUID REFERENCES backup_schedules(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_backup_jobs_schedule_id ON backup_jobs (schedule_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX   IF EXISTS idx_backup_jobs_schedule_id;
+ALTER TABLE  backup_jobs DROP COLUMN IF EXISTS schedule_id;
+DROP TRIGGER IF EXISTS set_updated_at ON backup_schedules;
+DROP INDEX   IF EXISTS idx_backup_schedules_next_run_at;
+DROP INDEX   IF EXISTS idx_backup_schedules_app_id;
+DROP TABLE   IF EXISTS backup_schedules;
+-- +goose StatementEnd
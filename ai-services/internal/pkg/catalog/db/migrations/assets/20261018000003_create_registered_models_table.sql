@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE registered_model_status AS ENUM (
+    'registering',
+    'ready',
+    'failed'
+);
+
+-- Custom models registered in the catalog from a directory on the catalog host or
+-- from an OCI artifact. Ready models are offered, next to the models of their
+-- values.schema.json, by the providers of their component type that accept
+-- custom models.
+CREATE TABLE registered_models (
+    id               UUID                     PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Model id used in component params and as directory below the models
+    -- directory, e.g. "acme/granite-3.3-8b-support".
+    name             VARCHAR(255)             NOT NULL UNIQUE,
+    description      TEXT,
+    -- Component type serving the model: "llm", "embedding" or "reranker".
+    component_type   VARCHAR(100)             NOT NULL,
+
+    -- "local" for a directory, "oci" for an OCI artifact or modelcar image.
+    source_type      VARCHAR(20)              NOT NULL,
+    -- Directory path or image reference the model was registered from.
+    source           TEXT                     NOT NULL,
+    -- Manifest digest of an OCI source, populated once the model is ready.
+    digest           VARCHAR(100),
+
+    status           registered_model_status  NOT NULL DEFAULT 'registering',
+    size_bytes       BIGINT,
+    error            TEXT,
+
+    created_by       VARCHAR(100),
+    created_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ              NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_registered_models_component_type ON registered_models (component_type) WHERE status = 'ready';
+
+CREATE TRIGGER set_updated_at
+    BEFORE UPDATE ON registered_models
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS set_updated_at ON registered_models;
+DROP INDEX   IF EXISTS idx_registered_models_component_type;
+DROP TABLE   IF EXISTS registered_models;
+DROP TYPE    IF EXISTS registered_model_status;
+-- +goose StatementEnd
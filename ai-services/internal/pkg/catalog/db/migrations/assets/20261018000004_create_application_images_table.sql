@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Images of applications deployed with digest pinning: the image a template
+-- names and the reference, pinned by digest, the application runs instead.
+CREATE TABLE application_images (
+    app_id       UUID          NOT NULL,
+    -- Image as named by the pod template, e.g. "icr.io/ai-services/vllm:v1".
+    image        TEXT          NOT NULL,
+    -- Image pinned to the digest resolved when the application was planned,
+    -- e.g. "icr.io/ai-services/vllm@sha256:...".
+    pinned       TEXT          NOT NULL,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (app_id, image),
+    CONSTRAINT fk_application_images_app_id FOREIGN KEY (app_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS application_images;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- CPU and memory enforced on the pods of a service or component: the runtime
+-- metadata resources with the overrides given at deployment, e.g.
+-- {"cpu": 4, "memory_bytes": 8589934592}. NULL for rows deployed before the
+-- limits were enforced, whose resources are taken from the runtime metadata.
+ALTER TABLE services
+    ADD COLUMN resources JSONB;
+
+ALTER TABLE components
+    ADD COLUMN resources JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE components
+    DROP COLUMN IF EXISTS resources;
+
+ALTER TABLE services
+    DROP COLUMN IF EXISTS resources;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Application events surface background activity such as scheduled backup
+-- failures without overwriting the application status maintained by the sync service.
+CREATE TABLE application_events (
+    id          UUID               PRIMARY KEY DEFAULT gen_random_uuid(),
+    app_id      UUID               NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    -- "Normal" or "Warning".
+    type        VARCHAR(20)        NOT NULL,
+    -- Short machine-readable cause, e.g. "BackupFailed".
+    reason      VARCHAR(100)       NOT NULL,
+    message     TEXT               NOT NULL,
+    created_at  TIMESTAMPTZ        NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_application_events_app_id ON application_events (app_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_application_events_app_id;
+DROP TABLE IF EXISTS application_events;
+-- +goose StatementEnd
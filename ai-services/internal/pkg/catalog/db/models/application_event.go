@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationEventType is the severity of an application event.
+type ApplicationEventType string
+
+const (
+	ApplicationEventNormal  ApplicationEventType = "Normal"
+	ApplicationEventWarning ApplicationEventType = "Warning"
+)
+
+// ApplicationEvent represents an application_events row.
+type ApplicationEvent struct {
+	ID        uuid.UUID            `json:"id"`
+	AppID     uuid.UUID            `json:"app_id"`
+	Type      ApplicationEventType `json:"type"`
+	Reason    string               `json:"reason"`
+	Message   string               `json:"message"`
+	CreatedAt time.Time            `json:"created_at"`
+}
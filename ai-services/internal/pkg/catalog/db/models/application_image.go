@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationImage represents an application_images row: an image of an
+// application deployed with digest pinning and the digest it is pinned to.
+type ApplicationImage struct {
+	AppID     uuid.UUID `json:"app_id"`
+	Image     string    `json:"image"`  // Image as named by the pod template
+	Pinned    string    `json:"pinned"` // Image pinned by digest
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupJobType distinguishes backup jobs from restore jobs.
+type BackupJobType string
+
+const (
+	BackupJobTypeBackup  BackupJobType = "backup"
+	BackupJobTypeRestore BackupJobType = "restore"
+)
+
+// BackupJobStatus represents the lifecycle status of a backup or restore job.
+type BackupJobStatus string
+
+const (
+	BackupJobStatusPending   BackupJobStatus = "pending"
+	BackupJobStatusRunning   BackupJobStatus = "running"
+	BackupJobStatusCompleted BackupJobStatus = "completed"
+	BackupJobStatusFailed    BackupJobStatus = "failed"
+)
+
+// BackupJob represents a backup_jobs row. AppID is nil once the application has
+// been deleted; the job and its archive are kept.
+type BackupJob struct {
+	ID             uuid.UUID       `json:"id"`
+	AppID          *uuid.UUID      `json:"app_id,omitempty"`
+	AppName        string          `json:"app_name"`
+	Type           BackupJobType   `json:"type"`
+	Target         string          `json:"target"`
+	Status         BackupJobStatus `json:"status"`
+	Progress       int             `json:"progress"`
+	Message        string          `json:"message,omitempty"`
+	FileName       string          `json:"file_name,omitempty"`
+	SizeBytes      *int64          `json:"size_bytes,omitempty"`
+	SourceBackupID *uuid.UUID      `json:"source_backup_id,omitempty"`
+	ScheduleID     *uuid.UUID      `json:"schedule_id,omitempty"`
+	CreatedBy      string          `json:"created_by,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+}
+
+// BackupJobUpdate carries the fields to update on a backup_jobs row.
+// Only non-nil fields are written.
+type BackupJobUpdate struct {
+	Status      *BackupJobStatus
+	Progress    *int
+	Message     *string
+	SizeBytes   *int64
+	CompletedAt *time.Time
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupRetention selects the completed backups of a schedule that are kept.
+// A backup is kept if any rule selects it; all rules 0 keeps every backup.
+type BackupRetention struct {
+	KeepLast   int `json:"keep_last"`
+	KeepDaily  int `json:"keep_daily"`
+	KeepWeekly int `json:"keep_weekly"`
+}
+
+// BackupSchedule represents a backup_schedules row.
+type BackupSchedule struct {
+	ID         uuid.UUID        `json:"id"`
+	AppID      uuid.UUID        `json:"app_id"`
+	Target     string           `json:"target"`
+	CronExpr   string           `json:"cron_expr"`
+	Retention  BackupRetention  `json:"retention"`
+	Enabled    bool             `json:"enabled"`
+	NextRunAt  *time.Time       `json:"next_run_at,omitempty"`
+	LastRunAt  *time.Time       `json:"last_run_at,omitempty"`
+	LastStatus *BackupJobStatus `json:"last_status,omitempty"`
+	LastError  string           `json:"last_error,omitempty"`
+	CreatedBy  string           `json:"created_by,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// BackupScheduleUpdate carries the fields to update on a backup_schedules row.
+// Only non-nil fields are written; ClearNextRun sets next_run_at to NULL.
+type BackupScheduleUpdate struct {
+	Target       *string
+	CronExpr     *string
+	Retention    *BackupRetention
+	Enabled      *bool
+	NextRunAt    *time.Time
+	ClearNextRun bool
+	LastRunAt    *time.Time
+	LastStatus   *BackupJobStatus
+	LastError    *string
+}
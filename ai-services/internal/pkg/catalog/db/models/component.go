@@ -18,6 +18,7 @@ type Component struct {
 	Endpoints []map[string]any `json:"endpoints,omitempty"` // JSONB field for endpoint configurations
 	Version   string           `json:"version"`             // Component version
 	Metadata  map[string]any   `json:"metadata,omitempty"`  // JSONB field for additional metadata
+	Resources *ResourceLimits  `json:"resources,omitempty"` // CPU and memory enforced on the pods
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 }
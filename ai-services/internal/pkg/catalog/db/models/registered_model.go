@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegisteredModelSourceType is where a registered model was taken from.
+type RegisteredModelSourceType string
+
+const (
+	RegisteredModelSourceLocal RegisteredModelSourceType = "local"
+	RegisteredModelSourceOCI   RegisteredModelSourceType = "oci"
+)
+
+// RegisteredModelStatus represents the lifecycle status of a registered model.
+type RegisteredModelStatus string
+
+const (
+	RegisteredModelStatusRegistering RegisteredModelStatus = "registering"
+	RegisteredModelStatusReady       RegisteredModelStatus = "ready"
+	RegisteredModelStatusFailed      RegisteredModelStatus = "failed"
+)
+
+// RegisteredModel represents a registered_models row: a custom model stored in
+// the models directory under Name.
+type RegisteredModel struct {
+	ID            uuid.UUID                 `json:"id"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description,omitempty"`
+	ComponentType string                    `json:"component_type"`
+	SourceType    RegisteredModelSourceType `json:"source_type"`
+	Source        string                    `json:"source"`
+	Digest        string                    `json:"digest,omitempty"`
+	Status        RegisteredModelStatus     `json:"status"`
+	SizeBytes     *int64                    `json:"size_bytes,omitempty"`
+	Error         string                    `json:"error,omitempty"`
+	CreatedBy     string                    `json:"created_by,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// RegisteredModelUpdate carries the fields to update on a registered_models row.
+// Only non-nil fields are written.
+type RegisteredModelUpdate struct {
+	Status    *RegisteredModelStatus
+	Digest    *string
+	SizeBytes *int64
+	Error     *string
+}
@@ -16,6 +16,14 @@ type Service struct {
 	Endpoints []map[string]any `json:"endpoints,omitempty"`
 	Component Component        `json:"component,omitempty"`
 	Version   string           `json:"version"`
+	Resources *ResourceLimits  `json:"resources,omitempty"`
 	CreatedAt time.Time        `json:"created_at"`
 	UpdatedAt time.Time        `json:"updated_at"`
 }
+
+// ResourceLimits are the CPU and memory enforced on the pods of a service or
+// component: its runtime metadata resources with the overrides given at deployment.
+type ResourceLimits struct {
+	CPU         int   `json:"cpu"`          // Cores
+	MemoryBytes int64 `json:"memory_bytes"` // Bytes
+}
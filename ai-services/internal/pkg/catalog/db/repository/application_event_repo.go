@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ApplicationEventRepository defines the interface for application_events data operations.
+type ApplicationEventRepository interface {
+	// Insert creates a new event row and populates e.ID and e.CreatedAt.
+	Insert(ctx context.Context, e *models.ApplicationEvent) error
+
+	// ListByApp returns the most recent events of an application, newest first.
+	// A limit of 0 returns every event.
+	ListByApp(ctx context.Context, appID uuid.UUID, limit int) ([]models.ApplicationEvent, error)
+}
+
+// applicationEventRepo implements ApplicationEventRepository using pgx.
+type applicationEventRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewApplicationEventRepository creates a new ApplicationEventRepository backed by the given connection pool.
+func NewApplicationEventRepository(pool *pgxpool.Pool) ApplicationEventRepository {
+	return &applicationEventRepo{pool: pool}
+}
+
+// Insert inserts a new row and populates e.ID and e.CreatedAt.
+func (r *applicationEventRepo) Insert(ctx context.Context, e *models.ApplicationEvent) error {
+	query := `
+		INSERT INTO application_events (app_id, type, reason, message)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	if err := r.pool.QueryRow(ctx, query, e.AppID, e.Type, e.Reason, e.Message).Scan(&e.ID, &e.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert application event: %w", err)
+	}
+
+	return nil
+}
+
+// ListByApp returns the most recent events of an application, newest first.
+func (r *applicationEventRepo) ListByApp(ctx context.Context, appID uuid.UUID, limit int) ([]models.ApplicationEvent, error) {
+	query := `SELECT id, app_id, type, reason, message, created_at FROM application_events
+		WHERE app_id = $1 ORDER BY created_at DESC`
+	args := []any{appID}
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.ApplicationEvent{}
+
+	for rows.Next() {
+		var e models.ApplicationEvent
+		if err := rows.Scan(&e.ID, &e.AppID, &e.Type, &e.Reason, &e.Message, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan application event: %w", err)
+		}
+
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ApplicationImageRepository defines the interface for application_images data operations.
+type ApplicationImageRepository interface {
+	// Insert records the pinned images of an application.
+	Insert(ctx context.Context, appID uuid.UUID, pins map[string]string) error
+	// GetByAppID returns the pinned images of an application ordered by image.
+	GetByAppID(ctx context.Context, appID uuid.UUID) ([]models.ApplicationImage, error)
+	// GetAll returns the pinned images of all applications.
+	GetAll(ctx context.Context) ([]models.ApplicationImage, error)
+}
+
+// applicationImageRepo implements ApplicationImageRepository using pgx.
+type applicationImageRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewApplicationImageRepository creates a new ApplicationImageRepository instance.
+func NewApplicationImageRepository(pool *pgxpool.Pool) ApplicationImageRepository {
+	return &applicationImageRepo{pool: pool}
+}
+
+// Insert records the pinned images of an application.
+func (r *applicationImageRepo) Insert(ctx context.Context, appID uuid.UUID, pins map[string]string) error {
+	query := `
+		INSERT INTO application_images (app_id, image, pinned)
+		VALUES ($1, $2, $3)
+	`
+
+	for image, pinned := range pins {
+		if _, err := r.pool.Exec(ctx, query, appID, image, pinned); err != nil {
+			return fmt.Errorf("failed to insert application image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByAppID returns the pinned images of an application ordered by image.
+func (r *applicationImageRepo) GetByAppID(ctx context.Context, appID uuid.UUID) ([]models.ApplicationImage, error) {
+	query := `
+		SELECT app_id, image, pinned, created_at
+		FROM application_images
+		WHERE app_id = $1
+		ORDER BY image
+	`
+
+	rows, err := r.pool.Query(ctx, query, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query application images: %w", err)
+	}
+
+	return scanApplicationImages(rows)
+}
+
+// GetAll returns the pinned images of all applications.
+func (r *applicationImageRepo) GetAll(ctx context.Context) ([]models.ApplicationImage, error) {
+	query := `
+		SELECT app_id, image, pinned, created_at
+		FROM application_images
+		ORDER BY app_id, image
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query application images: %w", err)
+	}
+
+	return scanApplicationImages(rows)
+}
+
+func scanApplicationImages(rows pgx.Rows) ([]models.ApplicationImage, error) {
+	defer rows.Close()
+
+	var images []models.ApplicationImage
+	for rows.Next() {
+		var img models.ApplicationImage
+		if err := rows.Scan(&img.AppID, &img.Image, &img.Pinned, &img.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan application image: %w", err)
+		}
+		images = append(images, img)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application images: %w", err)
+	}
+
+	return images, nil
+}
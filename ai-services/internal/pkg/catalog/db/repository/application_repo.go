@@ -55,6 +55,7 @@ type scannedServiceFields struct {
 	message   sql.NullString
 	endpoint  []byte
 	version   string
+	resources []byte
 	created   sql.NullTime
 	updated   sql.NullTime
 }
@@ -126,7 +127,7 @@ func (r *applicationRepo) buildGetAllQuery(filters *ApplicationFilters) (string,
 		)
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.resources, s.created_at, s.updated_at
 		FROM paged_applications a
 		INNER JOIN services s ON a.id = s.app_id
 		ORDER BY a.created_at DESC, s.created_at ASC
@@ -152,7 +153,7 @@ func (r *applicationRepo) scanApplicationsWithServices(rows pgx.Rows) ([]models.
 			&app.ID, &app.Name, &app.CatalogID, &app.DeploymentType, &app.Status,
 			&message, &app.Version, &app.CreatedBy, &workerID, &app.CreatedAt, &app.UpdatedAt,
 			&svc.id, &svc.appID, &svc.catalogID, &svc.status, &svc.message,
-			&svc.endpoint, &svc.version, &svc.created, &svc.updated,
+			&svc.endpoint, &svc.version, &svc.resources, &svc.created, &svc.updated,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan application with services: %w", err)
@@ -253,6 +254,12 @@ func (s *scannedServiceFields) toService() (*models.Service, error) {
 		service.Endpoints = endpoints
 	}
 
+	resources, err := unmarshalResourceLimits(s.resources)
+	if err != nil {
+		return nil, err
+	}
+	service.Resources = resources
+
 	return service, nil
 }
 
@@ -268,7 +275,7 @@ func scanApplicationWithService(rows pgx.Rows, app *models.Application) (*models
 		&app.ID, &app.Name, &app.CatalogID, &app.DeploymentType, &app.Status,
 		&message, &app.Version, &app.CreatedBy, &workerID, &app.CreatedAt, &app.UpdatedAt,
 		&svc.id, &svc.appID, &svc.catalogID, &svc.status, &svc.message,
-		&svc.endpoint, &svc.version, &svc.created, &svc.updated,
+		&svc.endpoint, &svc.version, &svc.resources, &svc.created, &svc.updated,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan application with services: %w", err)
@@ -317,7 +324,7 @@ func (r *applicationRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Ap
 	query := `
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.resources, s.created_at, s.updated_at
 		FROM applications a
 		INNER JOIN services s ON a.id = s.app_id
 		WHERE a.id = $1
@@ -338,7 +345,7 @@ func (r *applicationRepo) GetByName(ctx context.Context, name string) (*models.A
 	query := `
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.resources, s.created_at, s.updated_at
 		FROM applications a
 		LEFT JOIN services s ON a.id = s.app_id
 		WHERE LOWER(a.name) = LOWER($1)
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ErrBackupJobNotFound is returned by Update when no row matches the given id.
+var ErrBackupJobNotFound = errors.New("backup job not found")
+
+// BackupJobFilters defines optional filters for listing backup jobs.
+type BackupJobFilters struct {
+	Type   models.BackupJobType // Only return jobs of this type when set.
+	Limit  int                  // Number of records to return (for pagination).
+	Offset int                  // Number of records to skip (for pagination).
+}
+
+// BackupJobRepository defines the interface for backup_jobs data operations.
+type BackupJobRepository interface {
+	// Insert creates a new job row with status 'pending'.
+	// Insert populates j.ID, j.Status, j.CreatedAt and j.UpdatedAt.
+	Insert(ctx context.Context, j *models.BackupJob) error
+
+	// GetByID retrieves a single job row by its UUID primary key.
+	// Returns (nil, nil) when not found.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.BackupJob, error)
+
+	// Update applies only the non-nil fields in upd to the row identified by id.
+	// Returns an error if no fields are set.
+	Update(ctx context.Context, id uuid.UUID, upd models.BackupJobUpdate) error
+
+	// ListByApp returns the jobs of an application ordered by created_at DESC.
+	ListByApp(ctx context.Context, appID uuid.UUID, filters *BackupJobFilters) ([]models.BackupJob, error)
+
+	// ListCompletedBySchedule returns the completed backups created by a schedule ordered by created_at DESC.
+	ListCompletedBySchedule(ctx context.Context, scheduleID uuid.UUID) ([]models.BackupJob, error)
+
+	// Delete permanently removes the row.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// FailUnfinished marks every pending or running job failed with message and
+	// returns the number of jobs updated. Used at startup for jobs interrupted by a restart.
+	FailUnfinished(ctx context.Context, message string) (int64, error)
+}
+
+// backupJobRepo implements BackupJobRepository using pgx.
+type backupJobRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackupJobRepository creates a new BackupJobRepository backed by the given connection pool.
+func NewBackupJobRepository(pool *pgxpool.Pool) BackupJobRepository {
+	return &backupJobRepo{pool: pool}
+}
+
+const backupJobSelectCols = "id, app_id, app_name, type, target, status, progress, message, file_name, " +
+	"size_bytes, source_backup_id, schedule_id, created_by, created_at, updated_at, completed_at"
+
+// scanBackupJob scans a single backup_jobs row into a BackupJob struct.
+func scanBackupJob(scan func(dest ...any) error) (*models.BackupJob, error) {
+	var (
+		j              models.BackupJob
+		appID          uuid.NullUUID
+		message        sql.NullString
+		fileName       sql.NullString
+		sizeBytes      sql.NullInt64
+		sourceBackupID uuid.NullUUID
+		scheduleID     uuid.NullUUID
+		createdBy      sql.NullString
+		completedAt    sql.NullTime
+	)
+
+	err := scan(
+		&j.ID,
+		&appID,
+		&j.AppName,
+		&j.Type,
+		&j.Target,
+		&j.Status,
+		&j.Progress,
+		&message,
+		&fileName,
+		&sizeBytes,
+		&sourceBackupID,
+		&scheduleID,
+		&createdBy,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if appID.Valid {
+		j.AppID = &appID.UUID
+	}
+	if sizeBytes.Valid {
+		j.SizeBytes = &sizeBytes.Int64
+	}
+	if sourceBackupID.Valid {
+		j.SourceBackupID = &sourceBackupID.UUID
+	}
+	if scheduleID.Valid {
+		j.ScheduleID = &scheduleID.UUID
+	}
+	if completedAt.Valid {
+		j.CompletedAt = &completedAt.Time
+	}
+	j.Message = message.String
+	j.FileName = fileName.String
+	j.CreatedBy = createdBy.String
+
+	return &j, nil
+}
+
+// Insert inserts a new row with status 'pending' and populates j.ID, j.Status, j.CreatedAt, j.UpdatedAt.
+func (r *backupJobRepo) Insert(ctx context.Context, j *models.BackupJob) error {
+	query := `
+		INSERT INTO backup_jobs (app_id, app_name, type, target, file_name, source_backup_id, schedule_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		nullUUID(j.AppID),
+		j.AppName,
+		j.Type,
+		j.Target,
+		sql.NullString{String: j.FileName, Valid: j.FileName != ""},
+		nullUUID(j.SourceBackupID),
+		nullUUID(j.ScheduleID),
+		sql.NullString{String: j.CreatedBy, Valid: j.CreatedBy != ""},
+	).Scan(&j.ID, &j.Status, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert backup job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single job row by UUID. Returns (nil, nil) when not found.
+func (r *backupJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.BackupJob, error) {
+	query := `SELECT ` + backupJobSelectCols + ` FROM backup_jobs WHERE id = $1`
+
+	j, err := scanBackupJob(r.pool.QueryRow(ctx, query, id).Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get backup job by id: %w", err)
+	}
+
+	return j, nil
+}
+
+// Update applies only the non-nil fields in upd to the row identified by id.
+// Returns an error if upd is empty (no fields set).
+func (r *backupJobRepo) Update(ctx context.Context, id uuid.UUID, upd models.BackupJobUpdate) error {
+	var setClauses []string
+	var args []any
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if upd.Status != nil {
+		set("status", *upd.Status)
+	}
+	if upd.Progress != nil {
+		set("progress", *upd.Progress)
+	}
+	if upd.Message != nil {
+		set("message", sql.NullString{String: *upd.Message, Valid: *upd.Message != ""})
+	}
+	if upd.SizeBytes != nil {
+		set("size_bytes", *upd.SizeBytes)
+	}
+	if upd.CompletedAt != nil {
+		set("completed_at", *upd.CompletedAt)
+	}
+
+	if len(setClauses) == 0 {
+		return fmt.Errorf("Update called with no fields to update")
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE backup_jobs SET %s WHERE id = $%d", strings.Join(setClauses, ", "), len(args))
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update backup job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrBackupJobNotFound, id)
+	}
+
+	return nil
+}
+
+// ListByApp returns the jobs of an application ordered by created_at DESC, applying filters.
+func (r *backupJobRepo) ListByApp(ctx context.Context, appID uuid.UUID, filters *BackupJobFilters) ([]models.BackupJob, error) {
+	query := `SELECT ` + backupJobSelectCols + ` FROM backup_jobs WHERE app_id = $1`
+	args := []any{appID}
+
+	if filters != nil && filters.Type != "" {
+		args = append(args, filters.Type)
+		query += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if filters != nil {
+		if filters.Limit > 0 {
+			args = append(args, filters.Limit)
+			query += fmt.Sprintf(" LIMIT $%d", len(args))
+		}
+		if filters.Offset > 0 {
+			args = append(args, filters.Offset)
+			query += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ListCompletedBySchedule returns the completed backups created by a schedule ordered by created_at DESC.
+func (r *backupJobRepo) ListCompletedBySchedule(ctx context.Context, scheduleID uuid.UUID) ([]models.BackupJob, error) {
+	query := `SELECT ` + backupJobSelectCols + ` FROM backup_jobs
+		WHERE schedule_id = $1 AND type = 'backup' AND status = 'completed'
+		ORDER BY created_at DESC`
+
+	return r.query(ctx, query, scheduleID)
+}
+
+// Delete permanently removes the row.
+func (r *backupJobRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM backup_jobs WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete backup job: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a SELECT of backupJobSelectCols and scans every row.
+func (r *backupJobRepo) query(ctx context.Context, query string, args ...any) ([]models.BackupJob, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.BackupJob{}
+
+	for rows.Next() {
+		j, err := scanBackupJob(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan backup job: %w", err)
+		}
+
+		jobs = append(jobs, *j)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating backup jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// FailUnfinished marks every pending or running job failed with message.
+func (r *backupJobRepo) FailUnfinished(ctx context.Context, message string) (int64, error) {
+	query := `
+		UPDATE backup_jobs SET status = 'failed', message = $1, completed_at = NOW()
+		WHERE status IN ('pending', 'running')
+	`
+
+	tag, err := r.pool.Exec(ctx, query, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished backup jobs: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// nullUUID converts an optional UUID to its nullable column value.
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ErrBackupScheduleNotFound is returned by Update when no row matches the given id.
+var ErrBackupScheduleNotFound = errors.New("backup schedule not found")
+
+// BackupScheduleRepository defines the interface for backup_schedules data operations.
+type BackupScheduleRepository interface {
+	// Insert creates a new schedule row and populates s.ID, s.CreatedAt and s.UpdatedAt.
+	Insert(ctx context.Context, s *models.BackupSchedule) error
+
+	// GetByID retrieves a single schedule row by its UUID primary key.
+	// Returns (nil, nil) when not found.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.BackupSchedule, error)
+
+	// ListByApp returns the schedules of an application ordered by created_at.
+	ListByApp(ctx context.Context, appID uuid.UUID) ([]models.BackupSchedule, error)
+
+	// ListDue returns the enabled schedules whose next_run_at is at or before now.
+	ListDue(ctx context.Context, now time.Time) ([]models.BackupSchedule, error)
+
+	// Update applies only the non-nil fields in upd to the row identified by id.
+	// Returns an error if no fields are set.
+	Update(ctx context.Context, id uuid.UUID, upd models.BackupScheduleUpdate) error
+
+	// Delete permanently removes the row.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// backupScheduleRepo implements BackupScheduleRepository using pgx.
+type backupScheduleRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackupScheduleRepository creates a new BackupScheduleRepository backed by the given connection pool.
+func NewBackupScheduleRepository(pool *pgxpool.Pool) BackupScheduleRepository {
+	return &backupScheduleRepo{pool: pool}
+}
+
+const backupScheduleSelectCols = "id, app_id, target, cron_expr, keep_last, keep_daily, keep_weekly, enabled, " +
+	"next_run_at, last_run_at, last_status, last_error, created_by, created_at, updated_at"
+
+// scanBackupSchedule scans a single backup_schedules row into a BackupSchedule struct.
+func scanBackupSchedule(scan func(dest ...any) error) (*models.BackupSchedule, error) {
+	var (
+		s          models.BackupSchedule
+		nextRunAt  sql.NullTime
+		lastRunAt  sql.NullTime
+		lastStatus sql.NullString
+		lastError  sql.NullString
+		createdBy  sql.NullString
+	)
+
+	err := scan(
+		&s.ID,
+		&s.AppID,
+		&s.Target,
+		&s.CronExpr,
+		&s.Retention.KeepLast,
+		&s.Retention.KeepDaily,
+		&s.Retention.KeepWeekly,
+		&s.Enabled,
+		&nextRunAt,
+		&lastRunAt,
+		&lastStatus,
+		&lastError,
+		&createdBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if nextRunAt.Valid {
+		s.NextRunAt = &nextRunAt.Time
+	}
+	if lastRunAt.Valid {
+		s.LastRunAt = &lastRunAt.Time
+	}
+	if lastStatus.Valid {
+		status := models.BackupJobStatus(lastStatus.String)
+		s.LastStatus = &status
+	}
+	s.LastError = lastError.String
+	s.CreatedBy = createdBy.String
+
+	return &s, nil
+}
+
+// Insert inserts a new row and populates s.ID, s.CreatedAt, s.UpdatedAt.
+func (r *backupScheduleRepo) Insert(ctx context.Context, s *models.BackupSchedule) error {
+	query := `
+		INSERT INTO backup_schedules (app_id, target, cron_expr, keep_last, keep_daily, keep_weekly, enabled, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+
+	var nextRunAt sql.NullTime
+	if s.NextRunAt != nil {
+		nextRunAt = sql.NullTime{Time: *s.NextRunAt, Valid: true}
+	}
+
+	err := r.pool.QueryRow(ctx, query,
+		s.AppID,
+		s.Target,
+		s.CronExpr,
+		s.Retention.KeepLast,
+		s.Retention.KeepDaily,
+		s.Retention.KeepWeekly,
+		s.Enabled,
+		nextRunAt,
+		sql.NullString{String: s.CreatedBy, Valid: s.CreatedBy != ""},
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert backup schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single schedule row by UUID. Returns (nil, nil) when not found.
+func (r *backupScheduleRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.BackupSchedule, error) {
+	query := `SELECT ` + backupScheduleSelectCols + ` FROM backup_schedules WHERE id = $1`
+
+	s, err := scanBackupSchedule(r.pool.QueryRow(ctx, query, id).Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get backup schedule by id: %w", err)
+	}
+
+	return s, nil
+}
+
+// ListByApp returns the schedules of an application ordered by created_at.
+func (r *backupScheduleRepo) ListByApp(ctx context.Context, appID uuid.UUID) ([]models.BackupSchedule, error) {
+	query := `SELECT ` + backupScheduleSelectCols + ` FROM backup_schedules WHERE app_id = $1 ORDER BY created_at`
+
+	return r.query(ctx, query, appID)
+}
+
+// ListDue returns the enabled schedules whose next_run_at is at or before now.
+func (r *backupScheduleRepo) ListDue(ctx context.Context, now time.Time) ([]models.BackupSchedule, error) {
+	query := `SELECT ` + backupScheduleSelectCols + ` FROM backup_schedules
+		WHERE enabled AND next_run_at <= $1
+		ORDER BY next_run_at`
+
+	return r.query(ctx, query, now)
+}
+
+// Update applies only the non-nil fields in upd to the row identified by id.
+// Returns an error if upd is empty (no fields set).
+func (r *backupScheduleRepo) Update(ctx context.Context, id uuid.UUID, upd models.BackupScheduleUpdate) error {
+	var setClauses []string
+	var args []any
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if upd.Target != nil {
+		set("target", *upd.Target)
+	}
+	if upd.CronExpr != nil {
+		set("cron_expr", *upd.CronExpr)
+	}
+	if upd.Retention != nil {
+		set("keep_last", upd.Retention.KeepLast)
+		set("keep_daily", upd.Retention.KeepDaily)
+		set("keep_weekly", upd.Retention.KeepWeekly)
+	}
+	if upd.Enabled != nil {
+		set("enabled", *upd.Enabled)
+	}
+	if upd.NextRunAt != nil {
+		set("next_run_at", *upd.NextRunAt)
+	}
+	if upd.ClearNextRun {
+		setClauses = append(setClauses, "next_run_at = NULL")
+	}
+	if upd.LastRunAt != nil {
+		set("last_run_at", *upd.LastRunAt)
+	}
+	if upd.LastStatus != nil {
+		set("last_status", *upd.LastStatus)
+	}
+	if upd.LastError != nil {
+		set("last_error", sql.NullString{String: *upd.LastError, Valid: *upd.LastError != ""})
+	}
+
+	if len(setClauses) == 0 {
+		return fmt.Errorf("Update called with no fields to update")
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE backup_schedules SET %s WHERE id = $%d", strings.Join(setClauses, ", "), len(args))
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update backup schedule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrBackupScheduleNotFound, id)
+	}
+
+	return nil
+}
+
+// Delete permanently removes the row.
+func (r *backupScheduleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM backup_schedules WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete backup schedule: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a SELECT of backupScheduleSelectCols and scans every row.
+func (r *backupScheduleRepo) query(ctx context.Context, query string, args ...any) ([]models.BackupSchedule, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backup schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []models.BackupSchedule{}
+
+	for rows.Next() {
+		s, err := scanBackupSchedule(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan backup schedule: %w", err)
+		}
+
+		schedules = append(schedules, *s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating backup schedules: %w", err)
+	}
+
+	return schedules, nil
+}
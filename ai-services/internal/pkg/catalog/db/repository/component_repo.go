@@ -47,8 +47,8 @@ func NewComponentRepository(pool *pgxpool.Pool) ComponentRepository {
 // Insert creates a new component in the database.
 func (r *componentRepo) Insert(ctx context.Context, component *models.Component) error {
 	query := `
-		INSERT INTO components (id, type, provider, status, message, endpoints, version, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO components (id, type, provider, status, message, endpoints, version, metadata, resources)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
 	`
 
@@ -76,6 +76,11 @@ func (r *componentRepo) Insert(ctx context.Context, component *models.Component)
 		}
 	}
 
+	resourcesJSON, err := marshalResourceLimits(component.Resources)
+	if err != nil {
+		return err
+	}
+
 	err = r.pool.QueryRow(
 		ctx,
 		query,
@@ -87,6 +92,7 @@ func (r *componentRepo) Insert(ctx context.Context, component *models.Component)
 		endpointsJSON,
 		sql.NullString{String: component.Version, Valid: component.Version != ""},
 		metadataJSON,
+		resourcesJSON,
 	).Scan(&component.CreatedAt, &component.UpdatedAt)
 
 	if err != nil {
@@ -99,7 +105,7 @@ func (r *componentRepo) Insert(ctx context.Context, component *models.Component)
 // GetByID retrieves a component by ID.
 func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, resources, created_at, updated_at
 		FROM components
 		WHERE id = $1
 	`
@@ -108,6 +114,7 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		component     models.Component
 		endpointsJSON []byte
 		metadataJSON  []byte
+		resourcesJSON []byte
 		version       sql.NullString
 		message       sql.NullString
 	)
@@ -121,6 +128,7 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		&endpointsJSON,
 		&version,
 		&metadataJSON,
+		&resourcesJSON,
 		&component.CreatedAt,
 		&component.UpdatedAt,
 	)
@@ -157,6 +165,10 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		component.Metadata = metadata
 	}
 
+	if component.Resources, err = unmarshalResourceLimits(resourcesJSON); err != nil {
+		return nil, err
+	}
+
 	return &component, nil
 }
 
@@ -166,12 +178,13 @@ func scanComponent(rows pgx.Rows) (*models.Component, error) {
 		component     models.Component
 		endpointsJSON []byte
 		metadataJSON  []byte
+		resourcesJSON []byte
 		version       sql.NullString
 		message       sql.NullString
 	)
 
 	err := rows.Scan(&component.ID, &component.Type, &component.Provider, &component.Status, &message,
-		&endpointsJSON, &version, &metadataJSON, &component.CreatedAt, &component.UpdatedAt)
+		&endpointsJSON, &version, &metadataJSON, &resourcesJSON, &component.CreatedAt, &component.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan component: %w", err)
 	}
@@ -200,13 +213,17 @@ func scanComponent(rows pgx.Rows) (*models.Component, error) {
 		component.Metadata = metadata
 	}
 
+	if component.Resources, err = unmarshalResourceLimits(resourcesJSON); err != nil {
+		return nil, err
+	}
+
 	return &component, nil
 }
 
 // GetAll retrieves all components from the database.
 func (r *componentRepo) GetAll(ctx context.Context) ([]models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, resources, created_at, updated_at
 		FROM components
 		ORDER BY created_at DESC
 	`
@@ -236,7 +253,7 @@ func (r *componentRepo) GetAll(ctx context.Context) ([]models.Component, error)
 // GetByType retrieves all components of a specific type.
 func (r *componentRepo) GetByType(ctx context.Context, componentType string) ([]models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, resources, created_at, updated_at
 		FROM components
 		WHERE type = $1
 		ORDER BY created_at DESC
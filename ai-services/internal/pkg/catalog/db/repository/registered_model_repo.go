@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+var (
+	// ErrRegisteredModelNotFound is returned by Update when no row matches the given id.
+	ErrRegisteredModelNotFound = errors.New("registered model not found")
+	// ErrRegisteredModelExists is returned by Insert when a model of the same name is registered.
+	ErrRegisteredModelExists = errors.New("registered model already exists")
+)
+
+// uniqueViolation is the PostgreSQL error code of a unique constraint violation.
+const uniqueViolation = "23505"
+
+// RegisteredModelFilters defines optional filters for listing registered models.
+type RegisteredModelFilters struct {
+	ComponentType string                       // Only return models of this component type when set.
+	Status        models.RegisteredModelStatus // Only return models in this status when set.
+}
+
+// RegisteredModelRepository defines the interface for registered_models data operations.
+type RegisteredModelRepository interface {
+	// Insert creates a new row with status 'registering' and populates m.ID,
+	// m.Status, m.CreatedAt and m.UpdatedAt. Returns ErrRegisteredModelExists
+	// when the name is taken.
+	Insert(ctx context.Context, m *models.RegisteredModel) error
+
+	// GetByName retrieves a single row by model name.
+	// Returns (nil, nil) when not found.
+	GetByName(ctx context.Context, name string) (*models.RegisteredModel, error)
+
+	// GetAll returns the rows ordered by name, applying filters.
+	GetAll(ctx context.Context, filters *RegisteredModelFilters) ([]models.RegisteredModel, error)
+
+	// Update applies only the non-nil fields in upd to the row identified by id.
+	// Returns an error if no fields are set.
+	Update(ctx context.Context, id uuid.UUID, upd models.RegisteredModelUpdate) error
+
+	// Delete permanently removes the row.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// FailUnfinished marks every registering model failed with message and
+	// returns the number of models updated. Used at startup for registrations
+	// interrupted by a restart.
+	FailUnfinished(ctx context.Context, message string) (int64, error)
+}
+
+// registeredModelRepo implements RegisteredModelRepository using pgx.
+type registeredModelRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewRegisteredModelRepository creates a new RegisteredModelRepository backed by the given connection pool.
+func NewRegisteredModelRepository(pool *pgxpool.Pool) RegisteredModelRepository {
+	return &registeredModelRepo{pool: pool}
+}
+
+const registeredModelSelectCols = "id, name, description, component_type, source_type, source, digest, " +
+	"status, size_bytes, error, created_by, created_at, updated_at"
+
+// scanRegisteredModel scans a single registered_models row into a RegisteredModel struct.
+func scanRegisteredModel(scan func(dest ...any) error) (*models.RegisteredModel, error) {
+	var (
+		m           models.RegisteredModel
+		description sql.NullString
+		digest      sql.NullString
+		sizeBytes   sql.NullInt64
+		errCol      sql.NullString
+		createdBy   sql.NullString
+	)
+
+	err := scan(
+		&m.ID,
+		&m.Name,
+		&description,
+		&m.ComponentType,
+		&m.SourceType,
+		&m.Source,
+		&digest,
+		&m.Status,
+		&sizeBytes,
+		&errCol,
+		&createdBy,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Description = description.String
+	m.Digest = digest.String
+	if sizeBytes.Valid {
+		m.SizeBytes = &sizeBytes.Int64
+	}
+	m.Error = errCol.String
+	m.CreatedBy = createdBy.String
+
+	return &m, nil
+}
+
+// Insert inserts a new row with status 'registering' and populates m.ID, m.Status, m.CreatedAt, m.UpdatedAt.
+func (r *registeredModelRepo) Insert(ctx context.Context, m *models.RegisteredModel) error {
+	query := `
+		INSERT INTO registered_models (name, description, component_type, source_type, source, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		m.Name,
+		sql.NullString{String: m.Description, Valid: m.Description != ""},
+		m.ComponentType,
+		m.SourceType,
+		m.Source,
+		sql.NullString{String: m.CreatedBy, Valid: m.CreatedBy != ""},
+	).Scan(&m.ID, &m.Status, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%w: %s", ErrRegisteredModelExists, m.Name)
+		}
+
+		return fmt.Errorf("failed to insert registered model: %w", err)
+	}
+
+	return nil
+}
+
+// GetByName retrieves a single row by model name. Returns (nil, nil) when not found.
+func (r *registeredModelRepo) GetByName(ctx context.Context, name string) (*models.RegisteredModel, error) {
+	query := `SELECT ` + registeredModelSelectCols + ` FROM registered_models WHERE name = $1`
+
+	m, err := scanRegisteredModel(r.pool.QueryRow(ctx, query, name).Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get registered model by name: %w", err)
+	}
+
+	return m, nil
+}
+
+// GetAll returns the rows ordered by name, applying filters.
+func (r *registeredModelRepo) GetAll(ctx context.Context, filters *RegisteredModelFilters) ([]models.RegisteredModel, error) {
+	var conditions []string
+	var args []any
+
+	if filters != nil {
+		if filters.ComponentType != "" {
+			args = append(args, filters.ComponentType)
+			conditions = append(conditions, fmt.Sprintf("component_type = $%d", len(args)))
+		}
+		if filters.Status != "" {
+			args = append(args, filters.Status)
+			conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+		}
+	}
+
+	query := `SELECT ` + registeredModelSelectCols + ` FROM registered_models`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registered models: %w", err)
+	}
+	defer rows.Close()
+
+	registered := []models.RegisteredModel{}
+
+	for rows.Next() {
+		m, err := scanRegisteredModel(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan registered model: %w", err)
+		}
+
+		registered = append(registered, *m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating registered models: %w", err)
+	}
+
+	return registered, nil
+}
+
+// Update applies only the non-nil fields in upd to the row identified by id.
+// Returns an error if upd is empty (no fields set).
+func (r *registeredModelRepo) Update(ctx context.Context, id uuid.UUID, upd models.RegisteredModelUpdate) error {
+	var setClauses []string
+	var args []any
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if upd.Status != nil {
+		set("status", *upd.Status)
+	}
+	if upd.Digest != nil {
+		set("digest", sql.NullString{String: *upd.Digest, Valid: *upd.Digest != ""})
+	}
+	if upd.SizeBytes != nil {
+		set("size_bytes", *upd.SizeBytes)
+	}
+	if upd.Error != nil {
+		set("error", sql.NullString{String: *upd.Error, Valid: *upd.Error != ""})
+	}
+
+	if len(setClauses) == 0 {
+		return fmt.Errorf("Update called with no fields to update")
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE registered_models SET %s WHERE id = $%d", strings.Join(setClauses, ", "), len(args))
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update registered model: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrRegisteredModelNotFound, id)
+	}
+
+	return nil
+}
+
+// Delete permanently removes the row.
+func (r *registeredModelRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM registered_models WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete registered model: %w", err)
+	}
+
+	return nil
+}
+
+// FailUnfinished marks every registering model failed with message.
+func (r *registeredModelRepo) FailUnfinished(ctx context.Context, message string) (int64, error) {
+	query := `UPDATE registered_models SET status = 'failed', error = $1 WHERE status = 'registering'`
+
+	tag, err := r.pool.Exec(ctx, query, message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished model registrations: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -43,8 +43,8 @@ func NewServiceRepository(pool *pgxpool.Pool) ServiceRepository {
 // Insert creates a new service in the database.
 func (r *serviceRepo) Insert(ctx context.Context, service *models.Service) error {
 	query := `
-		INSERT INTO services (id, app_id, catalog_id, status, message, endpoints, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO services (id, app_id, catalog_id, status, message, endpoints, version, resources)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING created_at, updated_at
 	`
 
@@ -63,6 +63,11 @@ func (r *serviceRepo) Insert(ctx context.Context, service *models.Service) error
 		}
 	}
 
+	resourcesJSON, err := marshalResourceLimits(service.Resources)
+	if err != nil {
+		return err
+	}
+
 	err = r.pool.QueryRow(
 		ctx,
 		query,
@@ -73,6 +78,7 @@ func (r *serviceRepo) Insert(ctx context.Context, service *models.Service) error
 		sql.NullString{String: service.Message, Valid: service.Message != ""},
 		endpointsJSON,
 		sql.NullString{String: service.Version, Valid: service.Version != ""},
+		resourcesJSON,
 	).Scan(&service.CreatedAt, &service.UpdatedAt)
 
 	if err != nil {
@@ -99,6 +105,7 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 	var (
 		service        models.Service
 		endpointsJSON  []byte
+		resourcesJSON  []byte
 		serviceVersion sql.NullString
 		message        sql.NullString
 	)
@@ -111,6 +118,7 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 		&message,
 		&endpointsJSON,
 		&serviceVersion,
+		&resourcesJSON,
 		&service.CreatedAt,
 		&service.UpdatedAt,
 	)
@@ -134,13 +142,46 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 		service.Endpoints = endpoints
 	}
 
+	service.Resources, err = unmarshalResourceLimits(resourcesJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	return &service, nil
 }
 
+// marshalResourceLimits marshals resource limits to JSONB; nil limits are stored as NULL.
+func marshalResourceLimits(limits *models.ResourceLimits) ([]byte, error) {
+	if limits == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resources: %w", err)
+	}
+
+	return data, nil
+}
+
+// unmarshalResourceLimits unmarshals resource limits stored as JSONB; NULL yields nil.
+func unmarshalResourceLimits(data []byte) (*models.ResourceLimits, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var limits models.ResourceLimits
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resources: %w", err)
+	}
+
+	return &limits, nil
+}
+
 // GetByAppID retrieves all services for a specific application.
 func (r *serviceRepo) GetByAppID(ctx context.Context, appID uuid.UUID) ([]models.Service, error) {
 	query := `
-		SELECT id, app_id, catalog_id, status, message, endpoints, version, created_at, updated_at
+		SELECT id, app_id, catalog_id, status, message, endpoints, version, resources, created_at, updated_at
 		FROM services
 		WHERE app_id = $1
 		ORDER BY created_at
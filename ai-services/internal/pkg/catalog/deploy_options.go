@@ -288,7 +288,7 @@ func hasNonEmptyProperties(schema map[string]any) bool {
 // If the schema file is not present, returns an empty schema instead of failing.
 func (p *CatalogProvider) GetComponentProviderParams(ctx context.Context, componentType, providerID string) (map[string]any, error) {
 	// Verify component exists and get its path
-	_, err := p.LoadComponent(componentType, providerID)
+	component, err := p.LoadComponent(componentType, providerID)
 	if err != nil {
 		return nil, fmt.Errorf("component provider not found: %w", err)
 	}
@@ -317,6 +317,10 @@ func (p *CatalogProvider) GetComponentProviderParams(ctx context.Context, compon
 		return nil, fmt.Errorf("failed to parse schema: %w", err)
 	}
 
+	if component.CustomModels {
+		p.addRegisteredModels(ctx, componentType, schema)
+	}
+
 	return schema, nil
 }
 
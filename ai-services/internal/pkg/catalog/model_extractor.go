@@ -10,6 +10,17 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 )
 
+// RegisteredModelKey marks the oneOf entries of model params that offer a model
+// registered in the catalog. Registered models are placed in the models directory
+// when they are registered, so they are never downloaded from the hub.
+const RegisteredModelKey = "x-registered-model"
+
+// ModelRegistry lists the custom models registered in the catalog.
+type ModelRegistry interface {
+	// RegisteredModels returns the models ready to be served by componentType.
+	RegisteredModels(ctx context.Context, componentType string) ([]types.RegisteredModel, error)
+}
+
 // GetCatalogModels collects all unique models from component schemas in a service or architecture template.
 // This is the main entry point for catalog-based model collection from CLI or API.
 // excludeComponentProviders is a variadic parameter that allows excluding specific components provider by ID.
@@ -155,11 +166,15 @@ func extractModelsFromSchema(schema map[string]any, modelSet map[string]bool) {
 }
 
 // extractModelsFromProperty extracts model values from a property's oneOf array.
+// Registered models are skipped; they are not downloaded.
 func extractModelsFromProperty(propMap map[string]any, modelSet map[string]bool) {
 	// Check for oneOf array with const values
 	if oneOf, ok := propMap["oneOf"].([]any); ok {
 		for _, option := range oneOf {
 			if optMap, ok := option.(map[string]any); ok {
+				if registered, _ := optMap[RegisteredModelKey].(bool); registered {
+					continue
+				}
 				if constVal, ok := optMap["const"].(string); ok && constVal != "" {
 					modelSet[constVal] = true
 				}
@@ -167,3 +182,62 @@ func extractModelsFromProperty(propMap map[string]any, modelSet map[string]bool)
 		}
 	}
 }
+
+// addRegisteredModels offers the models registered for componentType as options
+// of the model params of schema, after the models of the catalog.
+func (p *CatalogProvider) addRegisteredModels(ctx context.Context, componentType string, schema map[string]any) {
+	if p.models == nil {
+		return
+	}
+
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	registered, err := p.models.RegisteredModels(ctx, componentType)
+	if err != nil {
+		logger.WarningfCtx(ctx, "failed to list registered %s models: %v", componentType, err)
+
+		return
+	}
+	if len(registered) == 0 {
+		return
+	}
+
+	for key, value := range properties {
+		propMap, ok := value.(map[string]any)
+		if !ok || !strings.Contains(strings.ToLower(key), "model") {
+			continue
+		}
+		oneOf, ok := propMap["oneOf"].([]any)
+		if !ok {
+			continue
+		}
+
+		existing := make(map[string]bool)
+		extractModelsFromProperty(propMap, existing)
+		for _, m := range registered {
+			if existing[m.Name] {
+				continue
+			}
+			option := map[string]any{"const": m.Name, "title": m.Name, RegisteredModelKey: true}
+			if m.Description != "" {
+				option["description"] = m.Description
+			}
+			oneOf = append(oneOf, option)
+		}
+		propMap["oneOf"] = oneOf
+	}
+}
+
+// GetComponentModels returns the models the catalog offers for a component
+// provider, without the models registered in the catalog.
+func (p *CatalogProvider) GetComponentModels(ctx context.Context, componentType, providerID string) ([]string, error) {
+	models := make(map[string]bool)
+	if err := p.addComponentModels(ctx, componentType, providerID, models); err != nil {
+		return nil, err
+	}
+
+	return utils.ExtractMapKeys(models), nil
+}
@@ -0,0 +1,191 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "github.com/containers/podman/v5/pkg/k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	podmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+const milliPerCore = 1000
+
+// Allocation spreads the resources of a component or service over the
+// containers of its pods.
+type Allocation struct {
+	limits models.ResourceLimits
+	// cpuRequest and memoryRequest are reserved for each container whose
+	// template declares no request.
+	cpuRequest    *resource.Quantity
+	memoryRequest *resource.Quantity
+}
+
+// Allocate spreads limits over the containers of pods, all the pods of a
+// component or service. It returns nil when limits is nil.
+func Allocate(limits *models.ResourceLimits, pods []*podmodels.PodSpec) *Allocation {
+	if limits == nil {
+		return nil
+	}
+
+	cpuBudget := int64(limits.CPU) * milliPerCore
+	var cpuDeclared, memoryDeclared, cpuOpen, memoryOpen int64
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if q, ok := c.Resources.Requests[v1.ResourceCPU]; ok {
+				cpuDeclared += min(q.MilliValue(), cpuBudget)
+			} else {
+				cpuOpen++
+			}
+			if q, ok := c.Resources.Requests[v1.ResourceMemory]; ok {
+				memoryDeclared += min(q.Value(), limits.MemoryBytes)
+			} else {
+				memoryOpen++
+			}
+		}
+	}
+
+	a := &Allocation{limits: *limits}
+	if cpuOpen > 0 {
+		a.cpuRequest = resource.NewMilliQuantity(max(cpuBudget-cpuDeclared, 0)/cpuOpen, resource.DecimalSI)
+	}
+	if memoryOpen > 0 {
+		a.memoryRequest = resource.NewQuantity(max(limits.MemoryBytes-memoryDeclared, 0)/memoryOpen, resource.BinarySI)
+	}
+
+	return a
+}
+
+// Apply sets the limits and requests of the containers of a rendered pod
+// manifest holding a single document, as the pod templates do. Manifests of
+// other kinds are returned unchanged, as is every manifest for a nil Allocation.
+func (a *Allocation) Apply(manifest []byte) ([]byte, error) {
+	if a == nil {
+		return manifest, nil
+	}
+
+	var pod map[string]any
+	if err := k8syaml.Unmarshal(manifest, &pod); err != nil {
+		return nil, fmt.Errorf("failed to parse pod manifest: %w", err)
+	}
+	if pod["kind"] != "Pod" {
+		return manifest, nil
+	}
+
+	spec, _ := pod["spec"].(map[string]any)
+	for _, key := range []string{"initContainers", "containers"} {
+		containers, _ := spec[key].([]any)
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			// Init containers run before the others and hold no reservation.
+			if err := a.applyContainer(container, key == "containers"); err != nil {
+				return nil, fmt.Errorf("container %v: %w", container["name"], err)
+			}
+		}
+	}
+
+	out, err := k8syaml.Marshal(pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write pod manifest: %w", err)
+	}
+
+	return out, nil
+}
+
+func (a *Allocation) applyContainer(container map[string]any, reserve bool) error {
+	res := childMap(container, "resources")
+	limits := childMap(res, "limits")
+	requests := childMap(res, "requests")
+
+	if a.limits.CPU > 0 {
+		budget := resource.NewMilliQuantity(int64(a.limits.CPU)*milliPerCore, resource.DecimalSI)
+		if err := enforce(limits, requests, cpuKey, budget, a.cpuRequest, reserve); err != nil {
+			return err
+		}
+	}
+	if a.limits.MemoryBytes > 0 {
+		budget := resource.NewQuantity(a.limits.MemoryBytes, resource.BinarySI)
+		if err := enforce(limits, requests, memoryKey, budget, a.memoryRequest, reserve); err != nil {
+			return err
+		}
+	}
+
+	for key, m := range map[string]map[string]any{"limits": limits, "requests": requests} {
+		if len(m) == 0 {
+			delete(res, key)
+		}
+	}
+	if len(res) == 0 {
+		delete(container, "resources")
+	}
+
+	return nil
+}
+
+// enforce caps the limit of resource name at budget and the request at the
+// limit. A missing request is set to share when reserve is set.
+func enforce(limits, requests map[string]any, name string, budget, share *resource.Quantity, reserve bool) error {
+	limit := budget
+	declared, ok, err := quantity(limits, name)
+	if err != nil {
+		return err
+	}
+	if ok && declared.Cmp(*budget) < 0 {
+		limit = declared
+	}
+	limits[name] = limit.String()
+
+	request, ok, err := quantity(requests, name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ok && request.Cmp(*limit) > 0:
+		requests[name] = limit.String()
+	case !ok && reserve && share != nil && share.Sign() > 0:
+		if share.Cmp(*limit) > 0 {
+			share = limit
+		}
+		requests[name] = share.String()
+	}
+
+	return nil
+}
+
+// quantity returns the quantity of resource name in m.
+func quantity(m map[string]any, name string) (*resource.Quantity, bool, error) {
+	value, ok := m[name]
+	if !ok {
+		return nil, false, nil
+	}
+
+	s := fmt.Sprint(value)
+	if f, isFloat := value.(float64); isFloat {
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid %s quantity %v: %w", name, value, err)
+	}
+
+	return &q, true, nil
+}
+
+// childMap returns the map under key in m, adding an empty one when missing.
+func childMap(m map[string]any, key string) map[string]any {
+	child, ok := m[key].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		m[key] = child
+	}
+
+	return child
+}
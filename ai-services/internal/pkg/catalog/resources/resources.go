@@ -0,0 +1,165 @@
+// Package resources enforces the CPU and memory declared in the runtime metadata
+// of components and services on the pods podman deploys for them.
+//
+// The resources of a component or service cover all of its pods. Every container
+// is limited to them, so a runaway container cannot take the CPU and memory of
+// the other applications on the host. What the requests declared by the pod
+// templates leave of them is reserved evenly for the containers declaring none.
+// Limits and requests declared by the templates are kept as long as they fit.
+//
+// A deployment overrides the resources of a component or service with the
+// reserved 'resources' param, e.g. {"resources": {"cpu": 4, "memory": "8Gi"}}.
+package resources
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+)
+
+const (
+	// ParamKey is the param of a component or service overriding its resources.
+	ParamKey = "resources"
+
+	cpuKey    = "cpu"
+	memoryKey = "memory"
+)
+
+// FromMetadata returns the CPU and memory of runtime metadata; nil when it
+// declares neither.
+func FromMetadata(metadata *clitemplates.AppMetadata) *models.ResourceLimits {
+	if metadata == nil || metadata.Resources == nil {
+		return nil
+	}
+
+	return nonZero(models.ResourceLimits{
+		CPU:         metadata.Resources.CPU,
+		MemoryBytes: int64(metadata.Resources.Memory),
+	})
+}
+
+// Effective returns the resources enforced on a component or service: those of
+// its runtime metadata with the override in params applied. It returns nil when
+// neither declares any.
+func Effective(metadata *clitemplates.AppMetadata, params map[string]any) (*models.ResourceLimits, error) {
+	var limits models.ResourceLimits
+	if fromMetadata := FromMetadata(metadata); fromMetadata != nil {
+		limits = *fromMetadata
+	}
+
+	raw, ok := params[ParamKey]
+	if !ok {
+		return nonZero(limits), nil
+	}
+
+	override, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("param '%s' must be an object with '%s' and '%s'", ParamKey, cpuKey, memoryKey)
+	}
+
+	for key, value := range override {
+		switch key {
+		case cpuKey:
+			cpu, err := parseCPU(value)
+			if err != nil {
+				return nil, err
+			}
+			limits.CPU = cpu
+		case memoryKey:
+			memory, err := parseMemory(value)
+			if err != nil {
+				return nil, err
+			}
+			limits.MemoryBytes = memory
+		default:
+			return nil, fmt.Errorf("unknown key '%s.%s', expected '%s' or '%s'", ParamKey, key, cpuKey, memoryKey)
+		}
+	}
+
+	return nonZero(limits), nil
+}
+
+// Validate checks the override in params.
+func Validate(params map[string]any) error {
+	_, err := Effective(nil, params)
+
+	return err
+}
+
+// Strip returns params without the override, which is not a template value.
+func Strip(params map[string]any) map[string]any {
+	if _, ok := params[ParamKey]; !ok {
+		return params
+	}
+
+	stripped := maps.Clone(params)
+	delete(stripped, ParamKey)
+
+	return stripped
+}
+
+// parseCPU parses a number of cores.
+func parseCPU(value any) (int, error) {
+	var cores float64
+	switch v := value.(type) {
+	case float64:
+		cores = v
+	case int:
+		cores = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid '%s.%s' %q: must be a number of cores", ParamKey, cpuKey, v)
+		}
+		cores = parsed
+	default:
+		return 0, fmt.Errorf("invalid '%s.%s' %v: must be a number of cores", ParamKey, cpuKey, value)
+	}
+
+	if cores <= 0 || cores != float64(int(cores)) {
+		return 0, fmt.Errorf("invalid '%s.%s' %v: must be a positive whole number of cores", ParamKey, cpuKey, value)
+	}
+
+	return int(cores), nil
+}
+
+// parseMemory parses bytes, given as a number or a quantity such as "8Gi".
+func parseMemory(value any) (int64, error) {
+	var bytes int64
+	switch v := value.(type) {
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, fmt.Errorf("invalid '%s.%s' %v: must be a whole number of bytes", ParamKey, memoryKey, v)
+		}
+		bytes = int64(v)
+	case int:
+		bytes = int64(v)
+	case string:
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid '%s.%s' %q: must be bytes or a quantity such as 8Gi", ParamKey, memoryKey, v)
+		}
+		bytes = q.Value()
+	default:
+		return 0, fmt.Errorf("invalid '%s.%s' %v: must be bytes or a quantity such as 8Gi", ParamKey, memoryKey, value)
+	}
+
+	if bytes <= 0 {
+		return 0, fmt.Errorf("invalid '%s.%s' %v: must be positive", ParamKey, memoryKey, value)
+	}
+
+	return bytes, nil
+}
+
+func nonZero(limits models.ResourceLimits) *models.ResourceLimits {
+	if limits.CPU == 0 && limits.MemoryBytes == 0 {
+		return nil
+	}
+
+	return &limits
+}
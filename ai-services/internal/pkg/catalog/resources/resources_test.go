@@ -0,0 +1,133 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8syaml "sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	podmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+)
+
+const gib = int64(1 << 30)
+
+func TestEffective(t *testing.T) {
+	metadata := &clitemplates.AppMetadata{Resources: &clitemplates.RuntimeResources{CPU: 11, Memory: int(50 * gib)}}
+
+	limits, err := Effective(metadata, map[string]any{"model": "granite"})
+	require.NoError(t, err)
+	assert.Equal(t, &models.ResourceLimits{CPU: 11, MemoryBytes: 50 * gib}, limits)
+
+	limits, err = Effective(metadata, map[string]any{ParamKey: map[string]any{"cpu": float64(4)}})
+	require.NoError(t, err)
+	assert.Equal(t, &models.ResourceLimits{CPU: 4, MemoryBytes: 50 * gib}, limits, "memory keeps the metadata value")
+
+	limits, err = Effective(nil, map[string]any{ParamKey: map[string]any{"memory": "8Gi"}})
+	require.NoError(t, err)
+	assert.Equal(t, &models.ResourceLimits{MemoryBytes: 8 * gib}, limits)
+
+	limits, err = Effective(&clitemplates.AppMetadata{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, limits)
+}
+
+func TestValidate(t *testing.T) {
+	for name, override := range map[string]any{
+		"not an object":        "4",
+		"fractional cpu":       map[string]any{"cpu": 0.5},
+		"negative cpu":         map[string]any{"cpu": float64(-1)},
+		"invalid memory":       map[string]any{"memory": "lots"},
+		"zero memory":          map[string]any{"memory": float64(0)},
+		"unknown key":          map[string]any{"gpu": float64(1)},
+		"memory of wrong type": map[string]any{"memory": true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Validate(map[string]any{ParamKey: override}))
+		})
+	}
+
+	assert.NoError(t, Validate(map[string]any{ParamKey: map[string]any{"cpu": "2", "memory": float64(1 << 30)}}))
+}
+
+func TestStrip(t *testing.T) {
+	params := map[string]any{"model": "granite", ParamKey: map[string]any{"cpu": 2}}
+
+	assert.Equal(t, map[string]any{"model": "granite"}, Strip(params))
+	assert.Contains(t, params, ParamKey, "params are not modified")
+}
+
+const digitizePod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: digitize
+spec:
+  initContainers:
+    - name: init
+      image: tool
+  containers:
+    - name: ui
+      image: ui
+      resources:
+        requests:
+          memory: 512Mi
+        limits:
+          memory: 512Mi
+    - name: backend
+      image: backend
+      resources:
+        limits:
+          memory: 64Gi
+    - name: worker
+      image: worker
+`
+
+func TestAllocationApply(t *testing.T) {
+	var pod podmodels.PodSpec
+	require.NoError(t, k8syaml.Unmarshal([]byte(digitizePod), &pod))
+
+	alloc := Allocate(&models.ResourceLimits{CPU: 6, MemoryBytes: 8*gib + 512<<20}, []*podmodels.PodSpec{&pod})
+	out, err := alloc.Apply([]byte(digitizePod))
+	require.NoError(t, err)
+
+	var applied podmodels.PodSpec
+	require.NoError(t, k8syaml.Unmarshal(out, &applied))
+	assert.Equal(t, "digitize", applied.Name)
+
+	resourcesOf := func(i int) (string, string, string, string) {
+		r := applied.Spec.Containers[i].Resources
+
+		return r.Limits.Cpu().String(), r.Limits.Memory().String(), r.Requests.Cpu().String(), r.Requests.Memory().String()
+	}
+
+	// Declared limits below the budget are kept; the requests they leave are
+	// split between the containers declaring none.
+	cpuLimit, memLimit, cpuReq, memReq := resourcesOf(0)
+	assert.Equal(t, []string{"6", "512Mi", "2", "512Mi"}, []string{cpuLimit, memLimit, cpuReq, memReq})
+
+	cpuLimit, memLimit, cpuReq, memReq = resourcesOf(1)
+	assert.Equal(t, []string{"6", "8704Mi", "2", "4Gi"}, []string{cpuLimit, memLimit, cpuReq, memReq}, "the declared limit is capped at the budget")
+
+	cpuLimit, memLimit, cpuReq, memReq = resourcesOf(2)
+	assert.Equal(t, []string{"6", "8704Mi", "2", "4Gi"}, []string{cpuLimit, memLimit, cpuReq, memReq})
+
+	init := applied.Spec.InitContainers[0].Resources
+	assert.Equal(t, "6", init.Limits.Cpu().String())
+	assert.Empty(t, init.Requests, "init containers hold no reservation")
+}
+
+func TestAllocationApplyOtherKinds(t *testing.T) {
+	secret := []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: s\n")
+	alloc := Allocate(&models.ResourceLimits{CPU: 1}, nil)
+
+	out, err := alloc.Apply(secret)
+	require.NoError(t, err)
+	assert.Equal(t, secret, out)
+
+	var none *Allocation
+	out, err = none.Apply([]byte(digitizePod))
+	require.NoError(t, err)
+	assert.Equal(t, digitizePod, string(out))
+}
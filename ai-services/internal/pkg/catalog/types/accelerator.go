@@ -0,0 +1,47 @@
+package types
+
+// LocalHostName is the host name reported for accelerators attached to the
+// control-plane host, the only host whose accelerators are listed.
+const LocalHostName = "local"
+
+// AcceleratorOwnerKind identifies what kind of catalog entity holds an accelerator.
+type AcceleratorOwnerKind string
+
+const (
+	AcceleratorOwnerService   AcceleratorOwnerKind = "service"
+	AcceleratorOwnerComponent AcceleratorOwnerKind = "component"
+)
+
+// Accelerator is the public API representation of a single accelerator card.
+type Accelerator struct {
+	// Host is LocalHostName.
+	Host       string `json:"host"`
+	Type       string `json:"type"`
+	PCIAddress string `json:"pci_address"`
+	// NUMANode is -1 when the host does not report NUMA affinity.
+	NUMANode  int               `json:"numa_node"`
+	Driver    string            `json:"driver"`
+	VFIOBound bool              `json:"vfio_bound"`
+	Health    string            `json:"health"`
+	Problems  []string          `json:"problems,omitempty"`
+	Owner     *AcceleratorOwner `json:"owner,omitempty"`
+}
+
+// AcceleratorOwner describes the application and service or component holding a card.
+type AcceleratorOwner struct {
+	ApplicationID   string               `json:"application_id,omitempty"`
+	ApplicationName string               `json:"application_name,omitempty"`
+	Kind            AcceleratorOwnerKind `json:"kind,omitempty"`
+	ID              string               `json:"id,omitempty"`
+	// Name is the service catalog ID or "<component type>/<provider>".
+	Name string `json:"name,omitempty"`
+}
+
+// AcceleratorListResponse is returned by GET /api/v1/accelerators.
+type AcceleratorListResponse struct {
+	Accelerators []Accelerator `json:"accelerators"`
+	Total        int           `json:"total"`
+	InUse        int           `json:"in_use"`
+	// Errors lists why the inventory could not be collected.
+	Errors []string `json:"errors,omitempty"`
+}
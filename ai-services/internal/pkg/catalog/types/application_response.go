@@ -31,18 +31,26 @@ type ApplicationService struct {
 	Endpoints []map[string]any       `json:"endpoints,omitempty"`
 	Version   string                 `json:"version,omitempty"`
 	Component []ServiceComponentResp `json:"components,omitempty"`
+	Resources *ResourceLimits        `json:"resources,omitempty"`
 	CreatedAt string                 `json:"created_at,omitempty"`
 	UpdatedAt string                 `json:"updated_at,omitempty"`
 }
 
 // ServiceComponentResp represents a service component in the get response.
 type ServiceComponentResp struct {
-	ID       string         `json:"id"`
-	Type     string         `json:"type"`
-	Provider ProviderInfo   `json:"provider"`
-	Status   string         `json:"status,omitempty"`
-	Message  string         `json:"message,omitempty"`
-	Metadata map[string]any `json:"metadata,omitempty"`
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Provider  ProviderInfo    `json:"provider"`
+	Status    string          `json:"status,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	Metadata  map[string]any  `json:"metadata,omitempty"`
+	Resources *ResourceLimits `json:"resources,omitempty"`
+}
+
+// ResourceLimits represents the CPU and memory enforced on the pods of a service or component.
+type ResourceLimits struct {
+	CPU         int   `json:"cpu"`          // CPU cores
+	MemoryBytes int64 `json:"memory_bytes"` // Memory in bytes
 }
 
 // ProviderInfo represents provider information with ID and name.
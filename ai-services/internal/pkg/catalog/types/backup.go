@@ -0,0 +1,116 @@
+package types
+
+// BackupTargetAll selects every deployed component and service that supports backups.
+const BackupTargetAll = "all"
+
+// CreateBackupRequest is the body of POST /api/v1/applications/{id}/backups.
+type CreateBackupRequest struct {
+	// Target is a backup target such as "opensearch" or "digitize". Defaults to "all".
+	Target string `json:"target,omitempty" example:"all"`
+}
+
+// RestoreBackupRequest is the body of POST /api/v1/applications/{id}/backups/{backup_id}/restore.
+type RestoreBackupRequest struct {
+	// Target restores a single target from a combined backup. Defaults to the
+	// target the backup was created for.
+	Target string `json:"target,omitempty" example:"opensearch"`
+	// SourceApplicationID restores a backup of another application, e.g. to
+	// promote a corpus from a development application. The backup_id in the path
+	// then names a backup of that application. Defaults to the application itself.
+	SourceApplicationID string `json:"source_application_id,omitempty" example:"7b1c3a52-8f0e-4d2b-9c55-2f1a6e0b9d41"`
+	// RenameIndices restores OpenSearch indices under new names, keyed by the
+	// index name in the backup.
+	RenameIndices map[string]string `json:"rename_indices,omitempty"`
+	// IndexPrefix is prepended to the names of OpenSearch indices that
+	// RenameIndices does not cover.
+	IndexPrefix string `json:"index_prefix,omitempty" example:"promoted-"`
+	// SkipExistingIndices leaves OpenSearch indices that already exist untouched
+	// instead of replacing them.
+	SkipExistingIndices bool `json:"skip_existing_indices,omitempty"`
+}
+
+// BackupJob is the public API representation of a backup or restore job.
+type BackupJob struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id,omitempty"`
+	// ApplicationName is kept after the application is deleted.
+	ApplicationName string `json:"application_name"`
+	// Type is "backup" or "restore".
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	// Status is one of pending, running, completed or failed.
+	Status string `json:"status"`
+	// Progress is the percentage of targets processed.
+	Progress int    `json:"progress"`
+	Message  string `json:"message,omitempty"`
+	// FileName is the archive path relative to the backup volume (backup jobs only).
+	FileName  string `json:"file_name,omitempty"`
+	SizeBytes *int64 `json:"size_bytes,omitempty"`
+	// SourceBackupID is the backup restored by a restore job.
+	SourceBackupID string `json:"source_backup_id,omitempty"`
+	CreatedBy      string `json:"created_by,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+	CompletedAt    string `json:"completed_at,omitempty"`
+}
+
+// BackupJobListResponse is returned by GET /api/v1/applications/{id}/backups.
+type BackupJobListResponse struct {
+	Jobs  []BackupJob `json:"jobs"`
+	Total int         `json:"total"`
+}
+
+// BackupRetention selects the scheduled backups that are kept after each run. A
+// backup is kept if any rule selects it; all rules 0 keeps every backup.
+type BackupRetention struct {
+	// KeepLast keeps the N most recent backups.
+	KeepLast int `json:"keep_last" example:"3"`
+	// KeepDaily keeps the newest backup of each of the last N days with a backup.
+	KeepDaily int `json:"keep_daily" example:"7"`
+	// KeepWeekly keeps the newest backup of each of the last N ISO weeks with a backup.
+	KeepWeekly int `json:"keep_weekly" example:"4"`
+}
+
+// CreateBackupScheduleRequest is the body of POST /api/v1/applications/{id}/backup-schedules.
+type CreateBackupScheduleRequest struct {
+	// Target is a backup target such as "opensearch" or "digitize". Defaults to "all".
+	Target string `json:"target,omitempty" example:"all"`
+	// Cron is a five-field cron expression or a macro such as @daily.
+	Cron      string          `json:"cron" binding:"required" example:"0 2 * * *"`
+	Retention BackupRetention `json:"retention"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// UpdateBackupScheduleRequest is the body of PUT /api/v1/applications/{id}/backup-schedules/{schedule_id}.
+// Only the fields that are set are changed.
+type UpdateBackupScheduleRequest struct {
+	Target    *string          `json:"target,omitempty"`
+	Cron      *string          `json:"cron,omitempty"`
+	Retention *BackupRetention `json:"retention,omitempty"`
+	Enabled   *bool            `json:"enabled,omitempty"`
+}
+
+// BackupSchedule is the public API representation of a backup schedule.
+type BackupSchedule struct {
+	ID            string          `json:"id"`
+	ApplicationID string          `json:"application_id"`
+	Target        string          `json:"target"`
+	Cron          string          `json:"cron"`
+	Retention     BackupRetention `json:"retention"`
+	Enabled       bool            `json:"enabled"`
+	NextRunAt     string          `json:"next_run_at,omitempty"`
+	LastRunAt     string          `json:"last_run_at,omitempty"`
+	// LastStatus is the status of the backup job started by the last run.
+	LastStatus string `json:"last_status,omitempty"`
+	LastError  string `json:"last_error,omitempty"`
+	CreatedBy  string `json:"created_by,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// BackupScheduleListResponse is returned by GET /api/v1/applications/{id}/backup-schedules.
+type BackupScheduleListResponse struct {
+	Schedules []BackupSchedule `json:"schedules"`
+	Total     int              `json:"total"`
+}
@@ -0,0 +1,18 @@
+package types
+
+// ApplicationEvent is the public API representation of an application event.
+type ApplicationEvent struct {
+	ID string `json:"id"`
+	// Type is "Normal" or "Warning".
+	Type string `json:"type"`
+	// Reason is a short machine-readable cause such as "BackupFailed".
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ApplicationEventListResponse is returned by GET /api/v1/applications/{id}/events.
+type ApplicationEventListResponse struct {
+	Events []ApplicationEvent `json:"events"`
+	Total  int                `json:"total"`
+}
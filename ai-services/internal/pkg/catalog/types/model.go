@@ -0,0 +1,127 @@
+package types
+
+import "time"
+
+// CachedModel is the public API representation of a model in the models directory
+// of the catalog host.
+type CachedModel struct {
+	// Model is the Hugging Face id of the model, e.g. "ibm-granite/granite-3.3-8b-instruct".
+	Model    string `json:"model"`
+	Revision string `json:"revision,omitempty"`
+	// Source is "huggingface" or "offline", or "local" or "oci" for registered
+	// models; empty for models downloaded before it was recorded.
+	Source   string           `json:"source,omitempty"`
+	Size     int64            `json:"size"`
+	StoredAt time.Time        `json:"stored_at"`
+	UsedBy   []ModelReference `json:"used_by"`
+}
+
+// ModelReference is an application component configured to serve a cached model,
+// or a pod of the host that mounts it.
+type ModelReference struct {
+	ApplicationID   string `json:"application_id,omitempty"`
+	ApplicationName string `json:"application_name,omitempty"`
+	ComponentID     string `json:"component_id"`
+	// Component is "<component type>/<provider>".
+	Component string `json:"component"`
+	// Pod is the pod serving the model, for applications deployed outside the catalog.
+	Pod string `json:"pod,omitempty"`
+}
+
+// ModelListResponse is returned by GET /api/v1/models.
+type ModelListResponse struct {
+	Models    []CachedModel `json:"models"`
+	Total     int           `json:"total"`
+	TotalSize int64         `json:"total_size"`
+	// UnreferencedSize is the disk space held by models no application uses.
+	UnreferencedSize int64 `json:"unreferenced_size"`
+}
+
+// ModelVerifyRequest holds the optional filter for verifying models.
+type ModelVerifyRequest struct {
+	// Model restricts verification to one model.
+	Model string `json:"model,omitempty"`
+}
+
+// ModelVerification is the verification result of one model.
+type ModelVerification struct {
+	Model string `json:"model"`
+	// VerifiedAgainst is "metadata" for models placed by ai-services and
+	// "huggingface" for models verified against the checksums hf recorded.
+	VerifiedAgainst string `json:"verified_against,omitempty"`
+	Checked         int    `json:"checked"`
+	// Failed lists files that are missing or whose checksum does not match.
+	Failed []string `json:"failed,omitempty"`
+	// Unverifiable lists files without a recorded checksum.
+	Unverifiable []string `json:"unverifiable,omitempty"`
+	// Error is set when the model could not be verified at all.
+	Error string `json:"error,omitempty"`
+}
+
+// ModelVerifyResponse is returned by POST /api/v1/models/verify.
+type ModelVerifyResponse struct {
+	Models []ModelVerification `json:"models"`
+	// Healthy is true when every file that could be checked matched.
+	Healthy bool `json:"healthy"`
+}
+
+// ModelPruneRequest is the body of POST /api/v1/models/prune.
+type ModelPruneRequest struct {
+	// DryRun reports what would be removed without removing it.
+	DryRun bool `json:"dry_run"`
+}
+
+// ModelPruneResponse is returned by POST /api/v1/models/prune.
+type ModelPruneResponse struct {
+	DryRun  bool          `json:"dry_run"`
+	Removed []CachedModel `json:"removed"`
+	// Reclaimed is the disk space freed, or that would be freed on a dry run.
+	Reclaimed int64 `json:"reclaimed"`
+}
+
+// RegisteredModel is the public API representation of a custom model registered
+// in the catalog.
+type RegisteredModel struct {
+	ID string `json:"id"`
+	// Name is the model id used in component params, e.g. "acme/granite-support".
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// ComponentType is the component type serving the model: "llm", "embedding" or "reranker".
+	ComponentType string `json:"component_type"`
+	// SourceType is "local" or "oci".
+	SourceType string `json:"source_type"`
+	// Source is the directory or image reference the model was registered from.
+	Source string `json:"source"`
+	// Digest is the manifest digest of an OCI source.
+	Digest string `json:"digest,omitempty"`
+	// Status is "registering", "ready" or "failed". Only ready models can be selected.
+	Status    string    `json:"status"`
+	SizeBytes *int64    `json:"size_bytes,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RegisterModelRequest is the body of POST /api/v1/models/registered. Exactly
+// one of Path and Image is set.
+type RegisterModelRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Description   string `json:"description,omitempty"`
+	ComponentType string `json:"component_type" binding:"required"`
+	// Path is a directory on the catalog host holding the model files.
+	Path string `json:"path,omitempty"`
+	// Image is an OCI artifact or modelcar image holding the model files.
+	Image string `json:"image,omitempty"`
+	// Username and Password authenticate to the registry of Image; they are not stored.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// SkipTLSVerify disables certificate verification of the registry of Image.
+	SkipTLSVerify bool `json:"skip_tls_verify,omitempty"`
+}
+
+// RegisteredModelListResponse is returned by GET /api/v1/models/registered.
+type RegisteredModelListResponse struct {
+	Models []RegisteredModel `json:"models"`
+	Total  int               `json:"total"`
+}
@@ -0,0 +1,43 @@
+package types
+
+// PruneRequest is the body of POST /api/v1/images/prune and POST /api/v1/volumes/prune.
+type PruneRequest struct {
+	// DryRun reports what would be removed without removing it.
+	DryRun bool `json:"dry_run"`
+}
+
+// PrunedImage is a local image no deployed application refers to.
+type PrunedImage struct {
+	ID string `json:"id"`
+	// Names are the tags and digests of the image; empty for dangling images.
+	Names []string `json:"names"`
+	// Size is the disk space only this image holds.
+	Size int64 `json:"size"`
+}
+
+// ImagePruneResponse is returned by POST /api/v1/images/prune.
+type ImagePruneResponse struct {
+	DryRun  bool          `json:"dry_run"`
+	Removed []PrunedImage `json:"removed"`
+	// Reclaimed is the disk space freed, or that would be freed on a dry run. Layers
+	// shared only by removed images are not counted, so more may be freed.
+	Reclaimed int64 `json:"reclaimed"`
+	// Errors lists the images that could not be removed.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// PrunedVolume is a volume of a catalog application or component that no longer exists.
+type PrunedVolume struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// VolumePruneResponse is returned by POST /api/v1/volumes/prune.
+type VolumePruneResponse struct {
+	DryRun  bool           `json:"dry_run"`
+	Removed []PrunedVolume `json:"removed"`
+	// Reclaimed is the disk space freed, or that would be freed on a dry run.
+	Reclaimed int64 `json:"reclaimed"`
+	// Errors lists the volumes that could not be removed.
+	Errors []string `json:"errors,omitempty"`
+}
@@ -23,7 +23,10 @@ type Architecture struct {
 	GlobalComponents []ComponentReference `yaml:"global_components,omitempty" json:"global_components,omitempty"`
 	Services         []ServiceReference   `yaml:"services" json:"services"`
 	Links            *ArchitectureLinks   `yaml:"links,omitempty" json:"links,omitempty"`
-	About            *yaml.Node           `yaml:"-" json:"-"`
+	// Requirements is the minimum host capacity needed to deploy the architecture,
+	// enforced by admission control in addition to the sum of its parts.
+	Requirements *Resources `yaml:"requirements,omitempty" json:"requirements,omitempty"`
+	About        *yaml.Node `yaml:"-" json:"-"`
 }
 
 // MarshalJSON implements custom JSON marshaling for Architecture to properly handle yaml.Node.
@@ -113,6 +116,7 @@ type Service struct {
 	Dependencies      []DependencyReference `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
 	Standalone        bool                  `yaml:"standalone,omitempty" json:"standalone,omitempty"`
 	AcceptsDatasource bool                  `yaml:"accepts_datasource,omitempty" json:"accepts_datasource,omitempty"`
+	Backup            []string              `yaml:"backup,omitempty" json:"backup,omitempty"` // Supported backup hooks: backup, restore, verify
 	About             *yaml.Node            `yaml:"-" json:"-"`
 }
 
@@ -163,13 +167,15 @@ type ServiceSummary struct {
 
 // Component represents an infrastructure component (vector_store, embedding, llm, etc.).
 type Component struct {
-	ID            string `yaml:"id" json:"id"`
-	Name          string `yaml:"name" json:"name"`
-	Description   string `yaml:"description" json:"description"`
-	Type          string `yaml:"type" json:"type"`                           // "component"
-	ComponentType string `yaml:"component_type" json:"component_type"`       // "vector_store", "embedding", "llm", etc.
-	ComponentName string `yaml:"component_name" json:"component_name"`       // Display name for component type (e.g., "Vector store", "Large language model")
-	Default       bool   `yaml:"default,omitempty" json:"default,omitempty"` // Whether this is the default provider for this component type
+	ID            string   `yaml:"id" json:"id"`
+	Name          string   `yaml:"name" json:"name"`
+	Description   string   `yaml:"description" json:"description"`
+	Type          string   `yaml:"type" json:"type"`                                       // "component"
+	ComponentType string   `yaml:"component_type" json:"component_type"`                   // "vector_store", "embedding", "llm", etc.
+	ComponentName string   `yaml:"component_name" json:"component_name"`                   // Display name for component type (e.g., "Vector store", "Large language model")
+	Default       bool     `yaml:"default,omitempty" json:"default,omitempty"`             // Whether this is the default provider for this component type
+	Backup        []string `yaml:"backup,omitempty" json:"backup,omitempty"`               // Supported backup hooks: backup, restore, verify
+	CustomModels  bool     `yaml:"custom_models,omitempty" json:"custom_models,omitempty"` // Whether models registered in the catalog can be selected
 }
 
 // ComponentSummary represents a component for list API responses.
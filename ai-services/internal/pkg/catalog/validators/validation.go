@@ -9,8 +9,11 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/resources"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // ValidationError represents a validation error with HTTP status code.
@@ -139,6 +142,15 @@ func (v *ApplicationValidator) validateParamsWithSchema(
 	if len(params) == 0 {
 		return nil
 	}
+
+	// The resources override is not a template value, so the schema does not list it.
+	if _, ok := params[resources.ParamKey]; ok {
+		if err := validateResourcesParam(params, contextName); err != nil {
+			return err
+		}
+		params = resources.Strip(params)
+	}
+
 	schema, err := loadSchema()
 	if err == nil && len(schema) > 0 {
 		return ValidateParams(params, schema, contextName)
@@ -147,6 +159,26 @@ func (v *ApplicationValidator) validateParamsWithSchema(
 	return nil
 }
 
+// validateResourcesParam validates the override of the CPU and memory enforced
+// on the pods, which only podman enforces.
+func validateResourcesParam(params map[string]any, contextName string) error {
+	if vars.RuntimeFactory.GetRuntimeType() != runtimeTypes.RuntimeTypePodman {
+		return &ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Parameter '%s' of %s is only supported on podman", resources.ParamKey, contextName),
+		}
+	}
+
+	if err := resources.Validate(params); err != nil {
+		return &ValidationError{
+			Code:    http.StatusBadRequest,
+			Message: fmt.Sprintf("Parameter validation failed for %s: %v", contextName, err),
+		}
+	}
+
+	return nil
+}
+
 // ValidateServiceParams validates service-level parameters against schema.
 func (v *ApplicationValidator) ValidateServiceParams(ctx context.Context, serviceID string, params map[string]any) error {
 	return v.validateParamsWithSchema(params, func() (map[string]any, error) {
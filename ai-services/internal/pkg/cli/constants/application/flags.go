@@ -8,11 +8,13 @@ type CreateFlags struct {
 	Params         string
 	Values         string
 	Legacy         string
+	IgnoreCapacity string
 
 	// Podman-specific flags
 	SkipImageDownload string
 	SkipModelDownload string
 	ImagePullPolicy   string
+	Autostart         string
 
 	// OpenShift-specific flags
 	Timeout string
@@ -26,11 +28,13 @@ var Create = CreateFlags{
 	Params:         "params",
 	Values:         "values",
 	Legacy:         "legacy",
+	IgnoreCapacity: "ignore-capacity",
 
 	// Podman-specific flags
 	SkipImageDownload: "skip-image-download",
 	SkipModelDownload: "skip-model-download",
 	ImagePullPolicy:   "image-pull-policy",
+	Autostart:         "autostart",
 
 	// OpenShift-specific flags
 	Timeout: "timeout",
@@ -1,6 +1,7 @@
 package podman
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
@@ -23,7 +25,13 @@ const (
 // DeployPodAndReadinessCheck deploys a pod and performs readiness checks on its containers.
 func DeployPodAndReadinessCheck(ctx context.Context, rt runtime.Runtime, podSpec *models.PodSpec,
 	podTemplateName string, body io.Reader, opts map[string]string) error {
-	pods, err := rt.CreatePod(ctx, body, opts)
+	rendered, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read pod template: %w", err)
+	}
+
+	// Run the containers from the private registry their images are mirrored to, if any.
+	pods, err := rt.CreatePod(ctx, bytes.NewReader(mirror.ResolveManifest(rendered)), opts)
 	if err != nil {
 		return fmt.Errorf("failed pod creation: %w", err)
 	}
@@ -3,15 +3,47 @@ package image
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/opencontainers/go-digest"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/policy"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // PullImageFromRegistry pulls the required images from registry with retry logic.
+// Nothing is pulled unless all images satisfy the image policy of the host.
 func PullImageFromRegistry(ctx context.Context, runtime runtime.Runtime, images []string) error {
+	digests, err := enforcePolicy(ctx, images)
+	if err != nil {
+		return err
+	}
+
+	return pullImages(ctx, runtime, images, digests)
+}
+
+// enforcePolicy checks images against the image policy of the host and returns
+// the digests that images with a required signature or pinned digest were
+// verified at.
+func enforcePolicy(ctx context.Context, images []string) (map[string]digest.Digest, error) {
+	imagePolicy, err := policy.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return imagePolicy.Enforce(ctx, images)
+}
+
+// pullImages pulls images with retry logic. The pulled images with a verified
+// digest must be the verified images, so that a tag moved after verification is
+// never used.
+func pullImages(ctx context.Context, runtime runtime.Runtime, images []string, digests map[string]digest.Digest) error {
 	for _, image := range images {
 		logger.InfolnCtx(ctx, "Downloading image: "+image+"...")
 		if err := utils.Retry(ctx, vars.RetryCount, vars.RetryInterval, nil, func() error {
@@ -21,9 +53,67 @@ func PullImageFromRegistry(ctx context.Context, runtime runtime.Runtime, images
 		}
 	}
 
+	mismatched, err := FetchImagesNotVerified(runtime, images, digests)
+	if err != nil {
+		return err
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("pulled images do not match the digests they were verified at, their tags may have been moved: %v", mismatched)
+	}
+
 	return nil
 }
 
+// FetchImagesNotVerified returns the images with a verified digest whose local
+// image is missing or has a different digest.
+func FetchImagesNotVerified(runtime runtime.Runtime, images []string, digests map[string]digest.Digest) ([]string, error) {
+	if len(digests) == 0 {
+		return nil, nil
+	}
+
+	lImages, err := runtime.ListImages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local images: %w", err)
+	}
+
+	mirrors, err := mirror.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var notVerified []string
+	for _, image := range images {
+		dgst, ok := digests[image]
+		if !ok {
+			continue
+		}
+		if !hasDigest(lImages, []string{image, mirrors.Rewrite(image)}, dgst) {
+			notVerified = append(notVerified, image)
+		}
+	}
+
+	return notVerified, nil
+}
+
+// hasDigest reports whether a local image named by one of names carries dgst.
+func hasDigest(lImages []types.Image, names []string, dgst digest.Digest) bool {
+	for _, lImage := range lImages {
+		if !slices.ContainsFunc(names, func(name string) bool {
+			return slices.Contains(lImage.RepoTags, name) || slices.Contains(lImage.RepoDigests, name)
+		}) {
+			continue
+		}
+
+		for _, repoDigest := range lImage.RepoDigests {
+			if strings.HasSuffix(repoDigest, "@"+dgst.String()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // FetchImagesNotFound returns list of images which are not present locally.
 func FetchImagesNotFound(runtime runtime.Runtime, reqImages []string) ([]string, error) {
 	notfoundImages := make([]string, 0, len(reqImages))
@@ -46,9 +136,15 @@ func FetchImagesNotFound(runtime runtime.Runtime, reqImages []string) ([]string,
 		}
 	}
 
-	// Filter the requested images against the existingImages map to determine the non existing images
+	mirrors, err := mirror.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter the requested images against the existingImages map to determine the non existing images.
+	// Mirrored images are present locally under the name of their mirror.
 	for _, image := range reqImages {
-		if !existingImages[image] {
+		if !existingImages[image] && !existingImages[mirrors.Rewrite(image)] {
 			notfoundImages = append(notfoundImages, image)
 		}
 	}
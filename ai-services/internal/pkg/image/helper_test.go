@@ -0,0 +1,30 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+func TestHasDigest(t *testing.T) {
+	verified := digest.Digest("sha256:" + "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	moved := digest.Digest("sha256:" + "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0")
+
+	local := []types.Image{
+		{RepoTags: []string{"icr.io/ai-services/vllm:v1"}, RepoDigests: []string{"icr.io/ai-services/vllm@" + moved.String()}},
+		{RepoTags: []string{"mirror.local:5000/ai-services/opensearch:3"}, RepoDigests: []string{"mirror.local:5000/ai-services/opensearch@" + verified.String()}},
+		{RepoDigests: []string{"icr.io/ai-services/tool@" + verified.String()}},
+	}
+
+	// A tag that was moved after verification does not match.
+	assert.False(t, hasDigest(local, []string{"icr.io/ai-services/vllm:v1"}, verified))
+	assert.True(t, hasDigest(local, []string{"icr.io/ai-services/vllm:v1"}, moved))
+	// Mirrored images are matched under the name of their mirror.
+	assert.True(t, hasDigest(local, []string{"quay.io/ai-services/opensearch:3", "mirror.local:5000/ai-services/opensearch:3"}, verified))
+	// Images pulled by digest are matched by their repository digest.
+	assert.True(t, hasDigest(local, []string{"icr.io/ai-services/tool@" + verified.String()}, verified))
+	assert.False(t, hasDigest(local, []string{"icr.io/ai-services/missing:v1"}, verified))
+}
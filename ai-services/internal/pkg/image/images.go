@@ -99,20 +99,38 @@ func (img *Images) always(ctx context.Context, images []string) error {
 	return PullImageFromRegistry(ctx, img.Runtime, images)
 }
 
-// IfNotPresent pulls only the missing images for a given app template.
+// IfNotPresent pulls only the missing images for a given app template. Images
+// present locally are pulled again when their digest differs from the one the
+// image policy verified.
 func (img *Images) IfNotPresent(ctx context.Context, images []string) error {
+	digests, err := enforcePolicy(ctx, images)
+	if err != nil {
+		return err
+	}
+
 	notFoundImages, err := FetchImagesNotFound(img.Runtime, images)
 	if err != nil {
 		return err
 	}
 
+	notVerifiedImages, err := FetchImagesNotVerified(img.Runtime, images, digests)
+	if err != nil {
+		return err
+	}
+	for _, image := range notVerifiedImages {
+		if !slices.Contains(notFoundImages, image) {
+			logger.InfolnCtx(ctx, "Local image "+image+" does not match its verified digest")
+			notFoundImages = append(notFoundImages, image)
+		}
+	}
+
 	if len(notFoundImages) == 0 {
 		logger.InfolnCtx(ctx, "All required container images are already present locally.")
 
 		return nil
 	}
 
-	return PullImageFromRegistry(ctx, img.Runtime, notFoundImages)
+	return pullImages(ctx, img.Runtime, notFoundImages, digests)
 }
 
 // never -> never pulls any image.
@@ -0,0 +1,183 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.podman.io/image/v5/docker/reference"
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	configDirPermission  = 0o755
+	configFilePermission = 0o644
+)
+
+// Config maps image sources to the private registries they are mirrored to.
+type Config struct {
+	Mirrors []Mirror `json:"mirrors"`
+}
+
+// Mirror rewrites images below Source to the same path below Mirror. Source is a
+// registry host such as icr.io, or a repository prefix such as icr.io/ai-services.
+type Mirror struct {
+	Source string `json:"source"`
+	Mirror string `json:"mirror"`
+}
+
+// imageLine matches the image field of a container in a rendered pod template.
+var imageLine = regexp.MustCompile(`(?m)^(\s*(?:-\s+)?image:\s*)(["']?)([^\s"']+)(["']?)(\s*)$`)
+
+// Load reads the registry mirror configuration. A missing configuration means no
+// mirrors.
+func Load() (*Config, error) {
+	data, err := os.ReadFile(utils.GetRegistryMirrorsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry mirror configuration: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse registry mirror configuration %s: %w", utils.GetRegistryMirrorsPath(), err)
+	}
+
+	for _, m := range config.Mirrors {
+		if m.Source == "" || m.Mirror == "" {
+			return nil, fmt.Errorf("invalid registry mirror configuration %s: every mirror needs a source and a mirror", utils.GetRegistryMirrorsPath())
+		}
+	}
+
+	return &config, nil
+}
+
+// Save writes the registry mirror configuration.
+func (c *Config) Save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal registry mirror configuration: %w", err)
+	}
+
+	path := utils.GetRegistryMirrorsPath()
+	if err := os.MkdirAll(filepath.Dir(path), configDirPermission); err != nil {
+		return fmt.Errorf("failed to create directory for registry mirror configuration: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, configFilePermission); err != nil {
+		return fmt.Errorf("failed to write registry mirror configuration: %w", err)
+	}
+
+	return nil
+}
+
+// Set adds a mirror for source, replacing an existing mirror of the same source.
+func (c *Config) Set(source, mirror string) {
+	for i := range c.Mirrors {
+		if c.Mirrors[i].Source == source {
+			c.Mirrors[i].Mirror = mirror
+
+			return
+		}
+	}
+
+	c.Mirrors = append(c.Mirrors, Mirror{Source: source, Mirror: mirror})
+}
+
+// Rewrite returns image as pulled from its mirror. The most specific matching
+// source wins; images without a mirror are returned unchanged.
+func (c *Config) Rewrite(image string) string {
+	mirrors := append([]Mirror(nil), c.Mirrors...)
+	sort.SliceStable(mirrors, func(i, j int) bool {
+		return len(mirrors[i].Source) > len(mirrors[j].Source)
+	})
+
+	// Match short names such as postgres:16 by their full docker.io/library name.
+	name := image
+	if named, err := reference.ParseNormalizedNamed(image); err == nil {
+		name = named.String()
+	}
+
+	for _, m := range mirrors {
+		if rest, ok := cutSource(name, m.Source); ok {
+			return strings.TrimSuffix(m.Mirror, "/") + rest
+		}
+	}
+
+	return image
+}
+
+// RewriteManifest rewrites the container images of a rendered pod template.
+func (c *Config) RewriteManifest(manifest []byte) []byte {
+	if len(c.Mirrors) == 0 {
+		return manifest
+	}
+
+	return RewriteImages(manifest, c.Rewrite)
+}
+
+// RewriteImages replaces every container image of a rendered pod template with
+// what rewrite returns for it.
+func RewriteImages(manifest []byte, rewrite func(image string) string) []byte {
+	return imageLine.ReplaceAllFunc(manifest, func(line []byte) []byte {
+		m := imageLine.FindSubmatch(line)
+
+		return []byte(string(m[1]) + string(m[2]) + rewrite(string(m[3])) + string(m[4]) + string(m[5]))
+	})
+}
+
+// Resolve returns image as pulled from its mirror according to the registry
+// mirror configuration. A configuration that cannot be read is reported and
+// ignored.
+func Resolve(image string) string {
+	config, err := Load()
+	if err != nil {
+		logger.Warningf("Ignoring registry mirrors: %v\n", err)
+
+		return image
+	}
+
+	return config.Rewrite(image)
+}
+
+// ResolveManifest rewrites the container images of a rendered pod template
+// according to the registry mirror configuration.
+func ResolveManifest(manifest []byte) []byte {
+	config, err := Load()
+	if err != nil {
+		logger.Warningf("Ignoring registry mirrors: %v\n", err)
+
+		return manifest
+	}
+
+	return config.RewriteManifest(manifest)
+}
+
+// cutSource returns the remainder of image after source when image lies below
+// source. A registry host only matches whole path components; a repository also
+// matches its own tags and digests.
+func cutSource(image, source string) (string, bool) {
+	source = strings.TrimSuffix(source, "/")
+	rest, ok := strings.CutPrefix(image, source)
+	if !ok {
+		return "", false
+	}
+
+	if rest == "" || rest[0] == '/' {
+		return rest, true
+	}
+	if strings.Contains(source, "/") && (rest[0] == ':' || rest[0] == '@') {
+		return rest, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,87 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	config := &Config{Mirrors: []Mirror{
+		{Source: "icr.io", Mirror: "registry.local/ns"},
+		{Source: "icr.io/ppc64le-oss", Mirror: "registry.local/oss/"},
+		{Source: "docker.io", Mirror: "registry.local/hub"},
+	}}
+
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"icr.io/ai-services-cicd/postgres:18-5", "registry.local/ns/ai-services-cicd/postgres:18-5"},
+		{"icr.io/ppc64le-oss/vllm-ppc64le:0.19.1", "registry.local/oss/vllm-ppc64le:0.19.1"},
+		{"icr.io/ai-services/tools@sha256:" + sha, "registry.local/ns/ai-services/tools@sha256:" + sha},
+		{"postgres:16", "registry.local/hub/library/postgres:16"},
+		{"icr.io.example.com/app:1", "icr.io.example.com/app:1"},
+		{"registry.redhat.io/rhaii/vllm-spyre-rhel9:3.4.0", "registry.redhat.io/rhaii/vllm-spyre-rhel9:3.4.0"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, config.Rewrite(tt.image), tt.image)
+	}
+}
+
+func TestRewriteManifest(t *testing.T) {
+	config := &Config{Mirrors: []Mirror{{Source: "icr.io", Mirror: "registry.local/ns"}}}
+	manifest := `spec:
+  containers:
+    - name: db
+      image: icr.io/ai-services-cicd/postgres:18-5
+    - image: "icr.io/ai-services/tools:0.11"
+      name: tools
+  # image: icr.io/commented/out:1
+`
+
+	want := `spec:
+  containers:
+    - name: db
+      image: registry.local/ns/ai-services-cicd/postgres:18-5
+    - image: "registry.local/ns/ai-services/tools:0.11"
+      name: tools
+  # image: icr.io/commented/out:1
+`
+	assert.Equal(t, want, string(config.RewriteManifest([]byte(manifest))))
+	assert.Equal(t, manifest, string((&Config{}).RewriteManifest([]byte(manifest))))
+}
+
+func TestDestinationMatchesRewrite(t *testing.T) {
+	for _, image := range []string{"icr.io/ai-services-cicd/chatbot-ui:v0.0.50", "postgres:16"} {
+		dest, err := Destination(image, "registry.local/ns")
+		require.NoError(t, err)
+
+		source, err := Source(image)
+		require.NoError(t, err)
+
+		config := &Config{}
+		config.Set(source, "registry.local/ns")
+		assert.Equal(t, dest, config.Rewrite(image))
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, config.Mirrors)
+
+	config.Set("icr.io", "registry.local/old")
+	config.Set("icr.io", "registry.local/ns")
+	require.NoError(t, config.Save())
+
+	loaded, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []Mirror{{Source: "icr.io", Mirror: "registry.local/ns"}}, loaded.Mirrors)
+	assert.Equal(t, "registry.local/ns/app:1", Resolve("icr.io/app:1"))
+}
+
+const sha = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
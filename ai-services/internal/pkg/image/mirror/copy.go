@@ -0,0 +1,110 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.podman.io/image/v5/copy"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/signature"
+	imageTypes "go.podman.io/image/v5/types"
+)
+
+// CopyOptions configures how images are copied to a mirror.
+type CopyOptions struct {
+	// AuthFile holds the credentials of the source and mirror registries. The
+	// default credentials of the user are used when empty.
+	AuthFile string
+	// TLSVerify verifies the certificate of the mirror registry.
+	TLSVerify bool
+}
+
+// Result describes an image copied to a mirror.
+type Result struct {
+	Source      string
+	Destination string
+	// Digest is the manifest digest, identical on the source and the mirror.
+	Digest string
+}
+
+// Source returns the registry host of image, which is what a mirror of image
+// below to is configured for.
+func Source(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+
+	return reference.Domain(named), nil
+}
+
+// Destination returns where image is mirrored below to: the repository path of
+// image, without its registry, below to.
+func Destination(image, to string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+
+	rest, _ := cutSource(named.String(), reference.Domain(named))
+
+	return strings.TrimSuffix(to, "/") + rest, nil
+}
+
+// Copy copies image, with every platform of a multi-arch image, to its
+// destination below to. Digests are preserved so that images pinned by digest
+// resolve on the mirror.
+func Copy(ctx context.Context, image, to string, opts CopyOptions) (*Result, error) {
+	dest, err := Destination(image, to)
+	if err != nil {
+		return nil, err
+	}
+
+	srcRef, err := docker.ParseReference("//" + image)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+	destRef, err := docker.ParseReference("//" + dest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirror reference %s: %w", dest, err)
+	}
+
+	sys := &imageTypes.SystemContext{AuthFilePath: opts.AuthFile}
+	destSys := &imageTypes.SystemContext{
+		AuthFilePath:                opts.AuthFile,
+		DockerInsecureSkipTLSVerify: imageTypes.NewOptionalBool(!opts.TLSVerify),
+	}
+
+	// Honour the signature policy of the host, as podman does when it pulls.
+	policy, err := signature.DefaultPolicy(sys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image signature policy: %w", err)
+	}
+	policyCtx, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image signature policy context: %w", err)
+	}
+	defer func() {
+		_ = policyCtx.Destroy()
+	}()
+
+	copied, err := copy.Image(ctx, policyCtx, destRef, srcRef, &copy.Options{
+		SourceCtx:          sys,
+		DestinationCtx:     destSys,
+		ImageListSelection: copy.CopyAllImages,
+		PreserveDigests:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy %s to %s: %w", image, dest, err)
+	}
+
+	digest, err := manifest.Digest(copied)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute digest of %s: %w", dest, err)
+	}
+
+	return &Result{Source: image, Destination: dest, Digest: digest.String()}, nil
+}
@@ -0,0 +1,379 @@
+// Package policy enforces the image policy of the host before images are
+// pulled: the registries images may come from, the signatures they must carry
+// and whether applications run images pinned to the digests they were planned
+// with.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/signature"
+	imageTypes "go.podman.io/image/v5/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	// registriesConfig makes the docker transport read sigstore signatures
+	// attached to images in the registry, where cosign stores them.
+	registriesConfig     = "default-docker:\n  use-sigstore-attachments: true\n"
+	registriesConfigFile = "ai-services.yaml"
+	registriesDirPrefix  = "ai-services-registries.d-"
+)
+
+// Config is the image policy of the host.
+type Config struct {
+	// AllowedRegistries lists the registry hosts or repository prefixes images may
+	// be pulled from, after registry mirrors are applied. Empty allows all.
+	AllowedRegistries []string `json:"allowed_registries,omitempty"`
+	// Signatures lists the repositories whose images must carry a sigstore
+	// signature made with one of the given cosign public keys.
+	Signatures []SignatureRule `json:"signatures,omitempty"`
+	// PolicyFile is a containers-policy.json(5) file whose requirements images
+	// must satisfy, e.g. /etc/containers/policy.json. Signature rules take
+	// precedence over it for the images they match.
+	PolicyFile string `json:"policy_file,omitempty"`
+	// PinDigests resolves the images of an application to digests when it is
+	// planned, records them and runs the application from exactly those digests.
+	// Images checked against a signature rule or the policy file are always
+	// pinned to the digest they were verified at.
+	PinDigests bool `json:"pin_digests,omitempty"`
+
+	// reference returns the reference an image is pulled from; replaced in tests.
+	reference func(pulled string) (imageTypes.ImageReference, error)
+}
+
+// SignatureRule requires a signature on the images below Scope.
+type SignatureRule struct {
+	// Scope is a registry host such as icr.io, or a repository prefix such as
+	// icr.io/ai-services, matched against the images as the templates name them.
+	Scope string `json:"scope"`
+	// Keys are paths of cosign public keys; a signature by any of them is accepted.
+	Keys []string `json:"keys"`
+}
+
+// Violation is an image that does not satisfy the policy.
+type Violation struct {
+	Image  string
+	Reason string
+}
+
+// ViolationError is returned when images do not satisfy the policy.
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", v.Image, v.Reason))
+	}
+
+	return fmt.Sprintf("image policy %s rejects %d image(s): %s",
+		utils.GetImagePolicyPath(), len(e.Violations), strings.Join(reasons, "; "))
+}
+
+// Load reads the image policy. A missing policy allows every image.
+func Load() (*Config, error) {
+	path := utils.GetImagePolicyPath()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image policy: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image policy %s: %w", path, err)
+	}
+
+	for _, rule := range config.Signatures {
+		if rule.Scope == "" || len(rule.Keys) == 0 {
+			return nil, fmt.Errorf("invalid image policy %s: every signature rule needs a scope and at least one key", path)
+		}
+		for _, key := range rule.Keys {
+			if !filepath.IsAbs(key) {
+				return nil, fmt.Errorf("invalid image policy %s: key path must be absolute: %s", path, key)
+			}
+		}
+	}
+	if config.PolicyFile != "" && !filepath.IsAbs(config.PolicyFile) {
+		return nil, fmt.Errorf("invalid image policy %s: policy file path must be absolute: %s", path, config.PolicyFile)
+	}
+
+	return &config, nil
+}
+
+// Enabled reports whether the policy restricts or pins any image.
+func (c *Config) Enabled() bool {
+	return len(c.AllowedRegistries) > 0 || len(c.Signatures) > 0 || c.PolicyFile != "" || c.PinDigests
+}
+
+// Enforce checks images against the policy. Images that had to be inspected in
+// their registry, because their signature is verified or their digest pinned,
+// are returned with the digest they were verified at. All violations are
+// reported together in a ViolationError.
+func (c *Config) Enforce(ctx context.Context, images []string) (map[string]digest.Digest, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+
+	mirrors, err := mirror.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	sys, cleanup, err := c.systemContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	digests := make(map[string]digest.Digest)
+	var violations []Violation
+	for _, img := range images {
+		pulled := mirrors.Rewrite(img)
+		if !c.allowed(pulled) {
+			violations = append(violations, Violation{Image: img, Reason: fmt.Sprintf(
+				"%s is not below any of the allowed registries %s", normalizedName(pulled), strings.Join(c.AllowedRegistries, ", "))})
+
+			continue
+		}
+
+		policy, err := c.policyFor(img)
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil && !c.PinDigests {
+			continue
+		}
+
+		dgst, reason, err := c.inspect(ctx, sys, pulled, policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect image %s: %w", pulled, err)
+		}
+		if reason != "" {
+			violations = append(violations, Violation{Image: img, Reason: reason})
+
+			continue
+		}
+		digests[img] = dgst
+	}
+
+	if len(violations) > 0 {
+		return nil, &ViolationError{Violations: violations}
+	}
+
+	return digests, nil
+}
+
+// allowed reports whether pulled lies below one of the allowed registries.
+func (c *Config) allowed(pulled string) bool {
+	if len(c.AllowedRegistries) == 0 {
+		return true
+	}
+
+	name := normalizedName(pulled)
+	for _, scope := range c.AllowedRegistries {
+		if within(name, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signatureRule returns the most specific signature rule matching img.
+func (c *Config) signatureRule(img string) *SignatureRule {
+	name := normalizedName(img)
+
+	var match *SignatureRule
+	for i, rule := range c.Signatures {
+		if within(name, rule.Scope) && (match == nil || len(rule.Scope) > len(match.Scope)) {
+			match = &c.Signatures[i]
+		}
+	}
+
+	return match
+}
+
+// policyFor returns the signature policy img is verified with, or nil when no
+// signature is required for it.
+func (c *Config) policyFor(img string) (*signature.Policy, error) {
+	rule := c.signatureRule(img)
+	if rule == nil && c.PolicyFile == "" {
+		return nil, nil
+	}
+
+	policy := &signature.Policy{Default: signature.PolicyRequirements{signature.NewPRInsecureAcceptAnything()}}
+	if c.PolicyFile != "" {
+		var err error
+		if policy, err = signature.NewPolicyFromFile(c.PolicyFile); err != nil {
+			return nil, fmt.Errorf("failed to load policy file %s: %w", c.PolicyFile, err)
+		}
+	}
+	if rule == nil {
+		return policy, nil
+	}
+
+	// The signature names the repository the template refers to, also when the
+	// image is pulled from a mirror. Pulled images are always evaluated against
+	// this requirement; see inspect.
+	identity, err := signature.NewPRMExactRepository(normalizedName(img))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image %s: %w", img, err)
+	}
+	requirement, err := signature.NewPRSigstoreSigned(
+		signature.PRSigstoreSignedWithKeyPaths(rule.Keys),
+		signature.PRSigstoreSignedWithSignedIdentity(identity),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature rule for %s: %w", rule.Scope, err)
+	}
+	policy.Default = signature.PolicyRequirements{requirement}
+	policy.Transports = nil
+
+	return policy, nil
+}
+
+// inspect resolves the digest of the image pulled from pulled and, with a policy,
+// verifies it. A verification failure is returned as reason.
+func (c *Config) inspect(ctx context.Context, sys *imageTypes.SystemContext, pulled string, policy *signature.Policy) (digest.Digest, string, error) {
+	resolve := c.reference
+	if resolve == nil {
+		resolve = dockerReference
+	}
+	ref, err := resolve(pulled)
+	if err != nil {
+		return "", "", err
+	}
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	unparsed := image.UnparsedInstance(src, nil)
+	blob, _, err := unparsed.Manifest(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get manifest: %w", err)
+	}
+	dgst, err := manifest.Digest(blob)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to compute digest: %w", err)
+	}
+
+	if policy == nil {
+		return dgst, "", nil
+	}
+
+	policyCtx, err := signature.NewPolicyContext(policy)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create policy context: %w", err)
+	}
+	defer func() {
+		_ = policyCtx.Destroy()
+	}()
+
+	if _, err := policyCtx.IsRunningImageAllowed(ctx, unparsed); err != nil {
+		return "", fmt.Sprintf("signature verification failed: %v", err), nil
+	}
+
+	return dgst, "", nil
+}
+
+// systemContext returns the settings images are inspected with and a function
+// removing what it created.
+func (c *Config) systemContext() (*imageTypes.SystemContext, func(), error) {
+	sys := &imageTypes.SystemContext{AuthFilePath: os.Getenv("REGISTRY_AUTH_FILE")}
+	if len(c.Signatures) == 0 {
+		return sys, func() {}, nil
+	}
+
+	dir, err := os.MkdirTemp("", registriesDirPrefix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create registries configuration: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+	if err := os.WriteFile(filepath.Join(dir, registriesConfigFile), []byte(registriesConfig), 0o600); err != nil {
+		cleanup()
+
+		return nil, nil, fmt.Errorf("failed to write registries configuration: %w", err)
+	}
+	sys.RegistriesDirPath = dir
+
+	return sys, cleanup, nil
+}
+
+// Pin returns img referring to exactly dgst.
+func Pin(img string, dgst digest.Digest) (string, error) {
+	named, err := reference.ParseNormalizedNamed(img)
+	if err != nil {
+		return "", fmt.Errorf("invalid image %s: %w", img, err)
+	}
+
+	pinned, err := reference.WithDigest(reference.TrimNamed(named), dgst)
+	if err != nil {
+		return "", fmt.Errorf("failed to pin image %s: %w", img, err)
+	}
+
+	return pinned.String(), nil
+}
+
+// PinManifest replaces the container images of a rendered pod template with the
+// references they are pinned to.
+func PinManifest(rendered []byte, pins map[string]string) []byte {
+	if len(pins) == 0 {
+		return rendered
+	}
+
+	return mirror.RewriteImages(rendered, func(img string) string {
+		if pinned, ok := pins[img]; ok {
+			return pinned
+		}
+
+		return img
+	})
+}
+
+func dockerReference(pulled string) (imageTypes.ImageReference, error) {
+	return docker.ParseReference("//" + pulled)
+}
+
+// normalizedName returns the repository of img with short names expanded, e.g.
+// docker.io/library/postgres for postgres:16.
+func normalizedName(img string) string {
+	named, err := reference.ParseNormalizedNamed(img)
+	if err != nil {
+		return img
+	}
+
+	return named.Name()
+}
+
+// within reports whether the repository name lies below scope, a registry host
+// or repository prefix.
+func within(name, scope string) bool {
+	scope = strings.TrimSuffix(scope, "/")
+
+	return name == scope || strings.HasPrefix(name, scope+"/")
+}
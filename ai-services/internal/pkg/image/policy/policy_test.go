@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/oci/layout"
+	imageTypes "go.podman.io/image/v5/types"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+)
+
+const testTag = "v1"
+
+// unsignedLayout writes an OCI image layout holding one unsigned image and
+// returns its directory and manifest digest.
+func unsignedLayout(t *testing.T) (string, digest.Digest) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+
+	blob := func(mediaType string, content []byte) map[string]any {
+		sum := sha256.Sum256(content)
+		encoded := hex.EncodeToString(sum[:])
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "blobs", "sha256", encoded), content, 0o644))
+
+		return map[string]any{"mediaType": mediaType, "digest": "sha256:" + encoded, "size": len(content)}
+	}
+
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"config":        blob("application/vnd.oci.empty.v1+json", []byte("{}")),
+		"layers":        []any{},
+	})
+	require.NoError(t, err)
+	desc := blob("application/vnd.oci.image.manifest.v1+json", manifest)
+	desc["annotations"] = map[string]string{"org.opencontainers.image.ref.name": testTag}
+
+	index, err := json.Marshal(map[string]any{"schemaVersion": 2, "manifests": []any{desc}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), index, 0o644))
+
+	return dir, digest.Digest(desc["digest"].(string))
+}
+
+// layoutConfig returns a policy whose images are all read from dir.
+func layoutConfig(dir string, config Config) *Config {
+	config.reference = func(string) (imageTypes.ImageReference, error) {
+		return layout.NewReference(dir, testTag)
+	}
+
+	return &config
+}
+
+func TestEnforceAllowedRegistries(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+	require.NoError(t, (&mirror.Config{Mirrors: []mirror.Mirror{{Source: "quay.io", Mirror: "mirror.local:5000"}}}).Save())
+
+	config := &Config{AllowedRegistries: []string{"icr.io/ai-services", "mirror.local:5000"}}
+	digests, err := config.Enforce(context.Background(), []string{
+		"icr.io/ai-services/vllm:v1",
+		"quay.io/ai-services/opensearch:3",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, digests)
+
+	_, err = config.Enforce(context.Background(), []string{"icr.io/other/app:v1", "postgres:16"})
+	var violation *ViolationError
+	require.True(t, errors.As(err, &violation))
+	require.Len(t, violation.Violations, 2)
+	assert.Equal(t, "icr.io/other/app:v1", violation.Violations[0].Image)
+	assert.Equal(t, "postgres:16", violation.Violations[1].Image)
+	assert.Contains(t, err.Error(), "docker.io/library/postgres is not below any of the allowed registries")
+}
+
+func TestEnforceSignatures(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+	dir, dgst := unsignedLayout(t)
+	key := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(key, []byte("-----BEGIN PUBLIC KEY-----\n-----END PUBLIC KEY-----\n"), 0o644))
+
+	config := layoutConfig(dir, Config{Signatures: []SignatureRule{{Scope: "icr.io/ai-services", Keys: []string{key}}}})
+
+	t.Run("rejects unsigned images in scope", func(t *testing.T) {
+		_, err := config.Enforce(context.Background(), []string{"icr.io/ai-services/vllm:v1"})
+		var violation *ViolationError
+		require.True(t, errors.As(err, &violation))
+		require.Len(t, violation.Violations, 1)
+		assert.Contains(t, violation.Violations[0].Reason, "signature verification failed")
+	})
+
+	t.Run("ignores images out of scope", func(t *testing.T) {
+		digests, err := config.Enforce(context.Background(), []string{"icr.io/other/app:v1"})
+		require.NoError(t, err)
+		assert.Empty(t, digests)
+	})
+
+	t.Run("resolves digests when pinning", func(t *testing.T) {
+		pinning := layoutConfig(dir, Config{PinDigests: true})
+		digests, err := pinning.Enforce(context.Background(), []string{"icr.io/other/app:v1"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]digest.Digest{"icr.io/other/app:v1": dgst}, digests)
+	})
+}
+
+func TestEnforcePolicyFile(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+	dir, _ := unsignedLayout(t)
+	policyFile := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`{"default":[{"type":"reject"}]}`), 0o644))
+
+	_, err := layoutConfig(dir, Config{PolicyFile: policyFile}).Enforce(context.Background(), []string{"icr.io/ai-services/vllm:v1"})
+	var violation *ViolationError
+	require.True(t, errors.As(err, &violation))
+	assert.Equal(t, "icr.io/ai-services/vllm:v1", violation.Violations[0].Image)
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+
+	config, err := Load()
+	require.NoError(t, err)
+	assert.False(t, config.Enabled())
+
+	path := filepath.Join(os.Getenv("AI_SERVICES_BASE_DIR"), "image-policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("allowed_registries: [icr.io]\npin_digests: true\n"), 0o644))
+	config, err = Load()
+	require.NoError(t, err)
+	assert.True(t, config.Enabled())
+	assert.Equal(t, []string{"icr.io"}, config.AllowedRegistries)
+
+	require.NoError(t, os.WriteFile(path, []byte("signatures:\n- scope: icr.io\n"), 0o644))
+	_, err = Load()
+	assert.ErrorContains(t, err, "needs a scope and at least one key")
+}
+
+func TestPin(t *testing.T) {
+	dgst := digest.Digest("sha256:" + hex.EncodeToString(make([]byte, sha256.Size)))
+
+	pinned, err := Pin("postgres:16", dgst)
+	require.NoError(t, err)
+	assert.Equal(t, "docker.io/library/postgres@"+dgst.String(), pinned)
+
+	rendered := []byte("spec:\n  containers:\n  - image: postgres:16\n  - image: icr.io/other:v1\n")
+	assert.Equal(t,
+		"spec:\n  containers:\n  - image: docker.io/library/postgres@"+dgst.String()+"\n  - image: icr.io/other:v1\n",
+		string(PinManifest(rendered, map[string]string{"postgres:16": pinned})))
+}
@@ -0,0 +1,44 @@
+package modelstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// DownloadConfig configures model downloads. It lives in the base directory, so
+// the CLI and the catalog API server share it.
+type DownloadConfig struct {
+	// Endpoint is a Hugging Face-compatible hub to download from, such as a mirror.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Concurrency is the number of files downloaded at the same time.
+	Concurrency int `json:"concurrency,omitempty"`
+}
+
+// LoadDownloadConfig reads the model download configuration. A missing
+// configuration means the defaults.
+func LoadDownloadConfig() (*DownloadConfig, error) {
+	path := utils.GetModelDownloadConfigPath()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &DownloadConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model download configuration: %w", err)
+	}
+
+	var config DownloadConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse model download configuration %s: %w", path, err)
+	}
+	if config.Concurrency < 0 {
+		return nil, fmt.Errorf("invalid model download configuration %s: concurrency must not be negative", path)
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,550 @@
+package modelstore
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // git blob ids are SHA-1; used to compare, not to secure
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	// DefaultEndpoint is the Hugging Face Hub.
+	DefaultEndpoint = "https://huggingface.co"
+	// DefaultConcurrency is the number of files downloaded at the same time.
+	DefaultConcurrency = 4
+
+	// EndpointEnv and TokenEnv are read by the Hugging Face tools as well.
+	EndpointEnv = "HF_ENDPOINT"
+	TokenEnv    = "HF_TOKEN"
+
+	defaultRevision         = "main"
+	defaultProgressInterval = time.Second
+	// fileAttempts is how often a file is requested before its download fails;
+	// every attempt resumes where the previous one stopped.
+	fileAttempts = 3
+	partSuffix   = ".part"
+)
+
+// partialDir keeps the files of a model that are still being downloaded.
+var partialDir = filepath.Join(cacheDir, "ai-services")
+
+var errChecksum = errors.New("checksum mismatch")
+
+// Progress is the state of a download.
+type Progress struct {
+	// Downloaded counts the bytes present, including resumed and unchanged files.
+	Downloaded int64
+	Total      int64
+	FilesDone  int
+	Files      int
+}
+
+// String renders the progress, e.g. "3.2 GiB / 16.0 GiB (20%), 4/12 files".
+func (p Progress) String() string {
+	percent := 0
+	if p.Total > 0 {
+		percent = int(p.Downloaded * 100 / p.Total) //nolint:mnd // percentage
+	}
+
+	return fmt.Sprintf("%s / %s (%d%%), %d/%d files",
+		utils.FormatBytes(p.Downloaded), utils.FormatBytes(p.Total), percent, p.FilesDone, p.Files)
+}
+
+// DownloadOptions configures a download. Empty fields fall back to the
+// environment, then to the model download configuration, then to the defaults.
+type DownloadOptions struct {
+	Endpoint    string
+	Token       string
+	Revision    string
+	Concurrency int
+	// Progress is called periodically while downloading, and once at the end.
+	Progress         func(Progress)
+	ProgressInterval time.Duration
+	Client           *http.Client
+}
+
+func (o *DownloadOptions) setDefaults() error {
+	config, err := LoadDownloadConfig()
+	if err != nil {
+		return err
+	}
+
+	if o.Endpoint == "" {
+		o.Endpoint = os.Getenv(EndpointEnv)
+	}
+	if o.Endpoint == "" {
+		o.Endpoint = config.Endpoint
+	}
+	if o.Endpoint == "" {
+		o.Endpoint = DefaultEndpoint
+	}
+	o.Endpoint = strings.TrimSuffix(o.Endpoint, "/")
+
+	if o.Token == "" {
+		o.Token = os.Getenv(TokenEnv)
+	}
+	if o.Revision == "" {
+		o.Revision = defaultRevision
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = config.Concurrency
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.ProgressInterval <= 0 {
+		o.ProgressInterval = defaultProgressInterval
+	}
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+
+	return nil
+}
+
+// modelDownload is a model being downloaded.
+type modelDownload struct {
+	model     string
+	modelsDir string
+	dir       string
+	revision  string
+	mu        sync.Mutex
+	files     []File
+}
+
+// fileDownload is a file of a model being downloaded.
+type fileDownload struct {
+	model *modelDownload
+	file  sibling
+}
+
+// progress counts the bytes and files of a download.
+type progress struct {
+	downloaded atomic.Int64
+	filesDone  atomic.Int64
+	total      int64
+	files      int
+}
+
+func (p *progress) snapshot() Progress {
+	return Progress{
+		Downloaded: p.downloaded.Load(),
+		Total:      p.total,
+		FilesDone:  int(p.filesDone.Load()),
+		Files:      p.files,
+	}
+}
+
+// Download downloads models into modelsDir from a Hugging Face-compatible hub.
+// Files of all models are downloaded in parallel up to the configured
+// concurrency. Interrupted downloads resume where they stopped, and every file is
+// verified against the checksum the hub reports before the model is recorded as
+// complete. Models that are already complete are skipped.
+func Download(ctx context.Context, modelsDir string, models []string, opts DownloadOptions) error {
+	if err := opts.setDefaults(); err != nil {
+		return err
+	}
+	client := &hubClient{endpoint: opts.Endpoint, token: opts.Token, http: opts.Client}
+
+	var (
+		downloads []*modelDownload
+		files     []fileDownload
+		p         progress
+	)
+	for _, model := range models {
+		if Complete(modelsDir, model) {
+			logger.InfofCtx(ctx, "Model %s is already present in %s, skipping download\n", model, modelsDir)
+
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(model)) {
+			return fmt.Errorf("invalid model id %s", model)
+		}
+
+		info, err := client.info(ctx, model, opts.Revision)
+		if err != nil {
+			return err
+		}
+
+		m := &modelDownload{model: model, modelsDir: modelsDir, dir: Dir(modelsDir, model), revision: info.SHA}
+		downloads = append(downloads, m)
+		for _, s := range info.Siblings {
+			if !filepath.IsLocal(filepath.FromSlash(s.Path)) {
+				return fmt.Errorf("model %s has invalid file path %s", model, s.Path)
+			}
+			files = append(files, fileDownload{model: m, file: s})
+			p.total += s.size()
+		}
+		logger.InfofCtx(ctx, "Downloading model %s (%s, %d files) from %s\n", model, utils.FormatBytes(sizeOf(info.Siblings)), len(info.Siblings), opts.Endpoint)
+	}
+	p.files = len(files)
+
+	if len(files) > 0 {
+		if err := fetchAll(ctx, client, files, &p, opts); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range downloads {
+		if err := m.finish(); err != nil {
+			return err
+		}
+		logger.InfofCtx(ctx, "Model %s downloaded to %s\n", m.model, m.dir)
+	}
+
+	return nil
+}
+
+// fetchAll downloads files with up to opts.Concurrency workers, stopping at the
+// first failure.
+func fetchAll(ctx context.Context, client *hubClient, files []fileDownload, p *progress, opts DownloadOptions) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stopReporting := reportProgress(ctx, p, opts)
+	defer stopReporting()
+
+	jobs := make(chan fileDownload)
+	var wg sync.WaitGroup
+	for range min(opts.Concurrency, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				f, err := fetch(ctx, client, job, p)
+				if err != nil {
+					cancel(fmt.Errorf("failed to download %s of model %s: %w", job.file.Path, job.model.model, err))
+
+					continue
+				}
+				job.model.add(f)
+				p.filesDone.Add(1)
+			}
+		}()
+	}
+
+	for _, f := range files {
+		select {
+		case jobs <- f:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reportProgress calls opts.Progress every interval until the returned function
+// is called, which reports once more.
+func reportProgress(ctx context.Context, p *progress, opts DownloadOptions) func() {
+	if opts.Progress == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(opts.ProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				opts.Progress(p.snapshot())
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		opts.Progress(p.snapshot())
+	}
+}
+
+// fetch places one file in the model directory. A file that is already present
+// and matches is kept; otherwise it is downloaded next to the model and moved into
+// place once verified.
+func fetch(ctx context.Context, client *hubClient, job fileDownload, p *progress) (File, error) {
+	dst := filepath.Join(job.model.dir, filepath.FromSlash(job.file.Path))
+
+	if info, err := os.Stat(dst); err == nil && info.Size() == job.file.size() {
+		f, err := verifyExisting(dst, job.file)
+		if err == nil {
+			p.downloaded.Add(f.Size)
+
+			return f, nil
+		}
+		if !errors.Is(err, errChecksum) {
+			return File{}, err
+		}
+	}
+
+	part := filepath.Join(job.model.dir, partialDir, filepath.FromSlash(job.file.Path)+partSuffix)
+	if err := os.MkdirAll(filepath.Dir(part), dirPermission); err != nil {
+		return File{}, fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	var (
+		f   File
+		err error
+	)
+	for attempt := 1; attempt <= fileAttempts; attempt++ {
+		f, err = resume(ctx, client, job, part, p)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		if errors.Is(err, errChecksum) {
+			_ = os.Remove(part)
+		}
+		logger.WarningfCtx(ctx, "Download of %s of model %s failed (attempt %d/%d): %v\n", job.file.Path, job.model.model, attempt, fileAttempts, err)
+	}
+	if err != nil {
+		return File{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), dirPermission); err != nil {
+		return File{}, fmt.Errorf("failed to create directory for %s: %w", dst, err)
+	}
+	if err := os.Rename(part, dst); err != nil {
+		return File{}, fmt.Errorf("failed to move %s into place: %w", dst, err)
+	}
+
+	return f, nil
+}
+
+// resume continues downloading a file into part and verifies it.
+func resume(ctx context.Context, client *hubClient, job fileDownload, part string, p *progress) (File, error) {
+	out, err := os.OpenFile(part, os.O_CREATE|os.O_RDWR, filePermission)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to open %s: %w", part, err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	v := newVerifier(job.file)
+	offset, err := io.Copy(v, out)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read %s: %w", part, err)
+	}
+	counted := offset
+	p.downloaded.Add(counted)
+	// Bytes of a failed attempt are counted again by the next one.
+	defer func() {
+		p.downloaded.Add(-counted)
+	}()
+
+	if offset < job.file.size() {
+		n, err := download(ctx, client, job, out, offset, v, p)
+		counted += n
+		if err != nil {
+			return File{}, err
+		}
+	}
+
+	f, err := v.verify(job.file)
+	if err != nil {
+		_ = out.Truncate(0)
+
+		return File{}, err
+	}
+	counted = 0 // the file is complete; keep its bytes counted
+
+	return f, out.Close()
+}
+
+// download appends the file from offset to out. It returns the bytes written
+// since offset, restarting from the beginning when the server ignores the range.
+func download(ctx context.Context, client *hubClient, job fileDownload, out *os.File, offset int64, v *verifier, p *progress) (int64, error) {
+	resp, err := client.file(ctx, job.model.model, job.model.revision, job.file.Path, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var written int64
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		if offset > 0 {
+			// The server sends the whole file; drop what was resumed.
+			if err := out.Truncate(0); err != nil {
+				return 0, fmt.Errorf("failed to restart download: %w", err)
+			}
+			if _, err := out.Seek(0, io.SeekStart); err != nil {
+				return 0, fmt.Errorf("failed to restart download: %w", err)
+			}
+			v.reset()
+			p.downloaded.Add(-offset)
+			written = -offset
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is larger than the file on the hub.
+		return 0, errChecksum
+	default:
+		if err := client.checkStatus(resp, job.model.model); err != nil {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("unexpected HTTP %d", resp.StatusCode)
+	}
+
+	n, err := io.Copy(io.MultiWriter(out, v, progressWriter{p}), resp.Body)
+	written += n
+	if err != nil {
+		return written, fmt.Errorf("download interrupted: %w", err)
+	}
+
+	return written, nil
+}
+
+// verifyExisting checks a file already in the model directory.
+func verifyExisting(path string, file sibling) (File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return File{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	v := newVerifier(file)
+	if _, err := io.Copy(v, f); err != nil {
+		return File{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return v.verify(file)
+}
+
+// verifier hashes a file as it is written: SHA-256 for the metadata and LFS
+// files, and the git blob id for files stored in git.
+type verifier struct {
+	size   int64
+	sha256 hash.Hash
+	blob   hash.Hash
+	file   sibling
+}
+
+func newVerifier(file sibling) *verifier {
+	v := &verifier{file: file}
+	v.reset()
+
+	return v
+}
+
+func (v *verifier) reset() {
+	v.size = 0
+	v.sha256 = sha256.New()
+	v.blob = nil
+	if v.file.LFS == nil && v.file.BlobID != "" {
+		v.blob = sha1.New() //nolint:gosec // git blob ids are SHA-1
+		fmt.Fprintf(v.blob, "blob %d\x00", v.file.Size)
+	}
+}
+
+func (v *verifier) Write(b []byte) (int, error) {
+	v.size += int64(len(b))
+	v.sha256.Write(b)
+	if v.blob != nil {
+		v.blob.Write(b)
+	}
+
+	return len(b), nil
+}
+
+// verify compares the hashed content with the checksum the hub reported.
+func (v *verifier) verify(file sibling) (File, error) {
+	sum := hex.EncodeToString(v.sha256.Sum(nil))
+
+	if v.size != file.size() {
+		return File{}, fmt.Errorf("%w: %s has %d bytes, expected %d", errChecksum, file.Path, v.size, file.size())
+	}
+	if file.LFS != nil && !strings.EqualFold(sum, file.LFS.SHA256) {
+		return File{}, fmt.Errorf("%w: %s has SHA-256 %s, expected %s", errChecksum, file.Path, sum, file.LFS.SHA256)
+	}
+	if v.blob != nil {
+		if id := hex.EncodeToString(v.blob.Sum(nil)); !strings.EqualFold(id, file.BlobID) {
+			return File{}, fmt.Errorf("%w: %s has blob id %s, expected %s", errChecksum, file.Path, id, file.BlobID)
+		}
+	}
+
+	return File{Path: file.Path, Size: v.size, SHA256: sum}, nil
+}
+
+// progressWriter counts downloaded bytes.
+type progressWriter struct {
+	p *progress
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	w.p.downloaded.Add(int64(len(b)))
+
+	return len(b), nil
+}
+
+func (m *modelDownload) add(f File) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files = append(m.files, f)
+}
+
+// finish records a downloaded model as complete and drops its download state.
+func (m *modelDownload) finish() error {
+	sort.Slice(m.files, func(i, j int) bool {
+		return m.files[i].Path < m.files[j].Path
+	})
+
+	metadata := &Metadata{
+		Model:    m.model,
+		Revision: m.revision,
+		Source:   SourceHuggingFace,
+		StoredAt: time.Now().UTC(),
+		Files:    m.files,
+	}
+	if err := WriteMetadata(m.modelsDir, metadata); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Join(m.dir, partialDir)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warningf("Failed to remove download state of model %s: %v\n", m.model, err)
+	}
+
+	return nil
+}
+
+func sizeOf(files []sibling) int64 {
+	var size int64
+	for _, f := range files {
+		size += f.size()
+	}
+
+	return size
+}
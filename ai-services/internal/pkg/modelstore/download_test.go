@@ -0,0 +1,176 @@
+package modelstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // git blob ids are SHA-1
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testModel  = "ibm-granite/granite-embedding"
+	testCommit = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// fakeHub serves one model the way the Hugging Face Hub does.
+type fakeHub struct {
+	files map[string][]byte
+	// corrupt is served instead of the real content when set.
+	corrupt map[string][]byte
+	served  atomic.Int64
+}
+
+func (h *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/models/"+testModel+"/revision/main":
+		info := repoInfo{SHA: testCommit}
+		for name, content := range h.files {
+			s := sibling{Path: name, Size: int64(len(content))}
+			if strings.HasSuffix(name, ".safetensors") {
+				sum := sha256.Sum256(content)
+				s.LFS = &lfsInfo{SHA256: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+				s.BlobID = "pointer"
+			} else {
+				s.BlobID = blobID(content)
+			}
+			info.Siblings = append(info.Siblings, s)
+		}
+		_ = json.NewEncoder(w).Encode(info)
+	case strings.HasPrefix(r.URL.Path, "/"+testModel+"/resolve/"+testCommit+"/"):
+		name := strings.TrimPrefix(r.URL.Path, "/"+testModel+"/resolve/"+testCommit+"/")
+		content, ok := h.files[name]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+		if bad, ok := h.corrupt[name]; ok {
+			content = bad
+		}
+		cw := &countingResponseWriter{ResponseWriter: w, n: &h.served}
+		http.ServeContent(cw, r, name, time.Time{}, bytes.NewReader(content))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	n *atomic.Int64
+}
+
+func (w *countingResponseWriter) Write(b []byte) (int, error) {
+	w.n.Add(int64(len(b)))
+
+	return w.ResponseWriter.Write(b)
+}
+
+func blobID(content []byte) string {
+	h := sha1.New() //nolint:gosec // git blob ids are SHA-1
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newFakeHub(t *testing.T) (*fakeHub, *httptest.Server) {
+	t.Helper()
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+	t.Setenv(EndpointEnv, "")
+	t.Setenv(TokenEnv, "")
+
+	hub := &fakeHub{files: map[string][]byte{
+		"config.json":       []byte(`{"hidden": 1024}`),
+		"model.safetensors": bytes.Repeat([]byte("weights-"), 1024),
+	}}
+	server := httptest.NewServer(hub)
+	t.Cleanup(server.Close)
+
+	return hub, server
+}
+
+func TestDownload(t *testing.T) {
+	hub, server := newFakeHub(t)
+	modelsDir := t.TempDir()
+
+	var last Progress
+	opts := DownloadOptions{Endpoint: server.URL, Concurrency: 2, Progress: func(p Progress) { last = p }}
+	require.NoError(t, Download(context.Background(), modelsDir, []string{testModel}, opts))
+
+	metadata, err := ReadMetadata(modelsDir, testModel)
+	require.NoError(t, err)
+	assert.Equal(t, testCommit, metadata.Revision)
+	assert.Equal(t, SourceHuggingFace, metadata.Source)
+	require.Len(t, metadata.Files, 2)
+	assert.Equal(t, "config.json", metadata.Files[0].Path)
+
+	assert.Equal(t, last.Total, last.Downloaded)
+	assert.Equal(t, 2, last.FilesDone)
+	assert.NoDirExists(t, filepath.Join(Dir(modelsDir, testModel), partialDir))
+
+	bad, err := Verify(modelsDir, metadata)
+	require.NoError(t, err)
+	assert.Empty(t, bad)
+
+	// A complete model is not requested again.
+	served := hub.served.Load()
+	require.NoError(t, Download(context.Background(), modelsDir, []string{testModel}, opts))
+	assert.Equal(t, served, hub.served.Load())
+}
+
+func TestDownloadResumes(t *testing.T) {
+	hub, server := newFakeHub(t)
+	modelsDir := t.TempDir()
+	dir := Dir(modelsDir, testModel)
+
+	// An interrupted download left half of the weights behind, and the config was
+	// placed by an earlier 'hf download'.
+	weights := hub.files["model.safetensors"]
+	part := filepath.Join(dir, partialDir, "model.safetensors"+partSuffix)
+	require.NoError(t, os.MkdirAll(filepath.Dir(part), 0o755))
+	require.NoError(t, os.WriteFile(part, weights[:len(weights)/2], 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), hub.files["config.json"], 0o644))
+
+	require.NoError(t, Download(context.Background(), modelsDir, []string{testModel}, DownloadOptions{Endpoint: server.URL}))
+
+	assert.Equal(t, int64(len(weights)-len(weights)/2), hub.served.Load(), "only the missing bytes are downloaded")
+	got, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, weights, got)
+}
+
+func TestDownloadRejectsCorruptFiles(t *testing.T) {
+	hub, server := newFakeHub(t)
+	modelsDir := t.TempDir()
+	hub.corrupt = map[string][]byte{"config.json": []byte(`{"hidden": 2048}`)}
+
+	err := Download(context.Background(), modelsDir, []string{testModel}, DownloadOptions{Endpoint: server.URL})
+	require.ErrorIs(t, err, errChecksum)
+	assert.False(t, Complete(modelsDir, testModel))
+	assert.NoFileExists(t, filepath.Join(Dir(modelsDir, testModel), "config.json"))
+}
+
+func TestDownloadEndpointFromConfig(t *testing.T) {
+	_, server := newFakeHub(t)
+	modelsDir := t.TempDir()
+
+	config := fmt.Sprintf("endpoint: %s/\nconcurrency: 1\n", server.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(os.Getenv("AI_SERVICES_BASE_DIR"), "model-download.yaml"), []byte(config), 0o644))
+
+	require.NoError(t, Download(context.Background(), modelsDir, []string{testModel}, DownloadOptions{}))
+	assert.True(t, Complete(modelsDir, testModel))
+}
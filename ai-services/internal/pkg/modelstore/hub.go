@@ -0,0 +1,126 @@
+package modelstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// repoInfo is the part of the Hub model API response the downloader needs.
+type repoInfo struct {
+	// SHA is the commit the revision resolved to.
+	SHA      string    `json:"sha"`
+	Siblings []sibling `json:"siblings"`
+}
+
+// sibling is a file of a model repository.
+type sibling struct {
+	Path string `json:"rfilename"`
+	Size int64  `json:"size"`
+	// BlobID is the git blob id of the file, or of the LFS pointer for LFS files.
+	BlobID string   `json:"blobId"`
+	LFS    *lfsInfo `json:"lfs,omitempty"`
+}
+
+type lfsInfo struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// size returns the size of the file content.
+func (s sibling) size() int64 {
+	if s.LFS != nil {
+		return s.LFS.Size
+	}
+
+	return s.Size
+}
+
+// hubClient talks to a Hugging Face-compatible hub.
+type hubClient struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+// info resolves a revision of a model and lists its files with their checksums.
+func (c *hubClient) info(ctx context.Context, model, revision string) (*repoInfo, error) {
+	u := fmt.Sprintf("%s/api/models/%s/revision/%s?blobs=true", c.endpoint, escapePath(model), url.PathEscape(revision))
+
+	resp, err := c.get(ctx, u, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err := c.checkStatus(resp, model); err != nil {
+		return nil, err
+	}
+
+	var info repoInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of model %s from %s: %w", model, c.endpoint, err)
+	}
+	if info.SHA == "" {
+		return nil, fmt.Errorf("%s returned no commit for model %s revision %s", c.endpoint, model, revision)
+	}
+
+	return &info, nil
+}
+
+// file requests a file of a model at a commit, starting at offset.
+func (c *hubClient) file(ctx context.Context, model, commit, path string, offset int64) (*http.Response, error) {
+	u := fmt.Sprintf("%s/%s/resolve/%s/%s", c.endpoint, escapePath(model), url.PathEscape(commit), escapePath(path))
+
+	return c.get(ctx, u, offset)
+}
+
+func (c *hubClient) get(ctx context.Context, u string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", c.endpoint, err)
+	}
+
+	return resp, nil
+}
+
+// checkStatus turns an error response for a model into an error.
+func (c *hubClient) checkStatus(resp *http.Response, model string) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("access to model %s denied by %s; set %s for gated or private models", model, c.endpoint, TokenEnv)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("model %s not found on %s", model, c.endpoint)
+	case resp.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("%s returned HTTP %d for model %s", c.endpoint, resp.StatusCode, model)
+	}
+
+	return nil
+}
+
+// escapePath escapes every segment of a slash-separated path.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	return strings.Join(segments, "/")
+}
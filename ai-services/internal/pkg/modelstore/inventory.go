@@ -0,0 +1,395 @@
+package modelstore
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // git blob ids are SHA-1; used to compare, not to secure
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hfDownloadDir is where 'hf download --local-dir' records, for every file, the
+// commit it was downloaded from, its ETag and the download time.
+var hfDownloadDir = filepath.Join(cacheDir, "huggingface", "download")
+
+const (
+	hfMetadataSuffix = ".metadata"
+	// The ETag of a file stored with Git LFS is its SHA-256 checksum; other files
+	// have the git blob id, a SHA-1 checksum, as ETag.
+	sha256HexLen = 64
+	sha1HexLen   = 40
+)
+
+// Verification sources of VerifyResult.
+const (
+	VerifiedAgainstMetadata    = "metadata"
+	VerifiedAgainstHuggingFace = "huggingface"
+)
+
+// CachedModel is a model found in the models directory.
+type CachedModel struct {
+	Model    string
+	Revision string
+	// Source is how the model was placed: SourceHuggingFace or SourceOffline. It
+	// is empty for models downloaded before metadata was recorded.
+	Source string
+	// Size is the disk usage of the model directory.
+	Size int64
+	// StoredAt is when the model was downloaded or imported.
+	StoredAt time.Time
+}
+
+// VerifyResult is the outcome of verifying a model.
+type VerifyResult struct {
+	Model string
+	// Against is VerifiedAgainstMetadata or VerifiedAgainstHuggingFace.
+	Against string
+	Checked int
+	// Failed lists files that are missing or do not match.
+	Failed []string
+	// Unverifiable lists files without a checksum to verify against.
+	Unverifiable []string
+}
+
+// hfFile is the download record of a file written by hf.
+type hfFile struct {
+	commit string
+	etag   string
+	time   time.Time
+}
+
+// List returns the models in modelsDir. Models are the directories of their
+// Hugging Face id: <org>/<name>, or <name> for ids without an organisation.
+func List(modelsDir string) ([]CachedModel, error) {
+	ids, err := modelIDs(modelsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]CachedModel, 0, len(ids))
+	for _, id := range ids {
+		model, err := inspect(modelsDir, id)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, *model)
+	}
+
+	return models, nil
+}
+
+// VerifyModel checks the files of a model against its metadata or, for models
+// downloaded before metadata was recorded, against the ETags hf recorded.
+func VerifyModel(modelsDir, model string) (*VerifyResult, error) {
+	result := &VerifyResult{Model: model}
+
+	metadata, err := ReadMetadata(modelsDir, model)
+	if err == nil {
+		failed, err := Verify(modelsDir, metadata)
+		if err != nil {
+			return nil, err
+		}
+		result.Against = VerifiedAgainstMetadata
+		result.Checked = len(metadata.Files)
+		result.Failed = failed
+
+		return result, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	dir := Dir(modelsDir, model)
+	files, err := readHFFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("model %s has no manifest to verify against", model)
+	}
+
+	result.Against = VerifiedAgainstHuggingFace
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		ok, err := matchesETag(filepath.Join(dir, filepath.FromSlash(p)), files[p].etag)
+		switch {
+		case errors.Is(err, errNoChecksum):
+			result.Unverifiable = append(result.Unverifiable, p)
+		case errors.Is(err, fs.ErrNotExist):
+			result.Checked++
+			result.Failed = append(result.Failed, p)
+		case err != nil:
+			return nil, err
+		default:
+			result.Checked++
+			if !ok {
+				result.Failed = append(result.Failed, p)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Remove deletes a model from modelsDir, and its organisation directory when it
+// holds no other model.
+func Remove(modelsDir, model string) error {
+	if !filepath.IsLocal(filepath.FromSlash(model)) {
+		return fmt.Errorf("invalid model id %s", model)
+	}
+	dir := Dir(modelsDir, model)
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove model %s: %w", model, err)
+	}
+
+	if parent := filepath.Dir(dir); parent != filepath.Clean(modelsDir) {
+		// Fails harmlessly when other models of the organisation remain.
+		_ = os.Remove(parent)
+	}
+
+	return nil
+}
+
+// modelIDs finds the models in modelsDir. A top-level directory holding files is
+// a model; otherwise each of its subdirectories is one.
+func modelIDs(modelsDir string) ([]string, error) {
+	entries, err := os.ReadDir(modelsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models directory: %w", err)
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		children, err := os.ReadDir(filepath.Join(modelsDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read models directory: %w", err)
+		}
+
+		var subdirs []string
+		holdsFiles := false
+		for _, c := range children {
+			switch {
+			case c.IsDir() && c.Name() == cacheDir:
+				holdsFiles = true
+			case c.IsDir():
+				subdirs = append(subdirs, e.Name()+"/"+c.Name())
+			default:
+				holdsFiles = true
+			}
+		}
+
+		if holdsFiles {
+			ids = append(ids, e.Name())
+		} else {
+			ids = append(ids, subdirs...)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// inspect collects the details of one model.
+func inspect(modelsDir, id string) (*CachedModel, error) {
+	dir := Dir(modelsDir, id)
+	model := &CachedModel{Model: id}
+
+	size, modTime, err := diskUsage(dir)
+	if err != nil {
+		return nil, err
+	}
+	model.Size = size
+	model.StoredAt = modTime
+
+	if metadata, err := ReadMetadata(modelsDir, id); err == nil {
+		model.Revision = metadata.Revision
+		model.Source = metadata.Source
+		model.StoredAt = metadata.StoredAt
+	}
+
+	// hf records the commit of each download; use it when metadata has none.
+	metadataRevision := model.Revision
+	files, err := readHFFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var latest time.Time
+	for _, f := range files {
+		if f.time.After(latest) {
+			latest = f.time
+			if metadataRevision == "" {
+				model.Revision = f.commit
+			}
+		}
+	}
+	if model.Source == "" && !latest.IsZero() {
+		model.StoredAt = latest
+	}
+
+	return model, nil
+}
+
+// diskUsage returns the total size of the files below dir and the latest
+// modification time among them.
+func diskUsage(dir string) (int64, time.Time, error) {
+	var (
+		size   int64
+		latest time.Time
+	)
+
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read model directory %s: %w", dir, err)
+	}
+
+	return size, latest, nil
+}
+
+// readHFFiles reads the download records hf keeps below a model directory,
+// keyed by the path of the file relative to the model directory.
+func readHFFiles(dir string) (map[string]hfFile, error) {
+	root := filepath.Join(dir, hfDownloadDir)
+	files := map[string]hfFile{}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == root {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, hfMetadataSuffix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, strings.TrimSuffix(path, hfMetadataSuffix))
+		if err != nil {
+			return err
+		}
+
+		f, err := parseHFMetadata(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = f
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download records in %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// parseHFMetadata parses a download record: the commit, the ETag and the
+// download time as Unix seconds, one per line.
+func parseHFMetadata(path string) (hfFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return hfFile{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return hfFile{}, err
+	}
+
+	var record hfFile
+	if len(lines) > 0 {
+		record.commit = lines[0]
+	}
+	if len(lines) > 1 {
+		record.etag = strings.Trim(lines[1], `"`)
+	}
+	if len(lines) > 2 {
+		if secs, err := strconv.ParseFloat(lines[2], 64); err == nil {
+			record.time = time.Unix(int64(secs), 0).UTC()
+		}
+	}
+
+	return record, nil
+}
+
+var errNoChecksum = errors.New("no checksum")
+
+// matchesETag reports whether the file at path matches an ETag recorded by hf.
+func matchesETag(path, etag string) (bool, error) {
+	var h hash.Hash
+	switch len(etag) {
+	case sha256HexLen:
+		h = sha256.New()
+	case sha1HexLen:
+		h = sha1.New() //nolint:gosec // git blob ids are SHA-1
+	default:
+		return false, errNoChecksum
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if len(etag) == sha1HexLen {
+		info, err := f.Stat()
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(h, "blob %d\x00", info.Size())
+	}
+
+	if _, err := io.Copy(h, f); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) == strings.ToLower(etag), nil
+}
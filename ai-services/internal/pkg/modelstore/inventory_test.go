@@ -0,0 +1,129 @@
+package modelstore
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Git blob id of "{}" and SHA-256 checksum of "weights".
+	configBlobID  = "9e26dfeeb6e641a33dae4961196235bdb965b21b"
+	weightsSHA256 = "9a129038d9a00aed0cf6a7ea059ca50a813449061ab87848cf1a13eafdf33b2c"
+)
+
+// writeHFModel places a model the way 'hf download --local-dir' does, with a
+// download record per file.
+func writeHFModel(t *testing.T, modelsDir, model, commit string, downloaded time.Time) string {
+	t.Helper()
+
+	dir := Dir(modelsDir, model)
+	files := map[string]struct{ content, etag string }{
+		"config.json":     {"{}", configBlobID},
+		"onnx/model.onnx": {"weights", weightsSHA256},
+	}
+	for name, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(f.content), 0o644))
+
+		record := filepath.Join(dir, hfDownloadDir, filepath.FromSlash(name)+hfMetadataSuffix)
+		require.NoError(t, os.MkdirAll(filepath.Dir(record), 0o755))
+		data := commit + "\n" + f.etag + "\n" + strconv.FormatInt(downloaded.Unix(), 10) + ".25\n"
+		require.NoError(t, os.WriteFile(record, []byte(data), 0o644))
+	}
+
+	return dir
+}
+
+func TestList(t *testing.T) {
+	modelsDir := t.TempDir()
+	downloaded := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	writeHFModel(t, modelsDir, "ibm-granite/granite-embedding", "abc123", downloaded)
+
+	// A model without an organisation and with ai-services metadata.
+	require.NoError(t, os.MkdirAll(Dir(modelsDir, "tiny"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(Dir(modelsDir, "tiny"), "weights.bin"), []byte("12345"), 0o644))
+	stored := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, WriteMetadata(modelsDir, &Metadata{Model: "tiny", Revision: "def456", Source: SourceOffline, StoredAt: stored}))
+
+	models, err := List(modelsDir)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+
+	assert.Equal(t, "ibm-granite/granite-embedding", models[0].Model)
+	assert.Equal(t, "abc123", models[0].Revision)
+	assert.Empty(t, models[0].Source)
+	assert.True(t, downloaded.Equal(models[0].StoredAt))
+	assert.Greater(t, models[0].Size, int64(9), "size includes the download records")
+
+	assert.Equal(t, "tiny", models[1].Model)
+	assert.Equal(t, "def456", models[1].Revision)
+	assert.Equal(t, SourceOffline, models[1].Source)
+	assert.True(t, stored.Equal(models[1].StoredAt))
+
+	missing, err := List(filepath.Join(modelsDir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestVerifyModel(t *testing.T) {
+	modelsDir := t.TempDir()
+	model := "ibm-granite/granite-embedding"
+	dir := writeHFModel(t, modelsDir, model, "abc123", time.Now())
+
+	t.Run("against hf download records", func(t *testing.T) {
+		result, err := VerifyModel(modelsDir, model)
+		require.NoError(t, err)
+		assert.Equal(t, VerifiedAgainstHuggingFace, result.Against)
+		assert.Equal(t, 2, result.Checked)
+		assert.Empty(t, result.Failed)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "onnx", "model.onnx"), []byte("tampered"), 0o644))
+		result, err = VerifyModel(modelsDir, model)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"onnx/model.onnx"}, result.Failed)
+	})
+
+	t.Run("against metadata", func(t *testing.T) {
+		files, err := Scan(dir)
+		require.NoError(t, err)
+		require.NoError(t, WriteMetadata(modelsDir, &Metadata{Model: model, Files: files}))
+
+		result, err := VerifyModel(modelsDir, model)
+		require.NoError(t, err)
+		assert.Equal(t, VerifiedAgainstMetadata, result.Against)
+		assert.Empty(t, result.Failed)
+
+		require.NoError(t, os.Remove(filepath.Join(dir, "config.json")))
+		result, err = VerifyModel(modelsDir, model)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"config.json"}, result.Failed)
+	})
+
+	t.Run("without manifest", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(Dir(modelsDir, "org/bare"), 0o755))
+		_, err := VerifyModel(modelsDir, "org/bare")
+		assert.Error(t, err)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	modelsDir := t.TempDir()
+	writeHFModel(t, modelsDir, "org/a", "abc", time.Now())
+	writeHFModel(t, modelsDir, "org/b", "abc", time.Now())
+
+	require.NoError(t, Remove(modelsDir, "org/a"))
+	assert.NoDirExists(t, Dir(modelsDir, "org/a"))
+	assert.DirExists(t, Dir(modelsDir, "org/b"))
+
+	require.NoError(t, Remove(modelsDir, "org/b"))
+	assert.NoDirExists(t, filepath.Join(modelsDir, "org"), "empty organisation directory is removed")
+
+	assert.Error(t, Remove(modelsDir, "../outside"))
+}
@@ -0,0 +1,205 @@
+// Package modelstore keeps track of the models in the models directory.
+//
+// Every model lives in <models dir>/<model id>, the layout 'hf download
+// --local-dir' produces. A model that was completely placed there is described by
+// a metadata file in its directory, which lists its files with their sizes and
+// SHA-256 checksums.
+package modelstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MetadataFile is the name of the metadata file in a model directory.
+const MetadataFile = ".ai-services-model.json"
+
+// cacheDir is where 'hf download --local-dir' keeps its download state.
+const cacheDir = ".cache"
+
+const (
+	dirPermission  = 0o755
+	filePermission = 0o644
+)
+
+// Source values of Metadata.
+const (
+	SourceHuggingFace = "huggingface"
+	SourceOffline     = "offline"
+)
+
+// Metadata describes a model in the models directory.
+type Metadata struct {
+	Model string `json:"model"`
+	// Revision is the commit of the model repository, when known.
+	Revision string    `json:"revision,omitempty"`
+	Source   string    `json:"source"`
+	StoredAt time.Time `json:"storedAt"`
+	Files    []File    `json:"files"`
+}
+
+// File is a file of a model, relative to the model directory.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Size returns the total size of the files of the model.
+func (m *Metadata) Size() int64 {
+	var size int64
+	for _, f := range m.Files {
+		size += f.Size
+	}
+
+	return size
+}
+
+// Dir returns the directory of model below modelsDir.
+func Dir(modelsDir, model string) string {
+	return filepath.Join(modelsDir, filepath.FromSlash(model))
+}
+
+// ReadMetadata reads the metadata of model. It returns an error satisfying
+// errors.Is(err, fs.ErrNotExist) when the model has none.
+func ReadMetadata(modelsDir, model string) (*Metadata, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(modelsDir, model), MetadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata of model %s: %w", model, err)
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of model %s: %w", model, err)
+	}
+
+	return &metadata, nil
+}
+
+// WriteMetadata writes the metadata of a model, marking it complete.
+func WriteMetadata(modelsDir string, metadata *Metadata) error {
+	return writeMetadata(Dir(modelsDir, metadata.Model), metadata)
+}
+
+// writeMetadata writes the metadata of a model to the model directory dir.
+func writeMetadata(dir string, metadata *Metadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata of model %s: %w", metadata.Model, err)
+	}
+
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return fmt.Errorf("failed to create directory of model %s: %w", metadata.Model, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, MetadataFile), data, filePermission); err != nil {
+		return fmt.Errorf("failed to write metadata of model %s: %w", metadata.Model, err)
+	}
+
+	return nil
+}
+
+// Complete reports whether model was completely placed in modelsDir.
+func Complete(modelsDir, model string) bool {
+	_, err := ReadMetadata(modelsDir, model)
+
+	return err == nil
+}
+
+// Scan lists the files of the model in dir with their checksums, skipping the
+// metadata file and the download state of hf.
+func Scan(dir string) ([]File, error) {
+	var files []File
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if rel == cacheDir {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if rel == MetadataFile || !d.Type().IsRegular() {
+			return nil
+		}
+
+		sum, size, err := checksum(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: filepath.ToSlash(rel), Size: size, SHA256: sum})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan model directory %s: %w", dir, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+// Verify checks the files of a model against its metadata. It returns the paths
+// of the files that are missing or differ.
+func Verify(modelsDir string, metadata *Metadata) ([]string, error) {
+	dir := Dir(modelsDir, metadata.Model)
+	var bad []string
+
+	for _, f := range metadata.Files {
+		sum, size, err := checksum(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			bad = append(bad, f.Path)
+
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if size != f.Size || sum != f.SHA256 {
+			bad = append(bad, f.Path)
+		}
+	}
+
+	return bad, nil
+}
+
+// checksum returns the SHA-256 checksum and the size of a file.
+func checksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
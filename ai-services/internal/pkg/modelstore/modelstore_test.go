@@ -0,0 +1,50 @@
+package modelstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAndVerify(t *testing.T) {
+	modelsDir := t.TempDir()
+	model := "ibm-granite/granite-embedding"
+	dir := Dir(modelsDir, model)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".cache", "huggingface"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".cache", "huggingface", "state"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "onnx"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "onnx", "model.onnx"), []byte("weights"), 0o644))
+
+	files, err := Scan(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "config.json", files[0].Path)
+	assert.Equal(t, "onnx/model.onnx", files[1].Path)
+	assert.Equal(t, int64(7), files[1].Size)
+
+	assert.False(t, Complete(modelsDir, model))
+	metadata := &Metadata{Model: model, Source: SourceHuggingFace, Files: files}
+	require.NoError(t, WriteMetadata(modelsDir, metadata))
+	assert.True(t, Complete(modelsDir, model))
+	assert.Equal(t, int64(9), metadata.Size())
+
+	// The metadata file itself is not part of the model.
+	rescanned, err := Scan(dir)
+	require.NoError(t, err)
+	assert.Equal(t, files, rescanned)
+
+	bad, err := Verify(modelsDir, metadata)
+	require.NoError(t, err)
+	assert.Empty(t, bad)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "onnx", "model.onnx"), []byte("corrupt"), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "config.json")))
+	bad, err = Verify(modelsDir, metadata)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config.json", "onnx/model.onnx"}, bad)
+}
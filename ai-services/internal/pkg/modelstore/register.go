@@ -0,0 +1,366 @@
+package modelstore
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/pkg/blobinfocache/none"
+	"go.podman.io/image/v5/pkg/compression"
+	imageTypes "go.podman.io/image/v5/types"
+)
+
+// Source values of Metadata for models registered in the catalog.
+const (
+	SourceLocal = "local"
+	SourceOCI   = "oci"
+)
+
+const (
+	// configFile is the file every Hugging Face transformers model has; vLLM
+	// refuses a model directory without it.
+	configFile = "config.json"
+	// stagingPrefix names the hidden directories models are assembled in before
+	// they are moved into place.
+	stagingPrefix = ".register-"
+
+	// annotationTitle names the file an ORAS artifact layer holds.
+	annotationTitle = "org.opencontainers.image.title"
+	// annotationUnpack marks an ORAS layer holding a directory as a tarball.
+	annotationUnpack = "io.deis.oras.content.unpack"
+	// modelcarDir is where modelcar images keep the model files.
+	modelcarDir = "models"
+	// whiteoutPrefix marks deleted files in image layers.
+	whiteoutPrefix = ".wh."
+)
+
+// ErrNotAModel is returned when the registered files do not form a model.
+var ErrNotAModel = errors.New("not a Hugging Face model: " + configFile + " is missing")
+
+// PullOptions configures how a model is pulled from a registry.
+type PullOptions struct {
+	// AuthFile holds the registry credentials. The default credentials of the
+	// user are used when empty.
+	AuthFile string
+	// Username and Password authenticate to the registry instead of AuthFile.
+	Username string
+	Password string
+	// TLSVerify verifies the certificate of the registry.
+	TLSVerify bool
+}
+
+// ImportDir registers the model in srcDir as model: its files are copied into
+// the models directory and recorded in its metadata. srcDir is left untouched.
+func ImportDir(modelsDir, model, srcDir string) (*Metadata, error) {
+	info, err := os.Stat(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access model directory %s: %w", srcDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", srcDir)
+	}
+
+	return place(modelsDir, model, SourceLocal, "", func(dir string) error {
+		return copyDir(srcDir, dir)
+	})
+}
+
+// Pull registers the model stored in an OCI image or artifact as model. Two
+// layouts are understood: ORAS artifacts, whose layers are the model files named
+// by their title annotation, and modelcar images, which hold the model files
+// below /models. The revision of the model is the manifest digest.
+func Pull(ctx context.Context, modelsDir, model, image string, opts PullOptions) (*Metadata, error) {
+	ref, err := docker.ParseReference("//" + strings.TrimPrefix(image, "docker://"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %s: %w", image, err)
+	}
+
+	sys := &imageTypes.SystemContext{
+		AuthFilePath:                opts.AuthFile,
+		DockerInsecureSkipTLSVerify: imageTypes.NewOptionalBool(!opts.TLSVerify),
+	}
+	if opts.Username != "" {
+		sys.DockerAuthConfig = &imageTypes.DockerAuthConfig{Username: opts.Username, Password: opts.Password}
+	}
+
+	return pull(ctx, modelsDir, model, ref, sys)
+}
+
+// pull registers the model in the image ref points to.
+func pull(ctx context.Context, modelsDir, model string, ref imageTypes.ImageReference, sys *imageTypes.SystemContext) (*Metadata, error) {
+	image := ref.StringWithinTransport()
+
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access %s: %w", image, err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	blob, mimeType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest of %s: %w", image, err)
+	}
+	digest, err := manifest.Digest(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute digest of %s: %w", image, err)
+	}
+
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		// Model files do not depend on the platform; take the host's instance.
+		list, err := manifest.ListFromBlob(blob, mimeType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest list of %s: %w", image, err)
+		}
+		instance, err := list.ChooseInstance(sys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to choose an instance of %s: %w", image, err)
+		}
+		if blob, mimeType, err = src.GetManifest(ctx, &instance); err != nil {
+			return nil, fmt.Errorf("failed to get manifest of %s: %w", image, err)
+		}
+	}
+
+	m, err := manifest.FromBlob(blob, mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", image, err)
+	}
+
+	return place(modelsDir, model, SourceOCI, digest.String(), func(dir string) error {
+		for _, layer := range m.LayerInfos() {
+			if err := fetchLayer(ctx, src, layer.BlobInfo, dir); err != nil {
+				return fmt.Errorf("failed to fetch layer %s of %s: %w", layer.Digest, image, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// place assembles a model with fill in a staging directory and moves it into
+// place once it is complete. An existing model is never replaced.
+func place(modelsDir, model, source, revision string, fill func(dir string) error) (*Metadata, error) {
+	if !filepath.IsLocal(filepath.FromSlash(model)) {
+		return nil, fmt.Errorf("invalid model id %s", model)
+	}
+	dest := Dir(modelsDir, model)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("model %s already exists in %s", model, modelsDir)
+	}
+
+	if err := os.MkdirAll(modelsDir, dirPermission); err != nil {
+		return nil, fmt.Errorf("failed to create models directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(modelsDir, stagingPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	if err := fill(staging); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(staging, configFile)); err != nil {
+		return nil, ErrNotAModel
+	}
+
+	files, err := Scan(staging)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{Model: model, Revision: revision, Source: source, StoredAt: time.Now().UTC(), Files: files}
+	if err := writeMetadata(staging, metadata); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), dirPermission); err != nil {
+		return nil, fmt.Errorf("failed to create directory of model %s: %w", model, err)
+	}
+	if err := os.Rename(staging, dest); err != nil {
+		return nil, fmt.Errorf("failed to move model %s into place: %w", model, err)
+	}
+
+	return metadata, nil
+}
+
+// fetchLayer writes the model files of one layer below dir, checking the layer
+// against its digest.
+func fetchLayer(ctx context.Context, src imageTypes.ImageSource, info imageTypes.BlobInfo, dir string) error {
+	body, _, err := src.GetBlob(ctx, info, none.NoCache)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	verifier := info.Digest.Verifier()
+	r := io.TeeReader(body, verifier)
+
+	title := info.Annotations[annotationTitle]
+	switch {
+	case title != "" && info.Annotations[annotationUnpack] != "true":
+		err = writeTitled(r, dir, title)
+	case title != "":
+		err = untar(r, dir, func(name string) (string, bool) { return path.Join(title, name), true })
+	default:
+		err = untar(r, dir, modelcarPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Drain what the tar reader left, e.g. the end-of-archive padding.
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("failed to read layer: %w", err)
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("%w: layer %s", errChecksum, info.Digest)
+	}
+
+	return nil
+}
+
+// writeTitled writes an ORAS file layer as the file it is titled with.
+func writeTitled(r io.Reader, dir, title string) error {
+	dest, err := localPath(dir, title)
+	if err != nil {
+		return err
+	}
+
+	return writeModelFile(dest, r)
+}
+
+// modelcarPath maps a file of a modelcar image to its path in the model.
+func modelcarPath(name string) (string, bool) {
+	rel, ok := strings.CutPrefix(path.Clean(strings.TrimPrefix(name, "/")), modelcarDir+"/")
+
+	return rel, ok
+}
+
+// untar writes the regular files of a possibly compressed tarball below dir at
+// the paths mapName returns for them, skipping those it rejects.
+func untar(r io.Reader, dir string, mapName func(name string) (string, bool)) error {
+	stream, _, err := compression.AutoDecompress(r)
+	if err != nil {
+		return fmt.Errorf("failed to decompress layer: %w", err)
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	tr := tar.NewReader(stream)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read layer: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || strings.HasPrefix(path.Base(hdr.Name), whiteoutPrefix) {
+			continue
+		}
+		name, ok := mapName(hdr.Name)
+		if !ok {
+			continue
+		}
+
+		dest, err := localPath(dir, name)
+		if err != nil {
+			return err
+		}
+		if err := writeModelFile(dest, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// localPath returns where the model file name goes below dir, rejecting names
+// that would escape it.
+func localPath(dir, name string) (string, error) {
+	rel := filepath.FromSlash(name)
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("refusing to write model file outside the model directory: %s", name)
+	}
+
+	return filepath.Join(dir, rel), nil
+}
+
+// copyDir copies the regular files below src to dst, skipping the download
+// state of hf and the metadata of an earlier copy.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && rel == cacheDir {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || rel == MetadataFile {
+			return nil
+		}
+
+		// Follow symlinks, as hf cache snapshots consist of them.
+		info, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("failed to access %s: %w", p, err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", p, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+
+		return writeModelFile(filepath.Join(dst, rel), f)
+	})
+}
+
+// writeModelFile writes r to path, creating its parent directories.
+func writeModelFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPermission); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package modelstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/oci/layout"
+)
+
+const testTag = "v1"
+
+// ociLayout writes an OCI image layout with one manifest of the given layers.
+type ociLayout struct {
+	t      *testing.T
+	dir    string
+	layers []map[string]any
+}
+
+func newOCILayout(t *testing.T) *ociLayout {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+
+	return &ociLayout{t: t, dir: dir}
+}
+
+func (l *ociLayout) blob(content []byte) map[string]any {
+	sum := sha256.Sum256(content)
+	encoded := hex.EncodeToString(sum[:])
+	require.NoError(l.t, os.WriteFile(filepath.Join(l.dir, "blobs", "sha256", encoded), content, 0o644))
+
+	return map[string]any{"digest": "sha256:" + encoded, "size": len(content)}
+}
+
+func (l *ociLayout) addLayer(mediaType string, content []byte, annotations map[string]string) {
+	desc := l.blob(content)
+	desc["mediaType"] = mediaType
+	if annotations != nil {
+		desc["annotations"] = annotations
+	}
+	l.layers = append(l.layers, desc)
+}
+
+// ref writes the manifest and the index and returns a reference to the image.
+func (l *ociLayout) ref() *ociRef {
+	config := l.blob([]byte("{}"))
+	config["mediaType"] = "application/vnd.oci.empty.v1+json"
+
+	data, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"config":        config,
+		"layers":        l.layers,
+	})
+	require.NoError(l.t, err)
+	desc := l.blob(data)
+	desc["mediaType"] = "application/vnd.oci.image.manifest.v1+json"
+	desc["annotations"] = map[string]string{"org.opencontainers.image.ref.name": testTag}
+
+	index, err := json.Marshal(map[string]any{"schemaVersion": 2, "manifests": []any{desc}})
+	require.NoError(l.t, err)
+	require.NoError(l.t, os.WriteFile(filepath.Join(l.dir, "index.json"), index, 0o644))
+
+	return &ociRef{dir: l.dir, digest: desc["digest"].(string)}
+}
+
+type ociRef struct {
+	dir    string
+	digest string
+}
+
+func tarball(t *testing.T, gz bool, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var tw *tar.Writer
+	var zw *gzip.Writer
+	if gz {
+		zw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(zw)
+	} else {
+		tw = tar.NewWriter(&buf)
+	}
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if zw != nil {
+		require.NoError(t, zw.Close())
+	}
+
+	return buf.Bytes()
+}
+
+func pullLayout(t *testing.T, modelsDir, model string, l *ociLayout) (*Metadata, string, error) {
+	t.Helper()
+
+	r := l.ref()
+	ref, err := layout.NewReference(r.dir, testTag)
+	require.NoError(t, err)
+
+	metadata, err := pull(context.Background(), modelsDir, model, ref, nil)
+
+	return metadata, r.digest, err
+}
+
+func TestImportDir(t *testing.T) {
+	modelsDir := t.TempDir()
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config.json"), []byte("{}"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "tokenizer"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "tokenizer", "vocab.txt"), []byte("a b c"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, cacheDir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, cacheDir, "state"), []byte("x"), 0o644))
+
+	metadata, err := ImportDir(modelsDir, "acme/granite-ft", src)
+	require.NoError(t, err)
+	assert.Equal(t, SourceLocal, metadata.Source)
+	require.Len(t, metadata.Files, 2)
+	assert.Equal(t, "tokenizer/vocab.txt", metadata.Files[1].Path)
+	assert.True(t, Complete(modelsDir, "acme/granite-ft"))
+	assert.NoDirExists(t, filepath.Join(Dir(modelsDir, "acme/granite-ft"), cacheDir))
+
+	_, err = ImportDir(modelsDir, "acme/granite-ft", src)
+	require.Error(t, err, "an existing model is not replaced")
+
+	_, err = ImportDir(modelsDir, "acme/empty", t.TempDir())
+	require.ErrorIs(t, err, ErrNotAModel)
+	assert.NoDirExists(t, Dir(modelsDir, "acme/empty"))
+
+	entries, err := os.ReadDir(modelsDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "staging directories are removed")
+}
+
+func TestPullORASArtifact(t *testing.T) {
+	modelsDir := t.TempDir()
+	l := newOCILayout(t)
+	l.addLayer("application/vnd.oci.image.layer.v1.tar", []byte(`{"hidden": 1}`), map[string]string{annotationTitle: "config.json"})
+	l.addLayer("application/octet-stream", []byte("weights"), map[string]string{annotationTitle: "model.safetensors"})
+	l.addLayer("application/vnd.oci.image.layer.v1.tar+gzip",
+		tarball(t, true, map[string]string{"tokenizer.json": "{}"}),
+		map[string]string{annotationTitle: "tokenizer", annotationUnpack: "true"})
+
+	metadata, digest, err := pullLayout(t, modelsDir, "acme/granite-ft", l)
+	require.NoError(t, err)
+	assert.Equal(t, SourceOCI, metadata.Source)
+	assert.Equal(t, digest, metadata.Revision)
+
+	var paths []string
+	for _, f := range metadata.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"config.json", "model.safetensors", "tokenizer/tokenizer.json"}, paths)
+
+	bad, err := Verify(modelsDir, metadata)
+	require.NoError(t, err)
+	assert.Empty(t, bad)
+}
+
+func TestPullModelcarImage(t *testing.T) {
+	modelsDir := t.TempDir()
+	l := newOCILayout(t)
+	l.addLayer("application/vnd.oci.image.layer.v1.tar+gzip", tarball(t, true, map[string]string{
+		"bin/sh":             "shell",
+		"models/config.json": "{}",
+	}), nil)
+	l.addLayer("application/vnd.oci.image.layer.v1.tar", tarball(t, false, map[string]string{
+		"models/weights/model.safetensors": "weights",
+		"models/.wh.stale":                 "",
+	}), nil)
+
+	metadata, _, err := pullLayout(t, modelsDir, "modelcar", l)
+	require.NoError(t, err)
+	require.Len(t, metadata.Files, 2)
+	assert.Equal(t, "config.json", metadata.Files[0].Path)
+	assert.Equal(t, "weights/model.safetensors", metadata.Files[1].Path)
+	assert.NoFileExists(t, filepath.Join(Dir(modelsDir, "modelcar"), "bin", "sh"))
+}
+
+func TestPullRejects(t *testing.T) {
+	t.Run("layer escaping the model directory", func(t *testing.T) {
+		l := newOCILayout(t)
+		l.addLayer("application/octet-stream", []byte("x"), map[string]string{annotationTitle: "../config.json"})
+
+		_, _, err := pullLayout(t, t.TempDir(), "evil", l)
+		assert.Error(t, err)
+	})
+
+	t.Run("image without model files", func(t *testing.T) {
+		l := newOCILayout(t)
+		l.addLayer("application/vnd.oci.image.layer.v1.tar", tarball(t, false, map[string]string{"etc/hosts": "x"}), nil)
+
+		_, _, err := pullLayout(t, t.TempDir(), "empty", l)
+		require.ErrorIs(t, err, ErrNotAModel)
+	})
+
+	t.Run("corrupt layer", func(t *testing.T) {
+		l := newOCILayout(t)
+		l.addLayer("application/octet-stream", []byte("{}"), map[string]string{annotationTitle: "config.json"})
+		r := l.ref()
+		blob := filepath.Join(r.dir, "blobs", "sha256", l.layers[0]["digest"].(string)[len("sha256:"):])
+		require.NoError(t, os.WriteFile(blob, []byte("[]"), 0o644))
+
+		ref, err := layout.NewReference(r.dir, testTag)
+		require.NoError(t, err)
+		_, err = pull(context.Background(), t.TempDir(), "corrupt", ref, nil)
+		require.ErrorIs(t, err, errChecksum)
+	})
+}
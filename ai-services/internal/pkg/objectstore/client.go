@@ -0,0 +1,386 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPartSize is the size of the parts of multipart uploads, and the
+	// largest object uploaded in a single request.
+	DefaultPartSize = 16 << 20
+	// maxParts is the S3 limit on the number of parts of a multipart upload.
+	maxParts = 10000
+	// errorBodyLimit caps how much of an error response is read.
+	errorBodyLimit = 64 << 10
+	// responseHeaderTimeout bounds the wait for the response to a request once
+	// it is sent, so an unresponsive server fails the transfer instead of
+	// hanging it.
+	responseHeaderTimeout = 2 * time.Minute
+	// idleConnTimeout closes connections left idle between requests.
+	idleConnTimeout = 90 * time.Second
+)
+
+var (
+	// ErrNotFound is returned for objects that do not exist.
+	ErrNotFound = errors.New("object not found")
+
+	errUploadStopped = errors.New("upload stopped")
+)
+
+// Error is an error response of the S3 API.
+type Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("object storage returned HTTP %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("object storage returned HTTP %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Client reads and writes the objects of one bucket.
+type Client struct {
+	cfg    Config
+	region string
+	http   *http.Client
+	// PartSize is the size of the parts of multipart uploads.
+	PartSize int
+}
+
+// NewClient returns a client for the bucket named by cfg.
+func NewClient(cfg Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("object storage config does not name a bucket (%s)", EnvBucket)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	// Bodies are streamed, so no overall request timeout is set.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+	transport.IdleConnTimeout = idleConnTimeout
+
+	return &Client{cfg: cfg, region: region, http: &http.Client{Transport: transport}, PartSize: DefaultPartSize}, nil
+}
+
+// Location returns the location of key in the client's bucket.
+func (c *Client) Location(key string) Location {
+	return Location{Bucket: c.cfg.Bucket, Key: key}
+}
+
+// Exists reports whether the object exists.
+func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_ = resp.Body.Close()
+
+	return true, nil
+}
+
+// Download returns the content of the object. The caller closes it.
+func (c *Client) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// DownloadRange returns length bytes of the object starting at offset. The caller
+// closes it. Servers that ignore the range return the whole object, so callers
+// read no more than length bytes.
+func (c *Client) DownloadRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	header := http.Header{"Range": {fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)}}
+	resp, err := c.do(ctx, http.MethodGet, key, nil, header, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// DownloadFile writes the content of the object to the file at path.
+func (c *Client) DownloadFile(ctx context.Context, key, path string) (int64, error) {
+	body, err := c.Download(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download %s: %w", c.Location(key), err)
+	}
+
+	return n, f.Close()
+}
+
+// UploadFrom uploads what write writes as the object, streaming it without
+// buffering more than one part.
+func (c *Client) UploadFrom(ctx context.Context, key string, write func(w io.Writer) error) (int64, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pw.CloseWithError(write(pw))
+	}()
+
+	n, err := c.Upload(ctx, key, pr)
+	// Unblock the writer if the upload stopped reading early.
+	_ = pr.CloseWithError(errUploadStopped)
+	<-done
+
+	return n, err
+}
+
+// Upload uploads the content of r as the object. Content larger than one part is
+// sent as a multipart upload, which is aborted if any part fails.
+func (c *Client) Upload(ctx context.Context, key string, r io.Reader) (int64, error) {
+	buf := make([]byte, c.PartSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return int64(n), c.putObject(ctx, key, buf[:n])
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read upload content: %w", err)
+	}
+
+	uploadID, err := c.createMultipartUpload(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := c.uploadParts(ctx, key, uploadID, r, buf)
+	if err != nil {
+		if abortErr := c.abortMultipartUpload(context.WithoutCancel(ctx), key, uploadID); abortErr != nil {
+			return total, fmt.Errorf("%w (aborting the upload also failed: %w)", err, abortErr)
+		}
+
+		return total, err
+	}
+
+	return total, nil
+}
+
+// uploadParts uploads the content of r, whose first part is already in buf, and
+// completes the multipart upload.
+func (c *Client) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, buf []byte) (int64, error) {
+	var (
+		parts []completedPart
+		total int64
+		n     = len(buf)
+	)
+
+	// A short read ends in a last, smaller part; the next read then hits EOF.
+	for number := 1; n > 0; number++ {
+		if number > maxParts {
+			return total, fmt.Errorf("upload of %s exceeds %d parts of %d bytes", c.Location(key), maxParts, c.PartSize)
+		}
+
+		etag, err := c.uploadPart(ctx, key, uploadID, number, buf[:n])
+		if err != nil {
+			return total, err
+		}
+		parts = append(parts, completedPart{PartNumber: number, ETag: etag})
+		total += int64(n)
+
+		n, err = io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return total, fmt.Errorf("failed to read upload content: %w", err)
+		}
+	}
+
+	return total, c.completeMultipartUpload(ctx, key, uploadID, parts)
+}
+
+func (c *Client) putObject(ctx context.Context, key string, data []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, key, nil, nil, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", c.Location(key), err)
+	}
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+func (c *Client) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to start upload of %s: %w", c.Location(key), err)
+	}
+
+	var result initiateMultipartUploadResult
+	if err := decodeXML(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to start upload of %s: %w", c.Location(key), err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("failed to start upload of %s: no upload ID returned", c.Location(key))
+	}
+
+	return result.UploadID, nil
+}
+
+func (c *Client) uploadPart(ctx context.Context, key, uploadID string, number int, data []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+	resp, err := c.do(ctx, http.MethodPut, key, query, nil, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d of %s: %w", number, c.Location(key), err)
+	}
+	_ = resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("failed to upload part %d of %s: no ETag returned", number, c.Location(key))
+	}
+
+	return etag, nil
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+func (c *Client) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return fmt.Errorf("failed to complete upload of %s: %w", c.Location(key), err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, nil, body)
+	if err != nil {
+		return fmt.Errorf("failed to complete upload of %s: %w", c.Location(key), err)
+	}
+
+	// S3 can report a failed completion in the body of a 200 response.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to complete upload of %s: %w", c.Location(key), err)
+	}
+	if bytes.Contains(data, []byte("<Error>")) {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		_ = xml.Unmarshal(data, apiErr)
+
+		return fmt.Errorf("failed to complete upload of %s: %w", c.Location(key), apiErr)
+	}
+
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// do sends a signed request for the object and returns the response of a
+// successful request. Errors from the API are returned as *Error, and missing
+// objects as ErrNotFound.
+func (c *Client) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid object storage endpoint: %w", err)
+	}
+
+	u.Path += "/" + c.cfg.Bucket + "/" + key
+	u.RawPath = escapePath(u.Path)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	c.sign(req, hashHex(body), time.Now())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("object storage request failed: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound && query == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, c.Location(key))
+	}
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit)); err == nil {
+		_ = xml.Unmarshal(data, apiErr)
+	}
+
+	return nil, apiErr
+}
+
+func decodeXML(resp *http.Response, v any) error {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package objectstore_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/objectstore"
+)
+
+// fakeS3 is a MinIO-style stand-in for an S3-compatible server that keeps objects
+// and multipart uploads in memory.
+type fakeS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+	parts   int
+	nextID  int
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *objectstore.Client) {
+	t.Helper()
+	fake := &fakeS3{t: t, objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}}
+
+	srv := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(srv.Close)
+
+	client, err := objectstore.NewClient(objectstore.Config{
+		Endpoint:        srv.URL,
+		Bucket:          "backups",
+		AccessKeyID:     "minio",
+		SecretAccessKey: "minio-secret",
+	})
+	require.NoError(t, err)
+
+	return fake, client
+}
+
+func (f *fakeS3) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	assert.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/"))
+
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	sum := sha256.Sum256(body)
+	assert.Equal(f.t, hex.EncodeToString(sum[:]), r.Header.Get("X-Amz-Content-Sha256"))
+
+	key := strings.TrimPrefix(r.URL.Path, "/backups/")
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.nextID++
+		id := fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[id] = map[int][]byte{}
+		_, _ = fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPut && uploadID != "":
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		f.uploads[uploadID][number] = body
+		f.parts++
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && uploadID != "":
+		var complete struct {
+			Parts []struct {
+				PartNumber int `xml:"PartNumber"`
+			} `xml:"Part"`
+		}
+		require.NoError(f.t, xml.Unmarshal(body, &complete))
+
+		numbers := make([]int, 0, len(complete.Parts))
+		for _, p := range complete.Parts {
+			numbers = append(numbers, p.PartNumber)
+		}
+		assert.True(f.t, sort.IntsAreSorted(numbers))
+
+		var data []byte
+		for _, n := range numbers {
+			data = append(data, f.uploads[uploadID][n]...)
+		}
+		f.objects[key] = data
+		delete(f.uploads, uploadID)
+		_, _ = w.Write([]byte("<CompleteMultipartUploadResult></CompleteMultipartUploadResult>"))
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(f.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>"))
+
+			return
+		}
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(data))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestUploadSinglePart(t *testing.T) {
+	fake, client := newFakeS3(t)
+	ctx := context.Background()
+
+	n, err := client.Upload(ctx, "app/small.tar.gz", strings.NewReader("small archive"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("small archive")), n)
+	assert.Zero(t, fake.parts, "content that fits in one part is uploaded with a single PUT")
+
+	body, err := client.Download(ctx, "app/small.tar.gz")
+	require.NoError(t, err)
+	defer func() {
+		_ = body.Close()
+	}()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "small archive", string(data))
+}
+
+func TestUploadMultipart(t *testing.T) {
+	fake, client := newFakeS3(t)
+	client.PartSize = 1024
+	ctx := context.Background()
+
+	content := bytes.Repeat([]byte("0123456789"), 250)
+	n, err := client.UploadFrom(ctx, "app/large.tar.gz", func(w io.Writer) error {
+		_, err := w.Write(content)
+
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, 3, fake.parts)
+	assert.Empty(t, fake.uploads, "completed uploads are not left pending")
+
+	path := t.TempDir() + "/large.tar.gz"
+	written, err := client.DownloadFile(ctx, "app/large.tar.gz", path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), written)
+	assert.Equal(t, content, fake.objects["app/large.tar.gz"])
+}
+
+func TestUploadAbortsOnWriteError(t *testing.T) {
+	fake, client := newFakeS3(t)
+	client.PartSize = 1024
+	ctx := context.Background()
+
+	_, err := client.UploadFrom(ctx, "app/broken.tar.gz", func(w io.Writer) error {
+		if _, err := w.Write(bytes.Repeat([]byte("x"), 3000)); err != nil {
+			return err
+		}
+
+		return errors.New("archive failed")
+	})
+	require.ErrorContains(t, err, "archive failed")
+	assert.Empty(t, fake.uploads, "failed uploads are aborted")
+
+	exists, err := client.Exists(ctx, "app/broken.tar.gz")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestDownloadMissing(t *testing.T) {
+	_, client := newFakeS3(t)
+
+	_, err := client.Download(context.Background(), "missing.tar.gz")
+	require.ErrorIs(t, err, objectstore.ErrNotFound)
+}
+
+func TestDownloadRange(t *testing.T) {
+	_, client := newFakeS3(t)
+	ctx := context.Background()
+	_, err := client.Upload(ctx, "archive.tar.gz", strings.NewReader("0123456789"))
+	require.NoError(t, err)
+
+	body, err := client.DownloadRange(ctx, "archive.tar.gz", 2, 3)
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "234", string(data))
+}
+
+func TestParseURL(t *testing.T) {
+	loc, err := objectstore.ParseURL("s3://backups/app/one.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, objectstore.Location{Bucket: "backups", Key: "app/one.tar.gz"}, loc)
+	assert.False(t, loc.IsDir())
+	assert.Equal(t, "s3://backups/app/one.tar.gz", loc.String())
+
+	loc, err = objectstore.ParseURL("s3://backups/app/")
+	require.NoError(t, err)
+	assert.True(t, loc.IsDir())
+
+	_, err = objectstore.ParseURL("s3:///key")
+	require.Error(t, err)
+	_, err = objectstore.ParseURL("/tmp/backup.tar.gz")
+	require.Error(t, err)
+}
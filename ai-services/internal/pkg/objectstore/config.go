@@ -0,0 +1,137 @@
+// Package objectstore is a minimal client for S3-compatible object storage. It
+// signs requests with AWS Signature Version 4, addresses buckets path-style so it
+// works against MinIO and other S3-compatible servers, and streams uploads as
+// multipart uploads so large objects are never held in full on disk or in memory.
+package objectstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Scheme is the URL scheme of object locations, as in s3://bucket/key.
+const Scheme = "s3"
+
+// DefaultRegion is used when the configuration names no region. MinIO and most
+// S3-compatible servers accept any region.
+const DefaultRegion = "us-east-1"
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvEndpoint        = "AI_SERVICES_S3_ENDPOINT"
+	EnvBucket          = "AI_SERVICES_S3_BUCKET"
+	EnvPrefix          = "AI_SERVICES_S3_PREFIX"
+	EnvAccessKeyID     = "AWS_ACCESS_KEY_ID"
+	EnvSecretAccessKey = "AWS_SECRET_ACCESS_KEY"
+	EnvSessionToken    = "AWS_SESSION_TOKEN"
+	EnvRegion          = "AWS_REGION"
+)
+
+// Config locates a bucket and the credentials to access it. Its JSON form matches
+// the configuration of the object_storage datasource connector, so a connector's
+// configuration can be used as is.
+type Config struct {
+	// Endpoint is the URL of the S3 API, e.g. https://s3.us-east-1.amazonaws.com.
+	Endpoint        string `json:"endpoint_url"`
+	Bucket          string `json:"bucket_name,omitempty"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	// SessionToken is set for temporary credentials, e.g. those of an assumed role.
+	SessionToken string `json:"session_token,omitempty"`
+	// Prefix is prepended to the keys of objects named without a key.
+	Prefix string `json:"prefix,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// ConfigFromEnv reads the configuration from the environment.
+func ConfigFromEnv() Config {
+	return Config{
+		Endpoint:        os.Getenv(EnvEndpoint),
+		Bucket:          os.Getenv(EnvBucket),
+		AccessKeyID:     os.Getenv(EnvAccessKeyID),
+		SecretAccessKey: os.Getenv(EnvSecretAccessKey),
+		SessionToken:    os.Getenv(EnvSessionToken),
+		Prefix:          os.Getenv(EnvPrefix),
+		Region:          os.Getenv(EnvRegion),
+	}
+}
+
+// LoadConfig reads the configuration from a JSON file, such as a mounted secret
+// or the exported configuration of an object_storage connector. Fields missing
+// from the file are taken from the environment.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read object storage config: %w", err)
+	}
+
+	cfg := ConfigFromEnv()
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse object storage config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the configuration names an endpoint and credentials.
+func (c Config) Validate() error {
+	var missing []string
+	if c.Endpoint == "" {
+		missing = append(missing, "endpoint_url ("+EnvEndpoint+")")
+	}
+	if c.AccessKeyID == "" {
+		missing = append(missing, "access_key_id ("+EnvAccessKeyID+")")
+	}
+	if c.SecretAccessKey == "" {
+		missing = append(missing, "secret_access_key ("+EnvSecretAccessKey+")")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("object storage config is missing %s", strings.Join(missing, ", "))
+	}
+
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid object storage endpoint %q, expected an http or https URL", c.Endpoint)
+	}
+
+	return nil
+}
+
+// Location is an object in a bucket. An empty key or a key ending in "/" names a
+// directory that an object name is yet to be appended to.
+type Location struct {
+	Bucket string
+	Key    string
+}
+
+// IsURL reports whether s is an object URL such as s3://bucket/key.
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, Scheme+"://")
+}
+
+// ParseURL parses an object URL such as s3://bucket/key.
+func ParseURL(raw string) (Location, error) {
+	if !IsURL(raw) {
+		return Location{}, fmt.Errorf("invalid object URL %q, expected %s://bucket/key", raw, Scheme)
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(raw, Scheme+"://"), "/")
+	if bucket == "" {
+		return Location{}, fmt.Errorf("object URL %q does not name a bucket", raw)
+	}
+
+	return Location{Bucket: bucket, Key: key}, nil
+}
+
+// IsDir reports whether the location names a directory rather than an object.
+func (l Location) IsDir() bool {
+	return l.Key == "" || strings.HasSuffix(l.Key, "/")
+}
+
+// String returns the location as an object URL.
+func (l Location) String() string {
+	return fmt.Sprintf("%s://%s/%s", Scheme, l.Bucket, l.Key)
+}
@@ -0,0 +1,129 @@
+package objectstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	signingService   = "s3"
+	amzDateFormat    = "20060102T150405Z"
+	scopeDateFormat  = "20060102"
+
+	headerDate          = "X-Amz-Date"
+	headerContentSHA256 = "X-Amz-Content-Sha256"
+	headerSecurityToken = "X-Amz-Security-Token"
+)
+
+// sign adds an AWS Signature Version 4 Authorization header to req. payloadHash
+// is the hex SHA-256 of the request body. The session token of temporary
+// credentials is sent and signed as X-Amz-Security-Token.
+func (c *Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := strings.Join([]string{now.UTC().Format(scopeDateFormat), c.region, signingService, "aws4_request"}, "/")
+
+	req.Header.Set(headerDate, amzDate)
+	req.Header.Set(headerContentSHA256, payloadHash)
+
+	// Signed headers are sorted by name.
+	signedHeaders := []string{"host", strings.ToLower(headerContentSHA256), strings.ToLower(headerDate)}
+	if c.cfg.SessionToken != "" {
+		req.Header.Set(headerSecurityToken, c.cfg.SessionToken)
+		signedHeaders = append(signedHeaders, strings.ToLower(headerSecurityToken))
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for _, name := range signedHeaders[1:] {
+		headers[name] = strings.TrimSpace(req.Header.Get(name))
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretAccessKey), []byte(now.UTC().Format(scopeDateFormat)))
+	key = hmacSHA256(key, []byte(c.region))
+	key = hmacSHA256(key, []byte(signingService))
+	key = hmacSHA256(key, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(key, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", signingAlgorithm+" Credential="+c.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+", Signature="+signature)
+}
+
+// canonicalQuery returns the query sorted by key with keys and values escaped.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, escape(k)+"="+escape(v))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// escapePath escapes each segment of an object path as SigV4 requires.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = escape(s)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes every byte except the RFC 3986 unreserved characters.
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+	}
+
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,271 @@
+package offline
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	goruntime "runtime"
+	"time"
+
+	"go.podman.io/image/v5/docker/reference"
+
+	"github.com/project-ai-services/ai-services/assets"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/image"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// watsonxProvider serves its models remotely; there is nothing to download.
+const watsonxProvider = "watsonx"
+
+const archivePermission = 0o644
+
+// ExportOptions configures an offline export.
+type ExportOptions struct {
+	Template string
+	// File is the bundle to write.
+	File string
+	// ModelsDir is where models are taken from, and downloaded to when missing.
+	ModelsDir string
+}
+
+// Export writes the images, models and catalog assets of a template into a
+// bundle. Images and models that are not present locally are pulled and
+// downloaded first.
+func Export(ctx context.Context, opts ExportOptions) error {
+	provider, err := catalog.NewCatalogProvider()
+	if err != nil {
+		return fmt.Errorf("failed to create catalog provider: %w", err)
+	}
+
+	images, err := provider.GetCatalogImages(ctx, opts.Template)
+	if err != nil {
+		return err
+	}
+	modelIDs, err := provider.GetCatalogModels(ctx, opts.Template, watsonxProvider)
+	if err != nil {
+		return err
+	}
+
+	digest, err := CatalogDigest()
+	if err != nil {
+		return err
+	}
+
+	rt, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	logger.Infof("Collecting %d images for template '%s'...\n", len(images), opts.Template)
+	if err := collectImages(ctx, rt, images); err != nil {
+		return err
+	}
+
+	logger.Infof("Collecting %d models for template '%s'...\n", len(modelIDs), opts.Template)
+	models, err := collectModels(ctx, opts.ModelsDir, modelIDs)
+	if err != nil {
+		return err
+	}
+
+	manifest := &Manifest{
+		Version:       ManifestVersion,
+		CLIVersion:    vars.CLIVersion,
+		Template:      opts.Template,
+		Architecture:  goruntime.GOARCH,
+		CreatedAt:     time.Now().UTC(),
+		CatalogDigest: digest,
+		Images:        images,
+		Models:        models,
+	}
+
+	// Podman needs the size of the image archive up front to write its tar
+	// header, so the images are exported next to the bundle first.
+	imagesFile, err := os.CreateTemp(filepath.Dir(opts.File), ".images-*.tar")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary image archive: %w", err)
+	}
+	defer func() {
+		_ = imagesFile.Close()
+		_ = os.Remove(imagesFile.Name())
+	}()
+
+	logger.Infoln("Exporting images from local storage...")
+	if err := rt.ExportImages(ctx, images, imagesFile); err != nil {
+		return err
+	}
+
+	if err := writeBundle(opts, manifest, imagesFile.Name()); err != nil {
+		_ = os.Remove(opts.File) // best-effort cleanup of a partial bundle
+
+		return err
+	}
+
+	logger.Infof("✅ Offline bundle written: %s\n", opts.File)
+
+	return nil
+}
+
+// collectImages makes the images present in local storage under their own names,
+// so that the bundle loads on hosts without a mirror configuration.
+func collectImages(ctx context.Context, rt *podman.PodmanClient, images []string) error {
+	if err := (&image.Images{Runtime: rt}).IfNotPresent(ctx, images); err != nil {
+		return fmt.Errorf("failed to pull images: %w", err)
+	}
+
+	for _, img := range images {
+		mirrored := mirror.Resolve(img)
+		if mirrored == img {
+			continue
+		}
+
+		named, err := reference.ParseNormalizedNamed(img)
+		if err != nil {
+			return fmt.Errorf("invalid image reference %s: %w", img, err)
+		}
+		tagged, ok := named.(reference.NamedTagged)
+		if !ok {
+			// Images pinned by digest keep their digest on the mirror.
+			continue
+		}
+
+		if err := rt.TagImage(mirrored, tagged.Name(), tagged.Tag()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectModels downloads the models that are not complete in modelsDir and
+// returns them with their files.
+func collectModels(ctx context.Context, modelsDir string, modelIDs []string) ([]Model, error) {
+	if err := helpers.DownloadModels(ctx, modelIDs, modelsDir, nil); err != nil {
+		return nil, err
+	}
+
+	models := make([]Model, 0, len(modelIDs))
+	for _, id := range modelIDs {
+		metadata, err := modelstore.ReadMetadata(modelsDir, id)
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, Model{Model: id, Revision: metadata.Revision, Files: metadata.Files})
+	}
+
+	return models, nil
+}
+
+// writeBundle writes the bundle: the manifest, the image archive, the model files
+// and the catalog assets.
+func writeBundle(opts ExportOptions, manifest *Manifest, imagesFile string) error {
+	f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_EXCL|os.O_WRONLY, archivePermission)
+	if err != nil {
+		return fmt.Errorf("failed to create offline bundle: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	tw := tar.NewWriter(f)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeEntry(tw, ManifestFile, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	logger.Infoln("Adding images...")
+	if err := writeFile(tw, ImagesFile, imagesFile); err != nil {
+		return err
+	}
+
+	for _, model := range manifest.Models {
+		logger.Infof("Adding model %s...\n", model.Model)
+		for _, file := range model.Files {
+			src := filepath.Join(modelstore.Dir(opts.ModelsDir, model.Model), filepath.FromSlash(file.Path))
+			if err := writeFile(tw, path.Join(ModelsDir, model.Model, file.Path), src); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeCatalogAssets(tw); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish offline bundle: %w", err)
+	}
+
+	return f.Close()
+}
+
+// writeCatalogAssets adds the catalog assets built into the CLI.
+func writeCatalogAssets(tw *tar.Writer) error {
+	return fs.WalkDir(&assets.CatalogFS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(&assets.CatalogFS, p)
+		if err != nil {
+			return fmt.Errorf("failed to read catalog asset %s: %w", p, err)
+		}
+
+		return writeEntry(tw, path.Join(CatalogDir, p), int64(len(data)), bytes.NewReader(data))
+	})
+}
+
+// writeFile adds the file src to the bundle as name.
+func writeFile(tw *tar.Writer, name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", src, err)
+	}
+
+	return writeEntry(tw, name, info.Size(), f)
+}
+
+// writeEntry adds a regular file of the given size, read from r, to the bundle.
+func writeEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     archivePermission,
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to offline bundle: %w", name, err)
+	}
+
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("failed to write %s to offline bundle: %w", name, err)
+	}
+
+	return nil
+}
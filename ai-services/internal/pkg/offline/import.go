@@ -0,0 +1,263 @@
+package offline
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const modelDirPermission = 0o755
+
+// ImportOptions configures an offline import.
+type ImportOptions struct {
+	// File is the bundle to import.
+	File string
+	// ModelsDir is where the models are placed.
+	ModelsDir string
+	// ManifestsDir keeps the manifest of the bundle once it is imported, so that
+	// its images are not pruned before the template is deployed. Empty skips it.
+	ManifestsDir string
+	// Force imports a bundle that was made for different catalog assets.
+	Force bool
+}
+
+// imageLoader loads an image archive into local storage.
+type imageLoader interface {
+	LoadImages(ctx context.Context, r io.Reader) ([]string, error)
+}
+
+// Import loads the images of a bundle into local storage and places its models in
+// the models directory, verifying every model file against the manifest.
+func Import(ctx context.Context, opts ImportOptions) error {
+	rt, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	return importBundle(ctx, rt, opts)
+}
+
+func importBundle(ctx context.Context, loader imageLoader, opts ImportOptions) error {
+	f, err := os.Open(opts.File)
+	if err != nil {
+		return fmt.Errorf("failed to open offline bundle: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	tr := tar.NewReader(f)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return err
+	}
+	if err := checkManifest(manifest, opts.Force); err != nil {
+		return err
+	}
+	logger.Infof("Importing offline bundle for template '%s' made with CLI %s at %s\n",
+		manifest.Template, manifest.CLIVersion, manifest.CreatedAt.Format(time.RFC3339))
+
+	models := newModelImporter(opts.ModelsDir, manifest.Models)
+	imagesLoaded := false
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read offline bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case header.Name == ImagesFile:
+			logger.Infof("Loading %d images into local storage...\n", len(manifest.Images))
+			names, err := loader.LoadImages(ctx, tr)
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				logger.Infof("- %s\n", name)
+			}
+			imagesLoaded = true
+		case strings.HasPrefix(header.Name, ModelsDir+"/"):
+			if err := models.extract(strings.TrimPrefix(header.Name, ModelsDir+"/"), tr); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !imagesLoaded && len(manifest.Images) > 0 {
+		return fmt.Errorf("offline bundle has no %s", ImagesFile)
+	}
+
+	if err := models.finish(); err != nil {
+		return err
+	}
+
+	if err := keepManifest(opts.ManifestsDir, manifest); err != nil {
+		return err
+	}
+
+	logger.Infof("✅ Offline bundle imported: %d images, %d models\n", len(manifest.Images), len(manifest.Models))
+
+	return nil
+}
+
+// readManifest reads the manifest, the first entry of a bundle.
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline bundle: %w", err)
+	}
+	if header.Name != ManifestFile {
+		return nil, fmt.Errorf("not an offline bundle: %s is missing", ManifestFile)
+	}
+
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse offline bundle manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// checkManifest checks that the bundle fits this host and CLI.
+func checkManifest(manifest *Manifest, force bool) error {
+	if manifest.Version > ManifestVersion {
+		return fmt.Errorf("offline bundle was written by a newer CLI (%s); upgrade ai-services to import it", manifest.CLIVersion)
+	}
+
+	if manifest.Architecture != goruntime.GOARCH {
+		return fmt.Errorf("offline bundle is for %s, this host is %s", manifest.Architecture, goruntime.GOARCH)
+	}
+
+	digest, err := CatalogDigest()
+	if err != nil {
+		return err
+	}
+	if manifest.CatalogDigest != digest {
+		if !force {
+			return fmt.Errorf("offline bundle was made with CLI %s whose templates differ from this CLI (%s); "+
+				"use the same CLI version or --force", manifest.CLIVersion, vars.CLIVersion)
+		}
+		logger.Warningf("Offline bundle was made with CLI %s whose templates differ from this CLI (%s)\n", manifest.CLIVersion, vars.CLIVersion)
+	}
+
+	return nil
+}
+
+// modelImporter places the model files of a bundle in the models directory.
+type modelImporter struct {
+	modelsDir string
+	models    []Model
+	// expected maps the bundle path of every model file to its model and file.
+	expected map[string]modelFile
+	seen     map[string]bool
+}
+
+type modelFile struct {
+	model string
+	file  modelstore.File
+}
+
+func newModelImporter(modelsDir string, models []Model) *modelImporter {
+	m := &modelImporter{
+		modelsDir: modelsDir,
+		models:    models,
+		expected:  map[string]modelFile{},
+		seen:      map[string]bool{},
+	}
+
+	for _, model := range models {
+		for _, f := range model.Files {
+			m.expected[path.Join(model.Model, f.Path)] = modelFile{model: model.Model, file: f}
+		}
+	}
+
+	return m
+}
+
+// extract writes the model file at name, relative to the models directory of
+// the bundle, and checks it against the manifest.
+func (m *modelImporter) extract(name string, r io.Reader) error {
+	expected, ok := m.expected[name]
+	if !ok {
+		return fmt.Errorf("offline bundle has unexpected model file %s", name)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return fmt.Errorf("offline bundle has invalid model file path %s", name)
+	}
+
+	dst := filepath.Join(m.modelsDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dst), modelDirPermission); err != nil {
+		return fmt.Errorf("failed to create directory for model %s: %w", expected.model, err)
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	if size != expected.file.Size || hex.EncodeToString(h.Sum(nil)) != expected.file.SHA256 {
+		return fmt.Errorf("model file %s does not match the offline bundle manifest", name)
+	}
+
+	m.seen[name] = true
+
+	return f.Close()
+}
+
+// finish checks that every model file was placed and records the models as
+// complete.
+func (m *modelImporter) finish() error {
+	for name, expected := range m.expected {
+		if !m.seen[name] {
+			return fmt.Errorf("offline bundle is missing file %s of model %s", name, expected.model)
+		}
+	}
+
+	for _, model := range m.models {
+		metadata := &modelstore.Metadata{
+			Model:    model.Model,
+			Revision: model.Revision,
+			Source:   modelstore.SourceOffline,
+			StoredAt: time.Now().UTC(),
+			Files:    model.Files,
+		}
+		if err := modelstore.WriteMetadata(m.modelsDir, metadata); err != nil {
+			return err
+		}
+		logger.Infof("Model %s placed in %s\n", model.Model, modelstore.Dir(m.modelsDir, model.Model))
+	}
+
+	return nil
+}
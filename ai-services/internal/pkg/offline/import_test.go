@@ -0,0 +1,139 @@
+package offline
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+)
+
+type fakeLoader struct {
+	loaded []byte
+}
+
+func (f *fakeLoader) LoadImages(_ context.Context, r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	f.loaded = data
+
+	return []string{"icr.io/ai-services/tools:0.11"}, err
+}
+
+const (
+	testModel = "ibm-granite/granite-embedding"
+	weights   = "weights"
+)
+
+// writeTestBundle writes a bundle with one image archive and one model file,
+// whose content is modelData, and returns its path.
+func writeTestBundle(t *testing.T, manifest *Manifest, modelData string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "bundle.tar")
+	f, err := os.Create(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, writeEntry(tw, ManifestFile, int64(len(data)), bytes.NewReader(data)))
+	require.NoError(t, writeEntry(tw, ImagesFile, 6, bytes.NewReader([]byte("images"))))
+	require.NoError(t, writeEntry(tw, "models/"+testModel+"/model.onnx", int64(len(modelData)), bytes.NewReader([]byte(modelData))))
+	require.NoError(t, writeEntry(tw, "catalog/metadata.yaml", 0, bytes.NewReader(nil)))
+	require.NoError(t, tw.Close())
+
+	return file
+}
+
+func testManifest(t *testing.T) *Manifest {
+	t.Helper()
+
+	digest, err := CatalogDigest()
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte(weights))
+
+	return &Manifest{
+		Version:       ManifestVersion,
+		Template:      "rag",
+		Architecture:  goruntime.GOARCH,
+		CatalogDigest: digest,
+		Images:        []string{"icr.io/ai-services/tools:0.11"},
+		Models: []Model{{
+			Model: testModel,
+			Files: []modelstore.File{{Path: "model.onnx", Size: int64(len(weights)), SHA256: hex.EncodeToString(sum[:])}},
+		}},
+	}
+}
+
+func TestImportBundle(t *testing.T) {
+	modelsDir := t.TempDir()
+	loader := &fakeLoader{}
+	file := writeTestBundle(t, testManifest(t), weights)
+
+	manifestsDir := t.TempDir()
+	require.NoError(t, importBundle(context.Background(), loader, ImportOptions{File: file, ModelsDir: modelsDir, ManifestsDir: manifestsDir}))
+
+	assert.Equal(t, "images", string(loader.loaded))
+	data, err := os.ReadFile(filepath.Join(modelsDir, testModel, "model.onnx"))
+	require.NoError(t, err)
+	assert.Equal(t, weights, string(data))
+
+	metadata, err := modelstore.ReadMetadata(modelsDir, testModel)
+	require.NoError(t, err)
+	assert.Equal(t, modelstore.SourceOffline, metadata.Source)
+	assert.True(t, modelstore.Complete(modelsDir, testModel))
+
+	images, err := ImportedImages(manifestsDir)
+	require.NoError(t, err)
+	assert.Equal(t, testManifest(t).Images, images)
+}
+
+func TestImportBundleRejects(t *testing.T) {
+	t.Run("corrupt model file", func(t *testing.T) {
+		file := writeTestBundle(t, testManifest(t), "corrupt")
+
+		err := importBundle(context.Background(), &fakeLoader{}, ImportOptions{File: file, ModelsDir: t.TempDir()})
+		require.ErrorContains(t, err, "does not match the offline bundle manifest")
+	})
+
+	t.Run("other architecture", func(t *testing.T) {
+		manifest := testManifest(t)
+		manifest.Architecture = "s390x-test"
+		file := writeTestBundle(t, manifest, weights)
+
+		err := importBundle(context.Background(), &fakeLoader{}, ImportOptions{File: file, ModelsDir: t.TempDir()})
+		require.ErrorContains(t, err, "offline bundle is for s390x-test")
+	})
+
+	t.Run("other catalog assets", func(t *testing.T) {
+		manifest := testManifest(t)
+		manifest.CatalogDigest = "sha256:other"
+		file := writeTestBundle(t, manifest, weights)
+
+		err := importBundle(context.Background(), &fakeLoader{}, ImportOptions{File: file, ModelsDir: t.TempDir()})
+		require.ErrorContains(t, err, "templates differ")
+
+		require.NoError(t, importBundle(context.Background(), &fakeLoader{}, ImportOptions{File: file, ModelsDir: t.TempDir(), Force: true}))
+	})
+
+	t.Run("unknown model file", func(t *testing.T) {
+		manifest := testManifest(t)
+		manifest.Models[0].Model = "other/model"
+		file := writeTestBundle(t, manifest, weights)
+
+		err := importBundle(context.Background(), &fakeLoader{}, ImportOptions{File: file, ModelsDir: t.TempDir()})
+		require.ErrorContains(t, err, "unexpected model file")
+	})
+}
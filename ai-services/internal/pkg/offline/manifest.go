@@ -0,0 +1,143 @@
+// Package offline packs everything a template needs into one archive, and loads
+// such an archive on a host without access to container registries or Hugging
+// Face.
+package offline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/project-ai-services/ai-services/assets"
+	"github.com/project-ai-services/ai-services/internal/pkg/modelstore"
+)
+
+// ManifestVersion is the version of the manifest layout.
+const ManifestVersion = 1
+
+const (
+	manifestDirPermission  = 0o755
+	manifestFilePermission = 0o644
+)
+
+// Layout of an offline bundle. The manifest is the first entry so that a bundle
+// is checked before anything is loaded from it.
+const (
+	ManifestFile = "manifest.json"
+	ImagesFile   = "images.tar"
+	ModelsDir    = "models"
+	CatalogDir   = "catalog"
+)
+
+// Manifest describes the contents of an offline bundle.
+type Manifest struct {
+	Version      int       `json:"version"`
+	CLIVersion   string    `json:"cliVersion"`
+	Template     string    `json:"template"`
+	Architecture string    `json:"architecture"`
+	CreatedAt    time.Time `json:"createdAt"`
+	// CatalogDigest identifies the catalog assets the bundle was made for. Images
+	// and models only match the templates of a CLI with the same assets.
+	CatalogDigest string   `json:"catalogDigest"`
+	Images        []string `json:"images"`
+	Models        []Model  `json:"models"`
+}
+
+// Model is a model in an offline bundle.
+type Model struct {
+	Model    string            `json:"model"`
+	Revision string            `json:"revision,omitempty"`
+	Files    []modelstore.File `json:"files"`
+}
+
+// keepManifest writes the manifest of an imported bundle into dir, replacing the
+// manifest of an earlier bundle of the same template.
+func keepManifest(dir string, manifest *Manifest) error {
+	if dir == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal offline bundle manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, manifestDirPermission); err != nil {
+		return fmt.Errorf("failed to create directory for offline bundle manifests: %w", err)
+	}
+
+	file := filepath.Join(dir, filepath.Base(manifest.Template)+".json")
+	if err := os.WriteFile(file, data, manifestFilePermission); err != nil {
+		return fmt.Errorf("failed to keep offline bundle manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ImportedImages returns the images of the bundles whose manifests are kept in dir.
+func ImportedImages(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read offline bundle manifest: %w", err)
+		}
+
+		var manifest Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse offline bundle manifest %s: %w", file, err)
+		}
+		images = append(images, manifest.Images...)
+	}
+
+	return images, nil
+}
+
+// Size returns the total size of the models in the bundle.
+func (m *Manifest) Size() int64 {
+	var size int64
+	for _, model := range m.Models {
+		for _, f := range model.Files {
+			size += f.Size
+		}
+	}
+
+	return size
+}
+
+// CatalogDigest returns the digest of the catalog assets built into the CLI.
+func CatalogDigest() (string, error) {
+	h := sha256.New()
+
+	// WalkDir visits entries in lexical order, so the digest is stable.
+	err := fs.WalkDir(&assets.CatalogFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(&assets.CatalogFS, path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(data))
+		h.Write(data)
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read catalog assets: %w", err)
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -81,13 +81,30 @@ func (c *caddyManager) RegisterRoute(ctx context.Context, route Route) error {
 		return fmt.Errorf("cannot register route: route ID is empty")
 	}
 
+	handler := map[string]any{
+		"handler":   "reverse_proxy",
+		"upstreams": []map[string]any{{"dial": route.Upstream}},
+	}
+	if route.UpstreamTLS {
+		handler["transport"] = map[string]any{
+			"protocol": "http",
+			"tls":      map[string]any{"insecure_skip_verify": true},
+		}
+	}
+
+	match := map[string]any{"host": []string{route.Domain}}
+	if len(route.Headers) > 0 {
+		headers := make(map[string][]string, len(route.Headers))
+		for name, value := range route.Headers {
+			headers[name] = []string{value}
+		}
+		match["header"] = headers
+	}
+
 	routeConfig := map[string]any{
-		"@id":   route.ID,
-		"match": []map[string]any{{"host": []string{route.Domain}}},
-		"handle": []map[string]any{{
-			"handler":   "reverse_proxy",
-			"upstreams": []map[string]any{{"dial": route.Upstream}},
-		}},
+		"@id":      route.ID,
+		"match":    []map[string]any{match},
+		"handle":   []map[string]any{handler},
 		"terminal": route.Terminal,
 	}
 
@@ -195,6 +212,41 @@ func (c *caddyManager) GetRouteByID(routeID string) (*Route, error) {
 	}, nil
 }
 
+// ListRoutes returns the routes of the server that have an ID.
+func (c *caddyManager) ListRoutes(ctx context.Context) ([]Route, error) {
+	routeURL, err := url.JoinPath(c.adminURL, "config", "apps", "http", "servers", c.serverName, "routes")
+	if err != nil {
+		return nil, err
+	}
+
+	var rawRoutes []map[string]any
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&rawRoutes).
+		Get(routeURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("caddy returned status %d listing routes: %s", resp.StatusCode(), resp.String())
+	}
+
+	var routes []Route
+	for _, rawRoute := range rawRoutes {
+		id, ok := rawRoute["@id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+
+		// Routes without a host matcher are listed with an empty domain.
+		domain, _ := extractDomainFromRoute(rawRoute)
+		routes = append(routes, Route{ID: id, Domain: domain})
+	}
+
+	return routes, nil
+}
+
 // UnregisterRoute removes a route from Caddy by its ID.
 // Returns ErrRouteNotFound if the route doesn't exist (404), nil if successfully deleted (200).
 func (c *caddyManager) UnregisterRoute(routeID string) error {
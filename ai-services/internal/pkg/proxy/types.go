@@ -15,6 +15,9 @@ type ProxyManager interface {
 
 	// GetRouteByID retrieves a specific route by its ID from the proxy
 	GetRouteByID(routeID string) (*Route, error)
+
+	// ListRoutes returns the ID and domain of every route with an ID
+	ListRoutes(ctx context.Context) ([]Route, error)
 }
 
 // Route represents a reverse proxy route configuration.
@@ -33,6 +36,13 @@ type Route struct {
 
 	// Type indicates the endpoint type
 	Type string
+
+	// UpstreamTLS indicates the upstream serves HTTPS, typically with a
+	// self-signed certificate, so its certificate is not verified
+	UpstreamTLS bool
+
+	// Headers restricts the route to requests carrying these header values
+	Headers map[string]string
 }
 
 // Made with Bob
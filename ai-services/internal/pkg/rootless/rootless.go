@@ -0,0 +1,336 @@
+// Package rootless checks and configures the host for running podman
+// applications as a non-root user.
+//
+// Rootless podman runs in the systemd user manager of the user, so the user
+// needs subordinate IDs for the user namespace of the containers, lingering so
+// the manager and its podman socket run without a login session, and the cgroup
+// controllers delegated so resource limits apply to the containers. The Spyre
+// cards are reached through the VFIO devices owned by the sentient group, and
+// the base directory holding models and application data is owned by the user.
+package rootless
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	// subIDStart is the first subordinate ID handed out, as useradd does.
+	subIDStart = 100000
+	// subIDCount is the number of subordinate IDs handed out per user.
+	subIDCount = 65536
+	// subIDFields is the number of fields of an /etc/subuid entry.
+	subIDFields = 3
+)
+
+// Paths of the host configuration; replaced in tests.
+var (
+	subuidPath     = "/etc/subuid"
+	subgidPath     = "/etc/subgid"
+	lingerDir      = "/var/lib/systemd/linger"
+	cgroupRoot     = "/sys/fs/cgroup"
+	delegateDropIn = "/etc/systemd/system/user@.service.d/delegate.conf"
+)
+
+// DelegatedControllers are the cgroup controllers delegated to the user manager,
+// which podman needs to apply the CPU, memory and pids limits of the containers.
+var DelegatedControllers = []string{"cpu", "cpuset", "io", "memory", "pids"}
+
+// User returns the user podman runs as: the user who ran sudo, or the current
+// user when not running as root. It returns nil when podman runs as root.
+func User() (*user.User, error) {
+	if os.Geteuid() != 0 {
+		u, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+
+		return u, nil
+	}
+
+	sudoUser := os.Getenv("SUDO_USER")
+	if sudoUser == "" {
+		return nil, nil
+	}
+
+	u, err := user.Lookup(sudoUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup user %s: %w", sudoUser, err)
+	}
+	if u.Uid == "0" {
+		return nil, nil
+	}
+
+	return u, nil
+}
+
+// MissingSubIDs returns the subordinate ID files without an entry for u.
+func MissingSubIDs(u *user.User) ([]string, error) {
+	var missing []string
+	for _, path := range []string{subuidPath, subgidPath} {
+		entries, err := readSubIDs(path)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(entries, func(e subIDRange) bool { return e.owner == u.Username || e.owner == u.Uid }) {
+			missing = append(missing, path)
+		}
+	}
+
+	return missing, nil
+}
+
+// AddSubIDs gives u a range of subordinate user and group IDs after the ranges
+// already handed out, in the files missing an entry for u.
+func AddSubIDs(u *user.User) error {
+	missing, err := MissingSubIDs(u)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range missing {
+		entries, err := readSubIDs(path)
+		if err != nil {
+			return err
+		}
+		start := nextSubIDStart(entries)
+		ids := fmt.Sprintf("%d-%d", start, start+subIDCount-1)
+
+		flag := "--add-subuids"
+		if path == subgidPath {
+			flag = "--add-subgids"
+		}
+		if out, err := exec.Command("usermod", flag, ids, u.Username).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to add subordinate IDs %s to %s: %w, output: %s", ids, u.Username, err, string(out))
+		}
+	}
+
+	return nil
+}
+
+// Lingering reports whether the user manager of u runs without a login session.
+func Lingering(u *user.User) bool {
+	_, err := os.Stat(filepath.Join(lingerDir, u.Username))
+
+	return err == nil
+}
+
+// EnableLinger keeps the user manager of u, and so its podman socket and pods,
+// running after logout and starts it on boot.
+func EnableLinger(u *user.User) error {
+	if out, err := exec.Command("loginctl", "enable-linger", u.Username).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to enable lingering for %s: %w, output: %s", u.Username, err, string(out))
+	}
+
+	return nil
+}
+
+// MissingControllers returns the DelegatedControllers not delegated to the user
+// manager of u. When the manager is not running, the controllers configured to
+// be delegated to it are checked instead.
+func MissingControllers(u *user.User) ([]string, error) {
+	controllersFile := filepath.Join(cgroupRoot, "user.slice", "user-"+u.Uid+".slice", "user@"+u.Uid+".service", "cgroup.controllers")
+	data, err := os.ReadFile(controllersFile)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = configuredControllers()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delegated cgroup controllers: %w", err)
+	}
+
+	have := strings.Fields(string(data))
+	var missing []string
+	for _, c := range DelegatedControllers {
+		if !slices.Contains(have, c) {
+			missing = append(missing, c)
+		}
+	}
+
+	return missing, nil
+}
+
+// ConfigureDelegation delegates DelegatedControllers to the user managers. It
+// applies once a user manager restarts, e.g. after 'loginctl terminate-user'.
+func ConfigureDelegation() error {
+	if err := os.MkdirAll(filepath.Dir(delegateDropIn), constants.DirPerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(delegateDropIn), err)
+	}
+
+	content := fmt.Sprintf("[Service]\nDelegate=%s\n", strings.Join(DelegatedControllers, " "))
+	if err := os.WriteFile(delegateDropIn, []byte(content), constants.FilePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", delegateDropIn, err)
+	}
+
+	if out, err := exec.Command("systemctl", "daemon-reload").CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to reload systemd daemon: %w, output: %s", err, string(out))
+	}
+
+	return nil
+}
+
+// configuredControllers returns the controllers the drop-in delegates.
+func configuredControllers() ([]byte, error) {
+	data, err := os.ReadFile(delegateDropIn)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var controllers []string
+	for line := range strings.Lines(string(data)) {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Delegate="); ok {
+			controllers = append(controllers, strings.Fields(value)...)
+		}
+	}
+
+	return []byte(strings.Join(controllers, " ")), nil
+}
+
+// InGroup reports whether u is a member of the group name.
+func InGroup(u *user.User, name string) (bool, error) {
+	group, err := user.LookupGroup(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to lookup group %s: %w", name, err)
+	}
+
+	gids, err := u.GroupIds()
+	if err != nil {
+		return false, fmt.Errorf("failed to get groups of %s: %w", u.Username, err)
+	}
+
+	return slices.Contains(gids, group.Gid), nil
+}
+
+// DataDirs returns the directories below the base directory the applications
+// and the catalog write to.
+func DataDirs() []string {
+	return []string{utils.GetBaseDir(), utils.GetApplicationsPath(), utils.GetModelsPath(), utils.GetSystemdUnitsPath()}
+}
+
+// UnownedDirs returns the DataDirs missing or not owned by u.
+func UnownedDirs(u *user.User) ([]string, error) {
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid %s: %w", u.Uid, err)
+	}
+
+	var unowned []string
+	for _, dir := range DataDirs() {
+		info, err := os.Stat(dir)
+		if err != nil || !ownedBy(info, uid) {
+			unowned = append(unowned, dir)
+		}
+	}
+
+	return unowned, nil
+}
+
+// OwnDataDirs creates the DataDirs and hands the ones not owned by u, with their
+// content, to u and the sentient group.
+func OwnDataDirs(u *user.User) error {
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return fmt.Errorf("invalid uid %s: %w", u.Uid, err)
+	}
+	group, err := user.LookupGroup(constants.SentientGroupName)
+	if err != nil {
+		return fmt.Errorf("failed to lookup group %s: %w", constants.SentientGroupName, err)
+	}
+	gid, err := strconv.Atoi(group.Gid)
+	if err != nil {
+		return fmt.Errorf("invalid gid %s: %w", group.Gid, err)
+	}
+
+	unowned, err := UnownedDirs(u)
+	if err != nil {
+		return err
+	}
+	for _, dir := range unowned {
+		if err := os.MkdirAll(dir, constants.DirPerm); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+		// Models or data written by root podman before are handed over too.
+		err := filepath.WalkDir(dir, func(path string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			return os.Lchown(path, uid, gid)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to change owner of %s to %s: %w", dir, u.Username, err)
+		}
+	}
+
+	return nil
+}
+
+func ownedBy(info os.FileInfo, uid int) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+
+	return ok && int(stat.Uid) == uid
+}
+
+// subIDRange is an entry of /etc/subuid or /etc/subgid.
+type subIDRange struct {
+	owner string
+	start int
+	count int
+}
+
+// readSubIDs returns the entries of a subordinate ID file; a missing file has none.
+func readSubIDs(path string) ([]subIDRange, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []subIDRange
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) != subIDFields {
+			continue
+		}
+		start, errStart := strconv.Atoi(fields[1])
+		count, errCount := strconv.Atoi(fields[2])
+		if errStart != nil || errCount != nil {
+			continue
+		}
+		entries = append(entries, subIDRange{owner: fields[0], start: start, count: count})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// nextSubIDStart returns the first ID after every range handed out.
+func nextSubIDStart(entries []subIDRange) int {
+	next := subIDStart
+	for _, e := range entries {
+		next = max(next, e.start+e.count)
+	}
+
+	return next
+}
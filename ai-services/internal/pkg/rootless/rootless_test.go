@@ -0,0 +1,88 @@
+package rootless
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setPaths(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	origSubuid, origSubgid, origCgroup, origDropIn := subuidPath, subgidPath, cgroupRoot, delegateDropIn
+	subuidPath = filepath.Join(dir, "subuid")
+	subgidPath = filepath.Join(dir, "subgid")
+	cgroupRoot = filepath.Join(dir, "cgroup")
+	delegateDropIn = filepath.Join(dir, "delegate.conf")
+	t.Cleanup(func() {
+		subuidPath, subgidPath, cgroupRoot, delegateDropIn = origSubuid, origSubgid, origCgroup, origDropIn
+	})
+
+	return dir
+}
+
+func TestMissingSubIDs(t *testing.T) {
+	setPaths(t)
+	u := &user.User{Username: "aiuser", Uid: "1001"}
+
+	missing, err := MissingSubIDs(u)
+	require.NoError(t, err)
+	assert.Equal(t, []string{subuidPath, subgidPath}, missing, "missing files have no entries")
+
+	require.NoError(t, os.WriteFile(subuidPath, []byte("other:100000:65536\naiuser:165536:65536\n"), 0o644))
+	require.NoError(t, os.WriteFile(subgidPath, []byte("1001:100000:65536\n"), 0o644))
+	missing, err = MissingSubIDs(u)
+	require.NoError(t, err)
+	assert.Empty(t, missing, "entries match the user name or the uid")
+}
+
+func TestNextSubIDStart(t *testing.T) {
+	assert.Equal(t, 100000, nextSubIDStart(nil))
+	assert.Equal(t, 231072, nextSubIDStart([]subIDRange{
+		{owner: "a", start: 165536, count: 65536},
+		{owner: "b", start: 100000, count: 65536},
+	}))
+}
+
+func TestMissingControllers(t *testing.T) {
+	setPaths(t)
+	u := &user.User{Username: "aiuser", Uid: "1001"}
+
+	missing, err := MissingControllers(u)
+	require.NoError(t, err)
+	assert.Equal(t, DelegatedControllers, missing, "nothing is delegated by default")
+
+	require.NoError(t, os.WriteFile(delegateDropIn, []byte("[Service]\nDelegate=cpu cpuset io memory pids\n"), 0o644))
+	missing, err = MissingControllers(u)
+	require.NoError(t, err)
+	assert.Empty(t, missing, "the drop-in is checked while the user manager is not running")
+
+	managerDir := filepath.Join(cgroupRoot, "user.slice", "user-1001.slice", "user@1001.service")
+	require.NoError(t, os.MkdirAll(managerDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(managerDir, "cgroup.controllers"), []byte("memory pids\n"), 0o644))
+	missing, err = MissingControllers(u)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "cpuset", "io"}, missing, "a running manager keeps the controllers it started with")
+}
+
+func TestUnownedDirs(t *testing.T) {
+	t.Setenv("AI_SERVICES_BASE_DIR", t.TempDir())
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	unowned, err := UnownedDirs(current)
+	require.NoError(t, err)
+	assert.Equal(t, DataDirs()[1:], unowned, "only the base directory exists")
+
+	for _, dir := range DataDirs() {
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+	}
+	unowned, err = UnownedDirs(current)
+	require.NoError(t, err)
+	assert.Empty(t, unowned)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/containers/podman/v5/pkg/specgen"
 	"github.com/project-ai-services/ai-services/internal/pkg/accelerator/spyre"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/image/mirror"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
@@ -84,6 +85,12 @@ func (pc *PodmanClient) ListImages() ([]types.Image, error) {
 }
 
 func (pc *PodmanClient) PullImage(ctx context.Context, image string) error {
+	// Pull from the private registry the image is mirrored to, if any.
+	if mirrored := mirror.Resolve(image); mirrored != image {
+		logger.DebugfCtx(ctx, "Image %s is mirrored to %s\n", image, mirrored)
+		image = mirrored
+	}
+
 	logger.InfofCtx(ctx, "Pulling image %s...\n", image)
 
 	// Create pull options with auth file from environment
@@ -107,6 +114,42 @@ func (pc *PodmanClient) PullImage(ctx context.Context, image string) error {
 	return nil
 }
 
+// ExportImages writes images from local storage into w as one docker archive.
+func (pc *PodmanClient) ExportImages(ctx context.Context, names []string, w io.Writer) error {
+	podCtx, cancel := pc.podmanCtx(ctx)
+	defer cancel()
+
+	format := "docker-archive"
+	if err := images.Export(podCtx, names, w, &images.ExportOptions{Format: &format}); err != nil {
+		return fmt.Errorf("failed to export images: %w", err)
+	}
+
+	return nil
+}
+
+// LoadImages loads the images of an image archive read from r into local storage
+// and returns their names.
+func (pc *PodmanClient) LoadImages(ctx context.Context, r io.Reader) ([]string, error) {
+	podCtx, cancel := pc.podmanCtx(ctx)
+	defer cancel()
+
+	report, err := images.Load(podCtx, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load images: %w", err)
+	}
+
+	return report.Names, nil
+}
+
+// TagImage adds the name repo:tag to a local image.
+func (pc *PodmanClient) TagImage(nameOrID, repo, tag string) error {
+	if err := images.Tag(pc.Context, nameOrID, tag, repo, nil); err != nil {
+		return fmt.Errorf("failed to tag image %s as %s:%s: %w", nameOrID, repo, tag, err)
+	}
+
+	return nil
+}
+
 func (pc *PodmanClient) ListPods(filters map[string][]string) ([]types.Pod, error) {
 	var listOpts pods.ListOptions
 
@@ -468,6 +511,86 @@ func (pc *PodmanClient) VolumeExists(nameOrID string) (bool, error) {
 	return volumes.Exists(pc.Context, nameOrID, nil)
 }
 
+// ExportVolume writes the contents of a volume to w as a tar archive.
+func (pc *PodmanClient) ExportVolume(name string, w io.Writer) error {
+	if err := volumes.Export(pc.Context, name, w); err != nil {
+		return fmt.Errorf("failed to export volume %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ImportVolume extracts a tar archive read from r into a volume.
+func (pc *PodmanClient) ImportVolume(name string, r io.Reader) error {
+	if err := volumes.Import(pc.Context, name, r); err != nil {
+		return fmt.Errorf("failed to import volume %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ImageDiskUsage lists the local images with the space only they hold and the
+// number of containers using them.
+func (pc *PodmanClient) ImageDiskUsage() ([]types.ImageUsage, error) {
+	list, err := images.List(pc.Context, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	df, err := system.DiskUsage(pc.Context, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	out := make([]types.ImageUsage, 0, len(list))
+	for _, img := range list {
+		usage := types.ImageUsage{
+			ID:          img.ID,
+			RepoTags:    img.RepoTags,
+			RepoDigests: img.RepoDigests,
+			Size:        img.Size,
+			Containers:  img.Containers,
+		}
+		for _, r := range df.Images {
+			if r.ImageID != "" && strings.HasPrefix(img.ID, r.ImageID) {
+				usage.Size = r.UniqueSize
+				usage.Containers = max(usage.Containers, r.Containers)
+
+				break
+			}
+		}
+		out = append(out, usage)
+	}
+
+	return out, nil
+}
+
+// RemoveImage removes an image with all its tags. Images used by containers are
+// not removed.
+func (pc *PodmanClient) RemoveImage(id string) error {
+	if _, errs := images.Remove(pc.Context, []string{id}, nil); len(errs) > 0 {
+		return fmt.Errorf("failed to remove image %s: %w", id, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// VolumeDiskUsage lists the volumes with their size and the number of containers
+// using them.
+func (pc *PodmanClient) VolumeDiskUsage() ([]types.VolumeUsage, error) {
+	df, err := system.DiskUsage(pc.Context, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	out := make([]types.VolumeUsage, 0, len(df.Volumes))
+	for _, v := range df.Volumes {
+		out = append(out, types.VolumeUsage{Name: v.VolumeName, Size: v.Size, Containers: v.Links})
+	}
+
+	return out, nil
+}
+
 func (pc *PodmanClient) ListSecrets(filters map[string][]string) ([]string, error) {
 	var listOpts secrets.ListOptions
 	if len(filters) >= 1 {
@@ -491,6 +614,32 @@ func (pc *PodmanClient) SecretExists(nameOrID string) (bool, error) {
 	return secrets.Exists(pc.Context, nameOrID)
 }
 
+// SecretData returns the data and labels of a secret.
+func (pc *PodmanClient) SecretData(name string) (string, map[string]string, error) {
+	opts := &secrets.InspectOptions{}
+	opts.WithShowSecret(true)
+
+	info, err := secrets.Inspect(pc.Context, name, opts)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to inspect secret %s: %w", name, err)
+	}
+
+	return info.SecretData, info.Spec.Labels, nil
+}
+
+// ReplaceSecret creates a secret with the given data and labels, replacing the
+// secret of that name if it exists. Containers see the new data once restarted.
+func (pc *PodmanClient) ReplaceSecret(name, data string, labels map[string]string) error {
+	opts := &secrets.CreateOptions{}
+	opts.WithName(name).WithLabels(labels).WithReplace(true)
+
+	if _, err := secrets.Create(pc.Context, strings.NewReader(data), opts); err != nil {
+		return fmt.Errorf("failed to replace secret %s: %w", name, err)
+	}
+
+	return nil
+}
+
 func (pc *PodmanClient) UpdateSecret(name, deploymentName string, data map[string][]byte) error {
 	logger.ErrorfCtx(pc.Context, "unsupported method called!")
 
@@ -704,6 +853,31 @@ func (pc *PodmanClient) ExecInContainerWithOutput(containerID string, cmd []stri
 	return string(output), nil
 }
 
+// ExecInContainerWithIO executes a command in a container with stdin and stdout
+// connected to the given reader and writer, either of which may be nil. It
+// streams data such as database dumps that are too large to buffer.
+func (pc *PodmanClient) ExecInContainerWithIO(ctx context.Context, containerID string, cmd []string, stdin io.Reader, stdout io.Writer) error {
+	args := make([]string, 0, execCommandFixedArgsCount+1+len(cmd))
+	args = append(args, "exec")
+	if stdin != nil {
+		args = append(args, "-i")
+	}
+	args = append(args, containerID)
+	args = append(args, cmd...)
+
+	var stderr strings.Builder
+	execCmd := exec.CommandContext(ctx, "podman", args...)
+	execCmd.Stdin = stdin
+	execCmd.Stdout = stdout
+	execCmd.Stderr = &stderr
+
+	if err := execCmd.Run(); err != nil {
+		return fmt.Errorf("command failed: %w, output: %s", err, stderr.String())
+	}
+
+	return nil
+}
+
 // ExecInContainerWithEnv executes a command in a container with environment variables.
 // This is used to pass sensitive data like passwords without exposing them in process lists.
 // Environment variables are set inline in the shell command to avoid exposure.
@@ -53,6 +53,23 @@ type Image struct {
 	RepoDigests []string
 }
 
+// ImageUsage is a local image with the disk space only it holds and the number
+// of containers using it.
+type ImageUsage struct {
+	ID          string
+	RepoTags    []string
+	RepoDigests []string
+	Size        int64
+	Containers  int
+}
+
+// VolumeUsage is a volume with its size and the number of containers using it.
+type VolumeUsage struct {
+	Name       string
+	Size       int64
+	Containers int
+}
+
 type Route struct {
 	Name       string
 	HostPort   string
@@ -0,0 +1,184 @@
+package systemd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	// SystemUnitDir is where Sync installs the units.
+	SystemUnitDir = "/etc/systemd/system"
+	// SyncUnitName is the path unit running Sync when the units directory changes.
+	SyncUnitName = "ai-services-units.path"
+
+	syncServiceName  = "ai-services-units.service"
+	systemctlTimeout = 2 * time.Minute
+)
+
+// systemctl runs systemctl with args; replaced in tests.
+var systemctl = func(args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), systemctlTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %v: %w, output: %s", args, err, string(out))
+	}
+
+	return nil
+}
+
+// SyncResult lists the units Sync changed.
+type SyncResult struct {
+	Installed []string
+	Updated   []string
+	Removed   []string
+}
+
+// Sync makes the pod units installed in installDir match those in dir: new
+// units are installed and enabled, changed ones replaced and units whose pod
+// was deleted disabled and removed. Units written by hand are left alone.
+func Sync(dir, installDir string) (*SyncResult, error) {
+	want, err := readUnits(dir)
+	if err != nil {
+		return nil, err
+	}
+	have, err := readUnits(installDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	for _, name := range slices.Sorted(maps.Keys(have)) {
+		if _, ok := want[name]; ok {
+			continue
+		}
+		if err := systemctl("disable", "--now", name); err != nil {
+			return result, err
+		}
+		if err := os.Remove(filepath.Join(installDir, name)); err != nil {
+			return result, fmt.Errorf("failed to remove unit %s: %w", name, err)
+		}
+		result.Removed = append(result.Removed, name)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		current, installed := have[name]
+		if installed && bytes.Equal(current, want[name]) {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(installDir, name), want[name], unitFileMode); err != nil {
+			return result, fmt.Errorf("failed to install unit %s: %w", name, err)
+		}
+		if installed {
+			result.Updated = append(result.Updated, name)
+		} else {
+			result.Installed = append(result.Installed, name)
+		}
+	}
+
+	if len(result.Installed)+len(result.Updated)+len(result.Removed) == 0 {
+		return result, nil
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return result, err
+	}
+	// Starting a unit starts its pod, which the deployment already did; it only
+	// makes systemd stop the pods in order on shutdown.
+	for _, name := range result.Installed {
+		if err := systemctl("enable", "--now", name); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Stale describes the pod units that no longer match the pods of the host: units
+// whose pod was deleted, and units written by the catalog but not installed
+// because the path unit is not running. podExists reports whether a pod exists.
+func Stale(dir, installDir string, podExists func(name string) (bool, error)) ([]string, error) {
+	want, err := readUnits(dir)
+	if err != nil {
+		return nil, err
+	}
+	have, err := readUnits(installDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, name := range slices.Sorted(maps.Keys(have)) {
+		exists, err := podExists(podOf(name))
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case !exists:
+			stale = append(stale, fmt.Sprintf("%s starts pod %s, which no longer exists", name, podOf(name)))
+		case want[name] == nil:
+			stale = append(stale, fmt.Sprintf("%s is no longer written by the catalog", name))
+		case !bytes.Equal(have[name], want[name]):
+			stale = append(stale, fmt.Sprintf("%s differs from the unit written by the catalog", name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(want)) {
+		if have[name] == nil {
+			stale = append(stale, fmt.Sprintf("%s is not installed in %s", name, installDir))
+		}
+	}
+
+	return stale, nil
+}
+
+// InstallSyncUnit installs and starts the path unit that runs 'sync-units' with
+// the ai-services binary at executable whenever dir changes, then syncs once.
+func InstallSyncUnit(executable, dir string) error {
+	if err := os.MkdirAll(dir, unitDirMode); err != nil {
+		return fmt.Errorf("failed to create units directory: %w", err)
+	}
+
+	path := fmt.Sprintf(`[Unit]
+Description=Install the systemd units of AI Services pods
+
+[Path]
+PathChanged=%s
+
+[Install]
+WantedBy=multi-user.target
+`, dir)
+	service := fmt.Sprintf(`[Unit]
+Description=Install the systemd units of AI Services pods
+
+[Service]
+Type=oneshot
+ExecStart=%s bootstrap sync-units --runtime podman --dir %s
+`, executable, dir)
+
+	for name, content := range map[string]string{SyncUnitName: path, syncServiceName: service} {
+		if err := os.WriteFile(filepath.Join(SystemUnitDir, name), []byte(content), unitFileMode); err != nil {
+			return fmt.Errorf("failed to write unit %s: %w", name, err)
+		}
+	}
+
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	if err := systemctl("enable", "--now", SyncUnitName); err != nil {
+		return err
+	}
+
+	// Install the units written before the path unit was running.
+	_, err := Sync(dir, SystemUnitDir)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package systemd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeSystemctl(t *testing.T) *[]string {
+	t.Helper()
+
+	var calls []string
+	orig := systemctl
+	systemctl = func(args ...string) error {
+		calls = append(calls, strings.Join(args, " "))
+
+		return nil
+	}
+	t.Cleanup(func() { systemctl = orig })
+
+	return &calls
+}
+
+func TestRender(t *testing.T) {
+	unit := string(Unit{Pod: "rag--chat", After: []string{"rag--opensearch", "rag--backend"}}.Render())
+
+	assert.True(t, strings.HasPrefix(unit, header))
+	assert.Contains(t, unit, "After=network-online.target ai-services-pod-rag--backend.service ai-services-pod-rag--opensearch.service\n")
+	assert.Contains(t, unit, "Wants=network-online.target ai-services-pod-rag--backend.service ai-services-pod-rag--opensearch.service\n")
+	assert.Contains(t, unit, "ExecStart=/usr/bin/podman pod start rag--chat\n")
+	assert.Contains(t, unit, "ExecStop=-/usr/bin/podman pod stop rag--chat\n")
+	assert.Contains(t, unit, "WantedBy=multi-user.target\n")
+
+	rootless := string(Unit{Pod: "rag--chat", URL: "unix:///run/user/1001/podman/podman.sock"}.Render())
+	assert.Contains(t, rootless, "After=network-online.target user@1001.service\n")
+	assert.Contains(t, rootless, "ExecStart=/usr/bin/podman --url unix:///run/user/1001/podman/podman.sock pod start rag--chat\n")
+}
+
+func TestWriteRemove(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "systemd")
+
+	require.NoError(t, Write(dir, []Unit{{Pod: "a"}, {Pod: "b", After: []string{"a"}}}))
+	units, err := readUnits(dir)
+	require.NoError(t, err)
+	assert.Len(t, units, 2)
+
+	require.NoError(t, Remove(dir, []string{"a", "missing"}))
+	units, err = readUnits(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{UnitName("b")}, keys(units))
+}
+
+func TestSync(t *testing.T) {
+	calls := fakeSystemctl(t)
+	dir, installDir := t.TempDir(), t.TempDir()
+
+	// A unit written by hand is never touched.
+	handWritten := filepath.Join(installDir, UnitName("custom"))
+	require.NoError(t, os.WriteFile(handWritten, []byte("[Unit]\n"), unitFileMode))
+
+	require.NoError(t, Write(dir, []Unit{{Pod: "a"}, {Pod: "b", After: []string{"a"}}}))
+	result, err := Sync(dir, installDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{UnitName("a"), UnitName("b")}, result.Installed)
+	assert.Equal(t, []string{
+		"daemon-reload",
+		"enable --now " + UnitName("a"),
+		"enable --now " + UnitName("b"),
+	}, *calls)
+
+	*calls = nil
+	result, err = Sync(dir, installDir)
+	require.NoError(t, err)
+	assert.Empty(t, result.Installed)
+	assert.Empty(t, *calls, "nothing to do when the units are installed")
+
+	require.NoError(t, Write(dir, []Unit{{Pod: "b"}}))
+	require.NoError(t, Remove(dir, []string{"a"}))
+	result, err = Sync(dir, installDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{UnitName("b")}, result.Updated)
+	assert.Equal(t, []string{UnitName("a")}, result.Removed)
+	assert.Equal(t, []string{"disable --now " + UnitName("a"), "daemon-reload"}, *calls)
+	assert.NoFileExists(t, filepath.Join(installDir, UnitName("a")))
+	assert.FileExists(t, handWritten)
+}
+
+func TestStale(t *testing.T) {
+	fakeSystemctl(t)
+	dir, installDir := t.TempDir(), t.TempDir()
+
+	require.NoError(t, Write(dir, []Unit{{Pod: "a"}, {Pod: "b"}, {Pod: "gone"}}))
+	_, err := Sync(dir, installDir)
+	require.NoError(t, err)
+
+	podExists := func(name string) (bool, error) { return name != "gone", nil }
+	stale, err := Stale(dir, installDir, podExists)
+	require.NoError(t, err)
+	assert.Equal(t, []string{UnitName("gone") + " starts pod gone, which no longer exists"}, stale)
+
+	require.NoError(t, Write(dir, []Unit{{Pod: "a", After: []string{"b"}}, {Pod: "c"}}))
+	require.NoError(t, Remove(dir, []string{"b"}))
+	stale, err = Stale(dir, installDir, podExists)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		UnitName("a") + " differs from the unit written by the catalog",
+		UnitName("b") + " is no longer written by the catalog",
+		UnitName("gone") + " starts pod gone, which no longer exists",
+		UnitName("c") + " is not installed in " + installDir,
+	}, stale)
+}
+
+func keys(m map[string][]byte) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,160 @@
+// Package systemd generates the systemd units that start the pods of podman
+// applications when the host boots, and installs them on the host.
+//
+// The catalog API server runs in a container, so it only writes the units to
+// the units directory below the base directory. A path unit installed by
+// 'ai-services bootstrap configure' watches that directory and runs Sync on the
+// host, which installs, enables and removes the units.
+//
+// The units start the pods created at deployment time rather than playing their
+// manifests again, so the pods keep the Spyre cards, ports and secrets they were
+// created with.
+package systemd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// unitPrefix is the prefix of the units of application pods.
+	unitPrefix = "ai-services-pod-"
+	// header is the first line of every unit written by this package, telling
+	// them from units written by hand.
+	header = "# Generated by ai-services; do not edit.\n"
+
+	unitFileMode = 0o644
+	unitDirMode  = 0o755
+)
+
+// podmanBinary is the podman executable the units run.
+var podmanBinary = "/usr/bin/podman"
+
+// userSocket matches the socket of rootless podman, served by the user manager
+// of the user.
+var userSocket = regexp.MustCompile(`^unix:///run/user/(\d+)/`)
+
+// Unit starts a pod on boot.
+type Unit struct {
+	// Pod is the name of the pod.
+	Pod string
+	// After are the pods started before this one.
+	After []string
+	// URL is the podman socket of rootless podman owning the pod; empty for root podman.
+	URL string
+}
+
+// UnitName returns the name of the unit starting pod.
+func UnitName(pod string) string {
+	return unitPrefix + pod + ".service"
+}
+
+// Render returns the unit file. Dependencies are ordered with After= and pulled
+// in with Wants=, so a pod that fails to start does not keep the pods depending
+// on it down; the pods restart their failing containers themselves.
+func (u Unit) Render() []byte {
+	deps := []string{"network-online.target"}
+	podman := podmanBinary
+	if u.URL != "" {
+		podman += " --url " + u.URL
+		// The socket is started by the user manager, which lingering starts on boot.
+		if m := userSocket.FindStringSubmatch(u.URL); m != nil {
+			deps = append(deps, "user@"+m[1]+".service")
+		}
+	}
+	for _, pod := range slices.Sorted(slices.Values(u.After)) {
+		deps = append(deps, UnitName(pod))
+	}
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("[Unit]\n")
+	fmt.Fprintf(&b, "Description=AI Services pod %s\n", u.Pod)
+	fmt.Fprintf(&b, "Wants=%s\n", strings.Join(deps, " "))
+	fmt.Fprintf(&b, "After=%s\n", strings.Join(deps, " "))
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=oneshot\n")
+	b.WriteString("RemainAfterExit=yes\n")
+	fmt.Fprintf(&b, "ExecStart=%s pod start %s\n", podman, u.Pod)
+	// The pod is gone once its application is deleted; stopping the unit then must not fail.
+	fmt.Fprintf(&b, "ExecStop=-%s pod stop %s\n", podman, u.Pod)
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=multi-user.target\n")
+
+	return b.Bytes()
+}
+
+// Write writes units to dir, replacing the units of the same pods.
+func Write(dir string, units []Unit) error {
+	if err := os.MkdirAll(dir, unitDirMode); err != nil {
+		return fmt.Errorf("failed to create units directory: %w", err)
+	}
+
+	for _, u := range units {
+		path := filepath.Join(dir, UnitName(u.Pod))
+		// Write and rename, so the path unit never installs a partial file.
+		tmp := filepath.Join(dir, "."+UnitName(u.Pod)+".tmp")
+		if err := os.WriteFile(tmp, u.Render(), unitFileMode); err != nil {
+			return fmt.Errorf("failed to write unit %s: %w", UnitName(u.Pod), err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("failed to write unit %s: %w", UnitName(u.Pod), err)
+		}
+	}
+
+	return nil
+}
+
+// Remove removes the units of pods from dir. Pods without a unit are ignored.
+func Remove(dir string, pods []string) error {
+	var errs []error
+	for _, pod := range pods {
+		if err := os.Remove(filepath.Join(dir, UnitName(pod))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to remove unit %s: %w", UnitName(pod), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// readUnits returns the content of the pod units in dir by unit name. Units
+// written by hand are skipped. A missing dir has no units.
+func readUnits(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read units directory: %w", err)
+	}
+
+	units := make(map[string][]byte)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, unitPrefix) || !strings.HasSuffix(name, ".service") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read unit %s: %w", name, err)
+		}
+		if !bytes.HasPrefix(data, []byte(header)) {
+			continue
+		}
+		units[name] = data
+	}
+
+	return units, nil
+}
+
+// podOf returns the pod a unit starts.
+func podOf(unit string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(unit, unitPrefix), ".service")
+}
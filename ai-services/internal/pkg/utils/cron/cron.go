@@ -0,0 +1,212 @@
+// Package cron parses standard five-field cron expressions and computes their
+// next activation time.
+//
+// Supported syntax per field: "*", single values, ranges ("1-5"), lists ("1,15"),
+// and steps ("*/15", "0-30/10"). Month and day-of-week accept three-letter names.
+// The macros @hourly, @daily (@midnight), @weekly, @monthly and @yearly (@annually)
+// are also accepted. As in Vixie cron, when both day-of-month and day-of-week are
+// restricted a time matches if either does.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fieldCount = 5
+	// maxSearchYears bounds Next for expressions such as "0 0 30 2 *" that never match.
+	maxSearchYears = 5
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes the bounds and names of one cron field.
+type field struct {
+	name     string
+	min, max int
+	names    []string // names[i] is the value min+i
+}
+
+var fields = [fieldCount]field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	// 7 is accepted as an alias for Sunday.
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr                          string
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Parse parses a five-field cron expression or macro.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != fieldCount {
+		return nil, fmt.Errorf("invalid cron expression %q: expected %d fields, got %d", expr, fieldCount, len(parts))
+	}
+
+	var bits [fieldCount]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// Fold Sunday=7 into Sunday=0.
+	const sunday, sundayAlias = 0, 7
+	if bits[4]&(1<<sundayAlias) != 0 {
+		bits[4] |= 1 << sunday
+	}
+
+	return &Schedule{
+		expr:   expr,
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*" || parts[2] == "?",
+		dowAny: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first activation time strictly after t, truncated to the minute,
+// in t's location. It returns the zero time if the schedule never activates.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// dayMatches applies the Vixie cron day-of-month / day-of-week rule.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// parseField parses a comma-separated list of ranges with optional steps into a bit set.
+func parseField(spec string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", item[i+1:], f.name)
+			}
+			rangeSpec, step = item[:i], n
+		}
+
+		lo, hi, err := parseRange(rangeSpec, f, step > 1)
+		if err != nil {
+			return 0, err
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// parseRange parses "*", "v" or "lo-hi". A single value followed by a step
+// ("5/10") runs to the end of the field.
+func parseRange(spec string, f field, stepped bool) (int, int, error) {
+	if spec == "*" || spec == "?" {
+		return f.min, f.max, nil
+	}
+
+	loSpec, hiSpec, isRange := strings.Cut(spec, "-")
+
+	lo, err := parseValue(loSpec, f)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	hi := lo
+	switch {
+	case isRange:
+		if hi, err = parseValue(hiSpec, f); err != nil {
+			return 0, 0, err
+		}
+	case stepped:
+		hi = f.max
+	}
+
+	if hi < lo {
+		return 0, 0, fmt.Errorf("invalid range %q in %s field", spec, f.name)
+	}
+
+	return lo, hi, nil
+}
+
+func parseValue(spec string, f field) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(spec, name) {
+			return f.min + i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(spec)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", spec, f.name, f.min, f.max)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	// Saturday 2026-10-17 13:47.
+	from := time.Date(2026, 10, 17, 13, 47, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 17, 13, 48, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 3 * * mon-fri", time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Day-of-month and day-of-week restricted: either matches.
+		{"0 0 20 * sun", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"5/20 13 * * *", time.Date(2026, 10, 18, 13, 5, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestNextNeverMatches(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
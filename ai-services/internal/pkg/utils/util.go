@@ -527,6 +527,37 @@ func GetModelsPath() string {
 	return filepath.Join(GetBaseDir(), "models")
 }
 
+// GetRegistryMirrorsPath returns the path of the registry mirror configuration
+// based on the configured base directory.
+func GetRegistryMirrorsPath() string {
+	return filepath.Join(GetBaseDir(), "registry-mirrors.yaml")
+}
+
+// GetImagePolicyPath returns the path of the image policy based on the
+// configured base directory.
+func GetImagePolicyPath() string {
+	return filepath.Join(GetBaseDir(), "image-policy.yaml")
+}
+
+// GetSystemdUnitsPath returns the directory the systemd units starting the pods
+// of podman applications on boot are written to, based on the configured base
+// directory.
+func GetSystemdUnitsPath() string {
+	return filepath.Join(GetBaseDir(), "systemd")
+}
+
+// GetOfflineManifestsPath returns the directory the manifests of imported offline
+// bundles are kept in, based on the configured base directory.
+func GetOfflineManifestsPath() string {
+	return filepath.Join(GetBaseDir(), "offline")
+}
+
+// GetModelDownloadConfigPath returns the path of the model download configuration
+// based on the configured base directory.
+func GetModelDownloadConfigPath() string {
+	return filepath.Join(GetBaseDir(), "model-download.yaml")
+}
+
 // ValidateBaseDir validates that the base directory exists or can be created.
 // It always appends 'ai-services' subdirectory to the provided base directory for all AI services content.
 func ValidateBaseDir(baseDir string) (string, error) {
@@ -559,6 +590,9 @@ func CreateDir(path string) error {
 	return nil
 }
 
+// ResolvePodmanURI returns the URI of the Podman socket to connect to: CONTAINER_HOST
+// when set, otherwise the socket of the user podman runs as. Rootless podman
+// serves a socket per user in the runtime directory of the user.
 func ResolvePodmanURI() (string, error) {
 	if v, found := os.LookupEnv("CONTAINER_HOST"); found {
 		return v, nil
@@ -568,6 +602,10 @@ func ResolvePodmanURI() (string, error) {
 		return getPodmanURIAsRoot()
 	}
 
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return "unix://" + filepath.Join(dir, "podman", "podman.sock"), nil
+	}
+
 	return fmt.Sprintf("unix:///run/user/%d/podman/podman.sock", os.Getuid()), nil
 }
 
@@ -631,7 +669,7 @@ func ExtractTarGz(srcFile, destDir string) error {
 			return err
 		}
 
-		if err := extractTarEntry(tr, header, destDir); err != nil {
+		if err := ExtractTarEntry(tr, header, destDir); err != nil {
 			return err
 		}
 	}
@@ -639,8 +677,8 @@ func ExtractTarGz(srcFile, destDir string) error {
 	return nil
 }
 
-// extractTarEntry extracts a single tar entry.
-func extractTarEntry(tr *tar.Reader, header *tar.Header, destDir string) error {
+// ExtractTarEntry extracts a single tar entry whose content is read from r.
+func ExtractTarEntry(r io.Reader, header *tar.Header, destDir string) error {
 	// Get the absolute path of the destination directory
 	destPath, err := filepath.Abs(destDir)
 	if err != nil {
@@ -673,7 +711,7 @@ func extractTarEntry(tr *tar.Reader, header *tar.Header, destDir string) error {
 			_ = outFile.Close()
 		}()
 
-		if _, err := io.Copy(outFile, tr); err != nil {
+		if _, err := io.Copy(outFile, r); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,61 @@
+package delegation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/rootless"
+)
+
+type DelegationRule struct{}
+
+func NewDelegationRule() *DelegationRule {
+	return &DelegationRule{}
+}
+
+func (r *DelegationRule) Name() string {
+	return "delegation"
+}
+
+func (r *DelegationRule) Description() string {
+	return "Validates that the cgroup controllers are delegated to the rootless podman user."
+}
+
+func (r *DelegationRule) Verify() error {
+	u, err := rootless.User()
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		logger.Debugln("Podman runs as root, skipping cgroup delegation check")
+
+		return nil
+	}
+	logger.Debugf("Validating cgroup delegation for %s\n", u.Username)
+
+	missing, err := rootless.MissingControllers(u)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("cgroup controllers %s are not delegated to %s; CPU and memory limits of the pods are not enforced", strings.Join(missing, ", "), u.Username)
+	}
+
+	logger.Debugln("✓ cgroup controllers are delegated")
+
+	return nil
+}
+
+func (r *DelegationRule) Message() string {
+	return "Cgroup controllers are delegated to the rootless podman user"
+}
+
+func (r *DelegationRule) Level() constants.ValidationLevel {
+	return constants.ValidationLevelWarning
+}
+
+func (r *DelegationRule) Hint() string {
+	return "Run 'sudo ai-services bootstrap configure --runtime podman', then 'sudo loginctl terminate-user <username>' and log in again so the user manager starts with the delegated controllers."
+}
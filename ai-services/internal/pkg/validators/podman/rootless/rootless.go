@@ -0,0 +1,78 @@
+package rootless
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/rootless"
+)
+
+type RootlessRule struct{}
+
+func NewRootlessRule() *RootlessRule {
+	return &RootlessRule{}
+}
+
+func (r *RootlessRule) Name() string {
+	return "rootless"
+}
+
+func (r *RootlessRule) Description() string {
+	return "Validates subordinate IDs, lingering and data directory ownership for rootless podman."
+}
+
+func (r *RootlessRule) Verify() error {
+	u, err := rootless.User()
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		logger.Debugln("Podman runs as root, skipping rootless podman check")
+
+		return nil
+	}
+	logger.Debugf("Validating rootless podman configuration for %s\n", u.Username)
+
+	var problems []string
+	missing, err := rootless.MissingSubIDs(u)
+	if err != nil {
+		return err
+	}
+	for _, path := range missing {
+		problems = append(problems, fmt.Sprintf("no subordinate IDs for %s in %s", u.Username, path))
+	}
+
+	if !rootless.Lingering(u) {
+		problems = append(problems, fmt.Sprintf("lingering is not enabled for %s", u.Username))
+	}
+
+	unowned, err := rootless.UnownedDirs(u)
+	if err != nil {
+		return err
+	}
+	for _, dir := range unowned {
+		problems = append(problems, fmt.Sprintf("%s is missing or not owned by %s", dir, u.Username))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("rootless podman is not configured: %s", strings.Join(problems, "; "))
+	}
+
+	logger.Debugln("✓ rootless podman is configured")
+
+	return nil
+}
+
+func (r *RootlessRule) Message() string {
+	return "Rootless podman is configured"
+}
+
+func (r *RootlessRule) Level() constants.ValidationLevel {
+	return constants.ValidationLevelError
+}
+
+func (r *RootlessRule) Hint() string {
+	return "Run 'sudo ai-services bootstrap configure --runtime podman' as the user running the applications to set up subordinate IDs, lingering and the data directories."
+}
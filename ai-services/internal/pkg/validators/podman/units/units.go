@@ -0,0 +1,58 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/systemd"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+type UnitsRule struct{}
+
+func NewUnitsRule() *UnitsRule {
+	return &UnitsRule{}
+}
+
+func (r *UnitsRule) Name() string {
+	return "units"
+}
+
+func (r *UnitsRule) Description() string {
+	return "Validates that the systemd units starting application pods on boot match the deployed pods."
+}
+
+func (r *UnitsRule) Verify() error {
+	logger.Debugln("Validating systemd units of application pods")
+
+	client, err := podman.NewPodmanClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman: %w", err)
+	}
+
+	stale, err := systemd.Stale(utils.GetSystemdUnitsPath(), systemd.SystemUnitDir, client.PodExists)
+	if err != nil {
+		return err
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("stale systemd units found:\n  - %s", strings.Join(stale, "\n  - "))
+	}
+
+	return nil
+}
+
+func (r *UnitsRule) Message() string {
+	return "Systemd units of application pods are up to date"
+}
+
+func (r *UnitsRule) Level() constants.ValidationLevel {
+	return constants.ValidationLevelWarning
+}
+
+func (r *UnitsRule) Hint() string {
+	return fmt.Sprintf("Run 'ai-services bootstrap configure --runtime podman' to install the units written by the catalog, or remove the units of deleted pods from %s.", systemd.SystemUnitDir)
+}
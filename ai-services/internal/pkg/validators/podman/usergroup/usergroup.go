@@ -6,6 +6,7 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/rootless"
 )
 
 type UsergroupRule struct{}
@@ -19,7 +20,7 @@ func (r *UsergroupRule) Name() string {
 }
 
 func (r *UsergroupRule) Description() string {
-	return "Validates that the sentient group exists for ulimit configurations."
+	return "Validates that the sentient group exists for ulimit configurations and that the rootless podman user is a member."
 }
 
 func (r *UsergroupRule) Verify() error {
@@ -33,6 +34,25 @@ func (r *UsergroupRule) Verify() error {
 
 	logger.Debugf("✓ %s group exists", constants.SentientGroupName)
 
+	// Rootless podman reaches the VFIO devices through the groups of its user.
+	u, err := rootless.User()
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return nil
+	}
+
+	member, err := rootless.InGroup(u, constants.SentientGroupName)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return fmt.Errorf("user %s running rootless podman is not a member of the %s group", u.Username, constants.SentientGroupName)
+	}
+
+	logger.Debugf("✓ %s is a member of the %s group", u.Username, constants.SentientGroupName)
+
 	return nil
 }
 
@@ -45,7 +65,7 @@ func (r *UsergroupRule) Level() constants.ValidationLevel {
 }
 
 func (r *UsergroupRule) Hint() string {
-	return "The sentient group is required for ulimit configurations. Run 'ai-services bootstrap configure' to create the group."
+	return "The sentient group is required for ulimit configurations and access to the Spyre cards. Run 'ai-services bootstrap configure' to create the group and add the user to it, then log in again."
 }
 
 // Made with Bob
@@ -10,13 +10,16 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/openshift/rhods"
 	spyrepolicy "github.com/project-ai-services/ai-services/internal/pkg/validators/openshift/spyreclusterpolicy"
 	storageclass "github.com/project-ai-services/ai-services/internal/pkg/validators/openshift/storageclass"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/delegation"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/numa"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/platform"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/power"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/rhn"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/rootless"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/slicelimits"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/spyre"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/ulimits"
+	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/units"
 	"github.com/project-ai-services/ai-services/internal/pkg/validators/podman/usergroup"
 )
 
@@ -31,6 +34,9 @@ func init() {
 	PodmanRegistry.Register(usergroup.NewUsergroupRule())
 	PodmanRegistry.Register(ulimits.NewUlimitsRule())
 	PodmanRegistry.Register(slicelimits.NewSliceLimitsRule())
+	PodmanRegistry.Register(rootless.NewRootlessRule())
+	PodmanRegistry.Register(delegation.NewDelegationRule())
+	PodmanRegistry.Register(units.NewUnitsRule())
 
 	// OpenshiftChecks
 	OpenshiftRegistry.Register(kubeconfig.NewKubeconfigRule())
@@ -10,6 +10,9 @@ import (
 var (
 	// RuntimeFactory defines Global runtime factory.
 	RuntimeFactory *runtime.RuntimeFactory
+
+	// CLIVersion is the version of the running binary, recorded in backup manifests.
+	CLIVersion = "unknown"
 )
 
 var (
@@ -33,3 +36,10 @@ var (
 	RetryCount    = 3
 	RetryInterval = 5 * time.Second
 )
+
+var (
+	// HFEndpoint and ModelDownloadConcurrency override the model download
+	// configuration when set.
+	HFEndpoint               string
+	ModelDownloadConcurrency int
+)
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/project-ai-services/mcp/internal/authenticator"
+	"github.com/project-ai-services/mcp/internal/catalog"
 	"github.com/project-ai-services/mcp/internal/config"
 	"github.com/project-ai-services/mcp/internal/errors"
 	"github.com/project-ai-services/mcp/internal/openapi"
@@ -15,8 +18,13 @@ import (
 )
 
 var (
-	description     string
-	endpoint        string
+	descriptions    []string
+	endpoints       []string
+	servicesFile    string
+	catalogURL      string
+	catalogUsername string
+	catalogPassword string
+	catalogRefresh  time.Duration
 	authAPIKey      string
 	authCLI         bool
 	authToken       string
@@ -28,6 +36,7 @@ var (
 	httpMode        bool
 	port            int
 	tlsSkipVerify   bool
+	fileRoot        string
 )
 
 var rootCmd = &cobra.Command{
@@ -40,8 +49,13 @@ This server loads OpenAPI specifications for AI Services and creates MCP tools t
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&description, "description", "d", "", "The local OpenAPI description file path or remote URL to use (required)")
-	rootCmd.Flags().StringVarP(&endpoint, "endpoint", "e", "", "The service endpoint URL to use")
+	rootCmd.Flags().StringArrayVarP(&descriptions, "description", "d", nil, "The local OpenAPI description file path or remote URL to use. Can be used multiple times")
+	rootCmd.Flags().StringArrayVarP(&endpoints, "endpoint", "e", nil, "The service endpoint URL to use, once per --description in the same order")
+	rootCmd.Flags().StringVarP(&servicesFile, "services-file", "f", "", "YAML or JSON file listing the description, endpoint, tags and authentication of each service to serve")
+	rootCmd.Flags().StringVar(&catalogURL, "catalog", "", "The AI Services catalog URL, to serve the APIs of the applications running in the catalog")
+	rootCmd.Flags().StringVar(&catalogUsername, "catalog-username", "", "The username with which to log in to the catalog")
+	rootCmd.Flags().StringVar(&catalogPassword, "catalog-password", "", "The catalog password, or environment variable ($VAR) holding it")
+	rootCmd.Flags().DurationVar(&catalogRefresh, "catalog-refresh", 30*time.Second, "How often to list the catalog applications to refresh the tools")
 	rootCmd.Flags().StringVarP(&authAPIKey, "auth-api-key", "k", "", "AI Services API key, environment variable ($VAR), or 1Password reference (op://...)")
 	rootCmd.Flags().BoolVarP(&authCLI, "auth-cli", "c", false, "Use the ibmcloud CLI to authenticate")
 	rootCmd.Flags().StringVarP(&authToken, "auth-token", "a", "", "IAM token to use for authentication")
@@ -54,9 +68,7 @@ func init() {
 	rootCmd.Flags().IntVarP(&port, "port", "p", 3000, "Port number for HTTP server (used with --http)")
 	rootCmd.Flags().BoolVar(&tlsSkipVerify, "tls-skip-verify", false, "Skip TLS certificate verification for the description fetch and API requests (insecure; for self-signed or internal-CA endpoints)")
 
-	if err := rootCmd.MarkFlagRequired("description"); err != nil {
-		panic(err)
-	}
+	rootCmd.Flags().StringVar(&fileRoot, "file-root", "", "Directory below which file arguments of tool calls can reference files with file:// URIs")
 }
 
 func main() {
@@ -72,19 +84,6 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return errors.NewUsageError("Must not use positional arguments. Got: %s", fmt.Sprintf("%v", args))
 	}
 
-	// Validate endpoint format if provided
-	if endpoint != "" {
-		if err := validateEndpoint(endpoint); err != nil {
-			return err
-		}
-	}
-
-	// Validate and create authenticator
-	auth, err := createAuthenticator()
-	if err != nil {
-		return err
-	}
-
 	// Parse global parameters
 	globalQuery, err := parseKeyValuePairs(queries, "query parameter")
 	if err != nil {
@@ -96,19 +95,35 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Load and parse OpenAPI description
-	doc, err := openapi.LoadDescription(description, tlsSkipVerify)
-	if err != nil {
-		return fmt.Errorf("failed to load OpenAPI description: %w", err)
-	}
+	var aggregator *tool.Aggregator
+	var discoverer *catalog.Discoverer
+	if catalogURL != "" {
+		// Discover the services of the applications running in the catalog
+		if discoverer, err = newCatalogDiscoverer(globalQuery, globalHeaders); err != nil {
+			return err
+		}
 
-	// Create interface
-	intf := openapi.NewInterface(doc)
+		if aggregator, err = discoverer.Discover(context.Background()); err != nil {
+			return fmt.Errorf("failed to discover the catalog applications: %w", err)
+		}
+	} else {
+		// Load the OpenAPI descriptions of the services to serve
+		services, err := buildServices(globalQuery, globalHeaders)
+		if err != nil {
+			return err
+		}
 
-	// Create tool aggregator
-	aggregator, err := tool.NewAggregator(intf, endpoint, auth, globalQuery, globalHeaders, tlsSkipVerify)
-	if err != nil {
-		return fmt.Errorf("failed to create tool aggregator: %w", err)
+		// Create tool aggregator
+		if aggregator, err = tool.NewServiceAggregator(services, tlsSkipVerify); err != nil {
+			return fmt.Errorf("failed to create tool aggregator: %w", err)
+		}
+	}
+
+	if fileRoot != "" {
+		if err := validateFileRoot(fileRoot); err != nil {
+			return err
+		}
+		aggregator.SetFileRoot(fileRoot)
 	}
 
 	// Handle config output
@@ -116,13 +131,19 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return outputConfig(aggregator.GetName())
 	}
 
-	// Validate tags if provided
-	if len(tags) > 0 {
+	// Validate tags if provided. The services of the catalog are not known in
+	// advance, so their tags are not validated
+	if len(tags) > 0 && discoverer == nil {
 		if err := validateTags(tags, aggregator.GetTags()); err != nil {
 			return err
 		}
 	}
 
+	// Refresh the tools as applications are created and deleted
+	if discoverer != nil {
+		go discoverer.Watch(context.Background(), aggregator, catalogRefresh)
+	}
+
 	// Start the appropriate server
 	if httpMode {
 		// Create simple implementations for dependencies
@@ -165,20 +186,239 @@ func validateEndpoint(endpoint string) error {
 	return nil
 }
 
-func createAuthenticator() (authenticator.Authenticator, error) {
+func validateFileRoot(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return errors.NewUsageError("Invalid file root: %s. %v", dir, err)
+	}
+	if !info.IsDir() {
+		return errors.NewUsageError("Invalid file root: %s. Must be a directory", dir)
+	}
+
+	return nil
+}
+
+// buildServices builds the services to serve from the --description and
+// --endpoint flags, or from the services file
+func buildServices(globalQuery, globalHeaders map[string]string) ([]tool.ServiceConfig, error) {
+	if servicesFile != "" {
+		if len(descriptions) > 0 || len(endpoints) > 0 {
+			return nil, errors.NewUsageError("Must not use --description or --endpoint with --services-file")
+		}
+		return buildServicesFromFile(servicesFile, globalQuery, globalHeaders)
+	}
+
+	if len(descriptions) == 0 {
+		return nil, errors.NewUsageError("Must provide --description or --services-file")
+	}
+	if len(endpoints) > 0 && len(endpoints) != len(descriptions) {
+		return nil, errors.NewUsageError("Must provide one --endpoint per --description, in the same order")
+	}
+
+	// Validate endpoint format if provided
+	for _, endpoint := range endpoints {
+		if err := validateEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate and create authenticator
+	auth, err := createAuthenticator()
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]tool.ServiceConfig, 0, len(descriptions))
+	for i, description := range descriptions {
+		intf, err := loadInterface(description)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint := ""
+		if len(endpoints) > 0 {
+			endpoint = endpoints[i]
+		}
+
+		services = append(services, tool.ServiceConfig{
+			Interface:     intf,
+			Endpoint:      endpoint,
+			Authenticator: auth,
+			GlobalQuery:   globalQuery,
+			GlobalHeaders: globalHeaders,
+		})
+	}
+
+	return services, nil
+}
+
+// buildServicesFromFile builds the services listed in a services file. Services
+// without authentication of their own use the authentication flags
+func buildServicesFromFile(path string, globalQuery, globalHeaders map[string]string) ([]tool.ServiceConfig, error) {
+	file, err := config.LoadServicesFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var globalAuth authenticator.Authenticator
+	if countAuthOptions(globalAuthOptions()) > 0 {
+		if globalAuth, err = createAuthenticator(); err != nil {
+			return nil, err
+		}
+	}
+
+	services := make([]tool.ServiceConfig, 0, len(file.Services))
+	for _, svc := range file.Services {
+		label := svc.Name
+		if label == "" {
+			label = svc.Description
+		}
+
+		if svc.Endpoint != "" {
+			if err := validateEndpoint(svc.Endpoint); err != nil {
+				return nil, err
+			}
+		}
+
+		auth := globalAuth
+		if svc.Auth != nil {
+			auth, err = newAuthenticator(authOptions{
+				apiKey:      svc.Auth.APIKey,
+				cli:         svc.Auth.CLI,
+				token:       svc.Auth.Token,
+				passthrough: svc.Auth.Passthrough,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("invalid authentication of service %s: %w", label, err)
+			}
+		}
+		if auth == nil {
+			return nil, errors.NewUsageError("Must provide an authentication option for service %s, "+
+				"in the services file or with the authentication flags", label)
+		}
+
+		intf, err := loadInterface(svc.Description)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", label, err)
+		}
+
+		services = append(services, tool.ServiceConfig{
+			Name:          svc.Name,
+			Interface:     intf,
+			Endpoint:      svc.Endpoint,
+			Authenticator: auth,
+			GlobalQuery:   mergePairs(globalQuery, svc.Query, false),
+			GlobalHeaders: mergePairs(globalHeaders, svc.Headers, true),
+			Tags:          svc.Tags,
+		})
+	}
+
+	return services, nil
+}
+
+// newCatalogDiscoverer creates the discoverer of the services of the catalog.
+// Tool calls are authenticated with the authentication flags when given, and
+// with the catalog session otherwise
+func newCatalogDiscoverer(globalQuery, globalHeaders map[string]string) (*catalog.Discoverer, error) {
+	if len(descriptions) > 0 || len(endpoints) > 0 || servicesFile != "" {
+		return nil, errors.NewUsageError("Must not use --description, --endpoint or --services-file with --catalog")
+	}
+	if err := validateEndpoint(catalogURL); err != nil {
+		return nil, err
+	}
+	if catalogUsername == "" || catalogPassword == "" {
+		return nil, errors.NewUsageError("Must provide --catalog-username and --catalog-password with --catalog")
+	}
+	if catalogRefresh <= 0 {
+		return nil, errors.NewUsageError("Invalid catalog refresh interval: %s. Must be positive", catalogRefresh)
+	}
+
+	password := catalogPassword
+	if strings.HasPrefix(password, "$") {
+		if password = os.Getenv(password[1:]); password == "" {
+			return nil, errors.NewUsageError("Environment variable %s is not set or empty", catalogPassword[1:])
+		}
+	}
+
+	client := catalog.NewClient(catalogURL, catalogUsername, password, tlsSkipVerify)
+
+	var auth authenticator.Authenticator = client
+	if httpMode || countAuthOptions(globalAuthOptions()) > 0 {
+		var err error
+		if auth, err = createAuthenticator(); err != nil {
+			return nil, err
+		}
+	}
+
+	return catalog.NewDiscoverer(client, auth, globalQuery, globalHeaders, tlsSkipVerify), nil
+}
+
+// loadInterface loads and parses an OpenAPI description
+func loadInterface(description string) (*openapi.Interface, error) {
+	doc, err := openapi.LoadDescription(description, tlsSkipVerify)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI description: %w", err)
+	}
+
+	return openapi.NewInterface(doc), nil
+}
+
+// mergePairs merges the key-value pairs of a service over the global ones.
+// Header names are case-insensitive and are lowercased
+func mergePairs(global, service map[string]string, caseInsensitive bool) map[string]string {
+	merged := make(map[string]string, len(global)+len(service))
+	for _, pairs := range []map[string]string{global, service} {
+		for k, v := range pairs {
+			if caseInsensitive {
+				k = strings.ToLower(k)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// authOptions holds the values of the authentication flags, or of the
+// authentication of a service in the services file
+type authOptions struct {
+	apiKey      string
+	cli         bool
+	token       string
+	passthrough bool
+}
+
+func globalAuthOptions() authOptions {
+	return authOptions{
+		apiKey:      authAPIKey,
+		cli:         authCLI,
+		token:       authToken,
+		passthrough: authPassthrough,
+	}
+}
+
+func countAuthOptions(opts authOptions) int {
 	authCount := 0
-	if authCLI {
+	if opts.cli {
 		authCount++
 	}
-	if authAPIKey != "" {
+	if opts.apiKey != "" {
 		authCount++
 	}
-	if authToken != "" {
+	if opts.token != "" {
 		authCount++
 	}
-	if authPassthrough {
+	if opts.passthrough {
 		authCount++
 	}
+	return authCount
+}
+
+func createAuthenticator() (authenticator.Authenticator, error) {
+	return newAuthenticator(globalAuthOptions())
+}
+
+func newAuthenticator(opts authOptions) (authenticator.Authenticator, error) {
+	authCount := countAuthOptions(opts)
 
 	if authCount == 0 {
 		return nil, errors.NewUsageError("Must provide an authentication option")
@@ -192,37 +432,37 @@ func createAuthenticator() (authenticator.Authenticator, error) {
 	// server-held credential would therefore be usable by anyone who can reach
 	// the port, so HTTP transport and passthrough authentication require each
 	// other. Stdio has no request headers to pass through.
-	if httpMode && !authPassthrough {
+	if httpMode && !opts.passthrough {
 		return nil, errors.NewUsageError(
 			"Must use --auth-passthrough with --http. The HTTP server does not authenticate " +
 				"incoming requests, so a server-held credential would be usable by any caller " +
 				"that can reach the port")
 	}
-	if authPassthrough && !httpMode {
+	if opts.passthrough && !httpMode {
 		return nil, errors.NewUsageError(
 			"Must use --http with --auth-passthrough. Stdio transport has no request headers " +
 				"to pass through")
 	}
 
-	if authCLI {
+	if opts.cli {
 		return authenticator.NewCLIAuthenticator(), nil
 	}
 
-	if authAPIKey != "" {
-		if strings.HasPrefix(authAPIKey, "op://") {
-			return authenticator.NewOPAuthenticator(authAPIKey), nil
-		} else if strings.HasPrefix(authAPIKey, "$") {
-			return authenticator.NewEnvAuthenticator(authAPIKey[1:])
+	if opts.apiKey != "" {
+		if strings.HasPrefix(opts.apiKey, "op://") {
+			return authenticator.NewOPAuthenticator(opts.apiKey), nil
+		} else if strings.HasPrefix(opts.apiKey, "$") {
+			return authenticator.NewEnvAuthenticator(opts.apiKey[1:])
 		} else {
-			return authenticator.NewAPIKeyAuthenticator(authAPIKey), nil
+			return authenticator.NewAPIKeyAuthenticator(opts.apiKey), nil
 		}
 	}
 
-	if authToken != "" {
-		return authenticator.NewTokenAuthenticator(authToken), nil
+	if opts.token != "" {
+		return authenticator.NewTokenAuthenticator(opts.token), nil
 	}
 
-	if authPassthrough {
+	if opts.passthrough {
 		return authenticator.NewPassthroughAuthenticator(), nil
 	}
 
@@ -295,12 +535,45 @@ func handleError(err error) {
 }
 
 func getUsage() string {
-	return `Usage: ai-services-mcp -d <API description> -e <service endpoint>
+	return `Usage: ai-services-mcp -d <API description> -e <service endpoint> [-d ... -e ...]
+       ai-services-mcp -f <services file>
+       ai-services-mcp --catalog <URL> --catalog-username <user> --catalog-password <password>
 
 Flags:
   -d, --description    <path> The local OpenAPI description to use.
                         <URL> The remote OpenAPI description to use.
-  -e, --endpoint        <URL> The service endpoint to use.
+                              Can be used multiple times to serve the tools
+                              of several services from one server. Tool
+                              names are then prefixed with the canonical
+                              title of each description.
+  -e, --endpoint        <URL> The service endpoint to use. With multiple
+                              --description flags, give one --endpoint per
+                              description, in the same order.
+  -f, --services-file  <path> A YAML or JSON file listing the services to
+                              serve, instead of --description and --endpoint.
+                              Each service has a description, and optionally
+                              a name prefixing its tool names, an endpoint,
+                              tags, query parameters, headers and an auth
+                              section (apiKey, cli, token or passthrough)
+                              overriding the authentication flags.
+  --catalog             <URL> The AI Services catalog to serve the APIs of,
+                              instead of --description and --endpoint. The
+                              services of the running applications are
+                              listed from the catalog, and their tools are
+                              named <application>_<service>_<operation>.
+                              Clients are notified as applications are
+                              created and deleted. Tool calls are
+                              authenticated with the catalog session, unless
+                              an authentication flag is given.
+  --catalog-username   <user> The username with which to log in to the
+                              catalog. Required by --catalog.
+  --catalog-password <password>
+                              The catalog password. Required by --catalog.
+                       $<VAR> As above, but read in the password from an
+                              environment variable.
+  --catalog-refresh <duration>
+                              How often to list the catalog applications
+                              (default: 30s).
   -k, --auth-api-key    <key> The AI Services API key with which to obtain
                               tokens to authenticate requests. Cannot be used
                               with --auth-cli, --auth-token or --http.
@@ -331,7 +604,9 @@ Flags:
                               Required when the API has globally required
                               request headers. Can be used multiple times.
   -T, --tag <tag name>        Only expose tools for operations with one of the
-                              provided tags. Can be used multiple times.
+                              provided tags. Can be used multiple times. Tags
+                              of a single service are set in the services
+                              file.
   -S, --http                  Use HTTP transport instead of stdio. Starts an
                               HTTP server with MCP Streamable HTTP transport.
                               Requires --auth-passthrough, as the server does
@@ -342,6 +617,11 @@ Flags:
                               the OpenAPI description and when calling the
                               service endpoint. Insecure; only for endpoints
                               with self-signed or internal-CA certificates.
+  --file-root           <dir> Allow file arguments of tool calls, such as
+                              multipart/form-data uploads, to reference files
+                              below the directory with file:// URIs. Without
+                              it, file content must be passed base64 encoded
+                              or as an embedded MCP resource.
   -C, --config                Instead of starting an MCP server, output an
                               MCP client-compatible configuration.
   --help                      Show this usage information.
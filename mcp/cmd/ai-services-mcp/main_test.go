@@ -2,10 +2,14 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/project-ai-services/mcp/internal/errors"
+	"github.com/project-ai-services/mcp/internal/tool"
 )
 
 func TestValidateEndpoint(t *testing.T) {
@@ -501,3 +505,177 @@ func TestMainFunctionExists(t *testing.T) {
 	// and execute the actual application
 	t.Log("main function exists and is part of the application entry point")
 }
+
+const testDescription = `openapi: 3.0.0
+info:
+  title: Digitize API
+  version: 1.0.0
+paths:
+  /v1/jobs:
+    get:
+      operationId: list_jobs
+      tags: [jobs]
+      responses:
+        '200':
+          description: OK
+`
+
+func TestBuildServices(t *testing.T) {
+	origDescriptions, origEndpoints, origServicesFile := descriptions, endpoints, servicesFile
+	origAuthToken, origAuthCLI, origHTTPMode := authToken, authCLI, httpMode
+	defer func() {
+		descriptions, endpoints, servicesFile = origDescriptions, origEndpoints, origServicesFile
+		authToken, authCLI, httpMode = origAuthToken, origAuthCLI, origHTTPMode
+	}()
+
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "digitize.yaml")
+	if err := os.WriteFile(spec, []byte(testDescription), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	files := 0
+	writeFile := func(content string) string {
+		files++
+		path := filepath.Join(dir, fmt.Sprintf("services-%d.yaml", files))
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name         string
+		descriptions []string
+		endpoints    []string
+		servicesFile string
+		authToken    string
+		wantErr      string
+		check        func(t *testing.T, services []tool.ServiceConfig)
+	}{
+		{
+			name:    "no description",
+			wantErr: "Must provide --description or --services-file",
+		},
+		{
+			name:         "endpoint count mismatch",
+			descriptions: []string{spec, spec},
+			endpoints:    []string{"https://a.example.com"},
+			authToken:    "token",
+			wantErr:      "one --endpoint per --description",
+		},
+		{
+			name:         "description and services file",
+			descriptions: []string{spec},
+			servicesFile: writeFile("services: []"),
+			wantErr:      "Must not use --description or --endpoint with --services-file",
+		},
+		{
+			name:         "multiple descriptions",
+			descriptions: []string{spec, spec},
+			endpoints:    []string{"https://a.example.com", "https://b.example.com"},
+			authToken:    "token",
+			check: func(t *testing.T, services []tool.ServiceConfig) {
+				if len(services) != 2 || services[1].Endpoint != "https://b.example.com" {
+					t.Errorf("services = %+v, want two services with their endpoints", services)
+				}
+			},
+		},
+		{
+			name: "services file with per-service authentication",
+			servicesFile: writeFile(`services:
+  - name: digitize
+    description: ` + spec + `
+    endpoint: https://lpar.example.com/digitize
+    tags: [jobs]
+    headers: {X-Tenant: acme}
+    auth:
+      token: digitize-token
+`),
+			check: func(t *testing.T, services []tool.ServiceConfig) {
+				svc := services[0]
+				if svc.Name != "digitize" || svc.Authenticator.GetType() != "token" || svc.GlobalHeaders["x-tenant"] != "acme" {
+					t.Errorf("service = %+v", svc)
+				}
+			},
+		},
+		{
+			name: "services file without authentication",
+			servicesFile: writeFile(`services:
+  - description: ` + spec + `
+`),
+			wantErr: "Must provide an authentication option for service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			descriptions, endpoints, servicesFile = tt.descriptions, tt.endpoints, tt.servicesFile
+			authToken, authCLI, httpMode = tt.authToken, false, false
+
+			services, err := buildServices(nil, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("buildServices() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildServices() error = %v", err)
+			}
+			tt.check(t, services)
+		})
+	}
+}
+
+func TestMergePairs(t *testing.T) {
+	merged := mergePairs(map[string]string{"X-Tenant": "global", "A": "1"}, map[string]string{"x-tenant": "service"}, true)
+	if merged["x-tenant"] != "service" || merged["a"] != "1" || len(merged) != 2 {
+		t.Errorf("mergePairs() = %v", merged)
+	}
+}
+
+func TestNewCatalogDiscoverer(t *testing.T) {
+	origCatalog, origUsername, origPassword, origRefresh := catalogURL, catalogUsername, catalogPassword, catalogRefresh
+	origDescriptions, origHTTPMode := descriptions, httpMode
+	defer func() {
+		catalogURL, catalogUsername, catalogPassword, catalogRefresh = origCatalog, origUsername, origPassword, origRefresh
+		descriptions, httpMode = origDescriptions, origHTTPMode
+	}()
+
+	t.Setenv("TEST_CATALOG_PASSWORD", "secret")
+
+	tests := []struct {
+		name         string
+		catalog      string
+		password     string
+		descriptions []string
+		httpMode     bool
+		wantErr      string
+	}{
+		{name: "valid", catalog: "https://catalog.example.com", password: "secret"},
+		{name: "password from environment", catalog: "https://catalog.example.com", password: "$TEST_CATALOG_PASSWORD"},
+		{name: "unset password variable", catalog: "https://catalog.example.com", password: "$TEST_CATALOG_UNSET", wantErr: "TEST_CATALOG_UNSET is not set"},
+		{name: "no password", catalog: "https://catalog.example.com", wantErr: "--catalog-password"},
+		{name: "with descriptions", catalog: "https://catalog.example.com", password: "secret", descriptions: []string{"spec.yaml"}, wantErr: "Must not use --description"},
+		{name: "insecure catalog", catalog: "http://catalog.example.com", password: "secret", wantErr: "Must use HTTPS"},
+		{name: "http without passthrough", catalog: "https://catalog.example.com", password: "secret", httpMode: true, wantErr: "authentication option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalogURL, catalogUsername, catalogPassword, catalogRefresh = tt.catalog, "admin", tt.password, 30*time.Second
+			descriptions, httpMode = tt.descriptions, tt.httpMode
+
+			discoverer, err := newCatalogDiscoverer(nil, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("newCatalogDiscoverer() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || discoverer == nil {
+				t.Errorf("newCatalogDiscoverer() error = %v", err)
+			}
+		})
+	}
+}
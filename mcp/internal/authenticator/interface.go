@@ -26,4 +26,5 @@ const (
 	AuthTypeEnv         AuthType = "env"
 	AuthTypeOP          AuthType = "1password"
 	AuthTypePassthrough AuthType = "passthrough"
+	AuthTypeCatalog     AuthType = "catalog"
 )
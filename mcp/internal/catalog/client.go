@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/project-ai-services/mcp/internal/authenticator"
+	"github.com/project-ai-services/mcp/internal/errors"
+)
+
+const (
+	// apiPrefix is the path of the catalog API below the catalog URL
+	apiPrefix = "/api/v1"
+	// pageSize is the largest page size of the catalog API
+	pageSize = 100
+)
+
+// Application is a deployed application, as returned by the catalog API
+type Application struct {
+	ID       string               `json:"id"`
+	Name     string               `json:"name"`
+	Status   string               `json:"status"`
+	Services []ApplicationService `json:"services"`
+}
+
+// ApplicationService is a service of a deployed application
+type ApplicationService struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CatalogID string `json:"catalog_id"`
+	Status    string `json:"status"`
+	// Endpoints are the routes registered for the service on the catalog proxy
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is a route of a service, e.g. {"type": "api", "url": "https://..."}
+type Endpoint struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type applicationList struct {
+	Data       []Application `json:"data"`
+	Pagination struct {
+		HasNext bool `json:"has_next"`
+	} `json:"pagination"`
+}
+
+type tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Client calls the catalog API, logging in with a username and password. It
+// refreshes its token when the API rejects it
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+}
+
+// NewClient creates a catalog API client. serverURL is the catalog URL, with or
+// without the /api/v1 path
+func NewClient(serverURL, username, password string, tlsSkipVerify bool) *Client {
+	httpClient := &http.Client{}
+	if tlsSkipVerify {
+		// #nosec G402 - verification is skipped only when the user passes --tls-skip-verify
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	baseURL := strings.TrimSuffix(strings.TrimSuffix(serverURL, "/"), apiPrefix)
+
+	return &Client{
+		baseURL:    baseURL + apiPrefix,
+		username:   username,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// ListApplications returns the applications of the catalog. The list does not
+// include their services
+func (c *Client) ListApplications(ctx context.Context) ([]Application, error) {
+	var applications []Application
+	for page := 1; ; page++ {
+		var list applicationList
+		path := fmt.Sprintf("/applications?page=%d&page_size=%d", page, pageSize)
+		if err := c.get(ctx, path, &list); err != nil {
+			return nil, err
+		}
+
+		applications = append(applications, list.Data...)
+		if !list.Pagination.HasNext {
+			return applications, nil
+		}
+	}
+}
+
+// GetApplication returns an application with its services
+func (c *Client) GetApplication(ctx context.Context, id string) (*Application, error) {
+	var application Application
+	if err := c.get(ctx, "/applications/"+url.PathEscape(id), &application); err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// GetBearerToken returns the access token of the catalog session, logging in
+// when there is none
+func (c *Client) GetBearerToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken == "" {
+		if err := c.login(ctx); err != nil {
+			return "", err
+		}
+	}
+	return c.accessToken, nil
+}
+
+// IsPassthrough returns false for catalog authentication
+func (c *Client) IsPassthrough() bool {
+	return false
+}
+
+// GetType returns the authenticator type
+func (c *Client) GetType() string {
+	return string(authenticator.AuthTypeCatalog)
+}
+
+// get calls the catalog API, renewing the token once if it is rejected
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	token, err := c.GetBearerToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.do(ctx, http.MethodGet, path, token, nil)
+	if err == nil && response.StatusCode == http.StatusUnauthorized {
+		response.Body.Close()
+		if token, err = c.renewToken(ctx, token); err != nil {
+			return err
+		}
+		response, err = c.do(ctx, http.MethodGet, path, token, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to call the catalog API: %w", err)
+	}
+	defer response.Body.Close()
+
+	return decodeResponse(response, out)
+}
+
+// renewToken refreshes the rejected token, or logs in again when the refresh
+// token is rejected as well. Concurrent callers share one renewal
+func (c *Client) renewToken(ctx context.Context, rejected string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != rejected {
+		return c.accessToken, nil
+	}
+
+	if c.refreshToken != "" {
+		if err := c.refresh(ctx); err == nil {
+			return c.accessToken, nil
+		}
+	}
+	if err := c.login(ctx); err != nil {
+		return "", err
+	}
+	return c.accessToken, nil
+}
+
+// login logs in to the catalog with the username and password
+func (c *Client) login(ctx context.Context) error {
+	body := map[string]string{"username": c.username, "password": c.password}
+	if err := c.requestTokens(ctx, "/auth/login", body); err != nil {
+		return errors.NewAuthenticationError("Could not log in to the catalog as %s: %v", c.username, err)
+	}
+	return nil
+}
+
+// refresh exchanges the refresh token for new tokens
+func (c *Client) refresh(ctx context.Context) error {
+	return c.requestTokens(ctx, "/auth/refresh", map[string]string{"refresh_token": c.refreshToken})
+}
+
+func (c *Client) requestTokens(ctx context.Context, path string, body map[string]string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.do(ctx, http.MethodPost, path, "", data)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var result tokens
+	if err := decodeResponse(response, &result); err != nil {
+		return err
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("no access token in the response")
+	}
+
+	c.accessToken, c.refreshToken = result.AccessToken, result.RefreshToken
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(request)
+}
+
+// decodeResponse decodes a JSON response, or returns an error for a non-2xx status
+func decodeResponse(response *http.Response, out interface{}) error {
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return errors.NewAPIError(fmt.Sprintf("%s %s failed: HTTP %d", response.Request.Method, response.Request.URL.Path, response.StatusCode),
+			response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the catalog response: %w", err)
+	}
+	return nil
+}
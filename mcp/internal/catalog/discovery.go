@@ -0,0 +1,243 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-ai-services/mcp/internal/authenticator"
+	"github.com/project-ai-services/mcp/internal/openapi"
+	"github.com/project-ai-services/mcp/internal/tool"
+)
+
+const (
+	// statusRunning is the status of running applications and services
+	statusRunning = "Running"
+	// endpointTypeAPI is the type of the routes serving a service API
+	endpointTypeAPI = "api"
+	// descriptionPath is the path of the OpenAPI description of a service API
+	descriptionPath = "/openapi.json"
+)
+
+// discovered is a service API found in the catalog
+type discovered struct {
+	// key identifies the application, service instance and endpoint
+	key    string
+	config tool.ServiceConfig
+}
+
+// Discoverer finds the APIs of the services of the applications running in the
+// catalog, and serves their operations as tools named after the application and
+// service, e.g. my-app_digitize_list_jobs
+type Discoverer struct {
+	client        *Client
+	auth          authenticator.Authenticator
+	globalQuery   map[string]string
+	globalHeaders map[string]string
+	tlsSkipVerify bool
+
+	// interfaces caches the descriptions of the services by key
+	interfaces map[string]*openapi.Interface
+	// keys are the keys of the services currently served
+	keys []string
+}
+
+// NewDiscoverer creates a discoverer. Tool calls are authenticated with auth
+func NewDiscoverer(client *Client, auth authenticator.Authenticator,
+	globalQuery, globalHeaders map[string]string, tlsSkipVerify bool) *Discoverer {
+
+	return &Discoverer{
+		client:        client,
+		auth:          auth,
+		globalQuery:   globalQuery,
+		globalHeaders: globalHeaders,
+		tlsSkipVerify: tlsSkipVerify,
+		interfaces:    make(map[string]*openapi.Interface),
+	}
+}
+
+// Discover returns an aggregator of the services running in the catalog. It
+// fails when the catalog cannot be listed, not when a service has no description
+func (d *Discoverer) Discover(ctx context.Context) (*tool.Aggregator, error) {
+	services, err := d.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator, err := d.newAggregator(services)
+	if err != nil {
+		return nil, err
+	}
+
+	d.keys = serviceKeys(services)
+	return aggregator, nil
+}
+
+// Watch lists the catalog every interval until ctx is done, and updates the
+// aggregator when applications are created or deleted, or when their services
+// start or stop
+func (d *Discoverer) Watch(ctx context.Context, aggregator *tool.Aggregator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := d.refresh(ctx, aggregator); err != nil {
+			log.Printf("Failed to refresh the catalog tools: %v", err)
+		}
+	}
+}
+
+// refresh updates the aggregator when the services running in the catalog changed
+func (d *Discoverer) refresh(ctx context.Context, aggregator *tool.Aggregator) error {
+	services, err := d.discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys := serviceKeys(services)
+	if slices.Equal(keys, d.keys) {
+		return nil
+	}
+
+	updated, err := d.newAggregator(services)
+	if err != nil {
+		return err
+	}
+
+	d.keys = keys
+	aggregator.Update(updated)
+	log.Printf("Catalog tools updated: %d service(s)", len(services))
+
+	return nil
+}
+
+// discover lists the API endpoints of the running services and loads their
+// descriptions. Applications that cannot be fetched and services whose
+// description cannot be loaded are skipped, and retried on the next refresh
+func (d *Discoverer) discover(ctx context.Context) ([]discovered, error) {
+	applications, err := d.client.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the catalog applications: %w", err)
+	}
+
+	var services []discovered
+	seen := make(map[string]bool)
+
+	for _, listed := range applications {
+		if listed.Status != statusRunning {
+			continue
+		}
+
+		// An application deleted since the list, or failing, keeps the others served
+		application, err := d.client.GetApplication(ctx, listed.ID)
+		if err != nil {
+			log.Printf("Skipping application %s: %v", listed.Name, err)
+			continue
+		}
+
+		for _, svc := range application.Services {
+			if svc.Status != "" && svc.Status != statusRunning {
+				continue
+			}
+
+			for _, endpoint := range svc.Endpoints {
+				if endpoint.Type != endpointTypeAPI || endpoint.URL == "" {
+					continue
+				}
+
+				key := application.Name + " " + svc.ID + " " + endpoint.URL
+				intf, err := d.loadInterface(key, endpoint.URL)
+				if err != nil {
+					log.Printf("Skipping service %s of application %s: %v", serviceName(svc), application.Name, err)
+					continue
+				}
+				seen[key] = true
+
+				services = append(services, discovered{
+					key: key,
+					config: tool.ServiceConfig{
+						Name:          application.Name + "_" + serviceName(svc),
+						Interface:     intf,
+						Endpoint:      strings.TrimSuffix(endpoint.URL, "/"),
+						Authenticator: d.auth,
+						GlobalQuery:   d.globalQuery,
+						GlobalHeaders: d.globalHeaders,
+					},
+				})
+			}
+		}
+	}
+
+	// Forget the descriptions of the services that are gone
+	for key := range d.interfaces {
+		if !seen[key] {
+			delete(d.interfaces, key)
+		}
+	}
+
+	// Sort the services so that colliding names get the same suffixes on each refresh
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].config.Name != services[j].config.Name {
+			return services[i].config.Name < services[j].config.Name
+		}
+		return services[i].key < services[j].key
+	})
+
+	return services, nil
+}
+
+// loadInterface returns the cached description of a service, or fetches it
+// from the service endpoint
+func (d *Discoverer) loadInterface(key, endpointURL string) (*openapi.Interface, error) {
+	if intf, ok := d.interfaces[key]; ok {
+		return intf, nil
+	}
+
+	doc, err := openapi.LoadDescription(strings.TrimSuffix(endpointURL, "/")+descriptionPath, d.tlsSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	intf := openapi.NewInterface(doc)
+	d.interfaces[key] = intf
+	return intf, nil
+}
+
+func (d *Discoverer) newAggregator(services []discovered) (*tool.Aggregator, error) {
+	configs := make([]tool.ServiceConfig, 0, len(services))
+	for _, svc := range services {
+		configs = append(configs, svc.config)
+	}
+
+	aggregator, err := tool.NewNamespacedAggregator(configs, d.tlsSkipVerify)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool aggregator: %w", err)
+	}
+	return aggregator, nil
+}
+
+// serviceName names a service after its catalog ID, e.g. digitize
+func serviceName(svc ApplicationService) string {
+	if svc.CatalogID != "" {
+		return svc.CatalogID
+	}
+	return svc.Type
+}
+
+func serviceKeys(services []discovered) []string {
+	keys := make([]string, 0, len(services))
+	for _, svc := range services {
+		keys = append(keys, svc.key)
+	}
+	return keys
+}
@@ -0,0 +1,225 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// serviceDescription is the OpenAPI description served by the fake services
+const serviceDescription = `{
+	"openapi": "3.1.0",
+	"info": {"title": "Digitize API", "version": "1.0.0"},
+	"paths": {
+		"/v1/jobs": {
+			"get": {"operationId": "list_jobs", "responses": {"200": {"description": "OK"}}}
+		}
+	}
+}`
+
+// fakeCatalog mimics the applications and authentication routes of the catalog API
+type fakeCatalog struct {
+	mu           sync.Mutex
+	applications []Application
+	logins       int
+	// expired rejects the next access token, as when it expires
+	expired bool
+	token   string
+	// deleted is listed, but not found
+	deleted Application
+}
+
+func (f *fakeCatalog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/auth/login":
+		var credentials map[string]string
+		json.NewDecoder(r.Body).Decode(&credentials)
+		if credentials["username"] != "admin" || credentials["password"] != "secret" {
+			http.Error(w, `{"error": "invalid credentials"}`, http.StatusUnauthorized)
+			return
+		}
+		f.logins++
+		f.token = strings.Repeat("t", f.logins)
+		json.NewEncoder(w).Encode(map[string]string{"access_token": f.token, "token_type": "Bearer"})
+		return
+	case r.Header.Get("Authorization") != "Bearer "+f.token || f.expired:
+		f.expired = false
+		f.token = ""
+		http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+		return
+	case r.URL.Path == "/api/v1/applications":
+		list := applicationList{}
+		for _, application := range f.applications {
+			application.Services = nil
+			list.Data = append(list.Data, application)
+		}
+		if f.deleted.ID != "" {
+			list.Data = append(list.Data, f.deleted)
+		}
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+
+	for _, application := range f.applications {
+		if r.URL.Path == "/api/v1/applications/"+application.ID {
+			json.NewEncoder(w).Encode(application)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (f *fakeCatalog) setApplications(applications ...Application) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applications = applications
+}
+
+// newService starts a service serving its OpenAPI description
+func newService(t *testing.T) *httptest.Server {
+	t.Helper()
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/openapi.json" {
+			w.Write([]byte(serviceDescription))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(service.Close)
+	return service
+}
+
+func application(id, name, status string, services ...ApplicationService) Application {
+	return Application{ID: id, Name: name, Status: status, Services: services}
+}
+
+func digitizeService(id, apiURL string) ApplicationService {
+	return ApplicationService{
+		ID:        id,
+		Type:      "Digitize Documents",
+		CatalogID: "digitize",
+		Status:    "Running",
+		Endpoints: []Endpoint{{Type: "ui", URL: apiURL + "/ui"}, {Type: "api", URL: apiURL}},
+	}
+}
+
+func toolNames(t *testing.T, d *Discoverer) []string {
+	t.Helper()
+	aggregator, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	var names []string
+	for _, tool := range aggregator.GetTools(nil) {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestDiscoverer_Discover(t *testing.T) {
+	fake := &fakeCatalog{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	service := newService(t)
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	fake.setApplications(
+		application("1", "papers", "Running", digitizeService("s1", service.URL)),
+		application("2", "invoices", "Deploying", digitizeService("s2", service.URL)),
+		application("3", "broken", "Running", digitizeService("s3", missing.URL)),
+	)
+	// An application deleted between the list and the get
+	fake.deleted = application("4", "deleted", "Running", digitizeService("s4", service.URL))
+
+	client := NewClient(server.URL+"/api/v1/", "admin", "secret", false)
+	discoverer := NewDiscoverer(client, client, nil, nil, false)
+
+	if names, want := toolNames(t, discoverer), []string{"papers_digitize_list_jobs"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tool names = %v, want %v", names, want)
+	}
+}
+
+func TestDiscoverer_Refresh(t *testing.T) {
+	fake := &fakeCatalog{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	service := newService(t)
+	fake.setApplications(application("1", "papers", "Running", digitizeService("s1", service.URL)))
+
+	client := NewClient(server.URL, "admin", "secret", false)
+	discoverer := NewDiscoverer(client, client, nil, nil, false)
+
+	aggregator, err := discoverer.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	updates := 0
+	aggregator.OnUpdate(func() { updates++ })
+
+	// Nothing changed
+	if err := discoverer.refresh(context.Background(), aggregator); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if updates != 0 {
+		t.Errorf("refresh() updated the aggregator %d times without changes", updates)
+	}
+
+	// An application is created, while the access token expired
+	fake.setApplications(
+		application("1", "papers", "Running", digitizeService("s1", service.URL)),
+		application("2", "invoices", "Running", digitizeService("s2", service.URL)),
+	)
+	fake.mu.Lock()
+	fake.expired = true
+	fake.mu.Unlock()
+
+	if err := discoverer.refresh(context.Background(), aggregator); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("refresh() updated the aggregator %d times, want 1", updates)
+	}
+	if fake.logins != 2 {
+		t.Errorf("logins = %d, want a new login after the token expired", fake.logins)
+	}
+
+	var names []string
+	for _, tool := range aggregator.GetTools(nil) {
+		names = append(names, tool.Name)
+	}
+	if want := []string{"invoices_digitize_list_jobs", "papers_digitize_list_jobs"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tool names = %v, want %v", names, want)
+	}
+
+	// The applications are deleted
+	fake.setApplications()
+	if err := discoverer.refresh(context.Background(), aggregator); err != nil {
+		t.Fatalf("refresh() error = %v", err)
+	}
+	if tools := aggregator.GetTools(nil); len(tools) != 0 || len(discoverer.interfaces) != 0 {
+		t.Errorf("tools = %v after the applications were deleted, want none", tools)
+	}
+}
+
+func TestClient_LoginError(t *testing.T) {
+	server := httptest.NewServer(&fakeCatalog{})
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin", "wrong", false)
+	_, err := client.ListApplications(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Could not log in to the catalog as admin") {
+		t.Errorf("ListApplications() error = %v, want a login error", err)
+	}
+}
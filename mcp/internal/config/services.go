@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServicesFile lists the OpenAPI descriptions served by one MCP server
+type ServicesFile struct {
+	Services []ServiceConfig `yaml:"services"`
+}
+
+// ServiceConfig configures a service of a services file
+type ServiceConfig struct {
+	// Name prefixes the tool names of the service. It defaults to the canonical
+	// title of the description
+	Name string `yaml:"name"`
+	// Description is the local path or remote URL of the OpenAPI description
+	Description string            `yaml:"description"`
+	Endpoint    string            `yaml:"endpoint"`
+	Tags        []string          `yaml:"tags"`
+	Query       map[string]string `yaml:"query"`
+	Headers     map[string]string `yaml:"headers"`
+	// Auth overrides the authentication flags for the service
+	Auth *AuthConfig `yaml:"auth"`
+}
+
+// AuthConfig configures how requests to a service are authenticated. It takes
+// the values of the authentication flags
+type AuthConfig struct {
+	APIKey      string `yaml:"apiKey"`
+	CLI         bool   `yaml:"cli"`
+	Token       string `yaml:"token"`
+	Passthrough bool   `yaml:"passthrough"`
+}
+
+// LoadServicesFile reads a services file, in YAML or JSON
+func LoadServicesFile(path string) (*ServicesFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read services file: %w", err)
+	}
+
+	var file ServicesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse services file %s: %w", path, err)
+	}
+
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("services file %s lists no services", path)
+	}
+
+	names := make(map[string]bool)
+	for i, service := range file.Services {
+		if service.Description == "" {
+			return nil, fmt.Errorf("service %d of %s has no description", i+1, path)
+		}
+		if service.Name != "" {
+			if names[service.Name] {
+				return nil, fmt.Errorf("service name %s is used more than once in %s", service.Name, path)
+			}
+			names[service.Name] = true
+		}
+	}
+
+	return &file, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeServicesFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "services.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write services file: %v", err)
+	}
+	return path
+}
+
+func TestLoadServicesFile(t *testing.T) {
+	path := writeServicesFile(t, `
+services:
+  - name: digitize
+    description: https://lpar.example.com/digitize/openapi.json
+    endpoint: https://lpar.example.com/digitize
+    tags: [jobs]
+    auth:
+      apiKey: $DIGITIZE_API_KEY
+  - description: ./chat.json
+    endpoint: https://lpar.example.com/chat
+    headers:
+      X-Tenant: acme
+`)
+
+	file, err := LoadServicesFile(path)
+	if err != nil {
+		t.Fatalf("LoadServicesFile() error = %v", err)
+	}
+
+	if len(file.Services) != 2 {
+		t.Fatalf("LoadServicesFile() returned %d services, want 2", len(file.Services))
+	}
+
+	digitize := file.Services[0]
+	if digitize.Name != "digitize" || digitize.Auth == nil || digitize.Auth.APIKey != "$DIGITIZE_API_KEY" {
+		t.Errorf("first service = %+v", digitize)
+	}
+	if len(digitize.Tags) != 1 || digitize.Tags[0] != "jobs" {
+		t.Errorf("Tags = %v, want [jobs]", digitize.Tags)
+	}
+
+	chat := file.Services[1]
+	if chat.Auth != nil || chat.Headers["X-Tenant"] != "acme" {
+		t.Errorf("second service = %+v", chat)
+	}
+}
+
+func TestLoadServicesFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "no services",
+			content: "services: []\n",
+			errMsg:  "lists no services",
+		},
+		{
+			name:    "missing description",
+			content: "services:\n  - endpoint: https://api.example.com\n",
+			errMsg:  "has no description",
+		},
+		{
+			name:    "duplicate name",
+			content: "services:\n  - name: chat\n    description: a.json\n  - name: chat\n    description: b.json\n",
+			errMsg:  "used more than once",
+		},
+		{
+			name:    "invalid YAML",
+			content: "services: [",
+			errMsg:  "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadServicesFile(writeServicesFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("LoadServicesFile() error = %v, want %q", err, tt.errMsg)
+			}
+		})
+	}
+
+	if _, err := LoadServicesFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadServicesFile() should fail for a missing file")
+	}
+}
@@ -2,11 +2,13 @@ package openapi
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	base "github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/project-ai-services/mcp/internal/types"
 )
 
@@ -144,33 +146,14 @@ func (intf *Interface) collectOperations() {
 					required = *rb.Required
 				}
 
-				// Find JSON content type
-				var contentType string
-				var schema *base.SchemaProxy
-
-				if rb.Content != nil {
-					// Prefer merge-patch+json, then any JSON content type
-					for pair := rb.Content.First(); pair != nil; pair = pair.Next() {
-						ct := pair.Key()
-						mediaType := pair.Value()
-
-						if strings.Contains(strings.ToLower(ct), "merge-patch+json") {
-							contentType = ct
-							schema = mediaType.Schema
-							break
-						} else if strings.Contains(strings.ToLower(ct), "json") && contentType == "" {
-							contentType = ct
-							schema = mediaType.Schema
-						}
-					}
-				}
+				contentType, schema := selectRequestBodyContent(rb.Content)
 
-				if contentType != "" && schema != nil {
-					jsonSchema := ConvertSchemaToJSONSchema(schema)
+				// Binary bodies are sent as a single file and need no schema
+				if contentType != "" && (schema != nil || types.GetBodyEncoding(contentType) == types.EncodingBinary) {
 					requestBody = &types.RequestBodyInfo{
 						Required:    required,
 						ContentType: contentType,
-						Schema:      jsonSchema,
+						Schema:      ConvertSchemaToJSONSchema(schema),
 					}
 				}
 			}
@@ -184,14 +167,15 @@ func (intf *Interface) collectOperations() {
 			}
 
 			operationInfo := types.OperationInfo{
-				OperationID: operation.OperationId,
-				Method:      method,
-				Path:        path,
-				Summary:     operation.Summary,
-				Description: operation.Description,
-				Tags:        tags,
-				Parameters:  allParams,
-				RequestBody: requestBody,
+				OperationID:    operation.OperationId,
+				Method:         method,
+				Path:           path,
+				Summary:        operation.Summary,
+				Description:    operation.Description,
+				Tags:           tags,
+				Parameters:     allParams,
+				RequestBody:    requestBody,
+				ResponseSchema: successResponseSchema(operation.Responses),
 			}
 
 			intf.Operations = append(intf.Operations, operationInfo)
@@ -199,6 +183,71 @@ func (intf *Interface) collectOperations() {
 	}
 }
 
+// bodyEncodingPreference ranks the request body encodings, most preferred first
+var bodyEncodingPreference = []types.BodyEncoding{
+	types.EncodingJSON,
+	types.EncodingMultipart,
+	types.EncodingForm,
+	types.EncodingBinary,
+}
+
+// selectRequestBodyContent picks the content type a tool sends the request body as.
+// merge-patch+json is preferred, then any JSON content type, then multipart/form-data,
+// application/x-www-form-urlencoded and finally binary content types
+func selectRequestBodyContent(content *orderedmap.Map[string, *v3.MediaType]) (string, *base.SchemaProxy) {
+	if content == nil {
+		return "", nil
+	}
+
+	for pair := content.First(); pair != nil; pair = pair.Next() {
+		if strings.Contains(strings.ToLower(pair.Key()), "merge-patch+json") {
+			return pair.Key(), pair.Value().Schema
+		}
+	}
+
+	for _, encoding := range bodyEncodingPreference {
+		for pair := content.First(); pair != nil; pair = pair.Next() {
+			if types.GetBodyEncoding(pair.Key()) == encoding {
+				return pair.Key(), pair.Value().Schema
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// successResponseSchema returns the schema of the JSON body of the success
+// response: the lowest 2xx status code declaring one, then the 2XX range
+func successResponseSchema(responses *v3.Responses) *jsonschema.Schema {
+	if responses == nil || responses.Codes == nil {
+		return nil
+	}
+
+	var codes []string
+	for pair := responses.Codes.First(); pair != nil; pair = pair.Next() {
+		code := strings.ToUpper(pair.Key())
+		if len(code) == 3 && code[0] == '2' {
+			codes = append(codes, pair.Key())
+		}
+	}
+	// Explicit codes sort before the 2XX range
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		response := responses.Codes.GetOrZero(code)
+		if response == nil || response.Content == nil {
+			continue
+		}
+		for pair := response.Content.First(); pair != nil; pair = pair.Next() {
+			if types.GetBodyEncoding(pair.Key()) == types.EncodingJSON && pair.Value().Schema != nil {
+				return ConvertSchemaToJSONSchema(pair.Value().Schema)
+			}
+		}
+	}
+
+	return nil
+}
+
 // collectTags extracts all unique tags from the specification
 func (intf *Interface) collectTags() {
 	tagSet := make(map[string]bool)
@@ -304,3 +304,75 @@ func TestRequestBodyContentTypePreference(t *testing.T) {
 		t.Errorf("Should prefer merge-patch+json content type, got %q", createResourceOp.RequestBody.ContentType)
 	}
 }
+
+func TestNewInterface_RequestBodyContentTypes(t *testing.T) {
+	doc, err := LoadDescription("testdata/upload.yaml", false)
+	if err != nil {
+		t.Fatalf("Failed to load upload spec: %v", err)
+	}
+
+	intf := NewInterface(doc)
+
+	tests := []struct {
+		operationID     string
+		wantContentType string
+		wantSchema      bool
+	}{
+		{operationID: "uploadDocuments", wantContentType: "multipart/form-data", wantSchema: true},
+		{operationID: "getToken", wantContentType: "application/json", wantSchema: true},
+		{operationID: "putImage", wantContentType: "application/octet-stream", wantSchema: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			var op *types.OperationInfo
+			for i := range intf.Operations {
+				if intf.Operations[i].OperationID == tt.operationID {
+					op = &intf.Operations[i]
+				}
+			}
+			if op == nil {
+				t.Fatalf("operation %s not found", tt.operationID)
+			}
+			if op.RequestBody == nil {
+				t.Fatalf("operation %s has no request body", tt.operationID)
+			}
+			if op.RequestBody.ContentType != tt.wantContentType {
+				t.Errorf("ContentType = %q, want %q", op.RequestBody.ContentType, tt.wantContentType)
+			}
+			if (op.RequestBody.Schema != nil) != tt.wantSchema {
+				t.Errorf("Schema = %v, want schema: %v", op.RequestBody.Schema, tt.wantSchema)
+			}
+		})
+	}
+
+	// File fields keep the binary format, which marks them as files
+	for _, op := range intf.Operations {
+		if op.OperationID == "uploadDocuments" {
+			if items := op.RequestBody.Schema.Properties["files"].Items; items == nil || items.Format != "binary" {
+				t.Errorf("files items = %+v, want the binary format", items)
+			}
+		}
+	}
+}
+
+func TestNewInterface_ResponseSchema(t *testing.T) {
+	doc, err := LoadDescription("testdata/upload.yaml", false)
+	if err != nil {
+		t.Fatalf("Failed to load upload spec: %v", err)
+	}
+
+	for _, op := range NewInterface(doc).Operations {
+		switch op.OperationID {
+		case "uploadDocuments":
+			// 200 is preferred over 202, and error responses are ignored
+			if op.ResponseSchema == nil || op.ResponseSchema.Type != "array" {
+				t.Errorf("ResponseSchema = %+v, want the array schema of the 200 response", op.ResponseSchema)
+			}
+		case "putImage":
+			if op.ResponseSchema != nil {
+				t.Errorf("ResponseSchema = %+v, want nil for a response without content", op.ResponseSchema)
+			}
+		}
+	}
+}
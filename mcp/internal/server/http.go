@@ -129,15 +129,11 @@ func (s *HTTPServer) Start() error {
 		Version: "1.0.0",
 	}
 
-	mcpServer := mcp.NewServer(impl, &mcp.ServerOptions{})
-
-	tools := s.aggregator.GetTools(s.tags)
+	mcpServer := mcp.NewServer(impl, newServerOptions())
 
 	handler := s.createToolHandler()
 
-	for _, tool := range tools {
-		mcpServer.AddTool(tool, handler)
-	}
+	registerTools(mcpServer, s.aggregator, s.tags, handler)
 
 	streamHandler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return mcpServer
@@ -103,6 +103,13 @@ func (w *FailingResponseWriter) WriteHeader(statusCode int) {
 }
 
 func createTestAggregator() *tool.Aggregator {
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	aggregator, _ := tool.NewAggregator(createTestInterface(), "https://api.example.com", auth, nil, nil, false)
+	return aggregator
+}
+
+func createTestInterface() *openapi.Interface {
 	// Create a simple test interface
 	operations := []types.OperationInfo{
 		{
@@ -133,17 +140,12 @@ func createTestAggregator() *tool.Aggregator {
 		Info:    info,
 	}
 
-	intf := &openapi.Interface{
+	return &openapi.Interface{
 		Doc:        doc,
 		Name:       "test-api",
 		Operations: operations,
 		Tags:       []string{"users"},
 	}
-
-	auth := &mockAuthenticator{token: "test-token", authType: "test"}
-
-	aggregator, _ := tool.NewAggregator(intf, "https://api.example.com", auth, nil, nil, false)
-	return aggregator
 }
 
 func TestHTTPServer_handleHealth(t *testing.T) {
@@ -38,17 +38,13 @@ func (s *StdioServer) Start() error {
 	}
 
 	// Create MCP server
-	mcpServer := mcp.NewServer(impl, &mcp.ServerOptions{})
+	mcpServer := mcp.NewServer(impl, newServerOptions())
 
-	// Register tools from aggregator
-	tools := s.aggregator.GetTools(s.tags)
 	// Create a single tool handler that delegates to the aggregator
 	handler := s.createToolHandler()
 
-	for _, tool := range tools {
-		// Add the tool to the MCP server with explicit types
-		mcpServer.AddTool(tool, handler)
-	}
+	// Register tools from aggregator, following its updates
+	registerTools(mcpServer, s.aggregator, s.tags, handler)
 
 	// Create stdio transport
 	transport := &mcp.StdioTransport{}
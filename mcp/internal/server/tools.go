@@ -0,0 +1,57 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/tool"
+)
+
+// newServerOptions returns the options of the MCP servers. The tools capability
+// is always advertised with list changes, as the tools of a catalog server may
+// appear after clients connect
+func newServerOptions() *mcp.ServerOptions {
+	return &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{
+			Logging: &mcp.LoggingCapabilities{},
+			Tools:   &mcp.ToolCapabilities{ListChanged: true},
+		},
+	}
+}
+
+// registerTools adds the tools of the aggregator to the MCP server and keeps
+// them in sync when the aggregator is updated. The server sends
+// notifications/tools/list_changed to the connected clients on each change
+func registerTools(mcpServer *mcp.Server, aggregator *tool.Aggregator, tags []string, handler mcp.ToolHandler) {
+	var mu sync.Mutex
+	registered := addTools(mcpServer, aggregator.GetTools(tags), handler)
+
+	aggregator.OnUpdate(func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		current := addTools(mcpServer, aggregator.GetTools(tags), handler)
+
+		var removed []string
+		for name := range registered {
+			if !current[name] {
+				removed = append(removed, name)
+			}
+		}
+		if len(removed) > 0 {
+			mcpServer.RemoveTools(removed...)
+		}
+
+		registered = current
+	})
+}
+
+// addTools adds or replaces tools on the MCP server, returning their names
+func addTools(mcpServer *mcp.Server, tools []*mcp.Tool, handler mcp.ToolHandler) map[string]bool {
+	names := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		mcpServer.AddTool(tool, handler)
+		names[tool.Name] = true
+	}
+	return names
+}
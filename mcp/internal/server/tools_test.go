@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/tool"
+)
+
+func TestRegisterTools_Update(t *testing.T) {
+	ctx := context.Background()
+
+	aggregator, err := tool.NewNamespacedAggregator(nil, false)
+	if err != nil {
+		t.Fatalf("NewNamespacedAggregator() error = %v", err)
+	}
+
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, newServerOptions())
+	handler := func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	registerTools(mcpServer, aggregator, nil, handler)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := mcpServer.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server Connect() error = %v", err)
+	}
+	defer serverSession.Close()
+
+	changed := make(chan struct{}, 1)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		},
+	})
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect() error = %v", err)
+	}
+	defer session.Close()
+
+	if tools := session.InitializeResult().Capabilities.Tools; tools == nil || !tools.ListChanged {
+		t.Errorf("tools capability = %+v, want list changes advertised", tools)
+	}
+
+	listTools := func() []string {
+		t.Helper()
+		result, err := session.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListTools() error = %v", err)
+		}
+		var names []string
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+	waitChanged := func() {
+		t.Helper()
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no notifications/tools/list_changed received")
+		}
+	}
+
+	if names := listTools(); len(names) != 0 {
+		t.Errorf("tools = %v, want none", names)
+	}
+
+	// An application is created
+	updated, err := tool.NewNamespacedAggregator([]tool.ServiceConfig{{
+		Name:          "my-app_chat",
+		Interface:     createTestInterface(),
+		Endpoint:      "https://api.example.com",
+		Authenticator: &mockAuthenticator{token: "test-token", authType: "test"},
+	}}, false)
+	if err != nil {
+		t.Fatalf("NewNamespacedAggregator() error = %v", err)
+	}
+	aggregator.Update(updated)
+	waitChanged()
+
+	if names, want := listTools(), []string{"my-app_chat_getUser"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tools = %v, want %v", names, want)
+	}
+
+	// The application is deleted
+	empty, _ := tool.NewNamespacedAggregator(nil, false)
+	aggregator.Update(empty)
+	waitChanged()
+
+	if names := listTools(); len(names) != 0 {
+		t.Errorf("tools = %v, want none", names)
+	}
+}
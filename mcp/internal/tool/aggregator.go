@@ -3,77 +3,279 @@ package tool
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/project-ai-services/mcp/internal/authenticator"
 	"github.com/project-ai-services/mcp/internal/openapi"
 )
 
-// Aggregator aggregates tools from OpenAPI operations
-type Aggregator struct {
+const (
+	// maxToolNameLength is the longest tool name MCP clients accept
+	maxToolNameLength = 128
+	// aggregatedName is the name of a server aggregating several services
+	aggregatedName         = "ai-services"
+	aggregatedFriendlyName = "AI Services"
+)
+
+// invalidToolNameChars matches the characters not allowed in MCP tool names
+var invalidToolNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// ServiceConfig configures an OpenAPI description whose operations an
+// Aggregator exposes as tools
+type ServiceConfig struct {
+	// Name prefixes the tool names of the service when several services are
+	// aggregated. It defaults to the canonical name of the description
+	Name          string
+	Interface     *openapi.Interface
+	Endpoint      string
+	Authenticator authenticator.Authenticator
+	GlobalQuery   map[string]string
+	GlobalHeaders map[string]string
+	// Tags only exposes the operations with one of the tags, when set
+	Tags []string
+}
+
+// service holds the tools of one OpenAPI description
+type service struct {
+	name          string
 	intf          *openapi.Interface
 	endpoint      string
-	authenticator authenticator.Authenticator
-	globalQuery   map[string]string
 	globalHeaders map[string]string
-	providers     []*Provider
 }
 
-// NewAggregator creates a new tool aggregator
+// Aggregator aggregates tools from the OpenAPI operations of one or more services
+type Aggregator struct {
+	mu        sync.RWMutex
+	services  []*service
+	providers []*Provider
+	tools     map[string]*Provider
+	// prefixed is set when tool names are prefixed with the service name
+	prefixed  bool
+	fileRoot  string
+	listeners []func()
+}
+
+// NewAggregator creates a new tool aggregator for a single service, whose tools
+// are named after their operations
 func NewAggregator(intf *openapi.Interface, endpoint string, auth authenticator.Authenticator,
 	globalQuery, globalHeaders map[string]string, tlsSkipVerify bool) (*Aggregator, error) {
 
+	return NewServiceAggregator([]ServiceConfig{{
+		Interface:     intf,
+		Endpoint:      endpoint,
+		Authenticator: auth,
+		GlobalQuery:   globalQuery,
+		GlobalHeaders: globalHeaders,
+	}}, tlsSkipVerify)
+}
+
+// NewServiceAggregator creates a tool aggregator exposing the operations of
+// several services from one server. When there is more than one service, tool
+// names are prefixed with the service name, e.g. digitize_list_jobs. Names still
+// colliding get a numeric suffix
+func NewServiceAggregator(configs []ServiceConfig, tlsSkipVerify bool) (*Aggregator, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no service to aggregate")
+	}
+
+	return newAggregator(configs, len(configs) > 1, tlsSkipVerify)
+}
+
+// NewNamespacedAggregator creates a tool aggregator whose tool names are always
+// prefixed with the service name, so that they do not change as services come
+// and go. It accepts no service at all
+func NewNamespacedAggregator(configs []ServiceConfig, tlsSkipVerify bool) (*Aggregator, error) {
+	return newAggregator(configs, true, tlsSkipVerify)
+}
+
+func newAggregator(configs []ServiceConfig, prefixed bool, tlsSkipVerify bool) (*Aggregator, error) {
 	aggregator := &Aggregator{
-		intf:          intf,
-		endpoint:      endpoint,
-		authenticator: auth,
-		globalQuery:   globalQuery,
-		globalHeaders: canonicalizeHeaders(globalHeaders),
-		providers:     make([]*Provider, 0),
-	}
-
-	// Create providers for each operation
-	for _, operation := range intf.Operations {
-		provider, err := NewProvider(operation, endpoint,
-			auth, globalQuery, aggregator.globalHeaders, tlsSkipVerify)
+		services:  make([]*service, 0, len(configs)),
+		providers: make([]*Provider, 0),
+		tools:     make(map[string]*Provider),
+		prefixed:  prefixed,
+	}
+
+	serviceNames := make(map[string]bool)
+
+	for _, cfg := range configs {
+		name := cfg.Name
+		if name == "" {
+			name = cfg.Interface.Name
+		}
+		name = uniqueName(sanitizeToolName(name), serviceNames, "-")
+		serviceNames[name] = true
+		svc := &service{
+			name:          name,
+			intf:          cfg.Interface,
+			endpoint:      cfg.Endpoint,
+			globalHeaders: canonicalizeHeaders(cfg.GlobalHeaders),
+		}
+
+		tagSet, err := serviceTagSet(svc, cfg.Tags)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create provider for operation %s: %w", operation.OperationID, err)
+			return nil, err
 		}
-		aggregator.providers = append(aggregator.providers, provider)
+
+		// Create providers for each operation
+		for _, operation := range cfg.Interface.Operations {
+			if len(tagSet) > 0 && !hasAnyTag(operation.Tags, tagSet) {
+				continue
+			}
+
+			provider, err := NewProvider(operation, cfg.Endpoint,
+				cfg.Authenticator, cfg.GlobalQuery, svc.globalHeaders, tlsSkipVerify)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create provider for operation %s: %w", operation.OperationID, err)
+			}
+
+			toolName := provider.name
+			if prefixed {
+				toolName = svc.name + "_" + toolName
+			}
+			provider.name = uniqueName(toolName, aggregator.tools, "_")
+			aggregator.tools[provider.name] = provider
+
+			aggregator.providers = append(aggregator.providers, provider)
+		}
+
+		aggregator.services = append(aggregator.services, svc)
 	}
 
 	return aggregator, nil
 }
 
+// serviceTagSet returns the set of tags a service is filtered by, checking that
+// the service knows them
+func serviceTagSet(svc *service, tags []string) (map[string]bool, error) {
+	tagSet := splitTags(tags)
+
+	known := make(map[string]bool)
+	for _, tag := range svc.intf.Tags {
+		known[tag] = true
+	}
+
+	var unknown []string
+	for tag := range tagSet {
+		if !known[tag] {
+			unknown = append(unknown, tag)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("tag(s) not found in service %s: %s", svc.name, strings.Join(unknown, ", "))
+	}
+
+	return tagSet, nil
+}
+
+// splitTags converts tags to a set, handling comma-separated tags
+func splitTags(tags []string) map[string]bool {
+	tagSet := make(map[string]bool)
+	for _, tag := range tags {
+		for _, t := range strings.Split(tag, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tagSet[t] = true
+			}
+		}
+	}
+	return tagSet
+}
+
+// hasAnyTag checks if any of tags is in tagSet
+func hasAnyTag(tags []string, tagSet map[string]bool) bool {
+	for _, tag := range tags {
+		if tagSet[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeToolName replaces the characters MCP does not allow in tool names
+func sanitizeToolName(name string) string {
+	return strings.Trim(invalidToolNameChars.ReplaceAllString(name, "-"), "-")
+}
+
+// uniqueName returns name, or name with the lowest numeric suffix not in used
+func uniqueName[V any](name string, used map[string]V, separator string) string {
+	if len(name) > maxToolNameLength {
+		name = name[:maxToolNameLength]
+	}
+
+	candidate := name
+	for i := 2; ; i++ {
+		if _, taken := used[candidate]; !taken {
+			break
+		}
+		suffix := fmt.Sprintf("%s%d", separator, i)
+		candidate = name[:min(len(name), maxToolNameLength-len(suffix))] + suffix
+	}
+
+	return candidate
+}
+
+// SetFileRoot allows file arguments of tool calls to reference files below dir
+// with file:// URIs
+func (a *Aggregator) SetFileRoot(dir string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.fileRoot = dir
+	for _, provider := range a.providers {
+		provider.SetFileRoot(dir)
+	}
+}
+
+// Update replaces the services and tools of the aggregator with those of other,
+// keeping its file root, then calls the functions registered with OnUpdate
+func (a *Aggregator) Update(other *Aggregator) {
+	other.mu.RLock()
+	services, providers, tools, prefixed := other.services, other.providers, other.tools, other.prefixed
+	other.mu.RUnlock()
+
+	a.mu.Lock()
+	a.services, a.providers, a.tools, a.prefixed = services, providers, tools, prefixed
+	if a.fileRoot != "" {
+		for _, provider := range a.providers {
+			provider.SetFileRoot(a.fileRoot)
+		}
+	}
+	listeners := append([]func(){}, a.listeners...)
+	a.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// OnUpdate registers a function called after each Update, e.g. to tell MCP
+// clients that the tool list changed
+func (a *Aggregator) OnUpdate(listener func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.listeners = append(a.listeners, listener)
+}
+
 // GetTools returns all tools, optionally filtered by tags
 func (a *Aggregator) GetTools(tags []string) []*mcp.Tool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	var tools []*mcp.Tool
 
 	// Filter providers by tags if specified
 	providers := a.providers
 	if len(tags) > 0 {
 		providers = make([]*Provider, 0)
-		tagSet := make(map[string]bool)
-
-		// Convert tags to a set for faster lookup
-		for _, tag := range tags {
-			// Handle comma-separated tags
-			for _, t := range strings.Split(tag, ",") {
-				tagSet[strings.TrimSpace(t)] = true
-			}
-		}
+		tagSet := splitTags(tags)
 
 		for _, provider := range a.providers {
 			// Check if provider has any of the requested tags
-			hasTag := false
-			for _, providerTag := range provider.operation.Tags {
-				if tagSet[providerTag] {
-					hasTag = true
-					break
-				}
-			}
-			if hasTag {
+			if hasAnyTag(provider.operation.Tags, tagSet) {
 				providers = append(providers, provider)
 			}
 		}
@@ -90,28 +292,57 @@ func (a *Aggregator) GetTools(tags []string) []*mcp.Tool {
 // HandleToolCall handles an MCP tool call request
 func (a *Aggregator) HandleToolCall(ctx context.Context, params *mcp.CallToolParamsRaw) (*mcp.CallToolResult, error) {
 	// Find the provider for this tool
-	for _, provider := range a.providers {
-		if provider.operation.OperationID == params.Name {
-			return provider.Execute(ctx, params)
-		}
+	a.mu.RLock()
+	provider, exists := a.tools[params.Name]
+	a.mu.RUnlock()
+
+	if exists {
+		return provider.Execute(ctx, params)
 	}
 
 	return nil, fmt.Errorf("unknown tool: %s", params.Name)
 }
 
-// GetFriendlyName returns the friendly name for the service
+// GetFriendlyName returns the friendly name for the service, or a generic name
+// when several services are aggregated
 func (a *Aggregator) GetFriendlyName() string {
-	return a.intf.Doc.Info.Title
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.prefixed {
+		return aggregatedFriendlyName
+	}
+	return a.services[0].intf.Doc.Info.Title
 }
 
-// GetName returns the canonical name for the service
+// GetName returns the canonical name for the service, or a generic name when
+// several services are aggregated
 func (a *Aggregator) GetName() string {
-	return a.intf.Name
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.prefixed {
+		return aggregatedName
+	}
+	return a.services[0].intf.Name
 }
 
 // GetTags returns all available tags
 func (a *Aggregator) GetTags() []string {
-	return a.intf.Tags
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var tags []string
+	for _, svc := range a.services {
+		for _, tag := range svc.intf.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
 }
 
 // canonicalizeHeaders converts headers to lowercase keys
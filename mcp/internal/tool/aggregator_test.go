@@ -3,6 +3,9 @@ package tool
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -134,12 +137,12 @@ func TestNewAggregator(t *testing.T) {
 					t.Fatal("NewAggregator() returned nil")
 				}
 
-				if aggregator.intf != tt.intf {
+				if aggregator.services[0].intf != tt.intf {
 					t.Error("Aggregator interface not set correctly")
 				}
 
-				if aggregator.endpoint != tt.endpoint {
-					t.Errorf("Aggregator endpoint = %q, want %q", aggregator.endpoint, tt.endpoint)
+				if aggregator.services[0].endpoint != tt.endpoint {
+					t.Errorf("Aggregator endpoint = %q, want %q", aggregator.services[0].endpoint, tt.endpoint)
 				}
 
 				if len(aggregator.providers) != len(tt.intf.Operations) {
@@ -147,7 +150,7 @@ func TestNewAggregator(t *testing.T) {
 				}
 
 				// Check that global headers are canonicalized (lowercase)
-				for key := range aggregator.globalHeaders {
+				for key := range aggregator.services[0].globalHeaders {
 					if key != strings.ToLower(key) {
 						t.Errorf("Global header key %q should be lowercase", key)
 					}
@@ -481,3 +484,144 @@ func TestAggregator_Integration(t *testing.T) {
 		t.Errorf("Expected 2 tags, got %d", len(tags))
 	}
 }
+
+func TestNewServiceAggregator(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Both services are built from the same description, so their operations collide
+	chat := createMockInterface()
+	summarize := createMockInterface()
+
+	aggregator, err := NewServiceAggregator([]ServiceConfig{
+		{
+			Name:          "chat",
+			Interface:     chat,
+			Endpoint:      server.URL,
+			Authenticator: &mockAuthenticator{token: "chat-token", authType: "test"},
+		},
+		{
+			Name:          "chat",
+			Interface:     summarize,
+			Endpoint:      server.URL,
+			Authenticator: &mockAuthenticator{token: "summarize-token", authType: "test"},
+			Tags:          []string{"users"},
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("NewServiceAggregator() error = %v", err)
+	}
+
+	var names []string
+	for _, tool := range aggregator.GetTools(nil) {
+		names = append(names, tool.Name)
+	}
+	want := []string{"chat_getUser", "chat_listUsers", "chat_createResource", "chat-2_getUser", "chat-2_listUsers"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("tool names = %v, want %v", names, want)
+	}
+
+	if aggregator.GetName() != "ai-services" || aggregator.GetFriendlyName() != "AI Services" {
+		t.Errorf("GetName() = %q, GetFriendlyName() = %q, want the aggregated names", aggregator.GetName(), aggregator.GetFriendlyName())
+	}
+
+	// Each service authenticates with its own credential
+	for _, name := range []string{"chat_listUsers", "chat-2_listUsers"} {
+		if _, err := aggregator.HandleToolCall(context.Background(), &mcp.CallToolParamsRaw{Name: name}); err != nil {
+			t.Fatalf("HandleToolCall(%s) error = %v", name, err)
+		}
+	}
+	if want := []string{"Bearer chat-token", "Bearer summarize-token"}; !reflect.DeepEqual(authorizations, want) {
+		t.Errorf("authorizations = %v, want %v", authorizations, want)
+	}
+
+	if _, err := aggregator.HandleToolCall(context.Background(), &mcp.CallToolParamsRaw{Name: "listUsers"}); err == nil {
+		t.Error("HandleToolCall() should not find unprefixed tool names")
+	}
+}
+
+func TestNewServiceAggregator_Errors(t *testing.T) {
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	if _, err := NewServiceAggregator(nil, false); err == nil {
+		t.Error("NewServiceAggregator() should fail without services")
+	}
+
+	_, err := NewServiceAggregator([]ServiceConfig{
+		{Interface: createMockInterface(), Authenticator: auth, Tags: []string{"users,billing"}},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "billing") {
+		t.Errorf("NewServiceAggregator() error = %v, want the unknown tag", err)
+	}
+}
+
+func TestNewNamespacedAggregator_Update(t *testing.T) {
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	aggregator, err := NewNamespacedAggregator(nil, false)
+	if err != nil {
+		t.Fatalf("NewNamespacedAggregator() error = %v", err)
+	}
+	if len(aggregator.GetTools(nil)) != 0 || aggregator.GetName() != "ai-services" {
+		t.Errorf("empty aggregator has tools %v and name %q", aggregator.GetTools(nil), aggregator.GetName())
+	}
+
+	root := t.TempDir()
+	aggregator.SetFileRoot(root)
+
+	updates := 0
+	aggregator.OnUpdate(func() { updates++ })
+
+	// A single service is prefixed as well, so its names do not change when others appear
+	updated, err := NewNamespacedAggregator([]ServiceConfig{
+		{Name: "my-app_digitize", Interface: createMockInterface(), Authenticator: auth, Tags: []string{"resources"}},
+	}, false)
+	if err != nil {
+		t.Fatalf("NewNamespacedAggregator() error = %v", err)
+	}
+	aggregator.Update(updated)
+
+	if updates != 1 {
+		t.Errorf("OnUpdate listener called %d times, want 1", updates)
+	}
+	tools := aggregator.GetTools(nil)
+	if len(tools) != 1 || tools[0].Name != "my-app_digitize_createResource" {
+		t.Errorf("tools after Update() = %v, want my-app_digitize_createResource", tools)
+	}
+	if provider := aggregator.tools["my-app_digitize_createResource"]; provider == nil || provider.fileRoot != root {
+		t.Error("Update() should keep the file root")
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	used := map[string]bool{"digitize_get": true, "digitize_get_2": true}
+
+	if got := uniqueName("digitize_get", used, "_"); got != "digitize_get_3" {
+		t.Errorf("uniqueName() = %q, want digitize_get_3", got)
+	}
+	if got := uniqueName("digitize_list", used, "_"); got != "digitize_list" {
+		t.Errorf("uniqueName() = %q, want the unused name as is", got)
+	}
+
+	long := strings.Repeat("a", 200)
+	truncated := uniqueName(long, used, "_")
+	if len(truncated) != maxToolNameLength {
+		t.Errorf("uniqueName() length = %d, want %d", len(truncated), maxToolNameLength)
+	}
+	used[truncated] = true
+	if got := uniqueName(long, used, "_"); len(got) != maxToolNameLength || !strings.HasSuffix(got, "_2") {
+		t.Errorf("uniqueName() = %q, want a truncated name with a suffix", got)
+	}
+}
+
+func TestGetToolName(t *testing.T) {
+	operation := types.OperationInfo{Method: types.GET, Path: "/v1/jobs/{job_id}/status"}
+	if got := getToolName(operation); got != "get_v1_jobs_job_id_status" {
+		t.Errorf("getToolName() = %q, want get_v1_jobs_job_id_status", got)
+	}
+}
@@ -0,0 +1,422 @@
+package tool
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/types"
+)
+
+const defaultFileMIMEType = "application/octet-stream"
+
+// fileArgument is a file passed as a tool argument. The content is given
+// base64 encoded or as text, or by reference to an MCP resource: a resource
+// link or an embedded resource can be passed as is
+type fileArgument struct {
+	Content  string                `json:"content"`
+	Text     string                `json:"text"`
+	URI      string                `json:"uri"`
+	Resource *mcp.ResourceContents `json:"resource"`
+	Filename string                `json:"filename"`
+	Name     string                `json:"name"`
+	MIMEType string                `json:"mimeType"`
+}
+
+// fileSchema returns the input schema of a file argument
+func fileSchema(description string) *jsonschema.Schema {
+	if description == "" {
+		description = "A file"
+	}
+
+	return &jsonschema.Schema{
+		Type: "object",
+		Description: description + ". Give the content base64 encoded in 'content' or as text in 'text', " +
+			"or reference it with a file URI in 'uri'. MCP resource links and embedded resources are accepted as is",
+		Properties: map[string]*jsonschema.Schema{
+			"content": {
+				Type:            "string",
+				ContentEncoding: "base64",
+				Description:     "The file content, base64 encoded",
+			},
+			"text": {
+				Type:        "string",
+				Description: "The file content as text",
+			},
+			"uri": {
+				Type:        "string",
+				Description: "A file:// URI of the file, below the file root the server was started with",
+			},
+			"resource": {
+				Type:        "object",
+				Description: "An MCP embedded resource holding the file",
+				Properties: map[string]*jsonschema.Schema{
+					"uri":      {Type: "string"},
+					"mimeType": {Type: "string"},
+					"text":     {Type: "string"},
+					"blob":     {Type: "string", ContentEncoding: "base64"},
+				},
+			},
+			"filename": {
+				Type:        "string",
+				Description: "The file name sent to the service",
+			},
+			"mimeType": {
+				Type:        "string",
+				Description: "The media type of the file",
+			},
+		},
+	}
+}
+
+// isBinarySchema checks if a schema describes file content: a string with the
+// binary format or a content media type
+func isBinarySchema(schema *jsonschema.Schema) bool {
+	if schema == nil {
+		return false
+	}
+
+	if schema.Type == "string" || slices.Contains(schema.Types, "string") {
+		if schema.Format == "binary" || schema.ContentMediaType != "" {
+			return true
+		}
+	}
+
+	// Optional files are described as anyOf the file and null
+	for _, sub := range schema.AnyOf {
+		if isBinarySchema(sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildFormSchema replaces the file properties of a multipart/form-data schema
+// with file argument schemas and records them in fileFields
+func buildFormSchema(schema *jsonschema.Schema, fileFields map[string]bool) *jsonschema.Schema {
+	if schema == nil || len(schema.Properties) == 0 {
+		return schema
+	}
+
+	// Create a shallow copy to avoid modifying the original
+	form := *schema
+	form.Properties = make(map[string]*jsonschema.Schema, len(schema.Properties))
+
+	for name, prop := range schema.Properties {
+		switch {
+		case isBinarySchema(prop):
+			form.Properties[name] = fileSchema(prop.Description)
+			fileFields[name] = true
+		case prop != nil && prop.Type == "array" && isBinarySchema(prop.Items):
+			array := *prop
+			array.Items = fileSchema(prop.Items.Description)
+			form.Properties[name] = &array
+			fileFields[name] = true
+		default:
+			form.Properties[name] = prop
+		}
+	}
+
+	return &form
+}
+
+// buildBody encodes the body argument of a tool call as the operation's content
+// type. It returns the body and the content type to send it with
+func (p *Provider) buildBody(bodyData interface{}) (io.ReadCloser, string, error) {
+	contentType := p.operation.RequestBody.ContentType
+
+	switch p.operation.RequestBody.Encoding() {
+	case types.EncodingMultipart:
+		fields, ok := bodyData.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("argument '%s' must be an object of form fields", p.bodyName)
+		}
+		return p.buildMultipartBody(fields)
+
+	case types.EncodingForm:
+		fields, ok := bodyData.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("argument '%s' must be an object of form fields", p.bodyName)
+		}
+		values := url.Values{}
+		for name, value := range fields {
+			for _, v := range formValues(value) {
+				values.Add(name, v)
+			}
+		}
+		return io.NopCloser(strings.NewReader(values.Encode())), contentType, nil
+
+	case types.EncodingBinary:
+		file, err := parseFileArgument(bodyData)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid argument '%s': %w", p.bodyName, err)
+		}
+		reader, err := file.open(p.fileRoot)
+		if err != nil {
+			return nil, "", err
+		}
+		// A wildcard content type is replaced by the type of the file
+		if strings.Contains(contentType, "*") {
+			contentType = file.mimeType()
+		}
+		return reader, contentType, nil
+
+	default:
+		bodyBytes, err := json.Marshal(bodyData)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		return io.NopCloser(bytes.NewReader(bodyBytes)), contentType, nil
+	}
+}
+
+// buildMultipartBody streams a multipart/form-data body, so that files are not
+// held in memory a second time while encoding
+func (p *Provider) buildMultipartBody(fields map[string]interface{}) (io.ReadCloser, string, error) {
+	// Resolve the files first, so that invalid arguments fail before the request is sent
+	files := make(map[string][]*fileArgument)
+	for name := range p.fileFields {
+		value, exists := fields[name]
+		if !exists || value == nil {
+			continue
+		}
+		values, isArray := value.([]interface{})
+		if !isArray {
+			values = []interface{}{value}
+		}
+		for _, v := range values {
+			file, err := parseFileArgument(v)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid file field '%s': %w", name, err)
+			}
+			files[name] = append(files[name], file)
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pipeReader, pipeWriter := io.Pipe()
+	writer := multipart.NewWriter(pipeWriter)
+
+	go func() {
+		pipeWriter.CloseWithError(p.writeMultipart(writer, names, fields, files))
+	}()
+
+	return pipeReader, writer.FormDataContentType(), nil
+}
+
+// writeMultipart writes the form fields and files as parts
+func (p *Provider) writeMultipart(writer *multipart.Writer, names []string,
+	fields map[string]interface{}, files map[string][]*fileArgument) error {
+
+	for _, name := range names {
+		if p.fileFields[name] {
+			for _, file := range files[name] {
+				if err := p.writeFilePart(writer, name, file); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for _, value := range formValues(fields[name]) {
+			if err := writer.WriteField(name, value); err != nil {
+				return fmt.Errorf("failed to write form field '%s': %w", name, err)
+			}
+		}
+	}
+
+	return writer.Close()
+}
+
+// writeFilePart streams a file into a part of its own
+func (p *Provider) writeFilePart(writer *multipart.Writer, name string, file *fileArgument) error {
+	reader, err := file.open(p.fileRoot)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     name,
+		"filename": file.filename(name),
+	}))
+	header.Set("Content-Type", file.mimeType())
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to write file field '%s': %w", name, err)
+	}
+	if _, err := io.Copy(part, reader); err != nil {
+		return fmt.Errorf("failed to write file field '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// formValues converts an argument to form field values. Arrays of scalars
+// repeat the field, objects and other arrays are sent as JSON
+func formValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				encoded, _ := json.Marshal(v)
+				return []string{string(encoded)}
+			}
+			values = append(values, formValues(item)...)
+		}
+		return values
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return []string{fmt.Sprintf("%v", v)}
+		}
+		return []string{string(encoded)}
+	}
+}
+
+// parseFileArgument parses a file argument
+func parseFileArgument(value interface{}) (*fileArgument, error) {
+	if _, ok := value.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("a file must be an object with 'content', 'text', 'uri' or 'resource'")
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal file argument: %w", err)
+	}
+
+	var file fileArgument
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse file argument: %w", err)
+	}
+
+	if file.Content == "" && file.Text == "" && file.URI == "" && file.Resource == nil {
+		return nil, fmt.Errorf("a file must have 'content', 'text', 'uri' or 'resource'")
+	}
+
+	return &file, nil
+}
+
+// open returns a reader of the file content
+func (f *fileArgument) open(fileRoot string) (io.ReadCloser, error) {
+	switch {
+	case f.Resource != nil && len(f.Resource.Blob) > 0:
+		return io.NopCloser(bytes.NewReader(f.Resource.Blob)), nil
+	case f.Resource != nil && f.Resource.Text != "":
+		return io.NopCloser(strings.NewReader(f.Resource.Text)), nil
+	case f.Resource != nil:
+		return openFileURI(f.Resource.URI, fileRoot)
+	case f.Content != "":
+		return io.NopCloser(base64.NewDecoder(base64.StdEncoding, strings.NewReader(f.Content))), nil
+	case f.Text != "":
+		return io.NopCloser(strings.NewReader(f.Text)), nil
+	default:
+		return openFileURI(f.URI, fileRoot)
+	}
+}
+
+// uri returns the URI the file references, if any
+func (f *fileArgument) uri() string {
+	if f.Resource != nil {
+		return f.Resource.URI
+	}
+	return f.URI
+}
+
+// filename returns the file name sent to the service
+func (f *fileArgument) filename(fieldName string) string {
+	if f.Filename != "" {
+		return f.Filename
+	}
+	if f.Name != "" {
+		return f.Name
+	}
+	if u, err := url.Parse(f.uri()); err == nil {
+		// URIs such as mem://notes.md name the resource in the host
+		for _, candidate := range []string{u.Path, u.Opaque, u.Host} {
+			if base := path.Base(candidate); base != "/" && base != "." {
+				return base
+			}
+		}
+	}
+	return fieldName
+}
+
+// mimeType returns the media type of the file
+func (f *fileArgument) mimeType() string {
+	if f.MIMEType != "" {
+		return f.MIMEType
+	}
+	if f.Resource != nil && f.Resource.MIMEType != "" {
+		return f.Resource.MIMEType
+	}
+	if byExtension := mime.TypeByExtension(path.Ext(f.filename(""))); byExtension != "" {
+		return byExtension
+	}
+	return defaultFileMIMEType
+}
+
+// openFileURI opens the file a file:// URI references. Only files below the file
+// root are served, as the URI is given by the MCP client
+func openFileURI(uri, fileRoot string) (io.ReadCloser, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file URI %q: %w", uri, err)
+	}
+	if parsed.Scheme != "file" {
+		return nil, fmt.Errorf("unsupported resource URI %q: only file:// URIs can be read, pass other resources embedded", uri)
+	}
+	if fileRoot == "" {
+		return nil, fmt.Errorf("cannot read %q: file URIs are only accepted when the server is started with --file-root", uri)
+	}
+
+	root, err := filepath.Abs(fileRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file root %q: %w", fileRoot, err)
+	}
+	relative, err := filepath.Rel(root, filepath.Clean(filepath.FromSlash(parsed.Path)))
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("cannot read %q: the file is not below the file root %s", uri, fileRoot)
+	}
+
+	// OpenInRoot also rejects symbolic links leading out of the root
+	file, err := os.OpenInRoot(root, relative)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q: %w", uri, err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,302 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/types"
+)
+
+// uploadOperation is shaped like the document upload of the digitize service
+var uploadOperation = types.OperationInfo{
+	OperationID: "uploadDocuments",
+	Method:      types.POST,
+	Path:        "/v1/documents",
+	RequestBody: &types.RequestBodyInfo{
+		Required:    true,
+		ContentType: "multipart/form-data",
+		Schema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"files": {
+					Type:  "array",
+					Items: &jsonschema.Schema{Type: "string", Format: "binary"},
+				},
+				"operation": {Type: "string"},
+				"output_format": {
+					AnyOf: []*jsonschema.Schema{{Type: "string"}, {Type: "null"}},
+				},
+			},
+			Required: []string{"files"},
+		},
+	},
+}
+
+type receivedPart struct {
+	name        string
+	filename    string
+	contentType string
+	content     string
+}
+
+// readParts reads the parts of a multipart/form-data request
+func readParts(t *testing.T, r *http.Request) []receivedPart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("Content-Type = %q, want multipart/form-data", r.Header.Get("Content-Type"))
+	}
+
+	var parts []receivedPart
+	reader := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		content, _ := io.ReadAll(part)
+		parts = append(parts, receivedPart{
+			name:        part.FormName(),
+			filename:    part.FileName(),
+			contentType: part.Header.Get("Content-Type"),
+			content:     string(content),
+		})
+	}
+}
+
+func TestProvider_buildRequestBodySchema_Multipart(t *testing.T) {
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	provider, err := NewProvider(uploadOperation, "https://api.example.com", auth, nil, nil, false)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	body := provider.inputSchema.Properties["data"]
+	if body == nil {
+		t.Fatal("input schema has no body property")
+	}
+
+	files := body.Properties["files"]
+	if files.Type != "array" || files.Items.Type != "object" || files.Items.Properties["content"] == nil {
+		t.Errorf("files should be an array of file arguments, got %+v", files)
+	}
+	if body.Properties["operation"].Type != "string" {
+		t.Errorf("form fields should keep their schema, got %+v", body.Properties["operation"])
+	}
+	if !provider.fileFields["files"] || provider.fileFields["operation"] {
+		t.Errorf("fileFields = %v, want only files", provider.fileFields)
+	}
+
+	// The operation schema is not modified
+	if uploadOperation.RequestBody.Schema.Properties["files"].Items.Format != "binary" {
+		t.Error("buildRequestBodySchema() modified the operation schema")
+	}
+}
+
+func TestProvider_ExecuteMultipart(t *testing.T) {
+	var parts []receivedPart
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts = readParts(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"job_id": "42"}`))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "report.pdf"), []byte("%PDF-report"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+	provider, err := NewProvider(uploadOperation, server.URL, auth, nil, nil, false)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	provider.SetFileRoot(root)
+
+	arguments := `{"data": {
+		"operation": "digitization",
+		"files": [
+			{"content": "aGVsbG8=", "filename": "hello.txt"},
+			{"uri": "file://` + filepath.ToSlash(filepath.Join(root, "report.pdf")) + `"},
+			{"type": "resource", "resource": {"uri": "mem://notes.md", "mimeType": "text/markdown", "text": "# notes"}}
+		]
+	}}`
+	result, err := provider.Execute(context.Background(), &mcp.CallToolParamsRaw{
+		Name:      uploadOperation.OperationID,
+		Arguments: json.RawMessage(arguments),
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "42") {
+		t.Errorf("Execute() returned %q", text)
+	}
+
+	want := []receivedPart{
+		{name: "files", filename: "hello.txt", contentType: "text/plain; charset=utf-8", content: "hello"},
+		{name: "files", filename: "report.pdf", contentType: "application/pdf", content: "%PDF-report"},
+		{name: "files", filename: "notes.md", contentType: "text/markdown", content: "# notes"},
+		{name: "operation", content: "digitization"},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("received %d parts, want %d: %+v", len(parts), len(want), parts)
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Errorf("part %d = %+v, want %+v", i, parts[i], want[i])
+		}
+	}
+}
+
+func TestProvider_ExecuteFormAndBinary(t *testing.T) {
+	var contentType, received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	tests := []struct {
+		name            string
+		contentType     string
+		arguments       string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "urlencoded form",
+			contentType:     "application/x-www-form-urlencoded",
+			arguments:       `{"data": {"grant_type": "password", "scope": ["read", "write"], "ttl": 60}}`,
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "grant_type=password&scope=read&scope=write&ttl=60",
+		},
+		{
+			name:            "octet-stream",
+			contentType:     "application/octet-stream",
+			arguments:       `{"data": {"content": "AAEC"}}`,
+			wantContentType: "application/octet-stream",
+			wantBody:        "\x00\x01\x02",
+		},
+		{
+			name:            "wildcard takes the file type",
+			contentType:     "*/*",
+			arguments:       `{"data": {"text": "a,b", "filename": "data.csv"}}`,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody:        "a,b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := types.OperationInfo{
+				OperationID: "send",
+				Method:      types.PUT,
+				Path:        "/send",
+				RequestBody: &types.RequestBodyInfo{ContentType: tt.contentType, Schema: &jsonschema.Schema{Type: "object"}},
+			}
+			provider, err := NewProvider(operation, server.URL, auth, nil, nil, false)
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+
+			if _, err := provider.Execute(context.Background(), &mcp.CallToolParamsRaw{
+				Name:      "send",
+				Arguments: json.RawMessage(tt.arguments),
+			}); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, tt.wantContentType)
+			}
+			if received != tt.wantBody {
+				t.Errorf("body = %q, want %q", received, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestOpenFileURI(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "doc.txt"), []byte("doc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		uri      string
+		fileRoot string
+		wantErr  string
+	}{
+		{name: "file below the root", uri: "file://" + filepath.Join(root, "doc.txt"), fileRoot: root},
+		{name: "no file root", uri: "file://" + filepath.Join(root, "doc.txt"), wantErr: "--file-root"},
+		{name: "outside the root", uri: "file://" + outside, fileRoot: root, wantErr: "not below the file root"},
+		{name: "traversal", uri: "file://" + root + "/../secret.txt", fileRoot: root, wantErr: "not below the file root"},
+		{name: "symbolic link out of the root", uri: "file://" + filepath.Join(root, "link.txt"), fileRoot: root, wantErr: "failed to open"},
+		{name: "other scheme", uri: "https://example.com/doc.txt", fileRoot: root, wantErr: "only file:// URIs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := openFileURI(tt.uri, tt.fileRoot)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("openFileURI() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("openFileURI() error = %v", err)
+			}
+			defer reader.Close()
+			if content, _ := io.ReadAll(reader); string(content) != "doc" {
+				t.Errorf("openFileURI() content = %q", content)
+			}
+		})
+	}
+}
+
+func TestParseFileArgument(t *testing.T) {
+	if _, err := parseFileArgument("aGVsbG8="); err == nil {
+		t.Error("parseFileArgument() should reject a plain string")
+	}
+	if _, err := parseFileArgument(map[string]interface{}{"filename": "a.txt"}); err == nil {
+		t.Error("parseFileArgument() should reject a file without content")
+	}
+
+	file, err := parseFileArgument(map[string]interface{}{"type": "resource_link", "uri": "file:///data/scan.png", "name": "scan"})
+	if err != nil {
+		t.Fatalf("parseFileArgument() error = %v", err)
+	}
+	if file.filename("files") != "scan" {
+		t.Errorf("filename() = %q, want the resource link name", file.filename("files"))
+	}
+}
@@ -1,7 +1,6 @@
 package tool
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -18,8 +17,13 @@ import (
 	"github.com/project-ai-services/mcp/internal/types"
 )
 
+// nonAlphanumeric matches the characters replaced when naming a tool after a path
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
 // Provider provides a single tool based on an OpenAPI operation
 type Provider struct {
+	// name is the tool name, the operation ID unless the aggregator renames it
+	name          string
 	operation     types.OperationInfo
 	endpoint      string
 	authenticator authenticator.Authenticator
@@ -27,7 +31,14 @@ type Provider struct {
 	globalHeaders map[string]string
 	bodyName      string
 	inputSchema   *jsonschema.Schema
+	outputSchema  *jsonschema.Schema
 	tlsSkipVerify bool
+	// wrapsResult is set when the output schema wraps a JSON response that is not an object
+	wrapsResult bool
+	// fileFields are the multipart/form-data fields holding files
+	fileFields map[string]bool
+	// fileRoot is the directory file:// URIs of file arguments are read from
+	fileRoot string
 }
 
 // NewProvider creates a new tool provider
@@ -37,6 +48,7 @@ func NewProvider(operation types.OperationInfo, endpoint string,
 	tlsSkipVerify bool) (*Provider, error) {
 
 	provider := &Provider{
+		name:          getToolName(operation),
 		operation:     operation,
 		endpoint:      endpoint,
 		authenticator: auth,
@@ -44,13 +56,30 @@ func NewProvider(operation types.OperationInfo, endpoint string,
 		globalHeaders: globalHeaders,
 		bodyName:      getBodyName(operation),
 		tlsSkipVerify: tlsSkipVerify,
+		fileFields:    make(map[string]bool),
 	}
 
 	provider.inputSchema = provider.buildInputSchema()
+	provider.outputSchema = provider.buildOutputSchema()
 
 	return provider, nil
 }
 
+// SetFileRoot allows file arguments to reference files below dir with file:// URIs
+func (p *Provider) SetFileRoot(dir string) {
+	p.fileRoot = dir
+}
+
+// getToolName names the tool after the operation ID, or after the method and
+// path of operations without one
+func getToolName(operation types.OperationInfo) string {
+	if operation.OperationID != "" {
+		return operation.OperationID
+	}
+	path := strings.Trim(nonAlphanumeric.ReplaceAllString(operation.Path, "_"), "_")
+	return strings.ToLower(string(operation.Method)) + "_" + path
+}
+
 // getBodyName determines the appropriate name for the request body parameter
 func getBodyName(operation types.OperationInfo) string {
 	if strings.HasPrefix(operation.OperationID, "create_") || strings.HasPrefix(operation.OperationID, "replace_") {
@@ -91,11 +120,16 @@ func (sb *schemaBuilder) addProperty(name string, prop *jsonschema.Schema, requi
 
 // GetTool returns the MCP tool definition
 func (p *Provider) GetTool() *mcp.Tool {
-	return &mcp.Tool{
-		Name:        p.operation.OperationID,
+	tool := &mcp.Tool{
+		Name:        p.name,
 		Description: p.operation.Description,
 		InputSchema: p.inputSchema,
 	}
+	// A nil *jsonschema.Schema must not be assigned to the OutputSchema interface
+	if p.outputSchema != nil {
+		tool.OutputSchema = p.outputSchema
+	}
+	return tool
 }
 
 // buildInputSchema builds the JSON schema for the tool's input
@@ -261,11 +295,23 @@ func (p *Provider) buildHeaderSchema(params []types.ParameterInfo) *jsonschema.S
 
 // buildRequestBodySchema builds a schema for the request body
 func (p *Provider) buildRequestBodySchema() *jsonschema.Schema {
-	if p.operation.RequestBody == nil || p.operation.RequestBody.Schema == nil {
+	if p.operation.RequestBody == nil {
 		return nil
 	}
 
-	return p.operation.RequestBody.Schema
+	switch p.operation.RequestBody.Encoding() {
+	case types.EncodingBinary:
+		// The whole body is a single file
+		description := ""
+		if p.operation.RequestBody.Schema != nil {
+			description = p.operation.RequestBody.Schema.Description
+		}
+		return fileSchema(description)
+	case types.EncodingMultipart:
+		return buildFormSchema(p.operation.RequestBody.Schema, p.fileFields)
+	default:
+		return p.operation.RequestBody.Schema
+	}
 }
 
 // Execute executes the tool operation
@@ -283,7 +329,7 @@ func (p *Provider) Execute(ctx context.Context, params *mcp.CallToolParamsRaw) (
 	}
 
 	// Build request body
-	var body io.Reader
+	var body io.ReadCloser
 	if p.operation.RequestBody != nil {
 		var args map[string]interface{}
 		if len(params.Arguments) > 0 {
@@ -295,12 +341,12 @@ func (p *Provider) Execute(ctx context.Context, params *mcp.CallToolParamsRaw) (
 		}
 
 		if bodyData, exists := args[p.bodyName]; exists {
-			bodyBytes, err := json.Marshal(bodyData)
+			var contentType string
+			body, contentType, err = p.buildBody(bodyData)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+				return nil, err
 			}
-			body = bytes.NewReader(bodyBytes)
-			headers["content-type"] = p.operation.RequestBody.ContentType
+			headers["content-type"] = contentType
 		}
 
 	}
@@ -308,6 +354,9 @@ func (p *Provider) Execute(ctx context.Context, params *mcp.CallToolParamsRaw) (
 	// Create HTTP request
 	httpRequest, err := http.NewRequestWithContext(ctx, string(p.operation.Method), requestURL, body)
 	if err != nil {
+		if body != nil {
+			body.Close()
+		}
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
@@ -330,19 +379,7 @@ func (p *Provider) Execute(ctx context.Context, params *mcp.CallToolParamsRaw) (
 	}
 	defer response.Body.Close()
 
-	// Read response
-	responseBody, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	textContent := &mcp.TextContent{
-		Text: string(responseBody),
-	}
-
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{textContent},
-	}, nil
+	return p.buildResult(response, requestURL)
 }
 
 // buildRequestURL builds the complete request URL
@@ -0,0 +1,185 @@
+package tool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/types"
+)
+
+// resultProperty holds JSON responses that are not objects, as structured
+// content must be an object
+const resultProperty = "result"
+
+// buildOutputSchema builds the output schema of the tool from the schema of the
+// success response. Schemas not strictly of type object, including untyped ones,
+// are wrapped in an object, so that any JSON response conforms
+func (p *Provider) buildOutputSchema() *jsonschema.Schema {
+	schema := p.operation.ResponseSchema
+	if schema == nil {
+		return nil
+	}
+
+	if schema.Type == "object" {
+		return schema
+	}
+
+	p.wrapsResult = true
+	return &jsonschema.Schema{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{resultProperty: schema},
+	}
+}
+
+// buildResult converts an HTTP response to a tool result. JSON responses are
+// returned as text and as structured content, images and audio as the matching
+// content kinds, and other binary responses as embedded resources. Responses
+// with a non-2xx status are returned as errors carrying the status and body.
+// When the tool declares an output schema, results always carry structured
+// content conforming to it, or are errors
+func (p *Provider) buildResult(response *http.Response, requestURL string) (*mcp.CallToolResult, error) {
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return buildErrorResult(response, responseBody), nil
+	}
+
+	result, isJSON := p.buildSuccessResult(response, responseBody, requestURL)
+	if p.outputSchema == nil || result.StructuredContent != nil {
+		return result, nil
+	}
+
+	// A JSON response of another type than the object of a strict output schema
+	// cannot conform to it. Other responses carry an empty object, when the
+	// schema allows one
+	if isJSON || (!p.wrapsResult && len(p.outputSchema.Required) > 0) {
+		return buildMismatchResult(response, responseBody), nil
+	}
+	result.StructuredContent = map[string]interface{}{}
+
+	return result, nil
+}
+
+// buildSuccessResult converts a 2xx response to a tool result, telling whether
+// the response is JSON
+func (p *Provider) buildSuccessResult(response *http.Response, responseBody []byte, requestURL string) (*mcp.CallToolResult, bool) {
+	mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	switch {
+	case len(responseBody) == 0:
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("HTTP %d %s", response.StatusCode, http.StatusText(response.StatusCode))}},
+		}, false
+
+	case strings.HasPrefix(mediaType, "image/"):
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.ImageContent{Data: responseBody, MIMEType: mediaType}},
+		}, false
+
+	case strings.HasPrefix(mediaType, "audio/"):
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.AudioContent{Data: responseBody, MIMEType: mediaType}},
+		}, false
+
+	case isBinaryMediaType(mediaType):
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.EmbeddedResource{Resource: &mcp.ResourceContents{
+				// The query is left out, as global query parameters may hold credentials
+				URI:      strings.SplitN(requestURL, "?", 2)[0],
+				MIMEType: mediaType,
+				Blob:     responseBody,
+			}}},
+		}, false
+	}
+
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(responseBody)}},
+	}
+
+	isJSON := false
+	if types.GetBodyEncoding(mediaType) == types.EncodingJSON || (mediaType == "" && json.Valid(responseBody)) {
+		var value interface{}
+		if err := json.Unmarshal(responseBody, &value); err == nil {
+			result.StructuredContent = p.structuredContent(value)
+			isJSON = true
+		}
+	}
+
+	return result, isJSON
+}
+
+// structuredContent returns the structured content of a JSON response: objects
+// as is and other values in the result property when the output schema wraps them
+func (p *Provider) structuredContent(value interface{}) interface{} {
+	if p.wrapsResult {
+		return map[string]interface{}{resultProperty: value}
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		return object
+	}
+	return nil
+}
+
+// buildErrorResult builds the error result of a non-2xx response. The text
+// content states the status and body for the model, the structured content
+// carries them for programmatic clients
+func buildErrorResult(response *http.Response, responseBody []byte) *mcp.CallToolResult {
+	status := fmt.Sprintf("HTTP %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+
+	errorBody := interface{}(string(responseBody))
+	var parsed interface{}
+	if len(responseBody) > 0 && json.Unmarshal(responseBody, &parsed) == nil {
+		errorBody = parsed
+	}
+
+	text := status
+	if len(responseBody) > 0 {
+		text = fmt.Sprintf("%s: %s", status, strings.TrimSpace(string(responseBody)))
+	}
+
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: text}},
+		StructuredContent: map[string]interface{}{
+			"status": response.StatusCode,
+			"error":  errorBody,
+		},
+	}
+}
+
+// buildMismatchResult builds the error result of a 2xx response that does not
+// match the output schema of the tool
+func buildMismatchResult(response *http.Response, responseBody []byte) *mcp.CallToolResult {
+	text := fmt.Sprintf("HTTP %d %s: the response does not match the output schema of the tool",
+		response.StatusCode, http.StatusText(response.StatusCode))
+	if len(responseBody) > 0 {
+		text = fmt.Sprintf("%s: %s", text, strings.TrimSpace(string(responseBody)))
+	}
+
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: text}},
+	}
+}
+
+// isBinaryMediaType checks if a response media type is binary, i.e. neither
+// text nor a structured text format
+func isBinaryMediaType(mediaType string) bool {
+	if mediaType == "" || strings.HasPrefix(mediaType, "text/") {
+		return false
+	}
+	for _, textual := range []string{"json", "xml", "yaml", "javascript", "x-www-form-urlencoded", "csv"} {
+		if strings.Contains(mediaType, textual) {
+			return false
+		}
+	}
+	return true
+}
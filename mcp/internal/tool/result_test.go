@@ -0,0 +1,255 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/project-ai-services/mcp/internal/types"
+)
+
+func TestProvider_GetToolOutputSchema(t *testing.T) {
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	objectSchema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{"id": {Type: "string"}},
+	}
+	arraySchema := &jsonschema.Schema{Type: "array", Items: objectSchema}
+
+	tests := []struct {
+		name           string
+		responseSchema *jsonschema.Schema
+		want           *jsonschema.Schema
+	}{
+		{
+			name: "no response schema",
+		},
+		{
+			name:           "object response",
+			responseSchema: objectSchema,
+			want:           objectSchema,
+		},
+		{
+			name:           "untyped response is wrapped",
+			responseSchema: &jsonschema.Schema{Properties: objectSchema.Properties},
+			want: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"result": {Properties: objectSchema.Properties}},
+			},
+		},
+		{
+			name:           "array response is wrapped",
+			responseSchema: arraySchema,
+			want: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{"result": arraySchema},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := types.OperationInfo{
+				OperationID:    "listItems",
+				Method:         types.GET,
+				Path:           "/items",
+				ResponseSchema: tt.responseSchema,
+			}
+			provider, err := NewProvider(operation, "https://api.example.com", auth, nil, nil, false)
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+
+			tool := provider.GetTool()
+			if tt.want == nil {
+				if tool.OutputSchema != nil {
+					t.Errorf("OutputSchema = %v, want nil", tool.OutputSchema)
+				}
+				return
+			}
+			if !reflect.DeepEqual(tool.OutputSchema, tt.want) {
+				t.Errorf("OutputSchema = %v, want %v", tool.OutputSchema, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_ExecuteResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/object":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "1"}`))
+		case "/array":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write([]byte(`[{"id": "1"}]`))
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(`plain`))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG"))
+		case "/pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF"))
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "/missing":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail": "Application not found"}`))
+		default:
+			http.Error(w, "internal failure", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	auth := &mockAuthenticator{token: "test-token", authType: "test"}
+
+	tests := []struct {
+		path           string
+		responseSchema *jsonschema.Schema
+		check          func(t *testing.T, result *mcp.CallToolResult)
+	}{
+		{
+			path:           "/object",
+			responseSchema: &jsonschema.Schema{Type: "object"},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if want := map[string]interface{}{"id": "1"}; !reflect.DeepEqual(result.StructuredContent, want) {
+					t.Errorf("StructuredContent = %v, want %v", result.StructuredContent, want)
+				}
+				if text, ok := result.Content[0].(*mcp.TextContent); !ok || text.Text != `{"id": "1"}` {
+					t.Errorf("Content = %+v, want the JSON text", result.Content)
+				}
+			},
+		},
+		{
+			path:           "/array",
+			responseSchema: &jsonschema.Schema{Type: "array"},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				want := map[string]interface{}{"result": []interface{}{map[string]interface{}{"id": "1"}}}
+				if !reflect.DeepEqual(result.StructuredContent, want) {
+					t.Errorf("StructuredContent = %v, want %v", result.StructuredContent, want)
+				}
+			},
+		},
+		{
+			path:           "/array",
+			responseSchema: &jsonschema.Schema{Type: "object"},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if !result.IsError || result.StructuredContent != nil {
+					t.Errorf("result = %+v, want an error for a response not matching the output schema", result)
+				}
+				if text := result.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "does not match the output schema") {
+					t.Errorf("Content = %q, want the mismatch", text)
+				}
+			},
+		},
+		{
+			path:           "/text",
+			responseSchema: &jsonschema.Schema{Type: "string"},
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if want := map[string]interface{}{}; result.IsError || !reflect.DeepEqual(result.StructuredContent, want) {
+					t.Errorf("StructuredContent = %v, want an empty object conforming to the wrapped schema", result.StructuredContent)
+				}
+			},
+		},
+		{
+			path: "/text",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if result.StructuredContent != nil {
+					t.Errorf("StructuredContent = %v, want nil for text", result.StructuredContent)
+				}
+				if text, ok := result.Content[0].(*mcp.TextContent); !ok || text.Text != "plain" {
+					t.Errorf("Content = %+v, want the text", result.Content)
+				}
+			},
+		},
+		{
+			path: "/image",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				image, ok := result.Content[0].(*mcp.ImageContent)
+				if !ok || image.MIMEType != "image/png" || string(image.Data) != "\x89PNG" {
+					t.Errorf("Content = %+v, want the image", result.Content)
+				}
+			},
+		},
+		{
+			path: "/pdf",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				resource, ok := result.Content[0].(*mcp.EmbeddedResource)
+				if !ok || resource.Resource.MIMEType != "application/pdf" || string(resource.Resource.Blob) != "%PDF" {
+					t.Fatalf("Content = %+v, want an embedded resource", result.Content)
+				}
+				if resource.Resource.URI != server.URL+"/pdf" {
+					t.Errorf("resource URI = %q, want the request URL without its query", resource.Resource.URI)
+				}
+			},
+		},
+		{
+			path: "/empty",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if result.IsError {
+					t.Error("IsError = true for 204")
+				}
+			},
+		},
+		{
+			path: "/missing",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if !result.IsError {
+					t.Error("IsError = false for 404")
+				}
+				want := map[string]interface{}{
+					"status": http.StatusNotFound,
+					"error":  map[string]interface{}{"detail": "Application not found"},
+				}
+				if !reflect.DeepEqual(result.StructuredContent, want) {
+					t.Errorf("StructuredContent = %v, want %v", result.StructuredContent, want)
+				}
+				if text := result.Content[0].(*mcp.TextContent).Text; !strings.HasPrefix(text, "HTTP 404 Not Found: ") {
+					t.Errorf("Content = %q, want the status and body", text)
+				}
+			},
+		},
+		{
+			path: "/failing",
+			check: func(t *testing.T, result *mcp.CallToolResult) {
+				if !result.IsError {
+					t.Error("IsError = false for 500")
+				}
+				if errorBody := result.StructuredContent.(map[string]interface{})["error"]; errorBody != "internal failure\n" {
+					t.Errorf("error = %q, want the raw body", errorBody)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			operation := types.OperationInfo{
+				OperationID:    "get",
+				Method:         types.GET,
+				Path:           tt.path,
+				ResponseSchema: tt.responseSchema,
+			}
+			provider, err := NewProvider(operation, server.URL, auth, map[string]string{"apikey": "secret"}, nil, false)
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+
+			result, err := provider.Execute(context.Background(), &mcp.CallToolParamsRaw{Name: "get", Arguments: json.RawMessage(`{}`)})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			tt.check(t, result)
+		})
+	}
+}
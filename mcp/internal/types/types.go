@@ -1,6 +1,10 @@
 package types
 
-import "github.com/google/jsonschema-go/jsonschema"
+import (
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
 
 // This file contains non-MCP types used throughout the application.
 // All MCP-related types should use the official SDK directly:
@@ -40,6 +44,8 @@ type OperationInfo struct {
 	Tags        []string
 	Parameters  []ParameterInfo
 	RequestBody *RequestBodyInfo
+	// ResponseSchema is the schema of the JSON body of the success response
+	ResponseSchema *jsonschema.Schema
 }
 
 // ParameterInfo contains information about an OpenAPI parameter
@@ -58,6 +64,40 @@ type RequestBodyInfo struct {
 	Schema      *jsonschema.Schema
 }
 
+// BodyEncoding represents how a request body is encoded
+type BodyEncoding string
+
+const (
+	// EncodingJSON sends the body as JSON
+	EncodingJSON BodyEncoding = "json"
+	// EncodingMultipart sends the body as multipart/form-data, with files as parts
+	EncodingMultipart BodyEncoding = "multipart"
+	// EncodingForm sends the body as application/x-www-form-urlencoded
+	EncodingForm BodyEncoding = "form"
+	// EncodingBinary sends a single file as the raw body, e.g. application/octet-stream
+	EncodingBinary BodyEncoding = "binary"
+)
+
+// GetBodyEncoding returns the encoding of a request body content type
+func GetBodyEncoding(contentType string) BodyEncoding {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "json"):
+		return EncodingJSON
+	case strings.HasPrefix(ct, "multipart/form-data"):
+		return EncodingMultipart
+	case strings.HasPrefix(ct, "application/x-www-form-urlencoded"):
+		return EncodingForm
+	default:
+		return EncodingBinary
+	}
+}
+
+// Encoding returns the encoding of the request body
+func (rb *RequestBodyInfo) Encoding() BodyEncoding {
+	return GetBodyEncoding(rb.ContentType)
+}
+
 // ConfigOutput represents the configuration output for MCP clients
 type ConfigOutput struct {
 	MCPServers map[string]MCPClientServerConfig `json:"mcpServers"`
@@ -205,3 +205,20 @@ func TestRequestBodyInfo(t *testing.T) {
 		t.Error("RequestBodyInfo.Schema should be nil")
 	}
 }
+
+func TestGetBodyEncoding(t *testing.T) {
+	tests := map[string]BodyEncoding{
+		"application/json":                  EncodingJSON,
+		"application/merge-patch+json":      EncodingJSON,
+		"multipart/form-data":               EncodingMultipart,
+		"application/x-www-form-urlencoded": EncodingForm,
+		"application/octet-stream":          EncodingBinary,
+		"image/png":                         EncodingBinary,
+	}
+
+	for contentType, want := range tests {
+		if got := GetBodyEncoding(contentType); got != want {
+			t.Errorf("GetBodyEncoding(%q) = %q, want %q", contentType, got, want)
+		}
+	}
+}